@@ -61,6 +61,10 @@ var (
 	healthAddr   = flag.String("health-addr", "0.0.0.0:8080", "For reverse buildlets, address to listen for /healthz requests separately from the reverse dialer to the coordinator.")
 )
 
+// extraReverseTypes are the additional host types a reverse buildlet
+// advertises to the coordinator.
+var extraReverseTypes = flag.String("extra-reverse-types", "", "comma-separated list of additional dashboard/builders.go Hosts map keys this buildlet can also receive work for in reverse mode. A builder key is required for each, as for -reverse-type.")
+
 // Bump this whenever something notable happens, or when another
 // component needs a certain feature. This shows on the coordinator
 // per reverse client, and is also accessible via the buildlet
@@ -83,7 +87,9 @@ var (
 //	24: removeAllIncludingReadonly
 //	25: use removeAllIncludingReadonly for all work area cleanup
 //	26: clean up path validation and normalization
-const buildletVersion = 26
+//	27: multiplexed revdial connections
+//	28: report capabilities and extra host types to the coordinator
+const buildletVersion = 28
 
 func defaultListenAddr() string {
 	if runtime.GOOS == "darwin" {
@@ -1838,6 +1844,13 @@ func appendSSHAuthorizedKey(sshUser, authKey string) error {
 // needed & available. Currently we only use it on Linux.
 var setWorkdirToTmpfs func()
 
+// machineCapacity returns the total memory of the machine and the disk
+// space available in dir, in bytes. Zero values are unknown.
+//
+// It is set non-nil on operating systems where the functionality is
+// available. Currently that is only Linux.
+var machineCapacity func(dir string) (memory, disk int64)
+
 func initBaseUnixEnv() {
 	if os.Getenv("USER") == "" {
 		os.Setenv("USER", "root")
@@ -20,6 +20,19 @@ import (
 func init() {
 	registerSignal = registerSignalUnix
 	setWorkdirToTmpfs = setWorkdirToTmpfsLinux
+	machineCapacity = machineCapacityLinux
+}
+
+func machineCapacityLinux(dir string) (memory, disk int64) {
+	var si syscall.Sysinfo_t
+	if err := syscall.Sysinfo(&si); err == nil {
+		memory = int64(si.Totalram) * int64(si.Unit)
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err == nil {
+		disk = int64(st.Bavail) * int64(st.Bsize)
+	}
+	return memory, disk
 }
 
 func registerSignalUnix(c chan<- os.Signal) {
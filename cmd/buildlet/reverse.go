@@ -73,6 +73,17 @@ func dialCoordinator() (net.Listener, error) {
 	if err != nil {
 		log.Fatalf("failed to find key for %s: %v", *reverseType, err)
 	}
+	var extraTypes []string // each "<host type> <key>"
+	for _, typ := range strings.Split(*extraReverseTypes, ",") {
+		if typ = strings.TrimSpace(typ); typ == "" {
+			continue
+		}
+		key, err := keyForMode(typ)
+		if err != nil {
+			log.Fatalf("failed to find key for %s: %v", typ, err)
+		}
+		extraTypes = append(extraTypes, typ+" "+key)
+	}
 
 	addr := *coordinator
 	if addr == "farmer.golang.org" {
@@ -125,6 +136,10 @@ func dialCoordinator() (net.Listener, error) {
 		req.Header.Set("X-Go-Builder-Hostname", *hostname)
 		req.Header.Set("X-Go-Builder-Version", strconv.Itoa(buildletVersion))
 		req.Header.Set("X-Revdial-Version", "2")
+		for _, v := range extraTypes {
+			req.Header.Add("X-Go-Extra-Host-Type", v)
+		}
+		setCapabilityHeaders(req.Header)
 		if err := req.Write(bufw); err != nil {
 			return nil, fmt.Errorf("coordinator /reverse request failed: %v", err)
 		}
@@ -149,6 +164,26 @@ func dialCoordinator() (net.Listener, error) {
 	return ln, nil
 }
 
+// setCapabilityHeaders sets the headers reporting the capacity of the
+// machine to the coordinator.
+func setCapabilityHeaders(h http.Header) {
+	h.Set("X-Go-Builder-CPUs", strconv.Itoa(runtime.NumCPU()))
+	if machineCapacity == nil {
+		return
+	}
+	dir := *workDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	memory, disk := machineCapacity(dir)
+	if memory > 0 {
+		h.Set("X-Go-Builder-Memory", strconv.FormatInt(memory, 10))
+	}
+	if disk > 0 {
+		h.Set("X-Go-Builder-Disk", strconv.FormatInt(disk, 10))
+	}
+}
+
 var coordDialer = &net.Dialer{
 	Timeout:   10 * time.Second,
 	KeepAlive: 15 * time.Second,
@@ -0,0 +1,224 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Buildmatrix prints which builders build a repo and branch post-submit,
+// as TryBots and as SlowBots, and which cmd/dist tests they skip, as
+// decided by the builder policies in the dashboard package.
+//
+// For example, to see the builders of x/tools on master when built with
+// Go 1.19, including the SlowBots of a TRY=darwin comment, use:
+//
+//	buildmatrix -repo=tools -gobranch=release-branch.go1.19 -try=darwin
+//
+// To review the effect of a change to dashboard/builders.go, regenerate
+// dashboard/builders.json with "go test ./dashboard -run=TestConfig -update"
+// and compare it to the previous version:
+//
+//	git show HEAD:dashboard/builders.json >/tmp/old.json
+//	buildmatrix -repo=all -old=/tmp/old.json -new=dashboard/builders.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/repos"
+)
+
+var (
+	flagRepo     = flag.String("repo", "go", `comma-separated list of repos, or "all" for all repos the coordinator can build`)
+	flagBranch   = flag.String("branch", "master", "comma-separated list of branches of the repos")
+	flagGoBranch = flag.String("gobranch", "", "comma-separated list of Go branches to build x/ repos with; master if empty")
+	flagTry      = flag.String("try", "", "terms of a TRY= comment requesting SlowBots, such as `linux-arm64,darwin`")
+	flagTests    = flag.String("tests", "", "comma-separated list of cmd/dist tests whose policies to evaluate for the go repo")
+	flagGoroot   = flag.String("goroot", "", "`directory` of a Go checkout to list the cmd/dist tests of with 'go tool dist test -list'")
+	flagOld      = flag.String("old", "", "builders.json `file` of the configuration to diff against; no diff if empty")
+	flagNew      = flag.String("new", "", "builders.json `file` of the configuration to evaluate; the built-in one if empty")
+	flagJSON     = flag.Bool("json", false, "print the coverage as JSON")
+)
+
+func main() {
+	log.SetPrefix("buildmatrix: ")
+	log.SetFlags(0)
+
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	builders, err := loadBuilders(*flagNew)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var oldBuilders map[string]*dashboard.BuildConfig
+	if *flagOld != "" {
+		if oldBuilders, err = loadBuilders(*flagOld); err != nil {
+			log.Fatal(err)
+		}
+	}
+	tests := splitList(*flagTests)
+	if *flagGoroot != "" {
+		if tests, err = listDistTests(*flagGoroot); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var covs []*dashboard.Coverage
+	var diffs []string
+	for _, q := range queries(tests) {
+		cov := dashboard.CoverageOf(builders, q)
+		covs = append(covs, cov)
+		if oldBuilders == nil {
+			continue
+		}
+		for _, d := range dashboard.DiffCoverage(dashboard.CoverageOf(oldBuilders, q), cov) {
+			diffs = append(diffs, fmt.Sprintf("%s: %s", queryName(q), d))
+		}
+	}
+
+	switch {
+	case oldBuilders != nil && *flagJSON:
+		printJSON(diffs)
+	case oldBuilders != nil:
+		for _, d := range diffs {
+			fmt.Println(d)
+		}
+	case *flagJSON:
+		printJSON(covs)
+	default:
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		for i, cov := range covs {
+			if i > 0 {
+				fmt.Fprintln(tw)
+			}
+			printMatrix(tw, cov)
+		}
+		tw.Flush()
+	}
+}
+
+// loadBuilders returns the builders of the configuration in the
+// builders.json file, or the built-in builders if file is empty.
+func loadBuilders(file string) (map[string]*dashboard.BuildConfig, error) {
+	if file == "" {
+		return dashboard.Builders, nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	_, builders, err := dashboard.LoadConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	return builders, nil
+}
+
+// queries returns the coverage queries of the cross product of the
+// -repo, -branch and -gobranch flags.
+func queries(tests []string) []dashboard.CoverageQuery {
+	var projects []string
+	if *flagRepo == "all" {
+		for p, r := range repos.ByGerritProject {
+			if r.CoordinatorCanBuild {
+				projects = append(projects, p)
+			}
+		}
+		sort.Strings(projects)
+	} else {
+		projects = splitList(*flagRepo)
+	}
+	goBranches := splitList(*flagGoBranch)
+	if len(goBranches) == 0 {
+		goBranches = []string{"master"}
+	}
+
+	var qs []dashboard.CoverageQuery
+	for _, repo := range projects {
+		for _, branch := range splitList(*flagBranch) {
+			q := dashboard.CoverageQuery{
+				Repo:     repo,
+				Branch:   branch,
+				TryTerms: splitList(*flagTry),
+			}
+			if repo == "go" {
+				// The go repo is built with itself.
+				q.GoBranch, q.DistTests = branch, tests
+				qs = append(qs, q)
+				continue
+			}
+			for _, goBranch := range goBranches {
+				q.GoBranch = goBranch
+				qs = append(qs, q)
+			}
+		}
+	}
+	return qs
+}
+
+// queryName returns a short description of q, such as "tools@master"
+// or "tools@master (go@release-branch.go1.20)".
+func queryName(q dashboard.CoverageQuery) string {
+	if q.Repo == "go" {
+		return "go@" + q.Branch
+	}
+	return fmt.Sprintf("%s@%s (go@%s)", q.Repo, q.Branch, q.GoBranch)
+}
+
+// printMatrix prints the coverage as a table of builders, with the
+// dist tests they skip.
+func printMatrix(tw *tabwriter.Writer, cov *dashboard.Coverage) {
+	fmt.Fprintf(tw, "# %s\n", queryName(cov.CoverageQuery))
+	fmt.Fprintln(tw, "BUILDER\tPOST-SUBMIT\tTRYBOT\tSLOWBOT\tSKIPPED TESTS\tSKIPPED TRY TESTS")
+	mark := func(b bool) string {
+		if b {
+			return "x"
+		}
+		return ""
+	}
+	for _, b := range cov.Builders {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", b.Name,
+			mark(b.PostSubmit), mark(b.TryBot), mark(b.SlowBot),
+			strings.Join(b.SkippedTests, ","), strings.Join(b.SkippedTryTests, ","))
+	}
+}
+
+func printJSON(v interface{}) {
+	j, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(append(j, '\n'))
+}
+
+// listDistTests returns the cmd/dist tests of the Go checkout goroot.
+func listDistTests(goroot string) ([]string, error) {
+	cmd := exec.Command(filepath.Join(goroot, "bin", "go"), "tool", "dist", "test", "-list")
+	cmd.Env = append(os.Environ(), "GOROOT="+goroot)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing dist tests of %s: %v\n%s", goroot, err, stderr.Bytes())
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// splitList splits a comma- or space-separated list.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(c rune) bool { return c == ',' || unicode.IsSpace(c) })
+}
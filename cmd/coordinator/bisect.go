@@ -0,0 +1,375 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+// Code related to bisecting post-submit failures.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/coordinator/bisect"
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/internal/coordinator/pool/queue"
+	"golang.org/x/build/internal/coordinator/schedule"
+	"golang.org/x/build/internal/sourcecache"
+	"golang.org/x/build/internal/spanlog"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+)
+
+// maxBisectJobs is the number of bisection jobs kept for the status page.
+const maxBisectJobs = 20
+
+var (
+	// bisectCache holds the results of testing commits, so repeated
+	// or overlapping bisections don't test a commit twice.
+	bisectCache = bisect.NewCache(1000)
+
+	bisectMu   sync.Mutex
+	bisectJobs []*bisectJob // oldest first
+
+	// reportedCulprits holds the culprits already reported, keyed by
+	// culpritKey, so that overlapping bisections report each once.
+	// It is guarded by bisectMu.
+	reportedCulprits = map[string]bool{}
+)
+
+// A bisectJob is a running or finished bisection of a post-submit failure.
+type bisectJob struct {
+	bisect.Job
+	comment bool // post the culprit on its Gerrit CL
+	start   time.Time
+
+	mu      sync.Mutex
+	commits int             // size of the commit range; 0 until known
+	results []bisect.Result // in the order they became known
+	culprit *bisect.Result
+	done    bool
+	err     error
+}
+
+// startBisect starts bisecting j in its own goroutine.
+func startBisect(j bisect.Job, comment bool) *bisectJob {
+	bj := &bisectJob{Job: j, comment: comment, start: time.Now()}
+	bisectMu.Lock()
+	bisectJobs = append(bisectJobs, bj)
+	if len(bisectJobs) > maxBisectJobs {
+		bisectJobs = bisectJobs[len(bisectJobs)-maxBisectJobs:]
+	}
+	bisectMu.Unlock()
+	go bj.run(context.Background())
+	return bj
+}
+
+func (bj *bisectJob) run(ctx context.Context) {
+	culprit, err := bj.bisect(ctx)
+	bj.mu.Lock()
+	bj.done, bj.err = true, err
+	if err == nil {
+		bj.culprit = &culprit
+	}
+	bj.mu.Unlock()
+	if err != nil {
+		log.Printf("bisect %s %s..%s: %v", bj.Builder, bj.Good, bj.Bad, err)
+		return
+	}
+	log.Printf("bisect %s %s..%s: culprit is %s", bj.Builder, bj.Good, bj.Bad, culprit.Commit)
+	if !markCulpritReported(bj.Job, culprit.Commit) {
+		// Already reported by an earlier bisection.
+		return
+	}
+	bj.report(ctx, culprit)
+}
+
+// markCulpritReported records that commit is the culprit found for j,
+// and reports whether it wasn't already.
+func markCulpritReported(j bisect.Job, commit string) bool {
+	key := culpritKey(j, commit)
+	bisectMu.Lock()
+	defer bisectMu.Unlock()
+	if reportedCulprits[key] {
+		return false
+	}
+	reportedCulprits[key] = true
+	return true
+}
+
+// culpritKey identifies the culprit commit of a failure of j's test on
+// j's builder, whatever the commit range bisected.
+func culpritKey(j bisect.Job, commit string) string {
+	return j.Builder + "\x00" + strings.Join(j.Test, "\x00") + "\x00" + commit
+}
+
+func (bj *bisectJob) bisect(ctx context.Context) (bisect.Result, error) {
+	res, err := maintnerClient.ListCommitRange(ctx, &apipb.ListCommitRangeRequest{
+		Good: bj.Good,
+		Bad:  bj.Bad,
+	})
+	if err != nil {
+		return bisect.Result{}, err
+	}
+	if res.UnknownCommit {
+		return bisect.Result{}, errors.New("maintner does not know the good or bad commit")
+	}
+	if res.NotAncestor {
+		return bisect.Result{}, fmt.Errorf("%s is not an ancestor of %s", bj.Good, bj.Bad)
+	}
+	bj.mu.Lock()
+	bj.commits = len(res.Commits)
+	bj.mu.Unlock()
+	return bisect.Bisect(ctx, bj.Job, res.Commits, bj.testCommit, bisectCache, bj.addResult)
+}
+
+func (bj *bisectJob) addResult(r bisect.Result) {
+	bj.mu.Lock()
+	defer bj.mu.Unlock()
+	bj.results = append(bj.results, r)
+}
+
+// testCommit builds commit on a fresh buildlet of the job's builder
+// and runs the job's test command.
+func (bj *bisectJob) testCommit(ctx context.Context, commit string) (bisect.Result, error) {
+	conf, ok := dashboard.Builders[bj.Builder]
+	if !ok {
+		return bisect.Result{}, fmt.Errorf("unknown builder %q", bj.Builder)
+	}
+	br := buildgo.BuilderRev{Name: bj.Builder, Rev: commit}
+	p := new(bisectProbe)
+	start := time.Now()
+
+	sp := p.CreateSpan("get_buildlet")
+	bc, err := sched.GetBuildlet(ctx, &queue.SchedItem{
+		HostType:    conf.HostType,
+		BuilderRev:  br,
+		Repo:        "go",
+		RequestTime: start,
+	})
+	if err := sp.Done(err); err != nil {
+		return bisect.Result{}, err
+	}
+	defer bc.Close()
+
+	if err := bc.PutTar(ctx, buildgo.VersionTgz(commit), "go"); err != nil {
+		return bisect.Result{}, fmt.Errorf("writing VERSION tgz: %v", err)
+	}
+	srcTar, err := sourcecache.GetSourceTgz(p, "go", commit)
+	if err != nil {
+		return bisect.Result{}, err
+	}
+	if err := bc.PutTar(ctx, srcTar, "go"); err != nil {
+		return bisect.Result{}, fmt.Errorf("writing tarball from Gerrit: %v", err)
+	}
+	if u := conf.GoBootstrapURL(pool.NewGCEConfiguration().BuildEnv()); u != "" {
+		if err := bc.PutTarFromURL(ctx, u, "go1.4"); err != nil {
+			return bisect.Result{}, fmt.Errorf("writing bootstrap toolchain: %v", err)
+		}
+	}
+
+	gb := buildgo.GoBuilder{Logger: p, BuilderRev: br, Conf: conf, Goroot: "go"}
+	remoteErr, err := gb.RunMake(ctx, bc, p)
+	if err != nil {
+		return bisect.Result{}, err
+	}
+	if remoteErr == nil && len(bj.Test) > 0 {
+		sp := p.CreateSpan("run_test", strings.Join(bj.Test, " "))
+		remoteErr, err = bc.Exec(ctx, "go/bin/go", buildlet.ExecOpts{
+			Output:   p,
+			Dir:      "go/src",
+			ExtraEnv: append(conf.Env(), "GOBIN="),
+			Path:     []string{conf.FilePathJoin("$WORKDIR", "go", "bin"), "$PATH"},
+			Args:     bj.Test,
+		})
+		sp.Done(err)
+		if err != nil {
+			return bisect.Result{}, err
+		}
+	}
+	if remoteErr != nil {
+		fmt.Fprintf(p, "\n%v\n", remoteErr)
+	}
+	return bisect.Result{
+		Commit:   commit,
+		Passed:   remoteErr == nil,
+		Log:      p.String(),
+		Duration: time.Since(start),
+	}, nil
+}
+
+// report sends the culprit of the bisection to the dashboard and, if
+// requested, to the culprit's Gerrit CL.
+func (bj *bisectJob) report(ctx context.Context, culprit bisect.Result) {
+	what := "make.bash"
+	if len(bj.Test) > 0 {
+		what = "go " + strings.Join(bj.Test, " ")
+	}
+	summary := fmt.Sprintf("Bisecting %s from %s to %s found this commit to be the first where %s fails.",
+		bj.Builder, bj.Good[:8], bj.Bad[:8], what)
+
+	br := buildgo.BuilderRev{Name: bj.Builder, Rev: culprit.Commit}
+	if err := recordResult(br, false, summary+"\n\n"+culprit.Log, culprit.Duration); err != nil {
+		log.Printf("bisect: recording result of %s on the dashboard: %v", culprit.Commit, err)
+	}
+
+	if !bj.comment {
+		return
+	}
+	gerritClient := pool.NewGCEConfiguration().GerritClient()
+	cis, err := gerritClient.QueryChanges(ctx, "commit:"+culprit.Commit)
+	if err != nil || len(cis) != 1 {
+		log.Printf("bisect: finding CL of %s: %d changes, %v", culprit.Commit, len(cis), err)
+		return
+	}
+	msg := fmt.Sprintf("%s\nSee https://farmer.golang.org/bisect for details.", summary)
+	if err := gerritClient.SetReview(ctx, cis[0].ID, culprit.Commit, gerrit.ReviewInput{Message: msg}); err != nil {
+		log.Printf("bisect: leaving Gerrit comment on %s: %v", culprit.Commit, err)
+	}
+}
+
+// bisectProbe collects the output and events of testing one commit.
+type bisectProbe struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+var _ spanlog.Logger = (*bisectProbe)(nil)
+
+func (p *bisectProbe) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.buf.Write(b)
+}
+
+func (p *bisectProbe) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.buf.String()
+}
+
+func (p *bisectProbe) LogEventTime(event string, optText ...string) {
+	fmt.Fprintf(p, "%s %s %s\n", time.Now().UTC().Format(time.RFC3339), event, strings.Join(optText, " "))
+}
+
+func (p *bisectProbe) CreateSpan(event string, optText ...string) spanlog.Span {
+	return schedule.CreateSpan(p, event, optText...)
+}
+
+//go:embed templates/bisect.html
+var bisectTemplateStr string
+
+var bisectTemplate = template.Must(baseTmpl.New("bisect.html").Funcs(template.FuncMap{
+	"shortHash":     shortHash,
+	"timeSince":     timeSince,
+	"humanDuration": humanDuration,
+}).Parse(bisectTemplateStr))
+
+// bisectStatus is the status page data of a bisectJob.
+type bisectStatus struct {
+	bisect.Job
+	Start   time.Time
+	Commits int
+	Results []bisect.Result
+	Culprit *bisect.Result
+	Done    bool
+	Err     error
+}
+
+func (bj *bisectJob) status() bisectStatus {
+	bj.mu.Lock()
+	defer bj.mu.Unlock()
+	return bisectStatus{
+		Job:     bj.Job,
+		Start:   bj.start,
+		Commits: bj.commits,
+		Results: append([]bisect.Result(nil), bj.results...),
+		Culprit: bj.culprit,
+		Done:    bj.done,
+		Err:     bj.err,
+	}
+}
+
+// handleBisect serves the status of recent bisections, and starts
+// new ones on POST. A POST must be authenticated with the builder's
+// dashboard key.
+func handleBisect(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		job, comment, err := parseBisectRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if key := builderKey(job.Builder); key == "" || !hmac.Equal([]byte(r.FormValue("key")), []byte(key)) {
+			http.Error(w, "invalid key", http.StatusForbidden)
+			return
+		}
+		startBisect(job, comment)
+		http.Redirect(w, r, "/bisect", http.StatusSeeOther)
+		return
+	}
+
+	bisectMu.Lock()
+	jobs := append([]*bisectJob(nil), bisectJobs...)
+	bisectMu.Unlock()
+	var data struct{ Jobs []bisectStatus }
+	for i := len(jobs) - 1; i >= 0; i-- {
+		data.Jobs = append(data.Jobs, jobs[i].status())
+	}
+	if err := bisectTemplate.Execute(w, data); err != nil {
+		log.Printf("handleBisect: %v", err)
+	}
+}
+
+// parseBisectRequest parses the form of a request to start a bisection.
+func parseBisectRequest(r *http.Request) (job bisect.Job, comment bool, err error) {
+	job = bisect.Job{
+		Builder: r.FormValue("builder"),
+		Good:    r.FormValue("good"),
+		Bad:     r.FormValue("bad"),
+		Test:    strings.Fields(r.FormValue("test")),
+	}
+	if _, ok := dashboard.Builders[job.Builder]; !ok {
+		return bisect.Job{}, false, fmt.Errorf("unknown builder %q", job.Builder)
+	}
+	if !isHexCommit(job.Good) || !isHexCommit(job.Bad) {
+		return bisect.Job{}, false, errors.New("good and bad must be full commit hashes")
+	}
+	return job, r.FormValue("comment") == "1", nil
+}
+
+func isHexCommit(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func shortHash(s string) string {
+	if len(s) > 8 {
+		return s[:8]
+	}
+	return s
+}
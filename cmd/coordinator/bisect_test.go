@@ -0,0 +1,116 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+package main
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/bisect"
+)
+
+func TestParseBisectRequest(t *testing.T) {
+	const (
+		good = "1111111111111111111111111111111111111111"
+		bad  = "2222222222222222222222222222222222222222"
+	)
+	tests := []struct {
+		form        url.Values
+		wantTest    []string
+		wantComment bool
+		wantErr     bool
+	}{
+		{
+			form:     url.Values{"builder": {"linux-amd64"}, "good": {good}, "bad": {bad}},
+			wantTest: nil,
+		},
+		{
+			form:        url.Values{"builder": {"linux-amd64"}, "good": {good}, "bad": {bad}, "test": {"test  -run=TestFoo net/http"}, "comment": {"1"}},
+			wantTest:    []string{"test", "-run=TestFoo", "net/http"},
+			wantComment: true,
+		},
+		{
+			form:    url.Values{"builder": {"no-such-builder"}, "good": {good}, "bad": {bad}},
+			wantErr: true,
+		},
+		{
+			form:    url.Values{"builder": {"linux-amd64"}, "good": {"HEAD"}, "bad": {bad}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/bisect", strings.NewReader(tt.form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		job, comment, err := parseBisectRequest(req)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseBisectRequest(%v) error = %v; want error: %v", tt.form, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if job.Good != good || job.Bad != bad || strings.Join(job.Test, " ") != strings.Join(tt.wantTest, " ") || comment != tt.wantComment {
+			t.Errorf("parseBisectRequest(%v) = %+v, %v; want test %q, comment %v", tt.form, job, comment, tt.wantTest, tt.wantComment)
+		}
+	}
+}
+
+func TestHandleBisectStatus(t *testing.T) {
+	defer func(old []*bisectJob) { bisectJobs = old }(bisectJobs)
+	const culprit = "3333333333333333333333333333333333333333"
+	bisectJobs = []*bisectJob{{
+		Job: bisect.Job{
+			Builder: "linux-amd64",
+			Good:    "1111111111111111111111111111111111111111",
+			Bad:     "2222222222222222222222222222222222222222",
+			Test:    []string{"test", "runtime"},
+		},
+		start:   time.Now(),
+		commits: 3,
+		results: []bisect.Result{{Commit: culprit, Duration: time.Minute}},
+		culprit: &bisect.Result{Commit: culprit},
+		done:    true,
+	}}
+
+	w := httptest.NewRecorder()
+	handleBisect(w, httptest.NewRequest("GET", "/bisect", nil))
+	body := w.Body.String()
+	for _, want := range []string{"linux-amd64: 11111111..22222222", "go test runtime", "Culprit:", culprit} {
+		if !strings.Contains(body, want) {
+			t.Errorf("status page does not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestMarkCulpritReported(t *testing.T) {
+	defer func(old map[string]bool) { reportedCulprits = old }(reportedCulprits)
+	reportedCulprits = map[string]bool{}
+	const culprit = "3333333333333333333333333333333333333333"
+	j := bisect.Job{Builder: "linux-amd64", Good: "1111", Bad: "2222", Test: []string{"test", "net/http"}}
+
+	if !markCulpritReported(j, culprit) {
+		t.Errorf("first markCulpritReported = false; want true")
+	}
+	// A later bisection over another range finds the same culprit,
+	// maybe from a cached midpoint result: it's not reported again.
+	j2 := j
+	j2.Good = "0000"
+	if markCulpritReported(j2, culprit) {
+		t.Errorf("markCulpritReported for the same culprit = true; want false")
+	}
+	// The same commit failing another test is a distinct culprit.
+	j3 := j
+	j3.Test = []string{"test", "os"}
+	if !markCulpritReported(j3, culprit) {
+		t.Errorf("markCulpritReported for another test = false; want true")
+	}
+}
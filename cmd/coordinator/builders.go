@@ -17,11 +17,16 @@ import (
 	"html/template"
 	"net/http"
 	"strings"
+	"unicode"
 
 	"golang.org/x/build/dashboard"
 )
 
 func handleBuilders(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue("repo") != "" {
+		handleBuilderCoverage(w, r)
+		return
+	}
 	data := struct {
 		Builders map[string]*dashboard.BuildConfig
 		Hosts    map[string]*dashboard.HostConfig
@@ -45,6 +50,41 @@ func handleBuilders(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleBuilderCoverage serves the JSON form of the coverage by
+// builders of the repo, branch and goBranch parameters, such as
+//
+//	/builders?repo=tools&branch=master&goBranch=release-branch.go1.20&try=linux-arm64,darwin
+//
+// The optional try parameter is the terms of a TRY= comment, and the
+// optional tests parameter is a comma-separated list of cmd/dist tests.
+func handleBuilderCoverage(w http.ResponseWriter, r *http.Request) {
+	q := dashboard.CoverageQuery{
+		Repo:      r.FormValue("repo"),
+		Branch:    r.FormValue("branch"),
+		GoBranch:  r.FormValue("goBranch"),
+		TryTerms:  splitTerms(r.FormValue("try")),
+		DistTests: splitTerms(r.FormValue("tests")),
+	}
+	if q.Branch == "" {
+		q.Branch = "master"
+	}
+	if q.Repo != "go" && q.GoBranch == "" {
+		q.GoBranch = "master"
+	}
+	j, err := json.MarshalIndent(dashboard.CoverageOf(dashboard.Builders, q), "", "\t")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(j)
+}
+
+// splitTerms splits a comma- or space-separated list.
+func splitTerms(s string) []string {
+	return strings.FieldsFunc(s, func(c rune) bool { return c == ',' || unicode.IsSpace(c) })
+}
+
 //go:embed templates/builders.html
 var buildersTmplStr string
 
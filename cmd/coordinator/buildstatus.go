@@ -18,7 +18,6 @@ import (
 	"io"
 	"log"
 	"os"
-	"path"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -34,6 +33,7 @@ import (
 	"golang.org/x/build/internal/coordinator/pool"
 	"golang.org/x/build/internal/coordinator/pool/queue"
 	"golang.org/x/build/internal/coordinator/schedule"
+	"golang.org/x/build/internal/coordinator/state"
 	"golang.org/x/build/internal/singleflight"
 	"golang.org/x/build/internal/sourcecache"
 	"golang.org/x/build/internal/spanlog"
@@ -84,8 +84,11 @@ type buildStatus struct {
 	commitDetail
 	buildID   string // "B" + 9 random hex
 	conf      *dashboard.BuildConfig
-	startTime time.Time // actually time of newBuild (~same thing)
-	trySet    *trySet   // or nil
+	startTime time.Time    // actually time of newBuild (~same thing)
+	trySet    *trySet      // or nil
+	reattach  *state.Build // for a resumed try set, the saved build whose buildlet to reuse; or nil
+	attempt   int          // number of times the try build was rerun from the Gerrit Checks tab
+	tryDeps   []tryDep     // CLs of other x/ repos whose modules replace those of a try build
 
 	onceInitHelpers sync.Once // guards call of onceInitHelpersFunc
 	helpers         <-chan buildlet.Client
@@ -230,6 +233,9 @@ func (st *buildStatus) expectedBuildletStartDuration() time.Duration {
 	// measurements, it'll need GCE services (bigtable/bigquery?), so it's probably
 	// better in this file.
 	p := st.buildletPool()
+	if buildletPrewarmer.Ready(st.conf.HostType) {
+		return 0
+	}
 	switch p.(type) {
 	case *pool.GCEBuildlet:
 		if strings.HasPrefix(st.Name, "android-") {
@@ -261,7 +267,7 @@ func (st *buildStatus) expectedBuildletStartDuration() time.Duration {
 // ready, such that they're ready when make.bash is done. But we don't
 // want to start too early, lest we waste idle resources during make.bash.
 func (st *buildStatus) getHelpersReadySoon() {
-	if st.IsSubrepo() || st.conf.NumTestHelpers(st.isTry()) == 0 || st.conf.IsReverse() {
+	if st.IsSubrepo() || st.numTestHelpers() == 0 || st.conf.IsReverse() {
 		return
 	}
 	time.AfterFunc(st.expectedMakeBashDuration()-st.expectedBuildletStartDuration(),
@@ -288,7 +294,45 @@ func (st *buildStatus) onceInitHelpersFunc() {
 		Repo:       st.RepoOrGo(),
 		User:       st.AuthorEmail,
 	}
-	st.helpers = getBuildlets(st.ctx, st.conf.NumTestHelpers(st.isTry()), schedTmpl, st)
+	st.helpers = getBuildlets(st.ctx, st.numTestHelpers(), schedTmpl, st)
+}
+
+const (
+	// tryTestDuration and postSubmitTestDuration are how long the
+	// sharded tests of a build should take, used to pick the number
+	// of test helpers.
+	tryTestDuration        = 3 * time.Minute
+	postSubmitTestDuration = 10 * time.Minute
+)
+
+// numTestHelpers returns how many helper buildlets to use for sharding
+// tests. Once the builder's total test time is known from earlier
+// builds, it's as many as needed for the tests to take about
+// tryTestDuration or postSubmitTestDuration, up to twice the builder's
+// NumTestHelpers. Until then, or for builders without helpers, it's
+// NumTestHelpers.
+func (st *buildStatus) numTestHelpers() int {
+	n := st.conf.NumTestHelpers(st.isTry())
+	if n == 0 {
+		return 0
+	}
+	total, ok := testDurations.Total(st.Name)
+	if !ok {
+		return n
+	}
+	target := postSubmitTestDuration
+	if st.isTry() {
+		target = tryTestDuration
+	}
+	// The main buildlet runs tests too.
+	want := int((total+target-1)/target) - 1
+	if want < 0 {
+		want = 0
+	}
+	if want > 2*n {
+		want = 2 * n
+	}
+	return want
 }
 
 // useSnapshot reports whether this type of build uses a snapshot of
@@ -375,13 +419,17 @@ func (st *buildStatus) getBuildlet() (buildlet.Client, error) {
 	st.schedItem = schedItem
 	st.mu.Unlock()
 
-	sp := st.CreateSpan("get_buildlet")
-	bc, err := sched.GetBuildlet(st.ctx, schedItem)
-	sp.Done(err)
-	if err != nil {
-		err = fmt.Errorf("failed to get a buildlet: %v", err)
-		go st.reportErr(err)
-		return nil, err
+	bc := st.getReattachedBuildlet()
+	if bc == nil {
+		sp := st.CreateSpan("get_buildlet")
+		var err error
+		bc, err = sched.GetBuildlet(st.ctx, schedItem)
+		sp.Done(err)
+		if err != nil {
+			err = fmt.Errorf("failed to get a buildlet: %v", err)
+			go st.reportErr(err)
+			return nil, err
+		}
 	}
 	atomic.StoreInt32(&st.hasBuildlet, 1)
 
@@ -389,6 +437,9 @@ func (st *buildStatus) getBuildlet() (buildlet.Client, error) {
 	st.bc = bc
 	st.mu.Unlock()
 	st.LogEventTime("using_buildlet", bc.IPPort())
+	if st.trySet != nil {
+		go st.trySet.saveState()
+	}
 
 	return bc, nil
 }
@@ -499,6 +550,9 @@ func (st *buildStatus) build() error {
 			}
 			return fmt.Errorf("Build succeeded but failed to report it to the dashboard: %v", err)
 		}
+		if remoteErr != nil {
+			indexBuildLog(st, buildLog, dashboardLogURL(buildLog))
+		}
 	}
 	if remoteErr != nil {
 		return remoteErr
@@ -911,18 +965,19 @@ func (st *buildStatus) distTestList() (names []string, remoteErr, err error) {
 
 type token struct{}
 
-// newTestSet returns a new testSet given the dist test names (strings from "go tool dist test -list")
-// and benchmark items.
-func (st *buildStatus) newTestSet(testStats *buildstats.TestStats, distTestNames []string) (*testSet, error) {
+// newTestSet returns a new testSet given the dist test names (strings from "go tool dist test -list"),
+// with durations estimated by durations.
+func (st *buildStatus) newTestSet(durations *buildstats.DurationEstimator, distTestNames []string) (*testSet, error) {
 	set := &testSet{
 		st:        st,
-		testStats: testStats,
+		durations: durations,
+		workers:   1 + st.numTestHelpers(),
 	}
 	for _, name := range distTestNames {
 		set.items = append(set.items, &testItem{
 			set:      set,
 			name:     name,
-			duration: testStats.Duration(st.BuilderRev.Name, name),
+			duration: durations.Duration(st.BuilderRev.Name, name),
 			take:     make(chan token, 1),
 			done:     make(chan token),
 		})
@@ -933,6 +988,10 @@ func (st *buildStatus) newTestSet(testStats *buildstats.TestStats, distTestNames
 var (
 	testStats       atomic.Value // of *buildstats.TestStats
 	testStatsLoader singleflight.Group
+
+	// testDurations estimates test durations from the results of
+	// recent builds, falling back to testStats.
+	testDurations = buildstats.NewDurationEstimator()
 )
 
 func getTestStats(sl spanlog.Logger) *buildstats.TestStats {
@@ -954,6 +1013,7 @@ func getTestStats(sl spanlog.Logger) *buildstats.TestStats {
 			return nil, err
 		}
 		testStats.Store(ts)
+		testDurations.SetTestStats(ts)
 		return ts, nil
 	})
 	if err != nil {
@@ -1005,37 +1065,31 @@ func (st *buildStatus) runSubrepoTests() (remoteErr, err error) {
 	}
 
 	// Look for inner modules, in order to test them too. See golang.org/issue/32528.
-	sp := st.CreateSpan("listing_subrepo_modules", st.SubName)
-	err = st.bc.ListDir(st.ctx, "gopath/src/"+repoPath, buildlet.ListDirOpts{Recursive: true}, func(e buildlet.DirEntry) {
-		goModFile := path.Base(e.Name()) == "go.mod" && !e.IsDir()
-		if !goModFile {
-			return
-		}
-		// Found a go.mod file in a subdirectory, which indicates the root of a module.
-		modulePath := path.Join(repoPath, path.Dir(e.Name()))
-		if modulePath == repoPath {
-			// This is the go.mod file at the repository root.
-			// It's already a part of testRuns, so skip it.
-			return
-		} else if ignoredByGoTool(modulePath) || isVendored(modulePath) {
-			// go.mod file is in a directory we're not looking to support, so skip it.
-			return
-		}
+	moduleDirs, err := st.listModuleDirs("gopath/src/" + repoPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range moduleDirs[1:] {
 		// Add an additional test run entry that will test all packages in this module.
 		testRuns = append(testRuns, goTestRun{
-			Dir:      "gopath/src/" + modulePath,
+			Dir:      dir,
 			Patterns: []string{"./..."},
 		})
-	})
-	sp.Done(err)
-	if err != nil {
-		return nil, err
+	}
+
+	// Build with the CLs of other repos that the try run depends on.
+	var goWork string
+	if len(st.tryDeps) > 0 {
+		goWork, err = st.putTryDeps(moduleDirs)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Finally, execute all of the test runs.
 	// If any fail, keep going so that all test results are included in the output.
 
-	sp = st.CreateSpan("running_subrepo_tests", st.SubName)
+	sp := st.CreateSpan("running_subrepo_tests", st.SubName)
 	defer func() { sp.Done(err) }()
 
 	env := append(st.conf.Env(),
@@ -1047,6 +1101,9 @@ func (st *buildStatus) runSubrepoTests() (remoteErr, err error) {
 		env = append(env, "GOPROXY="+moduleProxy())
 	}
 	env = append(env, st.conf.ModulesEnv(st.SubName)...)
+	if goWork != "" {
+		env = append(env, "GOWORK="+st.conf.FilePathJoin(workDir, goWork))
+	}
 
 	args := []string{"test"}
 	if st.conf.CompileOnly {
@@ -1483,9 +1540,9 @@ func (st *buildStatus) runTests(helpers <-chan buildlet.Client) (remoteErr, err
 	if err != nil {
 		return nil, fmt.Errorf("distTestList exec: %v", err)
 	}
-	testStats := getTestStats(st)
+	getTestStats(st) // refreshes the fallback of testDurations
 
-	set, err := st.newTestSet(testStats, testNames)
+	set, err := st.newTestSet(testDurations, testNames)
 	if err != nil {
 		return nil, err
 	}
@@ -1626,8 +1683,9 @@ func (st *buildStatus) runTests(helpers <-chan buildlet.Client) (remoteErr, err
 		}
 	}
 	elapsed := time.Since(startTime)
+	testDurations.ObserveTotal(st.Name, serialDuration)
 	var msg string
-	if st.conf.NumTestHelpers(st.isTry()) > 0 {
+	if set.workers > 1 {
 		msg = fmt.Sprintf("took %v; aggregate %v; saved %v", elapsed, serialDuration, serialDuration-elapsed)
 	} else {
 		msg = fmt.Sprintf("took %v", elapsed)
@@ -1783,6 +1841,10 @@ func (st *buildStatus) runTestsOnBuildlet(bc buildlet.Client, tis []*testItem, g
 		return
 	}
 
+	if remoteErr == nil {
+		testDurations.Observe(st.Name, names, execDuration)
+	}
+
 	out := buf.Bytes()
 	out = bytes.Replace(out, []byte("\nALL TESTS PASSED (some were excluded)\n"), nil, 1)
 	out = bytes.Replace(out, []byte("\nALL TESTS PASSED\n"), nil, 1)
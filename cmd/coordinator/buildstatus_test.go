@@ -10,6 +10,11 @@ package main
 
 import (
 	"testing"
+	"time"
+
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/buildstats"
 )
 
 // TestParseOutputAndHeader tests header parsing by parseOutputAndHeader.
@@ -198,3 +203,43 @@ XXXBANNERXXX:Testing packages.`),
 		})
 	}
 }
+
+func TestNumTestHelpers(t *testing.T) {
+	defer func(old *buildstats.DurationEstimator) { testDurations = old }(testDurations)
+	testDurations = buildstats.NewDurationEstimator()
+
+	st := &buildStatus{
+		BuilderRev: buildgo.BuilderRev{Name: "linux-amd64"},
+		conf:       dashboard.Builders["linux-amd64"],
+	}
+	configured := st.conf.NumTestHelpers(false)
+	if configured == 0 {
+		t.Fatal("linux-amd64 has no test helpers; pick another builder for this test")
+	}
+	if got := st.numTestHelpers(); got != configured {
+		t.Errorf("numTestHelpers() without history = %d; want %d", got, configured)
+	}
+
+	for _, tt := range []struct {
+		total time.Duration
+		want  int
+	}{
+		{5 * time.Minute, 0},
+		{postSubmitTestDuration + time.Minute, 1},
+		{100 * postSubmitTestDuration, 2 * configured},
+	} {
+		testDurations = buildstats.NewDurationEstimator()
+		testDurations.ObserveTotal("linux-amd64", tt.total)
+		if got := st.numTestHelpers(); got != tt.want {
+			t.Errorf("numTestHelpers() with %v of tests = %d; want %d", tt.total, got, tt.want)
+		}
+	}
+
+	// Builders configured without helpers don't shard.
+	st.conf = dashboard.Builders["windows-amd64-2016"]
+	st.Name = st.conf.Name
+	testDurations.ObserveTotal(st.Name, time.Hour)
+	if got := st.numTestHelpers(); got != 0 {
+		t.Errorf("numTestHelpers() for %s = %d; want 0", st.Name, got)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+// Code related to the Gerrit checks provider, which reports the
+// builds of try runs to Gerrit's Checks tab.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/build/internal/access"
+	"golang.org/x/build/internal/lru"
+)
+
+// The types below are the JSON form of the Gerrit checks API, fetched
+// by the Gerrit frontend plugin that registers this provider. See
+// https://gerrit.googlesource.com/gerrit/+/master/polygerrit-ui/app/api/checks.ts.
+
+// Statuses of a checkRun.
+const (
+	checkScheduled = "SCHEDULED"
+	checkRunning   = "RUNNING"
+	checkCompleted = "COMPLETED"
+)
+
+// Categories of a checkResult.
+const (
+	checkSuccess = "SUCCESS"
+	checkInfo    = "INFO"
+	checkError   = "ERROR"
+)
+
+// rerunAction is the name of the action which reruns a failed build.
+// The frontend plugin implements it by POSTing the commit and check
+// name to /checks/rerun, which only serves callers authenticated by
+// IAP. See checksRerunHandler.
+const rerunAction = "Rerun"
+
+// maxCheckReruns is the number of times a build of a try run may be
+// rerun from the Checks tab.
+const maxCheckReruns = 2
+
+type checksResponse struct {
+	ResponseCode string     `json:"responseCode"` // "OK" or "ERROR"
+	ErrorMessage string     `json:"errorMessage,omitempty"`
+	Runs         []checkRun `json:"runs"`
+}
+
+type checkRun struct {
+	Attempt            int           `json:"attempt,omitempty"`
+	ExternalID         string        `json:"externalId,omitempty"`
+	CheckName          string        `json:"checkName"`
+	CheckLink          string        `json:"checkLink,omitempty"`
+	Status             string        `json:"status"`
+	StatusDescription  string        `json:"statusDescription,omitempty"`
+	StatusLink         string        `json:"statusLink,omitempty"`
+	LabelName          string        `json:"labelName,omitempty"`
+	Actions            []checkAction `json:"actions,omitempty"`
+	ScheduledTimestamp *time.Time    `json:"scheduledTimestamp,omitempty"`
+	StartedTimestamp   *time.Time    `json:"startedTimestamp,omitempty"`
+	FinishedTimestamp  *time.Time    `json:"finishedTimestamp,omitempty"`
+	Results            []checkResult `json:"results,omitempty"`
+}
+
+type checkResult struct {
+	Category string      `json:"category"`
+	Summary  string      `json:"summary"`
+	Message  string      `json:"message,omitempty"`
+	Links    []checkLink `json:"links,omitempty"`
+}
+
+type checkLink struct {
+	URL     string `json:"url"`
+	Tooltip string `json:"tooltip,omitempty"`
+	Primary bool   `json:"primary"`
+	Icon    string `json:"icon"`
+}
+
+type checkAction struct {
+	Name    string `json:"name"`
+	Tooltip string `json:"tooltip,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// finishedCheckRuns holds the check runs of recently finished try
+// runs, keyed by commit, which are no longer in the tries map.
+var finishedCheckRuns = lru.New(1000)
+
+// checkRuns returns the check runs of the builds of the try set.
+func (ts *trySet) checkRuns() []checkRun {
+	tss := ts.state()
+	failed := make(map[string]bool)
+	for _, name := range tss.failed {
+		failed[name] = true
+	}
+	runs := []checkRun{}
+	for _, bs := range tss.builds {
+		name := bs.NameAndBranch()
+		run := checkRun{
+			Attempt:    bs.attempt + 1,
+			ExternalID: bs.buildID,
+			CheckName:  name,
+			CheckLink:  ts.statusPage(),
+			StatusLink: ts.statusPage(),
+			LabelName:  "TryBot-Result",
+		}
+		start := bs.startTime
+		bs.mu.Lock()
+		done, succeeded, logURL, canceled := bs.done, bs.succeeded, bs.logURL, bs.canceled
+		bs.mu.Unlock()
+		switch {
+		case !done.IsZero():
+			run.Status = checkCompleted
+			run.StartedTimestamp, run.FinishedTimestamp = &start, &done
+			res := checkResult{Category: checkSuccess, Summary: "Build passed."}
+			if logURL != "" {
+				res.Links = []checkLink{{URL: logURL, Tooltip: "Build log", Primary: true, Icon: "external"}}
+			}
+			switch {
+			case canceled:
+				res.Category, res.Summary = checkInfo, "Build canceled."
+			case !succeeded:
+				res.Category, res.Summary = checkError, "Build failed."
+				if failed[name] && tss.remain > 0 && bs.attempt < maxCheckReruns {
+					run.Actions = []checkAction{{Name: rerunAction, Tooltip: "Run this build again", Primary: true}}
+				}
+			}
+			run.Results = []checkResult{res}
+		case bs.HasBuildlet():
+			run.Status = checkRunning
+			run.StartedTimestamp = &start
+			run.StatusDescription = "Building"
+		default:
+			run.Status = checkScheduled
+			run.ScheduledTimestamp = &start
+			run.StatusDescription = "Waiting for a buildlet"
+		}
+		runs = append(runs, run)
+	}
+	for _, r := range tss.reused {
+		runs = append(runs, checkRun{
+			CheckName:  r.name,
+			CheckLink:  ts.statusPage(),
+			StatusLink: ts.statusPage(),
+			LabelName:  "TryBot-Result",
+			Status:     checkCompleted,
+			Results: []checkResult{{
+				Category: checkSuccess,
+				Summary:  fmt.Sprintf("Passed on equivalent commit %s.", r.commit[:8]),
+				Message:  "The result was reused from an earlier patch set that has the same code, differing only by its commit message or a rebase that leaves the tree unchanged.",
+			}},
+		})
+	}
+	return runs
+}
+
+// rememberCheckRuns keeps the check runs of the finished try set, so
+// they're still served after it's removed from the tries map.
+func (ts *trySet) rememberCheckRuns() {
+	finishedCheckRuns.Add(ts.Commit, ts.checkRuns())
+}
+
+// checkRunsOfCommit returns the check runs of the try run of commit.
+func checkRunsOfCommit(commit string) []checkRun {
+	if ts := trySetOfCommit(commit); ts != nil {
+		return ts.checkRuns()
+	}
+	if runs, ok := finishedCheckRuns.Get(commit); ok {
+		return runs.([]checkRun)
+	}
+	return []checkRun{}
+}
+
+// trySetOfCommit returns the try set of the full commit hash, or nil.
+func trySetOfCommit(commit string) *trySet {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	for k, ts := range tries {
+		if k.Commit == commit {
+			return ts
+		}
+	}
+	return nil
+}
+
+// handleChecks serves the check runs of the try run of a commit, in
+// the form of the Gerrit checks API.
+func handleChecks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if r.Method == "OPTIONS" {
+		// This is likely a pre-flight CORS request.
+		return
+	}
+	resp := checksResponse{ResponseCode: "OK"}
+	if commit := r.FormValue("commit"); isHexCommit(commit) {
+		resp.Runs = checkRunsOfCommit(commit)
+	} else {
+		resp.ResponseCode, resp.ErrorMessage = "ERROR", "commit must be a full commit hash"
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+		log.Printf("Could not encode JSON response: %v", err)
+		http.Error(w, "error encoding JSON", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(buf.Bytes())
+}
+
+// checksRerunHandler returns the handler of /checks/rerun. Rerunning
+// builds uses the resources of the build farm, so unlike the read-only
+// /checks endpoint it requires a caller authenticated by IAP, and isn't
+// open to cross-origin requests.
+func checksRerunHandler() http.Handler {
+	return access.RequireIAPAuthHandler(http.HandlerFunc(handleChecksRerun), access.IAPSkipAudienceValidation)
+}
+
+// handleChecksRerun reruns a failed build of a try run which is still
+// in progress, for the Rerun action of the Checks tab. The caller must
+// have been authenticated; see checksRerunHandler.
+func handleChecksRerun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "requires POST method", http.StatusMethodNotAllowed)
+		return
+	}
+	commit, name := r.FormValue("commit"), r.FormValue("check")
+	if !isHexCommit(commit) {
+		http.Error(w, "commit must be a full commit hash", http.StatusBadRequest)
+		return
+	}
+	ts := trySetOfCommit(commit)
+	if ts == nil {
+		http.Error(w, "no try run in progress for "+commit, http.StatusNotFound)
+		return
+	}
+	if err := ts.rerun(name); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	log.Printf("Rerunning %s of try run %v from the Checks tab for %s", name, ts.tryKey, r.Header.Get("X-Goog-Authenticated-User-Email"))
+	w.Write([]byte("OK\n"))
+}
+
+// rerun starts the failed build named name (as returned by
+// buildStatus.NameAndBranch) of the try set again.
+func (ts *trySet) rerun(name string) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.canceled {
+		return errors.New("try run was canceled")
+	}
+	if ts.remain == 0 {
+		return errors.New("try run is finished; vote Run-TryBot+1 again to start a new one")
+	}
+	failedIdx := -1
+	for i, n := range ts.failed {
+		if n == name {
+			failedIdx = i
+		}
+	}
+	if failedIdx < 0 {
+		return fmt.Errorf("%q is not a failed build of the try run", name)
+	}
+	for idx, bs := range ts.builds {
+		if bs.NameAndBranch() != name {
+			continue
+		}
+		if bs.attempt >= maxCheckReruns {
+			return fmt.Errorf("%s was already rerun %d times", name, bs.attempt)
+		}
+		nbs, err := newBuild(bs.BuilderRev, bs.commitDetail)
+		if err != nil {
+			return err
+		}
+		nbs.trySet = ts
+		nbs.attempt = bs.attempt + 1
+		nbs.tryDeps = bs.tryDeps
+		ts.builds[idx] = nbs
+		ts.failed = append(ts.failed[:failedIdx], ts.failed[failedIdx+1:]...)
+		ts.remain++
+		removeFailureLine(&ts.errMsg, name)
+		if !testingKnobSkipBuilds {
+			go nbs.start() // acquires statusMu itself, so in a goroutine
+			go ts.awaitTryBuild(idx, nbs, nbs.BuilderRev)
+		}
+		go ts.saveState()
+		return nil
+	}
+	return fmt.Errorf("no build %q in the try run", name)
+}
+
+// removeFailureLine removes the "Failed on" line of build name from
+// the try set error message buf.
+func removeFailureLine(buf *bytes.Buffer, name string) {
+	var kept strings.Builder
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if !strings.HasPrefix(line, "Failed on "+name+": ") {
+			kept.WriteString(line)
+		}
+	}
+	buf.Reset()
+	buf.WriteString(kept.String())
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+)
+
+// newChecksTrySet returns a try set in the tries map with a passed, a
+// failed and a scheduled build.
+func newChecksTrySet(t *testing.T) *trySet {
+	testingKnobSkipBuilds = true
+	work := &apipb.GerritTryWorkItem{
+		Project:   "go",
+		Branch:    "master",
+		ChangeId:  "I5c2a4e6f8a0b2c4d6e8f0a2b4c6d8e0f2a4b6c8d",
+		Commit:    "8e1f3a5c7e9b1d3f5a7c9e1b3d5f7a9c1e3b5d7f",
+		GoCommit:  []string{"9995c6b50aa55c1cc1236d1d688929df512dad53"},
+		GoBranch:  []string{"master"},
+		GoVersion: []*apipb.MajorMinor{{Major: 1, Minor: 17}},
+	}
+	ts := newTrySet(work, tryStack{})
+	if len(ts.builds) < 3 {
+		t.Fatalf("got %d builds, want at least 3", len(ts.builds))
+	}
+	passed, failed := ts.builds[0], ts.builds[1]
+	passed.done, passed.succeeded, passed.logURL = time.Now(), true, "https://storage.googleapis.com/passed.log"
+	failed.done, failed.logURL = time.Now(), "https://storage.googleapis.com/failed.log"
+	ts.remain -= 2
+	ts.failed = []string{failed.NameAndBranch()}
+	ts.errMsg.WriteString("Failed on " + failed.NameAndBranch() + ": " + failed.logURL + "\n")
+
+	statusMu.Lock()
+	tries[ts.tryKey] = ts
+	statusMu.Unlock()
+	t.Cleanup(func() {
+		statusMu.Lock()
+		delete(tries, ts.tryKey)
+		statusMu.Unlock()
+	})
+	return ts
+}
+
+func TestHandleChecks(t *testing.T) {
+	ts := newChecksTrySet(t)
+	failedName := ts.builds[1].NameAndBranch()
+
+	getRuns := func() map[string]checkRun {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handleChecks(w, httptest.NewRequest("GET", "/checks?commit="+ts.Commit, nil))
+		var resp checksResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decoding /checks response: %v", err)
+		}
+		if resp.ResponseCode != "OK" {
+			t.Fatalf("/checks response code = %q (%s); want OK", resp.ResponseCode, resp.ErrorMessage)
+		}
+		runs := make(map[string]checkRun)
+		for _, r := range resp.Runs {
+			runs[r.CheckName] = r
+		}
+		if len(runs) != len(ts.builds) {
+			t.Errorf("/checks returned %d runs; want %d", len(runs), len(ts.builds))
+		}
+		return runs
+	}
+
+	runs := getRuns()
+	if r := runs[ts.builds[0].NameAndBranch()]; r.Status != checkCompleted || len(r.Results) != 1 || r.Results[0].Category != checkSuccess || len(r.Actions) != 0 {
+		t.Errorf("run of passed build = %+v; want completed successfully without actions", r)
+	}
+	r := runs[failedName]
+	if r.Status != checkCompleted || len(r.Results) != 1 || r.Results[0].Category != checkError {
+		t.Errorf("run of failed build = %+v; want completed with an error", r)
+	} else if links := r.Results[0].Links; len(links) != 1 || links[0].URL != ts.builds[1].logURL {
+		t.Errorf("links of failed build = %+v; want its log", links)
+	}
+	if len(r.Actions) != 1 || r.Actions[0].Name != rerunAction {
+		t.Errorf("actions of failed build = %+v; want %s", r.Actions, rerunAction)
+	}
+	if r := runs[ts.builds[2].NameAndBranch()]; r.Status != checkScheduled {
+		t.Errorf("status of unstarted build = %q; want %q", r.Status, checkScheduled)
+	}
+
+	remain := ts.remain
+	rerun := func(check string) int {
+		form := url.Values{"commit": {ts.Commit}, "check": {check}}
+		req := httptest.NewRequest("POST", "/checks/rerun", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handleChecksRerun(w, req)
+		return w.Code
+	}
+	// Without IAP credentials, the rerun endpoint is closed.
+	form := url.Values{"commit": {ts.Commit}, "check": {failedName}}
+	req := httptest.NewRequest("POST", "/checks/rerun", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	checksRerunHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("unauthenticated rerun: status %d, CORS %q; want %d and no CORS header", w.Code, w.Header().Get("Access-Control-Allow-Origin"), http.StatusUnauthorized)
+	}
+	if ts.remain != remain {
+		t.Errorf("unauthenticated rerun changed remain from %d to %d", remain, ts.remain)
+	}
+
+	if code := rerun(ts.builds[0].NameAndBranch()); code != http.StatusConflict {
+		t.Errorf("rerun of passed build: status %d; want %d", code, http.StatusConflict)
+	}
+	if code := rerun(failedName); code != http.StatusOK {
+		t.Fatalf("rerun of failed build: status %d; want %d", code, http.StatusOK)
+	}
+	if ts.remain != remain+1 || len(ts.failed) != 0 || strings.Contains(ts.errMsg.String(), failedName) {
+		t.Errorf("after rerun, remain = %d, failed = %q, errMsg = %q; want %d, none and no failure", ts.remain, ts.failed, ts.errMsg.String(), remain+1)
+	}
+	if r := getRuns()[failedName]; r.Status != checkScheduled || r.Attempt != 2 {
+		t.Errorf("rerun build has status %q, attempt %d; want %q, 2", r.Status, r.Attempt, checkScheduled)
+	}
+}
+
+// fakeGerrit records the reviews posted to it.
+type fakeGerrit struct {
+	mu      sync.Mutex
+	reviews []gerrit.ReviewInput
+}
+
+func (g *fakeGerrit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/review") {
+		var ri gerrit.ReviewInput
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &ri); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		g.mu.Lock()
+		g.reviews = append(g.reviews, ri)
+		g.mu.Unlock()
+	}
+	io.WriteString(w, ")]}'\n{}")
+}
+
+func TestGerritChecksFlag(t *testing.T) {
+	ts := newChecksTrySet(t)
+	ts.remain = 0
+
+	gce := pool.NewGCEConfiguration()
+	defer gce.SetGerritClient(gce.GerritClient())
+	defer func(old bool) { *gerritChecks = old }(*gerritChecks)
+
+	for _, checks := range []bool{true, false} {
+		*gerritChecks = checks
+		fake := new(fakeGerrit)
+		srv := httptest.NewServer(fake)
+		gce.SetGerritClient(gerrit.NewClient(srv.URL, gerrit.NoAuth))
+
+		ts.notifyStarting()
+		ts.notifyFinished()
+		srv.Close()
+
+		if checks {
+			if len(fake.reviews) != 1 {
+				t.Fatalf("with checks, posted %d reviews; want only the final vote", len(fake.reviews))
+			}
+			ri := fake.reviews[0]
+			if len(ri.Comments) != 0 || ri.Message != "" || ri.Labels["TryBot-Result"] != -1 {
+				t.Errorf("with checks, posted %+v; want a TryBot-Result-1 vote without comments", ri)
+			}
+			continue
+		}
+		if len(fake.reviews) != 2 {
+			t.Fatalf("without checks, posted %d reviews; want the beginning and final comments", len(fake.reviews))
+		}
+		if ri := fake.reviews[1]; len(ri.Comments["/PATCHSET_LEVEL"]) != 1 || ri.Labels["TryBot-Result"] != -1 {
+			t.Errorf("without checks, final review = %+v; want a comment and a TryBot-Result-1 vote", ri)
+		}
+	}
+
+	// The runs of the finished try set are still served after it's
+	// removed from the tries map.
+	statusMu.Lock()
+	delete(tries, ts.tryKey)
+	statusMu.Unlock()
+	if runs := checkRunsOfCommit(ts.Commit); len(runs) != len(ts.builds) {
+		t.Errorf("finished try set has %d check runs; want %d", len(runs), len(ts.builds))
+	}
+}
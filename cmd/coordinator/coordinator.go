@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"html"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -40,6 +41,7 @@ import (
 
 	"cloud.google.com/go/compute/metadata"
 	"cloud.google.com/go/storage"
+	"github.com/googleapis/google-cloud-go-testing/datastore/dsiface"
 	"golang.org/x/build/buildenv"
 	"golang.org/x/build/buildlet"
 	builddash "golang.org/x/build/cmd/coordinator/internal/dashboard"
@@ -55,6 +57,8 @@ import (
 	"golang.org/x/build/internal/coordinator/pool/queue"
 	"golang.org/x/build/internal/coordinator/remote"
 	"golang.org/x/build/internal/coordinator/schedule"
+	"golang.org/x/build/internal/coordinator/state"
+	"golang.org/x/build/internal/gcsfs"
 	"golang.org/x/build/internal/gomote"
 	gomoteprotos "golang.org/x/build/internal/gomote/protos"
 	"golang.org/x/build/internal/https"
@@ -113,8 +117,33 @@ var (
 	devEnableGCE  = flag.Bool("dev_gce", false, "Whether or not to enable the GCE pool when in dev mode. The pool is enabled by default in prod mode.")
 	devEnableEC2  = flag.Bool("dev_ec2", false, "Whether or not to enable the EC2 pool when in dev mode. The pool is enabled by default in prod mode.")
 	sshAddr       = flag.String("ssh_addr", ":2222", "Address the gomote SSH server should listen on")
+	sshRecordURL  = flag.String("ssh_recording_url", "", "If set, a file:// or gs:// URL where interactive gomote SSH sessions are recorded.")
+	sshRecordKeep = flag.Duration("ssh_recording_retention", 30*24*time.Hour, "How long gomote SSH session recordings are kept. Zero keeps them forever.")
+	gerritChecks  = flag.Bool("gerrit_checks", false, "Report trybot status to Gerrit through the checks provider at /checks instead of review comments. TryBot-Result is still voted.")
 )
 
+// Flags for pre-warming cloud buildlets.
+var (
+	prewarm             = flag.Bool("prewarm", false, "Whether to keep GCE and EC2 buildlets started ahead of demand for host types that are historically busy at the time. Only used in prod mode.")
+	prewarmMaxCPUs      = flag.Int("prewarm_max_cpus", pool.DefaultPrewarmConfig.MaxCPUs, "The most vCPUs of pre-warmed buildlets waiting for work at once.")
+	prewarmIdleCPUHours = flag.Float64("prewarm_idle_cpu_hours", pool.DefaultPrewarmConfig.MaxIdleCPUHoursPerDay, "The most vCPU-hours pre-warmed buildlets may spend waiting for work each day.")
+)
+
+// Flags for the internal module proxy used by privateGoProxy builders.
+var (
+	modProxyCacheURL = flag.String("modproxy_cache_url", "", "If set, a file:// or gs:// URL where the internal module proxy caches modules. Otherwise they're cached in a temporary directory.")
+	modProxyWarm     = flag.String("modproxy_warm", "", "Comma-separated list of go.mod files, as paths or http(s) URLs, whose requirements are fetched into the internal module proxy cache at startup.")
+)
+
+// Flags for the index of build log failures, served at /logsearch.
+var (
+	logIndexURL  = flag.String("log_index_url", "", "If set, a file:// or gs:// URL where the failures in build logs are indexed for searching at /logsearch.")
+	logIndexKeep = flag.Duration("log_index_retention", 90*24*time.Hour, "How long builds are kept in the build log index. Zero keeps them forever.")
+)
+
+// buildletPrewarmer is the pre-warmer of cloud buildlets, if -prewarm is set.
+var buildletPrewarmer *pool.Prewarmer
+
 // LOCK ORDER:
 //   statusMu, buildStatus.mu, trySet.mu
 // (Other locks, such as the remoteBuildlet mutex should
@@ -347,7 +376,9 @@ func main() {
 	dashV2 := &builddash.Handler{Datastore: gce.GoDSClient(), Maintner: maintnerClient}
 	gs := &gRPCServer{dashboardURL: "https://build.golang.org"}
 	setSessionPool(sp)
-	gomoteServer := gomote.New(sp, sched, sshCA, gomoteBucket, mustStorageClient())
+	sshRecorder := mustSessionRecorder()
+	defer sshRecorder.Close()
+	gomoteServer := gomote.New(sp, sched, sshCA, gomoteBucket, mustStorageClient(), sshRecorder)
 	protos.RegisterCoordinatorServer(grpcServer, gs)
 	gomoteprotos.RegisterGomoteServiceServer(grpcServer, gomoteServer)
 	mux.HandleFunc("/", grpcHandlerFunc(grpcServer, handleStatus)) // Serve a status page at farmer.golang.org.
@@ -363,11 +394,24 @@ func main() {
 	mux.HandleFunc("/status/post-submit-active.json", handlePostSubmitActiveJSON)
 	mux.Handle("/dashboard", dashV2)
 	mux.HandleFunc("/queues", handleQueues)
+	mux.HandleFunc("/bisect", handleBisect)
+	mux.HandleFunc("/logsearch", handleLogSearch)
+	startLogIndex()
+	mux.HandleFunc("/checks", handleChecks)
+	mux.Handle("/checks/rerun", checksRerunHandler())
 	if *mode == "dev" {
 		// TODO(crawshaw): do more in dev mode
 		gce.BuildletPool().SetEnabled(*devEnableGCE)
 		go findWorkLoop()
 	} else {
+		if dsClient := gce.DSClient(); dsClient != nil {
+			// Load the saved try sets before cleaning up VMs, so
+			// that their buildlets are reserved and not deleted.
+			tryStateStore = state.NewDatastore(dsiface.AdaptClient(dsClient))
+			if err := loadTryState(context.Background()); err != nil {
+				log.Printf("Error loading saved trybot state; starting over: %v", err)
+			}
+		}
 		go gce.BuildletPool().CleanUpOldVMs()
 
 		if gce.InStaging() {
@@ -378,6 +422,13 @@ func main() {
 		go findWorkLoop()
 		go findTryWorkLoop()
 		go reportReverseCountMetrics()
+		if *prewarm {
+			cfg := pool.DefaultPrewarmConfig
+			cfg.MaxCPUs = *prewarmMaxCPUs
+			cfg.MaxIdleCPUHoursPerDay = *prewarmIdleCPUHours
+			buildletPrewarmer = pool.StartPrewarmer(context.Background(), gce.BuildEnv(), cfg)
+			go reportPrewarmMetrics(buildletPrewarmer)
+		}
 		// TODO(cmang): gccgo will need its own findWorkLoop
 	}
 
@@ -387,7 +438,7 @@ func main() {
 		if err != nil {
 			return nil, fmt.Errorf("unable to retrieve keys for SSH Server: %v", err)
 		}
-		return remote.NewSSHServer(*sshAddr, privateKey, publicKey, sshCA, sp)
+		return remote.NewSSHServer(*sshAddr, privateKey, publicKey, sshCA, sp, sshRecorder)
 	}
 	sshServ, err := configureSSHServer()
 	if err != nil {
@@ -684,12 +735,16 @@ func serveTryStatusJSON(w http.ResponseWriter, r *http.Request, ts *trySet, tss
 		Succeeded bool      `json:"succeeded"`
 	}
 	var result struct {
-		ChangeID string      `json:"changeId"`
-		Commit   string      `json:"commit"`
-		Builds   []litebuild `json:"builds"`
+		ChangeID   string      `json:"changeId"`
+		Commit     string      `json:"commit"`
+		TestedWith []string    `json:"testedWith,omitempty"` // other CLs tested together, such as "tools CL 12345 PS 3"
+		Builds     []litebuild `json:"builds"`
 	}
 	result.Commit = ts.Commit
 	result.ChangeID = ts.ChangeID
+	for _, d := range ts.stack.deps {
+		result.TestedWith = append(result.TestedWith, d.String())
+	}
 
 	for _, bs := range tss.builds {
 		var lb litebuild
@@ -749,6 +804,20 @@ func serveTryStatusHTML(w http.ResponseWriter, ts *trySet, tss trySetState) {
 	fmt.Fprintf(buf, "<h1>Trybot Status</h1>")
 	fmt.Fprintf(buf, "<p>Change-ID: <a href='https://go-review.googlesource.com/#/q/%s'>%s</a><br />\n", ts.ChangeID, ts.ChangeID)
 	fmt.Fprintf(buf, "Commit: <a href='https://go-review.googlesource.com/#/q/%s'>%s</a></p>\n", ts.Commit, ts.Commit)
+	if len(ts.stack.deps) > 0 {
+		fmt.Fprintf(buf, "<p>Tested together with:<br />\n")
+		for _, d := range ts.stack.deps {
+			how := "applied to the builds"
+			if d.Ancestor {
+				how = "ancestor in the relation chain"
+			}
+			fmt.Fprintf(buf, "&#8226; <a href='%s'>%s</a> (%s)<br />\n", d.URL(), html.EscapeString(d.String()), how)
+		}
+		fmt.Fprintf(buf, "</p>\n")
+	}
+	for _, why := range ts.stack.ignored {
+		fmt.Fprintf(buf, "<p>Not tested together with %s.</p>\n", html.EscapeString(why))
+	}
 	fmt.Fprintf(buf, "<p>Builds remaining: %d</p>\n", tss.remain)
 	fmt.Fprintf(buf, "<h4>Builds</h4>\n")
 	fmt.Fprintf(buf, "<table cellpadding=5 border=0>\n")
@@ -771,6 +840,10 @@ func serveTryStatusHTML(w http.ResponseWriter, ts *trySet, tss trySetState) {
 		fmt.Fprintf(buf, "<tr><td class=\"nobr\">&#8226; %s</td><td>%s</td></tr>\n",
 			html.EscapeString(bs.NameAndBranch()), status)
 	}
+	for _, r := range tss.reused {
+		fmt.Fprintf(buf, "<tr><td class=\"nobr\">&#8226; %s</td><td>pass (reused from %s)</td></tr>\n",
+			html.EscapeString(r.name), html.EscapeString(r.commit[:8]))
+	}
 	fmt.Fprintf(buf, "</table>\n")
 	fmt.Fprintf(buf, "<h4>Full Detail</h4><table cellpadding=5 border=1>\n")
 	for _, bs := range tss.builds {
@@ -1081,6 +1154,10 @@ func findTryWork() error {
 		return err
 	}
 
+	// Look up the CLs to test new try work items together with,
+	// which takes Gerrit requests, before holding statusMu.
+	stacks := tryStacks(ctx, tryRes.Waiting)
+
 	now := time.Now()
 
 	statusMu.Lock()
@@ -1101,8 +1178,8 @@ func findTryWork() error {
 			// already in progress
 			ts.wantedAsOf = now
 			continue
-		} else {
-			ts := newTrySet(work)
+		} else if stack, ok := stacks[key]; ok {
+			ts := newTrySet(work, stack)
 			ts.wantedAsOf = now
 			tries[key] = ts
 		}
@@ -1113,9 +1190,63 @@ func findTryWork() error {
 			go ts.cancelBuilds()
 		}
 	}
+	if savedTrySets != nil {
+		discardSavedTrySets()
+	}
 	return nil
 }
 
+// maxTryStackLookups is the number of polls for try work in which
+// looking up the CLs to test a try work item with may fail before the
+// item is tested on its own.
+const maxTryStackLookups = 10
+
+// tryStackFailures counts the failed lookups of the CLs to test the
+// waiting try work items with. It's only used by tryStacks, which is
+// only called from the findTryWork loop.
+var tryStackFailures = make(map[tryKey]int)
+
+// tryStacks returns the CLs to test the waiting try work items without
+// try sets together with. An item whose lookup fails is left out, to
+// be retried on the next poll, until maxTryStackLookups lookups have
+// failed: then it's tested on its own.
+func tryStacks(ctx context.Context, waiting []*apipb.GerritTryWorkItem) map[tryKey]tryStack {
+	stacks := make(map[tryKey]tryStack)
+	wanted := make(map[tryKey]bool)
+	for _, work := range waiting {
+		key := tryWorkItemKey(work)
+		wanted[key] = true
+		if work.ChangeId == "" || work.Commit == "" || trySetOfKey(key) != nil {
+			continue
+		}
+		stack, err := lookupTryStack(ctx, work)
+		if err != nil {
+			tryStackFailures[key]++
+			if n := tryStackFailures[key]; n < maxTryStackLookups {
+				log.Printf("Not starting trybots for %v yet; looking up the CLs to test it with (attempt %d): %v", key, n, err)
+				continue
+			}
+			log.Printf("Starting trybots for %v on its own after %d failed attempts to look up the CLs to test it with: %v", key, maxTryStackLookups, err)
+			stack = tryStack{ignored: []string{"the CLs it depends on, which couldn't be looked up in Gerrit"}}
+		}
+		delete(tryStackFailures, key)
+		stacks[key] = stack
+	}
+	for key := range tryStackFailures {
+		if !wanted[key] {
+			delete(tryStackFailures, key)
+		}
+	}
+	return stacks
+}
+
+// trySetOfKey returns the try set of key, or nil.
+func trySetOfKey(key tryKey) *trySet {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	return tries[key]
+}
+
 type tryKey struct {
 	Project  string // "go", "net", etc
 	Branch   string // master
@@ -1140,6 +1271,7 @@ type trySet struct {
 	tryID    string                   // "T" + 9 random hex
 	slowBots []*dashboard.BuildConfig // any opt-in slower builders to run in a trybot run
 	xrepos   []*buildStatus           // any opt-in x/ repo builds to run in a trybot run
+	stack    tryStack                 // other CLs tested together with this one
 
 	// wantedAsOf is guarded by statusMu and is used by
 	// findTryWork. It records the last time this tryKey was still
@@ -1152,12 +1284,15 @@ type trySet struct {
 	canceled bool // try run is no longer wanted and its builds were canceled
 	trySetState
 	errMsg bytes.Buffer
+
+	saveMu sync.Mutex // serializes saveState and deleteState
 }
 
 type trySetState struct {
 	remain int
 	failed []string // builder names, with optional " ($branch)" suffix
 	builds []*buildStatus
+	reused []reusedResult // passing results carried over instead of builds
 }
 
 func (ts trySetState) clone() trySetState {
@@ -1165,6 +1300,7 @@ func (ts trySetState) clone() trySetState {
 		remain: ts.remain,
 		failed: append([]string(nil), ts.failed...),
 		builds: append([]*buildStatus(nil), ts.builds...),
+		reused: append([]reusedResult(nil), ts.reused...),
 	}
 }
 
@@ -1184,7 +1320,7 @@ var testingKnobSkipBuilds bool
 // It also starts goroutines for each build.
 //
 // Must hold statusMu.
-func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
+func newTrySet(work *apipb.GerritTryWorkItem, stack tryStack) *trySet {
 	goBranch := work.Branch
 	var subBranch string // branch of subrepository, empty for main Go repo.
 	if work.Project != "go" && len(work.GoBranch) > 0 {
@@ -1193,12 +1329,26 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 		goBranch = work.GoBranch[0]
 		subBranch = work.Branch
 	}
+	goDep, haveGoDep := stack.goDep()
+	if haveGoDep && work.Project != "go" {
+		goBranch = goDep.Branch
+	}
 	tryBots := dashboard.TryBuildersForProject(work.Project, work.Branch, goBranch)
 	slowBots := slowBotsFromComments(work)
 	builders := joinBuilders(tryBots, slowBots)
+	reusable := reusableTryResults(work)
+	if stack.crossRepo() {
+		// Earlier patch sets may have been tested with other CLs.
+		reusable = nil
+	}
 
 	key := tryWorkItemKey(work)
-	log.Printf("Starting new trybot set for %v", key)
+	saved := takeSavedTrySet(key)
+	if saved != nil {
+		log.Printf("Resuming saved trybot set for %v", key)
+	} else {
+		log.Printf("Starting new trybot set for %v", key)
+	}
 	ts := &trySet{
 		tryKey: key,
 		tryID:  "T" + randHex(9),
@@ -1206,6 +1356,10 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 			builds: make([]*buildStatus, 0, len(builders)),
 		},
 		slowBots: slowBots,
+		stack:    stack,
+	}
+	if saved != nil {
+		ts.tryID = saved.TryID
 	}
 
 	// Defensive check that the input is well-formed.
@@ -1224,8 +1378,29 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 		work.GoVersion = []*apipb.MajorMinor{{}}
 	}
 
+	var reattached int
 	addBuilderToSet := func(bs *buildStatus, brev buildgo.BuilderRev) {
 		bs.trySet = ts
+		if bs.SubName != "" {
+			bs.tryDeps = stack.xRepoDeps(bs.SubName)
+		}
+		// Builds of other repos test their current head, which may
+		// have moved on, so only builds of this repo are reused.
+		name := bs.NameAndBranch()
+		if from, ok := reusable[name]; ok && (bs.SubName == "" || bs.SubName == key.Project) {
+			ts.reused = append(ts.reused, reusedResult{name: name, commit: from})
+			recordTryResult(key, name, true)
+			return
+		}
+		if b := savedBuild(saved, brev); b != nil {
+			if resumeBuild(ts, bs, b) {
+				ts.builds = append(ts.builds, bs)
+				return
+			}
+			if bs.reattach != nil {
+				reattached++
+			}
+		}
 		status[brev] = bs
 
 		idx := len(ts.builds)
@@ -1244,15 +1419,20 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 		// For the main build, use the first GoCommit, which represents Go tip (master branch).
 		mainBuildGoCommit = work.GoCommit[0]
 	}
+	if key.Project != "go" && haveGoDep {
+		// Build with the Go CL the change depends on instead.
+		mainBuildGoCommit = goDep.Commit
+	}
+	// Applying CLs of other x/ repos takes a go.work file.
+	tooOldForDeps := func(v types.MajorMinor) bool {
+		return key.Project != "go" && len(stack.xRepoDeps(key.Project)) > 0 && v.Less(workspaceMinGo)
+	}
 
 	// Start the main TryBot build using the selected builders.
 	// There may be additional builds, those are handled below.
-	if !testingKnobSkipBuilds {
-		go ts.notifyStarting()
-	}
 	for _, bconf := range builders {
 		goVersion := types.MajorMinor{Major: int(work.GoVersion[0].Major), Minor: int(work.GoVersion[0].Minor)}
-		if goVersion.Less(bconf.MinimumGoVersion) {
+		if goVersion.Less(bconf.MinimumGoVersion) || tooOldForDeps(goVersion) {
 			continue
 		}
 		brev := tryKeyToBuilderRev(bconf.Name, key, mainBuildGoCommit)
@@ -1267,7 +1447,9 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 	// If this is a golang.org/x repo and there's more than one GoCommit,
 	// that means we're testing against prior releases of Go too.
 	// The version selection logic is currently in maintapi's GoFindTryWork implementation.
-	if key.Project != "go" && len(work.GoCommit) >= 2 {
+	// They don't include a Go CL the change depends on, so they're
+	// skipped if there is one.
+	if key.Project != "go" && len(work.GoCommit) >= 2 && !haveGoDep {
 		// linuxBuilder is the standard builder for this purpose.
 		linuxBuilder := dashboard.Builders["linux-amd64"]
 
@@ -1281,7 +1463,7 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 				continue
 			}
 			goVersion := types.MajorMinor{Major: int(work.GoVersion[i].Major), Minor: int(work.GoVersion[i].Minor)}
-			if goVersion.Less(linuxBuilder.MinimumGoVersion) {
+			if goVersion.Less(linuxBuilder.MinimumGoVersion) || tooOldForDeps(goVersion) {
 				continue
 			}
 			brev := tryKeyToBuilderRev(linuxBuilder.Name, key, goRev)
@@ -1319,10 +1501,22 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 				log.Printf("builder %q isn't configured to build %q@%q", builder.Name, project, branch)
 				return nil
 			}
-			rev, err := getRepoHead(project)
-			if err != nil {
-				log.Printf("can't determine repo head for %q: %v", project, err)
-				return nil
+			// getRepoHead always fetches master, so use that as the
+			// SubRevBranch, unless the change depends on a CL of
+			// the repo, which is tested instead.
+			rev, subBranch := "", "master"
+			for _, d := range stack.deps {
+				if d.Project == project && !d.Ancestor {
+					rev, subBranch = d.Commit, d.Branch
+				}
+			}
+			if rev == "" {
+				var err error
+				rev, err = getRepoHead(project)
+				if err != nil {
+					log.Printf("can't determine repo head for %q: %v", project, err)
+					return nil
+				}
 			}
 			brev := buildgo.BuilderRev{
 				Name:    builder.Name,
@@ -1330,8 +1524,7 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 				SubName: project,
 				SubRev:  rev,
 			}
-			// getRepoHead always fetches master, so use that as the SubRevBranch.
-			bs, err := newBuild(brev, commitDetail{RevBranch: branch, SubRevBranch: "master", AuthorEmail: work.AuthorEmail})
+			bs, err := newBuild(brev, commitDetail{RevBranch: branch, SubRevBranch: subBranch, AuthorEmail: work.AuthorEmail})
 			if err != nil {
 				log.Printf("can't create x/%s trybot build for go/master commit %s: %v", project, rev, err)
 				return nil
@@ -1340,8 +1533,12 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 			return bs
 		}
 
-		// First, add the opt-in x repos.
+		// First, add the opt-in x repos, and the repos of CLs the
+		// change depends on.
 		repoBuilders := xReposFromComments(work)
+		for _, d := range stack.xRepoDeps("go") {
+			repoBuilders[xRepoAndBuilder{Project: d.Project}] = true
+		}
 		for rb := range repoBuilders {
 			if bs := addXrepo(rb.Project, rb.Builder); bs != nil {
 				ts.xrepos = append(ts.xrepos, bs)
@@ -1355,6 +1552,35 @@ func newTrySet(work *apipb.GerritTryWorkItem) *trySet {
 		}
 	}
 
+	if len(ts.reused) > 0 {
+		log.Printf("Reusing %d passing results from earlier patch sets for %v", len(ts.reused), key)
+	}
+	if saved != nil {
+		// Give up the buildlets of saved builds which are no longer
+		// part of the try set.
+		for _, b := range saved.Builds {
+			if !b.Done && b.Instance != "" && !ts.reattaches(b.Instance) {
+				releaseBuildlet(b.Instance)
+			}
+		}
+	}
+	if !testingKnobSkipBuilds {
+		switch {
+		case saved != nil && ts.remain == 0:
+			// The previous coordinator finished the builds but
+			// didn't get to report the result.
+			go ts.notifyFinished()
+		case saved != nil:
+			go ts.notifyResumed(reattached)
+		case ts.remain == 0 && len(ts.reused) > 0:
+			go ts.notifyAllReused()
+		default:
+			go ts.notifyStarting()
+		}
+		if ts.remain > 0 {
+			go ts.saveState()
+		}
+	}
 	return ts
 }
 
@@ -1479,11 +1705,25 @@ func (ts *trySet) statusPage() string {
 // notifyStarting runs in its own goroutine and posts to Gerrit that
 // the trybots have started on the user's CL with a link of where to watch.
 func (ts *trySet) notifyStarting() {
+	if *gerritChecks {
+		// The status page is linked from the Checks tab instead.
+		return
+	}
 	name := "TryBots"
 	if len(ts.slowBots) > 0 {
 		name = "SlowBots"
 	}
 	msg := name + " beginning. Status page: " + ts.statusPage() + "\n"
+	if len(ts.stack.deps) > 0 || len(ts.stack.ignored) > 0 {
+		var buf strings.Builder
+		writeTryStack(&buf, ts.stack, "Testing")
+		msg += buf.String()
+	}
+	if reused := ts.state().reused; len(reused) > 0 {
+		var buf strings.Builder
+		writeReused(&buf, reused)
+		msg += buf.String()
+	}
 
 	// If any of the requested SlowBot builders
 	// have a known issue, give users a warning.
@@ -1539,6 +1779,21 @@ func (ts *trySet) notifyStarting() {
 	}
 }
 
+// notifyAllReused runs in its own goroutine and posts to Gerrit that
+// the trybots passed, for a try run whose results were all reused from
+// earlier patch sets.
+func (ts *trySet) notifyAllReused() {
+	msg := new(strings.Builder)
+	name := "TryBots"
+	if len(ts.slowBots) > 0 {
+		name = "SlowBots"
+	}
+	fmt.Fprintf(msg, "%s are happy.\n\n", name)
+	writeReused(msg, ts.state().reused)
+	ts.rememberCheckRuns()
+	ts.replyToBeginning(tryBotsTag("happy"), msg.String(), 1)
+}
+
 // awaitTryBuild runs in its own goroutine and waits for a build in a
 // trySet to complete.
 //
@@ -1575,8 +1830,10 @@ func (ts *trySet) awaitTryBuild(idx int, bs *buildStatus, brev buildgo.BuilderRe
 		if !ts.wanted() {
 			return
 		}
+		attempt := bs.attempt
 		bs, _ = newBuild(brev, bs.commitDetail)
 		bs.trySet = ts
+		bs.attempt = attempt
 		go bs.start()
 		ts.mu.Lock()
 		ts.builds[idx] = bs
@@ -1611,6 +1868,7 @@ func (ts *trySet) cancelBuilds() {
 	for _, bs := range ts.builds {
 		go bs.cancelBuild()
 	}
+	go ts.deleteState()
 }
 
 func (ts *trySet) noteBuildComplete(bs *buildStatus) {
@@ -1635,8 +1893,12 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 		// Be quiet and don't spam Gerrit.
 		return
 	}
-
-	const failureFooter = "Consult https://build.golang.org/ to see whether they are new failures. Keep in mind that TryBots currently test *exactly* your git commit, without rebasing. If your commit's git parent is old, the failure might've already been fixed.\n"
+	if bs.SubName == "" || bs.SubName == ts.Project {
+		recordTryResult(ts.tryKey, bs.NameAndBranch(), succeeded)
+	}
+	if remain > 0 {
+		defer ts.saveState()
+	}
 
 	s1 := sha1.New()
 	io.WriteString(s1, buildLog)
@@ -1656,69 +1918,106 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 	bs.mu.Unlock()
 
 	if !succeeded {
+		indexBuildLog(bs, buildLog, logURL)
 		ts.mu.Lock()
 		fmt.Fprintf(&ts.errMsg, "Failed on %s: %s\n", bs.NameAndBranch(), logURL)
 		ts.mu.Unlock()
 	}
 
-	postInProgressMessage := !succeeded && numFail == 1 && remain > 0
+	// With the checks provider, failures show up in the Checks tab
+	// as they happen.
+	postInProgressMessage := !succeeded && numFail == 1 && remain > 0 && !*gerritChecks
 	postFinishedMessage := remain == 0
 
 	if !postInProgressMessage && !postFinishedMessage {
 		return
 	}
 
-	var (
-		gerritMsg   = &strings.Builder{}
-		gerritTag   string
-		gerritScore int
-	)
-
 	if postInProgressMessage {
-		fmt.Fprintf(gerritMsg, "Build is still in progress... "+
+		msg := fmt.Sprintf("Build is still in progress... "+
 			"Status page: https://farmer.golang.org/try?commit=%s\n"+
 			"Failed on %s: %s\n"+
 			"Other builds still in progress; subsequent failure notices suppressed until final report.\n\n"+
 			failureFooter, ts.Commit[:8], bs.NameAndBranch(), logURL)
-		gerritTag = tryBotsTag("progress")
+		ts.replyToBeginning(tryBotsTag("progress"), msg, 0)
+		return
+	}
+	ts.notifyFinished()
+}
+
+const failureFooter = "Consult https://build.golang.org/ to see whether they are new failures. Keep in mind that TryBots currently test *exactly* your git commit, without rebasing. If your commit's git parent is old, the failure might've already been fixed.\n"
+
+// notifyFinished posts the result of the finished try set to Gerrit
+// and deletes its saved state.
+func (ts *trySet) notifyFinished() {
+	defer ts.deleteState()
+	ts.rememberCheckRuns()
+
+	tss := ts.state()
+	numFail := len(tss.failed)
+	var (
+		gerritMsg   = &strings.Builder{}
+		gerritTag   string
+		gerritScore int
+	)
+	name := "TryBots"
+	if len(ts.slowBots) > 0 {
+		name = "SlowBots"
 	}
 
-	if postFinishedMessage {
-		name := "TryBots"
-		if len(ts.slowBots) > 0 {
-			name = "SlowBots"
+	if numFail == 0 {
+		gerritScore = 1
+		fmt.Fprintf(gerritMsg, "%s are happy.\n", name)
+		gerritTag = tryBotsTag("happy")
+	} else {
+		gerritScore = -1
+		ts.mu.Lock()
+		errMsg := ts.errMsg.String()
+		ts.mu.Unlock()
+		fmt.Fprintf(gerritMsg, "%d of %d %s failed.\n%s\n"+failureFooter,
+			numFail, len(tss.builds), name, errMsg)
+		gerritTag = tryBotsTag("failed")
+	}
+	fmt.Fprintln(gerritMsg)
+	if len(ts.slowBots) > 0 {
+		fmt.Fprintf(gerritMsg, "SlowBot builds that ran:\n")
+		for _, c := range ts.slowBots {
+			fmt.Fprintf(gerritMsg, "* %s\n", c.Name)
+		}
+	}
+	if len(ts.xrepos) > 0 {
+		fmt.Fprintf(gerritMsg, "Also tested the following repos:\n")
+		for _, st := range ts.xrepos {
+			fmt.Fprintf(gerritMsg, "* %s\n", st.NameAndBranch())
 		}
+	}
+	writeTryStack(gerritMsg, ts.stack, "Tested")
+	writeReused(gerritMsg, tss.reused)
+	ts.replyToBeginning(gerritTag, gerritMsg.String(), gerritScore)
+}
 
-		if numFail == 0 {
-			gerritScore = 1
-			fmt.Fprintf(gerritMsg, "%s are happy.\n", name)
-			gerritTag = tryBotsTag("happy")
-		} else {
-			gerritScore = -1
-			ts.mu.Lock()
-			errMsg := ts.errMsg.String()
-			ts.mu.Unlock()
-			fmt.Fprintf(gerritMsg, "%d of %d %s failed.\n%s\n"+failureFooter,
-				numFail, len(ts.builds), name, errMsg)
-			gerritTag = tryBotsTag("failed")
-		}
-		fmt.Fprintln(gerritMsg)
-		if len(ts.slowBots) > 0 {
-			fmt.Fprintf(gerritMsg, "SlowBot builds that ran:\n")
-			for _, c := range ts.slowBots {
-				fmt.Fprintf(gerritMsg, "* %s\n", c.Name)
-			}
+// replyToBeginning posts msg to Gerrit as a reply to the try set's
+// "beginning" comment thread, voting gerritScore on TryBot-Result if
+// it's non-zero.
+func (ts *trySet) replyToBeginning(gerritTag, msg string, gerritScore int) {
+	gerritClient := pool.NewGCEConfiguration().GerritClient()
+	if *gerritChecks {
+		// Results are reported through the checks provider, so
+		// only vote.
+		if gerritScore == 0 {
+			return
 		}
-		if len(ts.xrepos) > 0 {
-			fmt.Fprintf(gerritMsg, "Also tested the following repos:\n")
-			for _, st := range ts.xrepos {
-				fmt.Fprintf(gerritMsg, "* %s\n", st.NameAndBranch())
-			}
+		ri := gerrit.ReviewInput{
+			Tag:    gerritTag,
+			Labels: map[string]int{"TryBot-Result": gerritScore},
+		}
+		if err := gerritClient.SetReview(context.Background(), ts.ChangeTriple(), ts.Commit, ri); err != nil {
+			log.Printf("Error voting TryBot-Result on %s: %v", ts.Commit[:8], err)
 		}
+		return
 	}
 
 	var inReplyTo string
-	gerritClient := pool.NewGCEConfiguration().GerritClient()
 	if patchSetThreads, err := listPatchSetThreads(gerritClient, ts.ChangeTriple()); err == nil {
 		for _, t := range patchSetThreads {
 			if t.root.Tag == tryBotsTag("beginning") && strings.Contains(t.root.Message, ts.statusPage()) {
@@ -1737,7 +2036,7 @@ func (ts *trySet) noteBuildComplete(bs *buildStatus) {
 		Comments: map[string][]gerrit.CommentInput{
 			"/PATCHSET_LEVEL": {{
 				InReplyTo:  inReplyTo,
-				Message:    gerritMsg.String(),
+				Message:    msg,
 				Unresolved: &unresolved,
 			}},
 		},
@@ -1789,16 +2088,26 @@ func getBuildlets(ctx context.Context, n int, schedTmpl *queue.SchedItem, lg poo
 	return ch
 }
 
+// testSet is the set of dist tests of a build. The main buildlet and
+// its helpers take chunks of pending tests from it as they become idle,
+// so tests are balanced dynamically rather than planned up front.
 type testSet struct {
 	st        *buildStatus
 	items     []*testItem
-	testStats *buildstats.TestStats
+	durations *buildstats.DurationEstimator
+	workers   int // number of buildlets running tests, including the main one
 
 	mu           sync.Mutex
-	inOrder      [][]*testItem
-	biggestFirst [][]*testItem
+	biggestFirst []*testItem // items by decreasing estimated duration
 }
 
+const (
+	// minChunkDuration and maxChunkDuration bound the estimated
+	// duration of the go_test:* tests run together as one chunk.
+	minChunkDuration = 3 * time.Second
+	maxChunkDuration = 10 * time.Second
+)
+
 // cancelAll cancels all pending tests.
 func (s *testSet) cancelAll() {
 	for _, ti := range s.items {
@@ -1806,117 +2115,83 @@ func (s *testSet) cancelAll() {
 	}
 }
 
+// testsToRunInOrder takes the next chunk of pending tests in dist's
+// order, for the main buildlet, so that its output streams smoothly.
 func (s *testSet) testsToRunInOrder() (chunk []*testItem, ok bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if s.inOrder == nil {
-		s.initInOrder()
-	}
-	return s.testsFromSlice(s.inOrder)
+	return s.takeChunk(s.items)
 }
 
+// testsToRunBiggestFirst takes the next chunk of pending tests
+// starting with the biggest, for helper buildlets (critical path
+// scheduling).
 func (s *testSet) testsToRunBiggestFirst() (chunk []*testItem, ok bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.biggestFirst == nil {
-		s.initBiggestFirst()
+		s.biggestFirst = append([]*testItem(nil), s.items...)
+		sort.SliceStable(s.biggestFirst, func(i, j int) bool {
+			return s.biggestFirst[i].duration > s.biggestFirst[j].duration
+		})
 	}
-	return s.testsFromSlice(s.biggestFirst)
+	return s.takeChunk(s.biggestFirst)
 }
 
-func (s *testSet) testsFromSlice(chunkList [][]*testItem) (chunk []*testItem, ok bool) {
-	for _, candChunk := range chunkList {
-		for _, ti := range candChunk {
-			if ti.tryTake() {
-				chunk = append(chunk, ti)
+// takeChunk takes the first pending test in order. If it is a
+// go_test:* test, it also takes the following pending go_test:* tests
+// which fit in the chunk's estimated duration, as chosen by chunkTarget.
+// Other tests are always run by themselves. s.mu must be held.
+func (s *testSet) takeChunk(order []*testItem) (chunk []*testItem, ok bool) {
+	target := s.chunkTarget()
+	var dur time.Duration
+	for _, ti := range order {
+		if len(chunk) > 0 {
+			if !isGoTest(chunk[0].name) {
+				break
+			}
+			if !isGoTest(ti.name) || dur+ti.duration > target {
+				continue
 			}
 		}
-		if len(chunk) > 0 {
-			return chunk, true
+		if ti.tryTake() {
+			chunk = append(chunk, ti)
+			dur += ti.duration
 		}
 	}
-	return nil, false
+	return chunk, len(chunk) > 0
 }
 
-func (s *testSet) initInOrder() {
-	names := make([]string, len(s.items))
-	namedItem := map[string]*testItem{}
-	for i, ti := range s.items {
-		names[i] = ti.name
-		namedItem[ti.name] = ti
-	}
-
-	// First do the go_test:* ones. partitionGoTests
-	// only returns those, which are the ones we merge together.
-	stdSets := partitionGoTests(s.testStats.Duration, s.st.BuilderRev.Name, names)
-	for _, set := range stdSets {
-		tis := make([]*testItem, len(set))
-		for i, name := range set {
-			tis[i] = namedItem[name]
-		}
-		s.inOrder = append(s.inOrder, tis)
-	}
-
-	// Then do the misc tests, which are always by themselves.
-	// (No benefit to merging them)
+// chunkTarget returns the estimated duration of go_test:* tests to run
+// together as one chunk. Chunks get smaller as pending tests run out,
+// so that the buildlets finish at about the same time.
+func (s *testSet) chunkTarget() time.Duration {
+	var pending time.Duration
 	for _, ti := range s.items {
-		if !strings.HasPrefix(ti.name, "go_test:") {
-			s.inOrder = append(s.inOrder, []*testItem{ti})
+		if ti.pending() {
+			pending += ti.duration
 		}
 	}
-}
-
-func partitionGoTests(testDuration func(string, string) time.Duration, builderName string, tests []string) (sets [][]string) {
-	var srcTests []string
-	var cmdTests []string
-	for _, name := range tests {
-		if strings.HasPrefix(name, "go_test:cmd/") {
-			cmdTests = append(cmdTests, name)
-		} else if strings.HasPrefix(name, "go_test:") {
-			srcTests = append(srcTests, name)
-		}
+	workers := s.workers
+	if workers < 1 {
+		workers = 1
 	}
-	sort.Strings(srcTests)
-	sort.Strings(cmdTests)
-	goTests := append(srcTests, cmdTests...)
-
-	const sizeThres = 10 * time.Second
-
-	var curSet []string
-	var curDur time.Duration
-
-	flush := func() {
-		if len(curSet) > 0 {
-			sets = append(sets, curSet)
-			curSet = nil
-			curDur = 0
-		}
+	target := pending / time.Duration(2*workers)
+	if target < minChunkDuration {
+		return minChunkDuration
 	}
-	for _, testName := range goTests {
-		d := testDuration(builderName, testName)
-		if curDur+d > sizeThres {
-			flush() // no-op if empty
-		}
-		curSet = append(curSet, testName)
-		curDur += d
+	if target > maxChunkDuration {
+		return maxChunkDuration
 	}
-
-	flush()
-	return
+	return target
 }
 
-func (s *testSet) initBiggestFirst() {
-	items := append([]*testItem(nil), s.items...)
-	sort.Sort(sort.Reverse(byTestDuration(items)))
-	for _, item := range items {
-		s.biggestFirst = append(s.biggestFirst, []*testItem{item})
-	}
-}
+func isGoTest(name string) bool { return strings.HasPrefix(name, "go_test:") }
 
 type testItem struct {
 	set      *testSet
 	name     string        // "go_test:sort"
-	duration time.Duration // optional approximate size
+	duration time.Duration // estimated duration
 
 	take chan token // buffered size 1: sending takes ownership of rest of fields:
 
@@ -1935,6 +2210,12 @@ type testItem struct {
 	execDuration time.Duration // actual time
 }
 
+// pending reports whether ti has not been taken to run.
+// The result may be stale by the time it's returned.
+func (ti *testItem) pending() bool {
+	return len(ti.take) == 0
+}
+
 func (ti *testItem) tryTake() bool {
 	select {
 	case ti.take <- token{}:
@@ -1959,12 +2240,6 @@ func (ti *testItem) failf(format string, args ...interface{}) {
 	close(ti.done)
 }
 
-type byTestDuration []*testItem
-
-func (s byTestDuration) Len() int           { return len(s) }
-func (s byTestDuration) Less(i, j int) bool { return s[i].duration < s[j].duration }
-func (s byTestDuration) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-
 type eventAndTime struct {
 	t    time.Time
 	evt  string // "get_source", "make_and_test", "make", etc
@@ -2226,6 +2501,21 @@ func mustStorageClient() *storage.Client {
 	return storageClient
 }
 
+// mustSessionRecorder creates the recorder used to audit gomote SSH sessions.
+// Audit events are written to stderr. Sessions are only recorded if the
+// ssh_recording_url flag is set.
+func mustSessionRecorder() *remote.SessionRecorder {
+	var fsys fs.FS
+	if *sshRecordURL != "" {
+		var err error
+		fsys, err = gcsfs.FromURL(context.Background(), mustStorageClient(), *sshRecordURL)
+		if err != nil {
+			log.Fatalf("unable to use %q for SSH session recordings: %s", *sshRecordURL, err)
+		}
+	}
+	return remote.NewSessionRecorder(context.Background(), fsys, *sshRecordKeep, os.Stderr)
+}
+
 func fromSecret(ctx context.Context, sc *secret.Client, secretName string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
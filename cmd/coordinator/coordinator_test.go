@@ -10,6 +10,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 	"time"
 
 	"golang.org/x/build/buildenv"
+	"golang.org/x/build/dashboard"
 	"golang.org/x/build/gerrit"
 	"golang.org/x/build/internal/buildgo"
 	"golang.org/x/build/internal/coordinator/pool"
@@ -46,28 +48,58 @@ var fixedTestDuration = map[string]Seconds{
 	"go_test:k": 6.5,
 }
 
-func TestPartitionGoTests(t *testing.T) {
-	var in []string
-	for name := range fixedTestDuration {
-		in = append(in, name)
-	}
-	testDuration := func(builder, testName string) time.Duration {
-		if s, ok := fixedTestDuration[testName]; ok {
-			return s.Duration()
+func TestTestSetChunks(t *testing.T) {
+	set := &testSet{workers: 1}
+	names := []string{"go_test:a", "go_test:b", "go_test:c", "go_test:d", "go_test:e", "go_test:f",
+		"go_test:g", "go_test:h", "go_test:i", "go_test:j", "go_test:k", "runtime:cpu124"}
+	for _, name := range names {
+		d := 3 * time.Second
+		if s, ok := fixedTestDuration[name]; ok {
+			d = s.Duration()
 		}
-		return 3 * time.Second
+		set.items = append(set.items, &testItem{
+			set:      set,
+			name:     name,
+			duration: d,
+			take:     make(chan token, 1),
+			done:     make(chan token),
+		})
 	}
-	sets := partitionGoTests(testDuration, "", in)
-	want := [][]string{
-		{"go_test:a", "go_test:b", "go_test:c", "go_test:d", "go_test:e"},
-		{"go_test:f", "go_test:g"},
-		{"go_test:h", "go_test:i"},
-		{"go_test:j"},
-		{"go_test:k"},
+	chunkNames := func(chunk []*testItem, ok bool) []string {
+		if !ok {
+			return nil
+		}
+		var names []string
+		for _, ti := range chunk {
+			names = append(names, ti.name)
+		}
+		return names
 	}
-	if !reflect.DeepEqual(sets, want) {
-		t.Errorf(" got: %v\nwant: %v", sets, want)
+	check := func(desc string, got, want []string) {
+		t.Helper()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s:\n got: %v\nwant: %v", desc, got, want)
+		}
 	}
+
+	// With plenty of pending work, chunks fill up to maxChunkDuration.
+	check("first in order", chunkNames(set.testsToRunInOrder()),
+		[]string{"go_test:a", "go_test:b", "go_test:c", "go_test:d", "go_test:e"})
+	check("first biggest", chunkNames(set.testsToRunBiggestFirst()),
+		[]string{"go_test:k", "go_test:f"})
+
+	// As more buildlets share less work, chunks shrink to a single test.
+	set.workers = 4
+	check("second in order", chunkNames(set.testsToRunInOrder()), []string{"go_test:g"})
+	check("second biggest", chunkNames(set.testsToRunBiggestFirst()), []string{"go_test:j"})
+
+	// Retried tests are taken again, and other tests run by themselves.
+	set.items[0].retry()
+	check("third biggest", chunkNames(set.testsToRunBiggestFirst()), []string{"go_test:i"})
+	check("third in order", chunkNames(set.testsToRunInOrder()), []string{"go_test:a"})
+	check("fourth in order", chunkNames(set.testsToRunInOrder()), []string{"go_test:h"})
+	check("fifth in order", chunkNames(set.testsToRunInOrder()), []string{"runtime:cpu124"})
+	check("done", chunkNames(set.testsToRunBiggestFirst()), nil)
 }
 
 func TestTryStatusJSON(t *testing.T) {
@@ -169,7 +201,7 @@ func TestIssue28891(t *testing.T) {
 		GoBranch:  []string{"release-branch.go1.15"},
 		GoVersion: []*apipb.MajorMinor{{Major: 1, Minor: 15}},
 	}
-	ts := newTrySet(work)
+	ts := newTrySet(work, tryStack{})
 	if len(ts.builds) == 0 {
 		t.Fatal("no builders in try set, want at least 1")
 	}
@@ -196,7 +228,7 @@ func TestIssue42127(t *testing.T) {
 		GoBranch:  []string{"release-branch.go1.15"},
 		GoVersion: []*apipb.MajorMinor{{Major: 1, Minor: 15}},
 	}
-	ts := newTrySet(work)
+	ts := newTrySet(work, tryStack{})
 	if len(ts.builds) == 0 {
 		t.Fatal("no builders in try set, want at least 1")
 	}
@@ -222,7 +254,7 @@ func TestXRepoBranches(t *testing.T) {
 		GoBranch:  []string{"master"},
 		GoVersion: []*apipb.MajorMinor{{Major: 1, Minor: 17}},
 	}
-	ts := newTrySet(work)
+	ts := newTrySet(work, tryStack{})
 	for i, bs := range ts.builds {
 		v := bs.NameAndBranch()
 		t.Logf("build[%d]: %s", i, v)
@@ -249,7 +281,7 @@ func TestIssue42084(t *testing.T) {
 	}
 
 	// First, determine builds without try messages. Our target SlowBot shouldn't be included.
-	ts := newTrySet(work)
+	ts := newTrySet(work, tryStack{})
 	hasLinuxArmBuilder := false
 	for _, bs := range ts.builds {
 		v := bs.NameAndBranch()
@@ -269,7 +301,7 @@ func TestIssue42084(t *testing.T) {
 		{Message: "TRY=linux", AuthorId: 1234, Version: 1},
 		{Message: "TRY=linux-arm", AuthorId: 1234, Version: 1},
 	}
-	ts = newTrySet(work)
+	ts = newTrySet(work, tryStack{})
 	hasLinuxArmBuilder = false
 	for i, bs := range ts.builds {
 		v := bs.NameAndBranch()
@@ -283,6 +315,66 @@ func TestIssue42084(t *testing.T) {
 	}
 }
 
+// Test that passing results of an earlier, equivalent patch set are reused,
+// including those of SlowBots, and that failures are not.
+func TestReuseTryResults(t *testing.T) {
+	testingKnobSkipBuilds = true
+
+	work := &apipb.GerritTryWorkItem{
+		Project:   "go",
+		Branch:    "master",
+		ChangeId:  "I023d5208374f867552ba68b45011f7990159868f",
+		Commit:    "dd38fd80c3667f891dbe06bd1d8ed153c2e208da",
+		Version:   1,
+		GoCommit:  []string{"9995c6b50aa55c1cc1236d1d688929df512dad53"},
+		GoBranch:  []string{"master"},
+		GoVersion: []*apipb.MajorMinor{{Major: 1, Minor: 17}},
+		TryMessage: []*apipb.TryVoteMessage{
+			{Message: "TRY=linux-arm", AuthorId: 1234, Version: 1},
+		},
+	}
+	first := newTrySet(work, tryStack{})
+	if len(first.builds) < 2 {
+		t.Fatalf("got %d builds, want at least 2", len(first.builds))
+	}
+	passed, failed := first.builds[0].NameAndBranch(), first.builds[1].NameAndBranch()
+	recordTryResult(first.tryKey, passed, true)
+	recordTryResult(first.tryKey, "linux-arm-aws", true)
+	recordTryResult(first.tryKey, failed, false)
+
+	// Patch set 2 is a trivial rebase of patch set 1.
+	work.Commit = "7b4e1f7a0b62a1d6dcbf8a3c52dcc4f1e0d4e79a"
+	work.Version = 2
+	work.EquivalentCommit = []string{"dd38fd80c3667f891dbe06bd1d8ed153c2e208da"}
+	second := newTrySet(work, tryStack{})
+	reused := make(map[string]string)
+	for _, r := range second.reused {
+		reused[r.name] = r.commit
+	}
+	for _, name := range []string{passed, "linux-arm-aws"} {
+		if reused[name] != work.EquivalentCommit[0] {
+			t.Errorf("result of %s was not reused", name)
+		}
+	}
+	if _, ok := reused[failed]; ok {
+		t.Errorf("failed result of %s was reused", failed)
+	}
+	for _, bs := range second.builds {
+		if _, ok := reused[bs.NameAndBranch()]; ok {
+			t.Errorf("%s was both reused and built", bs.NameAndBranch())
+		}
+	}
+	if got, want := second.remain+len(second.reused), len(first.builds); got != want {
+		t.Errorf("%d builds remain and %d were reused; want %d in total", second.remain, len(second.reused), want)
+	}
+
+	var buf strings.Builder
+	writeReused(&buf, second.reused)
+	if !strings.Contains(buf.String(), "* "+passed+" (from dd38fd80)") {
+		t.Errorf("writeReused output %q does not list %s", buf.String(), passed)
+	}
+}
+
 func TestFindWork(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode")
@@ -321,6 +413,32 @@ func TestBuildersJSON(t *testing.T) {
 	}
 }
 
+func TestBuilderCoverageJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handleBuilders(rec, httptest.NewRequest("GET", "https://farmer.tld/builders?repo=tools&goBranch=release-branch.go1.20&try=darwin", nil))
+	res := rec.Result()
+	if res.Header.Get("Content-Type") != "application/json" || res.StatusCode != 200 {
+		var buf bytes.Buffer
+		res.Write(&buf)
+		t.Fatal(buf.String())
+	}
+	var cov dashboard.Coverage
+	if err := json.NewDecoder(res.Body).Decode(&cov); err != nil {
+		t.Fatal(err)
+	}
+	if cov.Repo != "tools" || cov.Branch != "master" || cov.GoBranch != "release-branch.go1.20" {
+		t.Errorf("coverage is of %s on %s with Go %s; want tools on master with Go release-branch.go1.20", cov.Repo, cov.Branch, cov.GoBranch)
+	}
+	var sawTryBot, sawSlowBot bool
+	for _, b := range cov.Builders {
+		sawTryBot = sawTryBot || b.Name == "linux-amd64" && b.TryBot
+		sawSlowBot = sawSlowBot || b.SlowBot
+	}
+	if !sawTryBot || !sawSlowBot {
+		t.Errorf("coverage has linux-amd64 TryBot %v and a SlowBot %v; want both", sawTryBot, sawSlowBot)
+	}
+}
+
 func TestSlowBotsFromComments(t *testing.T) {
 	work := &apipb.GerritTryWorkItem{
 		Version: 2,
@@ -0,0 +1,45 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"golang.org/x/build/cmd/coordinator/protos"
+	"golang.org/x/build/internal/coordinator/pool"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// DrainReverseBuildlet implements the DrainReverseBuildlet RPC call from the CoordinatorService.
+//
+// The caller must authenticate with the builder key of a host type the
+// reverse buildlet serves. A draining buildlet finishes its current
+// build, if any, and is then given no new work until it is resumed.
+func (g *gRPCServer) DrainReverseBuildlet(ctx context.Context, req *protos.DrainReverseBuildletRequest) (*protos.DrainReverseBuildletResponse, error) {
+	key, err := keyFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetHostname() == "" {
+		return nil, grpcstatus.Error(codes.InvalidArgument, "Hostname must be provided")
+	}
+	p := pool.ReversePool()
+	if !p.ValidBuilderKey(req.GetHostname(), key) {
+		return nil, grpcstatus.Error(codes.PermissionDenied, codes.PermissionDenied.String())
+	}
+	idle, err := p.SetDraining(req.GetHostname(), req.GetDrain())
+	if err != nil {
+		// The buildlet disconnected after its key was checked.
+		return nil, grpcstatus.Error(codes.NotFound, err.Error())
+	}
+	log.Printf("gRPCServer.DrainReverseBuildlet: set draining of reverse buildlet %q to %v", req.GetHostname(), req.GetDrain())
+	return &protos.DrainReverseBuildletResponse{Draining: req.GetDrain(), Idle: idle}, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/build/cmd/coordinator/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestDrainReverseBuildletErrors(t *testing.T) {
+	cases := []struct {
+		desc     string
+		key      string
+		req      *protos.DrainReverseBuildletRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "missing key",
+			req:      &protos.DrainReverseBuildletRequest{Hostname: "host-darwin-amd64-1", Drain: true},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "missing hostname",
+			key:      "somekey",
+			req:      &protos.DrainReverseBuildletRequest{Drain: true},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "unknown buildlet",
+			key:      "somekey",
+			req:      &protos.DrainReverseBuildletRequest{Hostname: "host-darwin-amd64-1", Drain: true},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			md := metadata.New(map[string]string{})
+			if c.key != "" {
+				md.Set("coordinator-authorization", "builder "+c.key)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			gs := &gRPCServer{}
+			_, err := gs.DrainReverseBuildlet(ctx, c.req)
+			if grpcstatus.Code(err) != c.wantCode {
+				t.Errorf("gs.DrainReverseBuildlet(%v, %v) = _, %v, wanted %v", ctx, c.req, err, c.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+// Code related to the index of the failures in build logs, and the
+// page which searches it.
+
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/build/internal"
+	"golang.org/x/build/internal/coordinator/logindex"
+	"golang.org/x/build/internal/gcsfs"
+	"golang.org/x/build/internal/loghash"
+)
+
+var (
+	logIndexMu sync.Mutex
+	logIndex   *logindex.Index // nil until loaded, or if -log_index_url isn't set
+)
+
+// currentLogIndex returns the index of the failures in build logs, or
+// nil if it isn't loaded or enabled.
+func currentLogIndex() *logindex.Index {
+	logIndexMu.Lock()
+	defer logIndexMu.Unlock()
+	return logIndex
+}
+
+// logIndexPruneInterval is how often entries older than
+// -log_index_retention are removed from the log index.
+const logIndexPruneInterval = 6 * time.Hour
+
+// logIndexRetryInterval is how long to wait before retrying to load the
+// log index after an error.
+const logIndexRetryInterval = time.Minute
+
+// startLogIndex starts loading the log index at -log_index_url, if set,
+// in the background, and then removing its expired entries. Until it's
+// loaded, builds aren't indexed and the search page is unavailable.
+func startLogIndex() {
+	if *logIndexURL == "" {
+		return
+	}
+	go func() {
+		ctx := context.Background()
+		x := loadLogIndex(ctx)
+		log.Printf("Loaded %d builds into the build log index.", x.Len())
+		logIndexMu.Lock()
+		logIndex = x
+		logIndexMu.Unlock()
+		if *logIndexKeep > 0 {
+			internal.PeriodicallyDo(ctx, logIndexPruneInterval, func(ctx context.Context, t time.Time) {
+				if n := x.Prune(t); n > 0 {
+					log.Printf("Pruned %d expired builds from the build log index.", n)
+				}
+			})
+		}
+	}()
+}
+
+// loadLogIndex opens the log index at -log_index_url, retrying until it
+// succeeds.
+func loadLogIndex(ctx context.Context) *logindex.Index {
+	for {
+		fsys, err := gcsfs.FromURL(ctx, mustStorageClient(), *logIndexURL)
+		if err != nil {
+			log.Printf("Unable to use %q for the build log index: %v", *logIndexURL, err)
+		} else {
+			x, err := logindex.Open(ctx, fsys, *logIndexKeep)
+			if err == nil {
+				return x
+			}
+			log.Printf("Unable to open the build log index at %q: %v", *logIndexURL, err)
+		}
+		time.Sleep(logIndexRetryInterval)
+	}
+}
+
+// indexBuildLog adds the failures in the log of the failed build st,
+// stored at logURL, to the log index, if it's enabled.
+func indexBuildLog(st *buildStatus, buildLog, logURL string) {
+	x := currentLogIndex()
+	if x == nil {
+		return
+	}
+	e := &logindex.Entry{
+		ID:      st.buildID,
+		Builder: st.Name,
+		Repo:    st.RepoOrGo(),
+		Branch:  st.RevBranch,
+		Rev:     st.Rev,
+		TryBot:  st.trySet != nil,
+		Time:    st.startTime,
+		LogURL:  logURL,
+		Fails:   logindex.FailsOf(buildLog),
+	}
+	if st.IsSubrepo() {
+		e.Branch = st.SubRevBranch
+		e.Rev, e.GoRev = st.SubRev, st.Rev
+	}
+	if err := x.Add(e); err != nil {
+		log.Printf("Adding %s to the build log index: %v", st.buildID, err)
+	}
+}
+
+// dashboardLogURL returns the URL of a build log recorded on the
+// dashboard by recordResult.
+func dashboardLogURL(buildLog string) string {
+	return "https://build.golang.org/log/" + loghash.New(buildLog)
+}
+
+// logSearchLimit is the most results the log search page shows, unless
+// a lower limit is requested.
+const logSearchLimit = 1000
+
+// parseLogQuery parses the query of a log search request: the terms q,
+// the regular expression re, the builder and repo patterns, the since
+// and until dates in the form 2006-01-02, whether to include try runs,
+// and a limit.
+func parseLogQuery(r *http.Request) (logindex.Query, error) {
+	q := logindex.Query{
+		Terms:   strings.Fields(r.FormValue("q")),
+		Builder: r.FormValue("builder"),
+		Repo:    r.FormValue("repo"),
+		TryBot:  r.FormValue("trybot") != "",
+		Limit:   logSearchLimit,
+	}
+	if re := r.FormValue("re"); re != "" {
+		var err error
+		if q.Regexp, err = regexp.Compile(re); err != nil {
+			return logindex.Query{}, fmt.Errorf("invalid re: %v", err)
+		}
+	}
+	for _, d := range []struct {
+		name string
+		t    *time.Time
+	}{{"since", &q.Since}, {"until", &q.Until}} {
+		v := r.FormValue(d.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return logindex.Query{}, fmt.Errorf("invalid %s: want a date such as 2006-01-02", d.name)
+		}
+		*d.t = t
+	}
+	if !q.Until.IsZero() {
+		// Include the whole day.
+		q.Until = q.Until.Add(24 * time.Hour)
+	}
+	if v := r.FormValue("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return logindex.Query{}, fmt.Errorf("invalid limit %q", v)
+		}
+		if n < q.Limit {
+			q.Limit = n
+		}
+	}
+	return q, nil
+}
+
+//go:embed templates/logsearch.html
+var logSearchTemplateStr string
+
+var logSearchTemplate = template.Must(baseTmpl.New("logsearch.html").Funcs(template.FuncMap{
+	"shortHash": shortHash,
+}).Parse(logSearchTemplateStr))
+
+// handleLogSearch serves a page searching the failures in the build log
+// index, or the results as JSON if the mode parameter is "json".
+func handleLogSearch(w http.ResponseWriter, r *http.Request) {
+	jsonMode := r.FormValue("mode") == "json"
+	x := currentLogIndex()
+	if x == nil && *logIndexURL == "" {
+		http.Error(w, "the build log index isn't enabled", http.StatusNotFound)
+		return
+	} else if x == nil {
+		http.Error(w, "the build log index is still loading", http.StatusServiceUnavailable)
+		return
+	}
+	q, err := parseLogQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	searched := len(q.Terms) > 0 || q.Regexp != nil || q.Builder != "" || q.Repo != ""
+	var results []logindex.Result
+	if searched || jsonMode {
+		results = x.Search(q)
+	}
+
+	if jsonMode {
+		if results == nil {
+			results = []logindex.Result{}
+		}
+		j, err := json.MarshalIndent(results, "", "\t")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(j)
+		return
+	}
+
+	data := struct {
+		Form     map[string]string
+		TryBot   bool
+		Searched bool
+		Results  []logindex.Result
+		Limited  bool
+		Builds   int
+	}{
+		Form:     make(map[string]string),
+		TryBot:   q.TryBot,
+		Searched: searched,
+		Results:  results,
+		Limited:  len(results) == q.Limit,
+		Builds:   x.Len(),
+	}
+	for _, k := range []string{"q", "re", "builder", "repo", "since", "until"} {
+		data.Form[k] = r.FormValue(k)
+	}
+	if err := logSearchTemplate.Execute(w, data); err != nil {
+		log.Printf("handleLogSearch: %v", err)
+	}
+}
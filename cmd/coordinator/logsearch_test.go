@@ -0,0 +1,103 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/coordinator/logindex"
+	"golang.org/x/build/internal/gcsfs"
+)
+
+func TestLogSearch(t *testing.T) {
+	oldURL, oldIndex := *logIndexURL, logIndex
+	defer func() { *logIndexURL, logIndex = oldURL, oldIndex }()
+
+	// While the index is loading, the page is unavailable.
+	*logIndexURL, logIndex = "file:///logindex", nil
+	rec := httptest.NewRecorder()
+	handleLogSearch(rec, httptest.NewRequest("GET", "/logsearch?q=timeout", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("loading: status %d; want 503", rec.Code)
+	}
+
+	x, err := logindex.Open(context.Background(), gcsfs.DirFS(t.TempDir()), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logIndex = x
+
+	const buildLog = `##### Testing packages.
+--- FAIL: TestServerTimeouts (1.00s)
+    serve_test.go:123: read tcp: i/o timeout
+FAIL
+FAIL	net/http	1.234s
+`
+	st := &buildStatus{
+		BuilderRev: buildgo.BuilderRev{Name: "linux-amd64", Rev: "1111111111111111111111111111111111111111"},
+		buildID:    "B0123456789",
+		startTime:  time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	indexBuildLog(st, buildLog, dashboardLogURL(buildLog))
+	if x.Len() != 1 {
+		t.Fatalf("index has %d builds; want 1", x.Len())
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"q=timeout", 1},
+		{"q=timeout&builder=linux-*&repo=go&since=2023-03-01&until=2023-03-01", 1},
+		{"re=i/o+time", 1},
+		{"q=timeout&builder=windows-*", 0},
+		{"q=timeout&since=2023-03-02", 0},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handleLogSearch(rec, httptest.NewRequest("GET", "/logsearch?mode=json&"+tt.query, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status %d; want 200: %s", tt.query, rec.Code, rec.Body)
+			continue
+		}
+		var results []logindex.Result
+		if err := json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+		if len(results) != tt.want {
+			t.Errorf("%s: %d results; want %d", tt.query, len(results), tt.want)
+		}
+		for _, r := range results {
+			if r.Fail.Test != "TestServerTimeouts" || !strings.HasPrefix(r.LogURL, "https://build.golang.org/log/") {
+				t.Errorf("%s: result %+v; want TestServerTimeouts with a dashboard log URL", tt.query, r)
+			}
+		}
+	}
+
+	for _, query := range []string{"re=(", "since=March", "limit=-1"} {
+		rec := httptest.NewRecorder()
+		handleLogSearch(rec, httptest.NewRequest("GET", "/logsearch?mode=json&"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d; want 400", query, rec.Code)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handleLogSearch(rec, httptest.NewRequest("GET", "/logsearch?q=timeout", nil))
+	if body := rec.Body.String(); !strings.Contains(body, "TestServerTimeouts in net/http") {
+		t.Errorf("search page doesn't show the failure:\n%s", body)
+	}
+}
@@ -24,11 +24,16 @@ var (
 	kBuilderType        = tag.MustNewKey("go-build/coordinator/keys/builder_type")
 	kGomoteSSHSuccess   = tag.MustNewKey("go-build/coordinator/keys/gomote_ssh_success")
 	kHostType           = tag.MustNewKey("go-build/coordinator/host_type")
+	kModProxyResult     = tag.MustNewKey("go-build/coordinator/keys/modproxy_result")
 	mGitHubAPIRemaining = stats.Int64("go-build/githubapi/remaining", "remaining GitHub API rate limit", stats.UnitDimensionless)
 	mGomoteCreateCount  = stats.Int64("go-build/coordinator/gomote_create_count", "counter for gomote create invocations", stats.UnitDimensionless)
 	mGomoteRDPCount     = stats.Int64("go-build/coordinator/gomote_rdp_count", "counter for gomote RDP invocations", stats.UnitDimensionless)
 	mGomoteSSHCount     = stats.Int64("go-build/coordinator/gomote_ssh_count", "counter for gomote SSH invocations", stats.UnitDimensionless)
+	mModProxyRequests   = stats.Int64("go-build/coordinator/modproxy_requests", "counter for internal module proxy requests", stats.UnitDimensionless)
 	mReverseBuildlets   = stats.Int64("go-build/coordinator/reverse_buildlets_count", "number of reverse buildlets", stats.UnitDimensionless)
+	mPrewarmHits        = stats.Int64("go-build/coordinator/prewarm_hits", "cumulative buildlet requests served by a pre-warmed buildlet", stats.UnitDimensionless)
+	mPrewarmMisses      = stats.Int64("go-build/coordinator/prewarm_misses", "cumulative buildlet requests which found no pre-warmed buildlet during busy hours", stats.UnitDimensionless)
+	mPrewarmIdleCPU     = stats.Float64("go-build/coordinator/prewarm_idle_cpu_hours", "cumulative vCPU-hours pre-warmed buildlets spent waiting for work", stats.UnitDimensionless)
 )
 
 // views should contain all measurements. All *view.View added to this
@@ -41,6 +46,27 @@ var views = []*view.View{
 		TagKeys:     []tag.Key{kHostType},
 		Aggregation: view.LastValue(),
 	},
+	{
+		Name:        "go-build/coordinator/prewarm_hits",
+		Description: "Buildlet requests served by a pre-warmed buildlet",
+		Measure:     mPrewarmHits,
+		TagKeys:     []tag.Key{kHostType},
+		Aggregation: view.LastValue(),
+	},
+	{
+		Name:        "go-build/coordinator/prewarm_misses",
+		Description: "Buildlet requests which found no pre-warmed buildlet during busy hours",
+		Measure:     mPrewarmMisses,
+		TagKeys:     []tag.Key{kHostType},
+		Aggregation: view.LastValue(),
+	},
+	{
+		Name:        "go-build/coordinator/prewarm_idle_cpu_hours",
+		Description: "vCPU-hours pre-warmed buildlets spent waiting for work",
+		Measure:     mPrewarmIdleCPU,
+		TagKeys:     []tag.Key{kHostType},
+		Aggregation: view.LastValue(),
+	},
 	{
 		Name:        "go-build/githubapi/remaining",
 		Description: "Remaining GitHub API rate limit",
@@ -67,6 +93,13 @@ var views = []*view.View{
 		Measure:     mGomoteRDPCount,
 		Aggregation: view.Count(),
 	},
+	{
+		Name:        "go-build/coordinator/modproxy_requests",
+		Description: "Count of internal module proxy requests, by whether they were served from its cache",
+		Measure:     mModProxyRequests,
+		TagKeys:     []tag.Key{kModProxyResult},
+		Aggregation: view.Count(),
+	},
 }
 
 // reportReverseCountMetrics gathers and reports
@@ -86,6 +119,22 @@ func reportReverseCountMetrics() {
 	}
 }
 
+// reportPrewarmMetrics periodically reports the cumulative hits,
+// misses and idle cost of pre-warmed buildlets per host type.
+func reportPrewarmMetrics(p *pool.Prewarmer) {
+	for {
+		for hostType, s := range p.Stats() {
+			stats.RecordWithTags(context.Background(),
+				[]tag.Mutator{tag.Upsert(kHostType, hostType)},
+				mPrewarmHits.M(s.Hits),
+				mPrewarmMisses.M(s.Misses),
+				mPrewarmIdleCPU.M(s.IdleCPUHours))
+		}
+
+		time.Sleep(5 * time.Minute)
+	}
+}
+
 // recordBuildletCreate records information about gomote creates and sends them
 // to the configured metrics backend.
 func recordBuildletCreate(ctx context.Context, builderType string) {
@@ -9,21 +9,50 @@
 package main
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"golang.org/x/build/internal/gcsfs"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/sync/singleflight"
 )
 
 func listenAndServeInternalModuleProxy() {
-	err := http.ListenAndServe(":8123", http.HandlerFunc(proxyModuleCache))
+	store := gcsfs.DirFS(filepath.Join(os.TempDir(), "modproxy"))
+	if *modProxyCacheURL != "" {
+		var err error
+		store, err = gcsfs.FromURL(context.Background(), mustStorageClient(), *modProxyCacheURL)
+		if err != nil {
+			log.Fatalf("unable to use %q for the module proxy cache: %v", *modProxyCacheURL, err)
+		}
+	}
+	c := newModCache("https://proxy.golang.org", store)
+	if *modProxyWarm != "" {
+		go c.warm(context.Background(), strings.Split(*modProxyWarm, ","))
+	}
+	err := http.ListenAndServe(":8123", c)
 	log.Fatalf("error running internal module proxy: %v", err)
 }
 
-// proxyModuleCache proxies requests to https://proxy.golang.org/
-func proxyModuleCache(w http.ResponseWriter, r *http.Request) {
-	proxyURL(w, r, "https://proxy.golang.org")
-}
-
 func proxyURL(w http.ResponseWriter, r *http.Request, baseURL string) {
 	outReq, err := http.NewRequest("GET", baseURL+r.RequestURI, nil)
 	if err != nil {
@@ -50,3 +79,515 @@ func proxyURL(w http.ResponseWriter, r *http.Request, baseURL string) {
 	w.WriteHeader(res.StatusCode)
 	io.Copy(w, res.Body)
 }
+
+const (
+	// maxModFileSize is the largest module proxy file the cache will
+	// fetch, which is the limit on the size of module zips.
+	maxModFileSize = 500 << 20
+
+	// modNegativeTTL is how long a not found response of the
+	// upstream proxy is cached.
+	modNegativeTTL = 10 * time.Minute
+
+	// modFetchTimeout and modListTimeout limit fetches from the
+	// upstream proxy of immutable files and of the mutable list and
+	// @latest queries, respectively. The latter is short since a
+	// stale copy can be served instead.
+	modFetchTimeout = 5 * time.Minute
+	modListTimeout  = 15 * time.Second
+)
+
+// sumdbKey is the verifier key of the checksum database sum.golang.org.
+const sumdbKey = "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ky0Ii2MY3bVTDn2k"
+
+// A modCache is a caching module proxy (GOPROXY) in front of an
+// upstream module proxy.
+//
+// Files are stored in store under a content-addressed layout: the
+// contents of each file are in blob/sha256/<hash>, and the request
+// path, such as golang.org/x/text/@v/v0.3.7.zip, is mapped to its hash
+// by index/<request path>. The .info, .mod and .zip files of module
+// versions never change, so they're served from the cache once stored.
+// The @v/list and @latest queries are always fetched from upstream, and
+// the stored copy is only served when upstream is unavailable.
+// Module .mod and .zip files are verified against the checksum
+// database before they're stored.
+type modCache struct {
+	upstream string // base URL, such as "https://proxy.golang.org"
+	store    fs.FS  // a gcsfs.CreateFS
+	client   *http.Client
+
+	// lookupSum returns the checksum database lines of a module
+	// version, as sumdb.Client.Lookup does. If nil, files aren't
+	// verified.
+	lookupSum func(path, vers string) ([]string, error)
+
+	fill singleflight.Group // of fetches, keyed by request path
+
+	mu       sync.Mutex
+	negative map[string]*modNotFoundError // keyed by request path
+	counts   map[string]int64             // requests by result
+}
+
+// newModCache returns a cache of the module proxy at upstream, which
+// verifies files with the checksum database sum.golang.org, read
+// through upstream.
+func newModCache(upstream string, store fs.FS) *modCache {
+	c := &modCache{
+		upstream: strings.TrimSuffix(upstream, "/"),
+		store:    store,
+		client:   http.DefaultClient,
+		negative: make(map[string]*modNotFoundError),
+		counts:   make(map[string]int64),
+	}
+	c.lookupSum = sumdb.NewClient(&sumdbOps{c: c}).Lookup
+	return c
+}
+
+// Results of requests to a modCache, which are the values of the
+// kModProxyResult metrics tag.
+const (
+	modResultHit      = "hit"      // served from the cache
+	modResultMiss     = "miss"     // fetched from upstream
+	modResultNegative = "negative" // a cached not found response
+	modResultStale    = "stale"    // a stored list or @latest, as upstream failed
+	modResultError    = "error"
+)
+
+// A modRequest is a parsed module proxy request.
+type modRequest struct {
+	key  string // escaped request path, such as "golang.org/x/text/@v/v0.3.7.zip"
+	mod  string // module path
+	vers string // version or query, such as "master"; "" for "list" and "latest"
+	kind string // "list", "latest", "info", "mod" or "zip"
+}
+
+// immutable reports whether the response to r never changes, which is
+// the case for files of canonical versions, but not of queries.
+func (r modRequest) immutable() bool {
+	return r.vers != "" && module.CanonicalVersion(r.vers) == r.vers
+}
+
+// parseModRequest parses the URL path of a module proxy request, such
+// as "/golang.org/x/text/@v/v0.3.7.zip" or "/golang.org/x/text/@latest".
+func parseModRequest(p string) (modRequest, error) {
+	r := modRequest{key: strings.TrimPrefix(p, "/")}
+	escMod, rest, ok := strings.Cut(r.key, "/@v/")
+	switch {
+	case ok && rest == "list":
+		r.kind = "list"
+	case ok:
+		for _, kind := range []string{"info", "mod", "zip"} {
+			if escVers := strings.TrimSuffix(rest, "."+kind); escVers != rest {
+				v, err := module.UnescapeVersion(escVers)
+				if err != nil {
+					return modRequest{}, err
+				}
+				r.vers, r.kind = v, kind
+			}
+		}
+		if r.kind == "" {
+			return modRequest{}, fmt.Errorf("invalid module proxy file %q", rest)
+		}
+	case strings.HasSuffix(r.key, "/@latest"):
+		escMod, r.kind = strings.TrimSuffix(r.key, "/@latest"), "latest"
+	default:
+		return modRequest{}, fmt.Errorf("invalid module proxy path %q", p)
+	}
+	mod, err := module.UnescapePath(escMod)
+	if err != nil {
+		return modRequest{}, err
+	}
+	if err := module.CheckPath(mod); err != nil {
+		return modRequest{}, err
+	}
+	r.mod = mod
+	return r, nil
+}
+
+// A modNotFoundError is a 404 or 410 response of the upstream proxy,
+// which is passed on to clients.
+type modNotFoundError struct {
+	status  int
+	body    []byte
+	expires time.Time
+}
+
+func (e *modNotFoundError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.status, http.StatusText(e.status), bytes.TrimSpace(e.body))
+}
+
+func (c *modCache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		http.Error(w, "requires GET method", http.StatusMethodNotAllowed)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/sumdb/") {
+		// The go command verifies modules itself, through us.
+		proxyURL(w, r, c.upstream)
+		return
+	}
+	req, err := parseModRequest(r.URL.Path)
+	if err != nil {
+		http.Error(w, "not found: "+err.Error(), http.StatusNotFound)
+		return
+	}
+	data, result, err := c.get(req)
+	c.count(result)
+	var nf *modNotFoundError
+	switch {
+	case errors.As(err, &nf):
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(nf.status)
+		w.Write(nf.body)
+	case err != nil:
+		log.Printf("modproxy: %s: %v", req.key, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	default:
+		switch req.kind {
+		case "info", "latest":
+			w.Header().Set("Content-Type", "application/json")
+		case "zip":
+			w.Header().Set("Content-Type", "application/zip")
+		default:
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		w.Write(data)
+	}
+}
+
+// get returns the response to req and the result of the request, one
+// of the modResult constants.
+func (c *modCache) get(req modRequest) (data []byte, result string, err error) {
+	if req.immutable() {
+		if data, err := c.load(req.key); err == nil {
+			return data, modResultHit, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("modproxy: loading %s from the cache: %v", req.key, err)
+		}
+	}
+	if nf := c.negativeEntry(req.key); nf != nil {
+		return nil, modResultNegative, nf
+	}
+	v, err, _ := c.fill.Do(req.key, func() (interface{}, error) { return c.fetch(req) })
+	if err == nil {
+		return v.([]byte), modResultMiss, nil
+	}
+	var nf *modNotFoundError
+	if errors.As(err, &nf) {
+		return nil, modResultMiss, err
+	}
+	if !req.immutable() {
+		if data, lerr := c.load(req.key); lerr == nil {
+			log.Printf("modproxy: serving stored %s, as fetching it failed: %v", req.key, err)
+			return data, modResultStale, nil
+		}
+	}
+	return nil, modResultError, err
+}
+
+// fetch fetches the response to req from upstream, and stores it if
+// it's found and verified.
+func (c *modCache) fetch(req modRequest) ([]byte, error) {
+	timeout := modFetchTimeout
+	if !req.immutable() {
+		timeout = modListTimeout
+	}
+	// The fetch isn't tied to the request which started it, as other
+	// requests may be waiting for it.
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	data, status, err := c.readUpstream(ctx, "/"+req.key)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone:
+		nf := &modNotFoundError{status: status, body: data, expires: time.Now().Add(modNegativeTTL)}
+		c.addNegative(req.key, nf)
+		return nil, nf
+	default:
+		return nil, fmt.Errorf("upstream proxy returned %d %s", status, http.StatusText(status))
+	}
+	if err := c.verify(req, data); err != nil {
+		return nil, err
+	}
+	if err := c.save(req.key, data); err != nil {
+		// The file can still be served; it's just not cached.
+		log.Printf("modproxy: storing %s: %v", req.key, err)
+	}
+	return data, nil
+}
+
+// readUpstream reads the upstream file at path, which must begin with
+// a slash, and returns its contents and the HTTP status.
+func (c *modCache) readUpstream(ctx context.Context, path string) ([]byte, int, error) {
+	hreq, err := http.NewRequestWithContext(ctx, "GET", c.upstream+path, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	res, err := c.client.Do(hreq)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxModFileSize+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(data) > maxModFileSize {
+		return nil, 0, fmt.Errorf("%s is larger than %d bytes", path, maxModFileSize)
+	}
+	return data, res.StatusCode, nil
+}
+
+// verify checks the .mod or .zip file of req against the checksum
+// database. Other files aren't verified.
+func (c *modCache) verify(req modRequest, data []byte) error {
+	if c.lookupSum == nil || req.kind != "mod" && req.kind != "zip" {
+		return nil
+	}
+	var h, suffix string
+	var err error
+	if req.kind == "mod" {
+		suffix = "/go.mod"
+		h, err = dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		})
+	} else {
+		h, err = hashZip(data)
+	}
+	if err != nil {
+		return fmt.Errorf("hashing %s: %v", req.key, err)
+	}
+	lines, err := c.lookupSum(req.mod, req.vers)
+	if err != nil {
+		return fmt.Errorf("looking up %s@%s in the checksum database: %v", req.mod, req.vers, err)
+	}
+	want := fmt.Sprintf("%s %s%s %s", req.mod, req.vers, suffix, h)
+	for _, line := range lines {
+		if line == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("verifying %s@%s%s: checksum mismatch: downloaded %s", req.mod, req.vers, suffix, h)
+}
+
+// hashZip is like dirhash.HashZip with dirhash.Hash1, for a module zip
+// in memory.
+func hashZip(data []byte) (string, error) {
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+	var files []string
+	zfiles := make(map[string]*zip.File)
+	for _, f := range z.File {
+		files = append(files, f.Name)
+		zfiles[f.Name] = f
+	}
+	return dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		f := zfiles[name]
+		if f == nil {
+			return nil, fmt.Errorf("file %q not found in zip", name)
+		}
+		return f.Open()
+	})
+}
+
+// load returns the stored file of the request path key.
+func (c *modCache) load(key string) ([]byte, error) {
+	ref, err := fs.ReadFile(c.store, "index/"+key)
+	if err != nil {
+		return nil, err
+	}
+	hash := strings.TrimPrefix(string(ref), "sha256:")
+	if hash == string(ref) {
+		return nil, fmt.Errorf("invalid index entry %q", ref)
+	}
+	data, err := fs.ReadFile(c.store, "blob/sha256/"+hash)
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != hash {
+		return nil, fmt.Errorf("blob %s is corrupt", hash)
+	}
+	return data, nil
+}
+
+// save stores data as the file of the request path key.
+func (c *modCache) save(key string, data []byte) error {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	blob := "blob/sha256/" + hash
+	if _, err := fs.Stat(c.store, blob); err != nil {
+		if err := gcsfs.WriteFile(c.store, blob, data); err != nil {
+			return err
+		}
+	}
+	return gcsfs.WriteFile(c.store, "index/"+key, []byte("sha256:"+hash))
+}
+
+// negativeEntry returns the cached not found response of the request
+// path key, or nil.
+func (c *modCache) negativeEntry(key string) *modNotFoundError {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nf := c.negative[key]
+	if nf == nil || time.Now().After(nf.expires) {
+		return nil
+	}
+	return nf
+}
+
+func (c *modCache) addNegative(key string, nf *modNotFoundError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for k, e := range c.negative {
+		if now.After(e.expires) {
+			delete(c.negative, k)
+		}
+	}
+	c.negative[key] = nf
+}
+
+// count counts a request with result, one of the modResult constants.
+func (c *modCache) count(result string) {
+	c.mu.Lock()
+	c.counts[result]++
+	c.mu.Unlock()
+	stats.RecordWithTags(context.Background(),
+		[]tag.Mutator{tag.Upsert(kModProxyResult, result)},
+		mModProxyRequests.M(1))
+}
+
+// warm fetches the .info, .mod and .zip files of the modules required
+// by the go.mod files into the cache. Each go.mod file is a path or an
+// http(s) URL.
+func (c *modCache) warm(ctx context.Context, gomods []string) {
+	var fetched, failed int
+	for _, gomod := range gomods {
+		data, err := readGoMod(ctx, gomod)
+		if err != nil {
+			log.Printf("modproxy: warming the cache with %s: %v", gomod, err)
+			continue
+		}
+		f, err := modfile.ParseLax(gomod, data, nil)
+		if err != nil {
+			log.Printf("modproxy: warming the cache with %s: %v", gomod, err)
+			continue
+		}
+		for _, r := range f.Require {
+			escMod, err := module.EscapePath(r.Mod.Path)
+			if err != nil {
+				continue
+			}
+			escVers, err := module.EscapeVersion(r.Mod.Version)
+			if err != nil {
+				continue
+			}
+			for _, kind := range []string{"info", "mod", "zip"} {
+				if ctx.Err() != nil {
+					return
+				}
+				req, err := parseModRequest(fmt.Sprintf("/%s/@v/%s.%s", escMod, escVers, kind))
+				if err != nil {
+					continue
+				}
+				if _, result, err := c.get(req); err != nil {
+					log.Printf("modproxy: warming the cache with %s: %v", req.key, err)
+					failed++
+				} else if result == modResultMiss {
+					fetched++
+				}
+			}
+		}
+	}
+	log.Printf("modproxy: warmed the cache with %d files; %d failed", fetched, failed)
+}
+
+// readGoMod reads the go.mod file at the path or http(s) URL gomod.
+func readGoMod(ctx context.Context, gomod string) ([]byte, error) {
+	if !strings.HasPrefix(gomod, "https://") && !strings.HasPrefix(gomod, "http://") {
+		return os.ReadFile(gomod)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", gomod, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %s", res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// sumdbOps implements sumdb.ClientOps for sum.golang.org, which is
+// read through the upstream proxy of a modCache. Its configuration
+// and tiles are kept in the store of the cache.
+type sumdbOps struct {
+	c  *modCache
+	mu sync.Mutex // guards compare-and-swap of the configuration
+}
+
+func (o *sumdbOps) ReadRemote(path string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), modListTimeout)
+	defer cancel()
+	data, status, err := o.c.readUpstream(ctx, "/sumdb/sum.golang.org"+path)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("reading %s: %d %s", path, status, http.StatusText(status))
+	}
+	return data, nil
+}
+
+func (o *sumdbOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(sumdbKey), nil
+	}
+	data, err := fs.ReadFile(o.c.store, "sumdb/config/"+file)
+	if errors.Is(err, fs.ErrNotExist) {
+		// An empty latest tree, as the first time it's read.
+		return nil, nil
+	}
+	return data, err
+}
+
+func (o *sumdbOps) WriteConfig(file string, old, new []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	cur, err := o.ReadConfig(file)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(cur, old) {
+		return sumdb.ErrWriteConflict
+	}
+	return gcsfs.WriteFile(o.c.store, "sumdb/config/"+file, new)
+}
+
+func (o *sumdbOps) ReadCache(file string) ([]byte, error) {
+	return fs.ReadFile(o.c.store, "sumdb/cache/"+file)
+}
+
+func (o *sumdbOps) WriteCache(file string, data []byte) {
+	if err := gcsfs.WriteFile(o.c.store, "sumdb/cache/"+file, data); err != nil {
+		log.Printf("modproxy: caching sumdb %s: %v", file, err)
+	}
+}
+
+func (o *sumdbOps) Log(msg string) {
+	log.Printf("modproxy: sumdb: %s", msg)
+}
+
+func (o *sumdbOps) SecurityError(msg string) {
+	log.Printf("modproxy: sumdb SECURITY ERROR: %s", msg)
+}
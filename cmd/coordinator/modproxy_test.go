@@ -9,10 +9,21 @@
 package main
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+
+	"golang.org/x/build/internal/gcsfs"
+	"golang.org/x/mod/sumdb/dirhash"
 )
 
 // TestProxyURL tests that the response served from proxyURL is not a
@@ -59,3 +70,240 @@ func TestProxyURL(t *testing.T) {
 		t.Errorf("header(%q) = %q; want %q", header, h, content)
 	}
 }
+
+// fakeModProxy is an upstream module proxy serving the module
+// example.com/m at v1.0.0, which counts the requests of each path.
+type fakeModProxy struct {
+	zip, mod []byte
+
+	mu    sync.Mutex
+	down  bool // whether to fail all requests
+	reqs  map[string]int
+	files map[string][]byte
+}
+
+func newFakeModProxy(t *testing.T) *fakeModProxy {
+	const gomod = "module example.com/m\n"
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{"go.mod": gomod, "m.go": "package m\n"} {
+		w, err := zw.Create("example.com/m@v1.0.0/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.WriteString(w, content)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	p := &fakeModProxy{zip: buf.Bytes(), mod: []byte(gomod), reqs: make(map[string]int)}
+	p.files = map[string][]byte{
+		"/example.com/m/@v/list":        []byte("v1.0.0\n"),
+		"/example.com/m/@latest":        []byte(`{"Version":"v1.0.0"}`),
+		"/example.com/m/@v/v1.0.0.info": []byte(`{"Version":"v1.0.0"}`),
+		"/example.com/m/@v/v1.0.0.mod":  p.mod,
+		"/example.com/m/@v/v1.0.0.zip":  p.zip,
+	}
+	return p
+}
+
+func (p *fakeModProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reqs[r.URL.Path]++
+	if p.down {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+		return
+	}
+	data, ok := p.files[r.URL.Path]
+	if !ok {
+		http.Error(w, "not found: "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+	w.Write(data)
+}
+
+func (p *fakeModProxy) requests(path string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.reqs[path]
+}
+
+// lookupSum returns the checksum database lines of example.com/m.
+func (p *fakeModProxy) lookupSum(t *testing.T) func(path, vers string) ([]string, error) {
+	zipFile := filepath.Join(t.TempDir(), "m.zip")
+	if err := os.WriteFile(zipFile, p.zip, 0666); err != nil {
+		t.Fatal(err)
+	}
+	zipHash, err := dirhash.HashZip(zipFile, dirhash.Hash1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modHash, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(p.mod)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(path, vers string) ([]string, error) {
+		if path != "example.com/m" || vers != "v1.0.0" {
+			return nil, fmt.Errorf("%s@%s: not found", path, vers)
+		}
+		return []string{
+			"example.com/m v1.0.0 " + zipHash,
+			"example.com/m v1.0.0/go.mod " + modHash,
+		}, nil
+	}
+}
+
+// newTestModCache returns a cache of upstream, which stores files in
+// dir and verifies them with lookupSum.
+func newTestModCache(upstream, dir string, lookupSum func(path, vers string) ([]string, error)) *modCache {
+	c := newModCache(upstream, gcsfs.DirFS(dir))
+	c.lookupSum = lookupSum
+	return c
+}
+
+func getModFile(t *testing.T, c *modCache, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestModCache(t *testing.T) {
+	up := newFakeModProxy(t)
+	ts := httptest.NewServer(up)
+	defer ts.Close()
+	dir := t.TempDir()
+	c := newTestModCache(ts.URL, dir, up.lookupSum(t))
+
+	for _, path := range []string{"/example.com/m/@v/v1.0.0.info", "/example.com/m/@v/v1.0.0.mod", "/example.com/m/@v/v1.0.0.zip"} {
+		for i := 0; i < 2; i++ {
+			if code, body := getModFile(t, c, path); code != 200 || body != string(up.files[path]) {
+				t.Errorf("GET %s = %d %q; want 200 %q", path, code, body, up.files[path])
+			}
+		}
+		if n := up.requests(path); n != 1 {
+			t.Errorf("%s was fetched from upstream %d times; want once", path, n)
+		}
+	}
+	if c.counts[modResultHit] != 3 || c.counts[modResultMiss] != 3 {
+		t.Errorf("counts = %v; want 3 hits and 3 misses", c.counts)
+	}
+
+	// The cache persists in its store, and serves the files when
+	// upstream is down.
+	up.mu.Lock()
+	up.down = true
+	up.mu.Unlock()
+	c = newTestModCache(ts.URL, dir, up.lookupSum(t))
+	if code, body := getModFile(t, c, "/example.com/m/@v/v1.0.0.zip"); code != 200 || body != string(up.zip) {
+		t.Errorf("GET of the zip from a new cache = %d; want 200 and the zip", code)
+	}
+
+	// Stored copies of list and @latest are only served when
+	// upstream is down.
+	if code, _ := getModFile(t, c, "/example.com/m/@v/list"); code != http.StatusBadGateway {
+		t.Errorf("GET of list never fetched while upstream is down = %d; want %d", code, http.StatusBadGateway)
+	}
+	up.mu.Lock()
+	up.down = false
+	up.mu.Unlock()
+	if code, body := getModFile(t, c, "/example.com/m/@v/list"); code != 200 || body != "v1.0.0\n" {
+		t.Errorf("GET of list = %d %q; want 200 %q", code, body, "v1.0.0\n")
+	}
+	up.mu.Lock()
+	up.down = true
+	up.mu.Unlock()
+	if code, body := getModFile(t, c, "/example.com/m/@v/list"); code != 200 || body != "v1.0.0\n" {
+		t.Errorf("GET of stale list = %d %q; want 200 %q", code, body, "v1.0.0\n")
+	}
+	if c.counts[modResultStale] != 1 {
+		t.Errorf("counts = %v; want 1 stale", c.counts)
+	}
+}
+
+func TestModCacheNegative(t *testing.T) {
+	up := newFakeModProxy(t)
+	ts := httptest.NewServer(up)
+	defer ts.Close()
+	c := newTestModCache(ts.URL, t.TempDir(), up.lookupSum(t))
+
+	const path = "/example.com/m/@v/v1.1.0.info"
+	for i := 0; i < 2; i++ {
+		if code, body := getModFile(t, c, path); code != http.StatusNotFound || !strings.Contains(body, "not found") {
+			t.Errorf("GET %s = %d %q; want 404 not found", path, code, body)
+		}
+	}
+	if n := up.requests(path); n != 1 {
+		t.Errorf("%s was fetched from upstream %d times; want once", path, n)
+	}
+	if c.counts[modResultNegative] != 1 {
+		t.Errorf("counts = %v; want 1 negative", c.counts)
+	}
+}
+
+func TestModCacheVerify(t *testing.T) {
+	up := newFakeModProxy(t)
+	up.files["/example.com/m/@v/v1.0.0.mod"] = []byte("module example.com/m // tampered\n")
+	ts := httptest.NewServer(up)
+	defer ts.Close()
+	c := newTestModCache(ts.URL, t.TempDir(), up.lookupSum(t))
+
+	if code, body := getModFile(t, c, "/example.com/m/@v/v1.0.0.mod"); code != http.StatusBadGateway || !strings.Contains(body, "checksum mismatch") {
+		t.Errorf("GET of tampered go.mod = %d %q; want %d checksum mismatch", code, body, http.StatusBadGateway)
+	}
+	if code, _ := getModFile(t, c, "/example.com/m/@v/v1.0.0.zip"); code != 200 {
+		t.Errorf("GET of zip = %d; want 200", code)
+	}
+	if _, err := c.load("example.com/m/@v/v1.0.0.mod"); err == nil {
+		t.Errorf("tampered go.mod was stored")
+	}
+}
+
+func TestModCacheWarm(t *testing.T) {
+	up := newFakeModProxy(t)
+	ts := httptest.NewServer(up)
+	defer ts.Close()
+	c := newTestModCache(ts.URL, t.TempDir(), up.lookupSum(t))
+
+	gomod := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(gomod, []byte("module golang.org/x/foo\n\nrequire example.com/m v1.0.0\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	c.warm(context.Background(), []string{gomod})
+	for _, key := range []string{"example.com/m/@v/v1.0.0.info", "example.com/m/@v/v1.0.0.mod", "example.com/m/@v/v1.0.0.zip"} {
+		if _, err := c.load(key); err != nil {
+			t.Errorf("%s isn't cached after warming: %v", key, err)
+		}
+	}
+}
+
+func TestParseModRequest(t *testing.T) {
+	tests := []struct {
+		path string
+		want modRequest
+		imm  bool
+	}{
+		{"/golang.org/x/text/@v/list", modRequest{key: "golang.org/x/text/@v/list", mod: "golang.org/x/text", kind: "list"}, false},
+		{"/golang.org/x/text/@latest", modRequest{key: "golang.org/x/text/@latest", mod: "golang.org/x/text", kind: "latest"}, false},
+		{"/github.com/!burnt!sushi/toml/@v/v1.2.0.zip", modRequest{key: "github.com/!burnt!sushi/toml/@v/v1.2.0.zip", mod: "github.com/BurntSushi/toml", vers: "v1.2.0", kind: "zip"}, true},
+		{"/golang.org/x/text/@v/master.info", modRequest{key: "golang.org/x/text/@v/master.info", mod: "golang.org/x/text", vers: "master", kind: "info"}, false},
+	}
+	for _, tt := range tests {
+		got, err := parseModRequest(tt.path)
+		if err != nil {
+			t.Errorf("parseModRequest(%q): %v", tt.path, err)
+			continue
+		}
+		if got != tt.want || got.immutable() != tt.imm {
+			t.Errorf("parseModRequest(%q) = %+v, immutable %v; want %+v, %v", tt.path, got, got.immutable(), tt.want, tt.imm)
+		}
+	}
+	for _, path := range []string{"/golang.org/x/text", "/golang.org/x/text/@v/v1.0.0.tar", "/golang.org/x/Text/@latest"} {
+		if _, err := parseModRequest(path); err == nil {
+			t.Errorf("parseModRequest(%q) succeeded; want error", path)
+		}
+	}
+}
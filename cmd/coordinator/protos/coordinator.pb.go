@@ -105,25 +105,132 @@ func (m *ClearResultsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ClearResultsResponse proto.InternalMessageInfo
 
+// DrainReverseBuildletRequest specifies the reverse buildlet to drain or resume.
+type DrainReverseBuildletRequest struct {
+	// hostname is the hostname the reverse buildlet registered with.
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// drain is whether to drain the buildlet. If false, the buildlet is given work again.
+	Drain                bool     `protobuf:"varint,2,opt,name=drain,proto3" json:"drain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainReverseBuildletRequest) Reset()         { *m = DrainReverseBuildletRequest{} }
+func (m *DrainReverseBuildletRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainReverseBuildletRequest) ProtoMessage()    {}
+func (*DrainReverseBuildletRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_99e779eb11ceee19, []int{2}
+}
+
+func (m *DrainReverseBuildletRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainReverseBuildletRequest.Unmarshal(m, b)
+}
+func (m *DrainReverseBuildletRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainReverseBuildletRequest.Marshal(b, m, deterministic)
+}
+func (m *DrainReverseBuildletRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainReverseBuildletRequest.Merge(m, src)
+}
+func (m *DrainReverseBuildletRequest) XXX_Size() int {
+	return xxx_messageInfo_DrainReverseBuildletRequest.Size(m)
+}
+func (m *DrainReverseBuildletRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainReverseBuildletRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainReverseBuildletRequest proto.InternalMessageInfo
+
+func (m *DrainReverseBuildletRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *DrainReverseBuildletRequest) GetDrain() bool {
+	if m != nil {
+		return m.Drain
+	}
+	return false
+}
+
+type DrainReverseBuildletResponse struct {
+	// draining is whether the buildlet is draining.
+	Draining bool `protobuf:"varint,1,opt,name=draining,proto3" json:"draining,omitempty"`
+	// idle is whether the buildlet is not running a build.
+	Idle                 bool     `protobuf:"varint,2,opt,name=idle,proto3" json:"idle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainReverseBuildletResponse) Reset()         { *m = DrainReverseBuildletResponse{} }
+func (m *DrainReverseBuildletResponse) String() string { return proto.CompactTextString(m) }
+func (*DrainReverseBuildletResponse) ProtoMessage()    {}
+func (*DrainReverseBuildletResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_99e779eb11ceee19, []int{3}
+}
+
+func (m *DrainReverseBuildletResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainReverseBuildletResponse.Unmarshal(m, b)
+}
+func (m *DrainReverseBuildletResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainReverseBuildletResponse.Marshal(b, m, deterministic)
+}
+func (m *DrainReverseBuildletResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainReverseBuildletResponse.Merge(m, src)
+}
+func (m *DrainReverseBuildletResponse) XXX_Size() int {
+	return xxx_messageInfo_DrainReverseBuildletResponse.Size(m)
+}
+func (m *DrainReverseBuildletResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainReverseBuildletResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainReverseBuildletResponse proto.InternalMessageInfo
+
+func (m *DrainReverseBuildletResponse) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+func (m *DrainReverseBuildletResponse) GetIdle() bool {
+	if m != nil {
+		return m.Idle
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ClearResultsRequest)(nil), "protos.ClearResultsRequest")
 	proto.RegisterType((*ClearResultsResponse)(nil), "protos.ClearResultsResponse")
+	proto.RegisterType((*DrainReverseBuildletRequest)(nil), "protos.DrainReverseBuildletRequest")
+	proto.RegisterType((*DrainReverseBuildletResponse)(nil), "protos.DrainReverseBuildletResponse")
 }
 
 func init() { proto.RegisterFile("coordinator.proto", fileDescriptor_99e779eb11ceee19) }
 
 var fileDescriptor_99e779eb11ceee19 = []byte{
-	// 151 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4c, 0xce, 0xcf, 0x2f,
-	0x4a, 0xc9, 0xcc, 0x4b, 0x2c, 0xc9, 0x2f, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x03,
-	0x53, 0xc5, 0x4a, 0xce, 0x5c, 0xc2, 0xce, 0x39, 0xa9, 0x89, 0x45, 0x41, 0xa9, 0xc5, 0xa5, 0x39,
-	0x25, 0xc5, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x42, 0x12, 0x5c, 0xec, 0x49, 0xa5, 0x99,
-	0x39, 0x29, 0xa9, 0x45, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x30, 0xae, 0x90, 0x10, 0x17,
-	0x4b, 0x46, 0x62, 0x71, 0x86, 0x04, 0x13, 0x58, 0x18, 0xcc, 0x56, 0x12, 0xe3, 0x12, 0x41, 0x35,
-	0xa4, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0xd5, 0x28, 0x8a, 0x8b, 0xdb, 0x19, 0x61, 0xb3, 0x90, 0x37,
-	0x17, 0x0f, 0xb2, 0x32, 0x21, 0x69, 0x88, 0x5b, 0x8a, 0xf5, 0xb0, 0xb8, 0x40, 0x4a, 0x06, 0xbb,
-	0x24, 0xc4, 0x64, 0x25, 0x86, 0x24, 0x88, 0x07, 0x8c, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0x10,
-	0xa0, 0xb6, 0xbb, 0xdc, 0x00, 0x00, 0x00,
+	// 246 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0x4d, 0x4e, 0xc4, 0x30,
+	0x0c, 0x85, 0x29, 0xe2, 0xa7, 0x18, 0x36, 0x98, 0x0a, 0x55, 0x9d, 0x59, 0xa0, 0xc2, 0x82, 0xd5,
+	0x2c, 0xe0, 0x06, 0x94, 0x1d, 0x12, 0x48, 0xb9, 0x41, 0x66, 0x6a, 0xd1, 0x48, 0x21, 0x19, 0xe2,
+	0x94, 0xeb, 0x71, 0x35, 0xd4, 0xa4, 0x19, 0x40, 0x8a, 0x66, 0x55, 0xbf, 0xd6, 0xfe, 0x9e, 0xfd,
+	0x0a, 0x97, 0x1b, 0x6b, 0x5d, 0xaf, 0x8c, 0xf4, 0xd6, 0xad, 0xb6, 0xce, 0x7a, 0x8b, 0x27, 0xe1,
+	0xc1, 0x6d, 0x07, 0x57, 0x9d, 0x26, 0xe9, 0x04, 0xf1, 0xa8, 0x3d, 0x0b, 0xfa, 0x1c, 0x89, 0x3d,
+	0xd6, 0x70, 0xba, 0x1e, 0x95, 0xee, 0xc9, 0xd5, 0xc5, 0x4d, 0x71, 0x7f, 0x26, 0x92, 0x44, 0x84,
+	0xa3, 0x41, 0xf2, 0x50, 0x1f, 0x86, 0xd7, 0xa1, 0x6e, 0xaf, 0xa1, 0xfa, 0x0f, 0xe1, 0xad, 0x35,
+	0x4c, 0xed, 0x1b, 0x2c, 0x9e, 0x9d, 0x54, 0x46, 0xd0, 0x17, 0x39, 0xa6, 0xa7, 0x09, 0xa1, 0xc9,
+	0x27, 0x93, 0x06, 0xca, 0xc1, 0xb2, 0x37, 0xf2, 0x83, 0x66, 0x97, 0x9d, 0xc6, 0x0a, 0x8e, 0xfb,
+	0x69, 0x34, 0xf8, 0x94, 0x22, 0x8a, 0xf6, 0x15, 0x96, 0x79, 0x60, 0x34, 0x9c, 0x88, 0xa1, 0x51,
+	0x99, 0xf7, 0x40, 0x2c, 0xc5, 0x4e, 0x4f, 0x8b, 0xab, 0x5e, 0xd3, 0x0c, 0x0c, 0xf5, 0xc3, 0x77,
+	0x01, 0xe7, 0xdd, 0x6f, 0x36, 0xf8, 0x02, 0x17, 0x7f, 0x0f, 0xc1, 0x45, 0x4c, 0x8b, 0x57, 0x99,
+	0x8c, 0x9a, 0x65, 0xfe, 0xe3, 0x7c, 0xfb, 0x01, 0x6e, 0xa0, 0xca, 0x2d, 0x8b, 0xb7, 0x69, 0x6e,
+	0x4f, 0x36, 0xcd, 0xdd, 0xfe, 0xa6, 0x64, 0xb2, 0x8e, 0xff, 0xf1, 0xf1, 0x67, 0x00, 0x08, 0xd0,
+	0x2e, 0xa3, 0xe3, 0x01, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -140,6 +247,9 @@ const _ = grpc.SupportPackageIsVersion6
 type CoordinatorClient interface {
 	// ClearResults clears build failures from the coordinator to force them to rebuild.
 	ClearResults(ctx context.Context, in *ClearResultsRequest, opts ...grpc.CallOption) (*ClearResultsResponse, error)
+	// DrainReverseBuildlet stops giving new work to a reverse buildlet once its current build finishes,
+	// or resumes giving it work.
+	DrainReverseBuildlet(ctx context.Context, in *DrainReverseBuildletRequest, opts ...grpc.CallOption) (*DrainReverseBuildletResponse, error)
 }
 
 type coordinatorClient struct {
@@ -159,10 +269,22 @@ func (c *coordinatorClient) ClearResults(ctx context.Context, in *ClearResultsRe
 	return out, nil
 }
 
+func (c *coordinatorClient) DrainReverseBuildlet(ctx context.Context, in *DrainReverseBuildletRequest, opts ...grpc.CallOption) (*DrainReverseBuildletResponse, error) {
+	out := new(DrainReverseBuildletResponse)
+	err := c.cc.Invoke(ctx, "/protos.Coordinator/DrainReverseBuildlet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CoordinatorServer is the server API for Coordinator service.
 type CoordinatorServer interface {
 	// ClearResults clears build failures from the coordinator to force them to rebuild.
 	ClearResults(context.Context, *ClearResultsRequest) (*ClearResultsResponse, error)
+	// DrainReverseBuildlet stops giving new work to a reverse buildlet once its current build finishes,
+	// or resumes giving it work.
+	DrainReverseBuildlet(context.Context, *DrainReverseBuildletRequest) (*DrainReverseBuildletResponse, error)
 }
 
 // UnimplementedCoordinatorServer can be embedded to have forward compatible implementations.
@@ -172,6 +294,9 @@ type UnimplementedCoordinatorServer struct {
 func (*UnimplementedCoordinatorServer) ClearResults(ctx context.Context, req *ClearResultsRequest) (*ClearResultsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearResults not implemented")
 }
+func (*UnimplementedCoordinatorServer) DrainReverseBuildlet(ctx context.Context, req *DrainReverseBuildletRequest) (*DrainReverseBuildletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainReverseBuildlet not implemented")
+}
 
 func RegisterCoordinatorServer(s *grpc.Server, srv CoordinatorServer) {
 	s.RegisterService(&_Coordinator_serviceDesc, srv)
@@ -195,6 +320,24 @@ func _Coordinator_ClearResults_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Coordinator_DrainReverseBuildlet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainReverseBuildletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).DrainReverseBuildlet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Coordinator/DrainReverseBuildlet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).DrainReverseBuildlet(ctx, req.(*DrainReverseBuildletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Coordinator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Coordinator",
 	HandlerType: (*CoordinatorServer)(nil),
@@ -203,6 +346,10 @@ var _Coordinator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ClearResults",
 			Handler:    _Coordinator_ClearResults_Handler,
 		},
+		{
+			MethodName: "DrainReverseBuildlet",
+			Handler:    _Coordinator_DrainReverseBuildlet_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "coordinator.proto",
@@ -604,6 +604,12 @@ func handleStatus(w http.ResponseWriter, r *http.Request) {
 	data.EC2PoolStatus = template.HTML(buf.String())
 	buf.Reset()
 
+	if buildletPrewarmer != nil {
+		buildletPrewarmer.WriteHTMLStatus(&buf)
+		data.PrewarmStatus = template.HTML(buf.String())
+		buf.Reset()
+	}
+
 	pool.ReversePool().WriteHTMLStatus(&buf)
 	data.ReversePoolStatus = template.HTML(buf.String())
 
@@ -670,6 +676,7 @@ type statusData struct {
 	GCEPoolStatus     template.HTML // TODO: embed template
 	EC2PoolStatus     template.HTML // TODO: embed template
 	ReversePoolStatus template.HTML // TODO: embed template
+	PrewarmStatus     template.HTML // empty if pre-warming is off
 	GomoteInstances   template.HTML
 	SchedState        schedule.SchedulerState
 	DiskFree          string
@@ -0,0 +1,331 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+// Code related to the CLs which are tested together with the CL of a
+// try run: its ancestors in a relation chain, and CLs of other repos
+// that it depends on.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/internal/sourcecache"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+	"golang.org/x/build/repos"
+	"golang.org/x/build/types"
+)
+
+// A tryDep is a CL which is tested together with the CL of a try set.
+//
+// It's either an unsubmitted ancestor of the CL in its relation chain,
+// whose commit is included in the tested commit, or a CL of another
+// repo listed by a "Cq-Depend:" footer in the commit message or by a
+// "cl/NNNN" term of a TRY= comment. The latter are applied to builds:
+// a Go CL is built instead of the Go commit the repo is tested with,
+// and CLs of x/ repos replace their modules in a go.work file, so the
+// module graph is built with all of them.
+type tryDep struct {
+	Project  string // "go", "tools", etc.
+	Branch   string // "master", etc.
+	Number   int    // Gerrit change number
+	PatchSet int
+	Commit   string
+	Ancestor bool // in the relation chain of the tested CL
+
+	// Outdated is whether PatchSet isn't the current patch set of
+	// the CL, which happens when an ancestor is updated after the
+	// tested CL was uploaded on top of it.
+	Outdated bool
+}
+
+// String returns a description of d such as "tools CL 12345 PS 3".
+func (d tryDep) String() string {
+	s := fmt.Sprintf("%s CL %d PS %d", d.Project, d.Number, d.PatchSet)
+	if d.Outdated {
+		s += " (outdated)"
+	}
+	return s
+}
+
+// URL returns the Gerrit URL of the patch set of d.
+func (d tryDep) URL() string {
+	return fmt.Sprintf("https://go.dev/cl/%d/%d", d.Number, d.PatchSet)
+}
+
+// A tryStack is the combination of CLs tested by a try set, besides
+// its own CL.
+type tryStack struct {
+	deps    []tryDep
+	ignored []string // why depended-on CLs aren't tested, such as "CL 123 is abandoned"
+}
+
+// crossRepo reports whether the stack has CLs of other repos, which are
+// applied to builds.
+func (s tryStack) crossRepo() bool {
+	for _, d := range s.deps {
+		if !d.Ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupTryStack returns the CLs to test work together with.
+// It's a variable so tests can replace it.
+var lookupTryStack = func(ctx context.Context, work *apipb.GerritTryWorkItem) (tryStack, error) {
+	return tryStackOf(ctx, pool.NewGCEConfiguration().GerritClient(), work)
+}
+
+// tryStackOf returns the CLs to test work together with: the unsubmitted
+// ancestors of its CL in its relation chain, followed by the open CLs
+// of other repos it depends on, in the order of their change numbers.
+// It only returns an error if Gerrit fails.
+func tryStackOf(ctx context.Context, gc *gerrit.Client, work *apipb.GerritTryWorkItem) (tryStack, error) {
+	var stack tryStack
+	triple := fmt.Sprintf("%s~%s~%s", work.Project, work.Branch, work.ChangeId)
+	related, err := gc.GetRelatedChanges(ctx, triple, work.Commit)
+	if err != nil {
+		return tryStack{}, fmt.Errorf("getting related changes: %w", err)
+	}
+	// Related changes are sorted from the top of the chain to the
+	// bottom, so the ancestors of the tested commit follow it.
+	var isAncestor bool
+	for _, c := range related.Changes {
+		if !isAncestor {
+			isAncestor = c.Commit.CommitID == work.Commit
+			continue
+		}
+		if c.Status == gerrit.ChangeStatusMerged || c.Status == gerrit.ChangeStatusAbandoned {
+			continue
+		}
+		stack.deps = append(stack.deps, tryDep{
+			Project:  c.Project,
+			Branch:   work.Branch,
+			Number:   int(c.ChangeNumber),
+			PatchSet: int(c.RevisionNumber),
+			Commit:   c.Commit.CommitID,
+			Ancestor: true,
+			Outdated: c.RevisionNumber != c.CurrentRevisionNumber,
+		})
+	}
+
+	ci, err := gc.GetChange(ctx, triple, gerrit.QueryChangesOpt{Fields: []string{"ALL_REVISIONS", "ALL_COMMITS"}})
+	if err != nil {
+		return tryStack{}, fmt.Errorf("getting change: %w", err)
+	}
+	var msg string
+	if rev, ok := ci.Revisions[work.Commit]; ok && rev.Commit != nil {
+		msg = rev.Commit.Message
+	}
+	for _, num := range dependedOnCLs(msg, latestTryTerms(work)) {
+		if num == ci.ChangeNumber {
+			continue
+		}
+		dep, err := gc.GetChange(ctx, strconv.Itoa(num), gerrit.QueryChangesOpt{Fields: []string{"CURRENT_REVISION"}})
+		if errors.Is(err, gerrit.ErrResourceNotExist) {
+			stack.ignored = append(stack.ignored, fmt.Sprintf("CL %d doesn't exist", num))
+			continue
+		} else if err != nil {
+			return tryStack{}, fmt.Errorf("getting depended-on CL %d: %w", num, err)
+		}
+		switch {
+		case dep.Status == gerrit.ChangeStatusMerged:
+			// It's in its repo already.
+			continue
+		case dep.Status == gerrit.ChangeStatusAbandoned:
+			stack.ignored = append(stack.ignored, fmt.Sprintf("CL %d is abandoned", num))
+			continue
+		case dep.Project == work.Project:
+			stack.ignored = append(stack.ignored, fmt.Sprintf("CL %d is in the same repo; upload the CLs as a relation chain instead", num))
+			continue
+		case dep.Project != "go" && !repoCanBuild(dep.Project):
+			stack.ignored = append(stack.ignored, fmt.Sprintf("CL %d is in %s, which isn't built", num, dep.Project))
+			continue
+		}
+		stack.deps = append(stack.deps, tryDep{
+			Project:  dep.Project,
+			Branch:   dep.Branch,
+			Number:   dep.ChangeNumber,
+			PatchSet: dep.Revisions[dep.CurrentRevision].PatchSetNumber,
+			Commit:   dep.CurrentRevision,
+		})
+	}
+	return stack, nil
+}
+
+// writeTryStack writes the CLs of stack to w for a Gerrit comment, as
+// a list headed by "<verb> together with:", such as "Tested together
+// with:", followed by the depended-on CLs which aren't tested.
+func writeTryStack(w io.Writer, stack tryStack, verb string) {
+	if len(stack.deps) > 0 {
+		fmt.Fprintf(w, "%s together with:\n", verb)
+		for _, d := range stack.deps {
+			fmt.Fprintf(w, "* %v: %s\n", d, d.URL())
+		}
+	}
+	if len(stack.ignored) > 0 {
+		fmt.Fprintf(w, "Not %s together with:\n", strings.ToLower(verb))
+		for _, why := range stack.ignored {
+			fmt.Fprintf(w, "* %s\n", why)
+		}
+	}
+}
+
+// repoCanBuild reports whether the coordinator can build the Gerrit
+// project.
+func repoCanBuild(project string) bool {
+	r, ok := repos.ByGerritProject[project]
+	return ok && r.CoordinatorCanBuild
+}
+
+// dependedOnCLs returns the change numbers of the CLs listed by the
+// "Cq-Depend:" footers of the commit message msg and by the "cl/NNNN"
+// terms of a TRY= comment, in increasing order.
+//
+// Footer values are comma-separated, and may be change numbers or CL
+// URLs such as "12345", "go.dev/cl/12345" or "golang.org/cl/12345".
+func dependedOnCLs(msg string, tryTerms []string) []int {
+	seen := make(map[int]bool)
+	add := func(s string) {
+		s = strings.TrimSpace(s)
+		s = strings.TrimPrefix(s, "https://")
+		for _, prefix := range []string{"go.dev/cl/", "golang.org/cl/", "cl/"} {
+			s = strings.TrimPrefix(s, prefix)
+		}
+		// Drop the patch set of URLs like go.dev/cl/12345/2.
+		if i := strings.Index(s, "/"); i >= 0 {
+			s = s[:i]
+		}
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			seen[n] = true
+		}
+	}
+	for _, line := range strings.Split(msg, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "Cq-Depend") {
+			continue
+		}
+		for _, v := range strings.Split(value, ",") {
+			add(v)
+		}
+	}
+	for _, term := range tryTerms {
+		if strings.HasPrefix(term, "cl/") {
+			add(term)
+		}
+	}
+	var nums []int
+	for n := range seen {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// goDep returns the Go CL of the stack which isn't an ancestor, if any.
+func (s tryStack) goDep() (tryDep, bool) {
+	for _, d := range s.deps {
+		if d.Project == "go" && !d.Ancestor {
+			return d, true
+		}
+	}
+	return tryDep{}, false
+}
+
+// xRepoDeps returns the CLs of the stack of x/ repos other than
+// project, whose modules are replaced in builds of project.
+func (s tryStack) xRepoDeps(project string) []tryDep {
+	var xdeps []tryDep
+	for _, d := range s.deps {
+		if !d.Ancestor && d.Project != "go" && d.Project != project {
+			xdeps = append(xdeps, d)
+		}
+	}
+	return xdeps
+}
+
+// workspaceMinGo is the oldest Go version with go.work files, which
+// builds need to apply the CLs of other x/ repos.
+var workspaceMinGo = types.MajorMinor{Major: 1, Minor: 18}
+
+// putTryDeps puts the repos of the CLs of other x/ repos, which the
+// try run of the build depends on, in the GOPATH of the buildlet, and
+// writes a go.work file using them and the modules in testDirs, which
+// are relative to the work directory.
+// It returns the path of the go.work file, relative to the work
+// directory.
+func (st *buildStatus) putTryDeps(testDirs []string) (goWork string, err error) {
+	uses := append([]string(nil), testDirs...)
+	for _, d := range st.tryDeps {
+		st.LogEventTime("fetching_try_dep", d.String())
+		repoDir := "gopath/src/" + importPathOfRepo(d.Project)
+		tgz, err := sourcecache.GetSourceTgz(st, d.Project, d.Commit)
+		if err != nil {
+			return "", fmt.Errorf("fetching %v: %w", d, err)
+		}
+		if err := st.bc.PutTar(st.ctx, tgz, repoDir); err != nil {
+			return "", err
+		}
+		dirs, err := st.listModuleDirs(repoDir)
+		if err != nil {
+			return "", err
+		}
+		uses = append(uses, dirs...)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("go 1.18\n\nuse (\n")
+	for _, dir := range uses {
+		fmt.Fprintf(&buf, "\t./%s\n", strings.TrimPrefix(dir, "gopath/"))
+	}
+	buf.WriteString(")\n")
+	goWork = "gopath/go.work"
+	if err := st.bc.Put(st.ctx, &buf, goWork, 0644); err != nil {
+		return "", err
+	}
+	return goWork, nil
+}
+
+// listModuleDirs returns the directories of the modules in the repo
+// checked out in repoDir, relative to the work directory, starting
+// with repoDir itself. Modules in directories ignored by the go tool
+// or vendored are skipped.
+func (st *buildStatus) listModuleDirs(repoDir string) ([]string, error) {
+	dirs := []string{repoDir}
+	repoPath := strings.TrimPrefix(repoDir, "gopath/src/")
+	sp := st.CreateSpan("listing_subrepo_modules", repoPath)
+	err := st.bc.ListDir(st.ctx, repoDir, buildlet.ListDirOpts{Recursive: true}, func(e buildlet.DirEntry) {
+		goModFile := path.Base(e.Name()) == "go.mod" && !e.IsDir()
+		if !goModFile {
+			return
+		}
+		// Found a go.mod file in a subdirectory, which indicates the root of a module.
+		modulePath := path.Join(repoPath, path.Dir(e.Name()))
+		if modulePath == repoPath {
+			// This is the go.mod file at the repository root.
+			return
+		} else if ignoredByGoTool(modulePath) || isVendored(modulePath) {
+			// go.mod file is in a directory we're not looking to support, so skip it.
+			return
+		}
+		dirs = append(dirs, "gopath/src/"+modulePath)
+	})
+	sp.Done(err)
+	return dirs, err
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+)
+
+func TestDependedOnCLs(t *testing.T) {
+	msg := `x/tools: use the new modfile API
+
+It needs the x/mod change, and the go change for tests.
+
+Cq-Depend: 12345, go.dev/cl/23456
+cq-depend: https://golang.org/cl/34567
+Cq-Depend: https://go.dev/cl/56789/3, go.dev/cl/67890/
+Change-Id: I0123456789abcdef0123456789abcdef01234567
+`
+	got := dependedOnCLs(msg, []string{"linux-arm64", "cl/45678", "cl/12345", "cl/x"})
+	want := []int{12345, 23456, 34567, 45678, 56789, 67890}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dependedOnCLs = %v; want %v", got, want)
+	}
+}
+
+func TestTryStackOf(t *testing.T) {
+	const (
+		commit       = "1111111111111111111111111111111111111111"
+		parentCommit = "2222222222222222222222222222222222222222"
+		modCommit    = "3333333333333333333333333333333333333333"
+	)
+	responses := map[string]interface{}{
+		"/changes/tools~master~Iaaa/revisions/" + commit + "/related": gerrit.RelatedChangesInfo{Changes: []gerrit.RelatedChangeAndCommitInfo{
+			{Project: "tools", ChangeNumber: 103, Commit: gerrit.CommitInfo{CommitID: "4444444444444444444444444444444444444444"}, Status: "NEW", RevisionNumber: 1, CurrentRevisionNumber: 1},
+			{Project: "tools", ChangeNumber: 102, Commit: gerrit.CommitInfo{CommitID: commit}, Status: "NEW", RevisionNumber: 2, CurrentRevisionNumber: 2},
+			{Project: "tools", ChangeNumber: 101, Commit: gerrit.CommitInfo{CommitID: parentCommit}, Status: "NEW", RevisionNumber: 1, CurrentRevisionNumber: 3},
+			{Project: "tools", ChangeNumber: 100, Commit: gerrit.CommitInfo{CommitID: "5555555555555555555555555555555555555555"}, Status: "MERGED", RevisionNumber: 4, CurrentRevisionNumber: 4},
+		}},
+		"/changes/tools~master~Iaaa": gerrit.ChangeInfo{ChangeNumber: 102, Revisions: map[string]gerrit.RevisionInfo{
+			commit: {PatchSetNumber: 2, Commit: &gerrit.CommitInfo{Message: "x/tools: change\n\nCq-Depend: 200, 201, 202\n"}},
+		}},
+		"/changes/200": gerrit.ChangeInfo{Project: "mod", Branch: "master", ChangeNumber: 200, Status: "NEW", CurrentRevision: modCommit,
+			Revisions: map[string]gerrit.RevisionInfo{modCommit: {PatchSetNumber: 5}}},
+		"/changes/201": gerrit.ChangeInfo{Project: "net", ChangeNumber: 201, Status: "ABANDONED"},
+		"/changes/202": gerrit.ChangeInfo{Project: "sys", ChangeNumber: 202, Status: "MERGED"},
+		"/changes/203": gerrit.ChangeInfo{Project: "tools", ChangeNumber: 203, Status: "NEW"},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(")]}'\n"))
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+	gc := gerrit.NewClient(ts.URL, gerrit.NoAuth)
+
+	work := &apipb.GerritTryWorkItem{
+		Project:    "tools",
+		Branch:     "master",
+		ChangeId:   "Iaaa",
+		Commit:     commit,
+		Version:    2,
+		TryMessage: []*apipb.TryVoteMessage{{Message: "cl/203, cl/204", Version: 2}},
+	}
+	got, err := tryStackOf(context.Background(), gc, work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := tryStack{
+		deps: []tryDep{
+			{Project: "tools", Branch: "master", Number: 101, PatchSet: 1, Commit: parentCommit, Ancestor: true, Outdated: true},
+			{Project: "mod", Branch: "master", Number: 200, PatchSet: 5, Commit: modCommit},
+		},
+		ignored: []string{
+			"CL 201 is abandoned",
+			"CL 203 is in the same repo; upload the CLs as a relation chain instead",
+			"CL 204 doesn't exist",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tryStackOf = %+v; want %+v", got, want)
+	}
+	if !got.crossRepo() {
+		t.Errorf("crossRepo = false; want true")
+	}
+}
+
+func TestNewTrySetWithStack(t *testing.T) {
+	testingKnobSkipBuilds = true
+
+	const goCLCommit = "6666666666666666666666666666666666666666"
+	work := &apipb.GerritTryWorkItem{
+		Project:   "tools",
+		Branch:    "master",
+		ChangeId:  "Ica799fcf117bf607c0c59f41b08a78552339dc53",
+		Commit:    "13af72af5ccdfe6f1e75b57b02cfde3bb0a77a76",
+		GoCommit:  []string{"9995c6b50aa55c1cc1236d1d688929df512dad53", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		GoBranch:  []string{"master", "release-branch.go1.19"},
+		GoVersion: []*apipb.MajorMinor{{Major: 1, Minor: 20}, {Major: 1, Minor: 19}},
+	}
+	modDep := tryDep{Project: "mod", Branch: "master", Number: 200, PatchSet: 5, Commit: "3333333333333333333333333333333333333333"}
+	stack := tryStack{deps: []tryDep{
+		{Project: "tools", Branch: "master", Number: 101, PatchSet: 1, Commit: "2222222222222222222222222222222222222222", Ancestor: true},
+		{Project: "go", Branch: "master", Number: 300, PatchSet: 1, Commit: goCLCommit},
+		modDep,
+	}}
+	ts := newTrySet(work, stack)
+	if len(ts.builds) == 0 {
+		t.Fatal("no builders in try set, want at least 1")
+	}
+	for _, bs := range ts.builds {
+		if bs.Rev != goCLCommit {
+			t.Errorf("%s is built with Go commit %s; want the Go CL commit %s", bs.NameAndBranch(), bs.Rev, goCLCommit)
+		}
+		if !reflect.DeepEqual(bs.tryDeps, []tryDep{modDep}) {
+			t.Errorf("%s applies CLs %v; want %v", bs.NameAndBranch(), bs.tryDeps, []tryDep{modDep})
+		}
+	}
+
+	// The status shows which CLs were tested together.
+	rec := httptest.NewRecorder()
+	serveTryStatusJSON(rec, httptest.NewRequest("GET", "/try.json", nil), ts, ts.trySetState.clone())
+	var resp struct {
+		Payload struct {
+			TestedWith []string `json:"testedWith"`
+		} `json:"payload"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"tools CL 101 PS 1", "go CL 300 PS 1", "mod CL 200 PS 5"}; !reflect.DeepEqual(resp.Payload.TestedWith, want) {
+		t.Errorf("testedWith = %q; want %q", resp.Payload.TestedWith, want)
+	}
+}
+
+func TestTryStacksFallback(t *testing.T) {
+	defer func(old func(context.Context, *apipb.GerritTryWorkItem) (tryStack, error)) { lookupTryStack = old }(lookupTryStack)
+	lookupTryStack = func(context.Context, *apipb.GerritTryWorkItem) (tryStack, error) {
+		return tryStack{}, errors.New("gerrit is down")
+	}
+	defer func(old map[tryKey]int) { tryStackFailures = old }(tryStackFailures)
+	tryStackFailures = make(map[tryKey]int)
+
+	work := &apipb.GerritTryWorkItem{
+		Project:  "tools",
+		Branch:   "master",
+		ChangeId: "Ica799fcf117bf607c0c59f41b08a78552339dc53",
+		Commit:   "13af72af5ccdfe6f1e75b57b02cfde3bb0a77a76",
+	}
+	waiting := []*apipb.GerritTryWorkItem{work}
+	for i := 1; i < maxTryStackLookups; i++ {
+		if stacks := tryStacks(context.Background(), waiting); len(stacks) != 0 {
+			t.Fatalf("after %d failed lookups, tryStacks = %v; want none", i, stacks)
+		}
+	}
+	stacks := tryStacks(context.Background(), waiting)
+	stack, ok := stacks[tryWorkItemKey(work)]
+	if !ok || len(stack.deps) != 0 || len(stack.ignored) != 1 {
+		t.Fatalf("after %d failed lookups, tryStacks = %v; want the CL on its own with a note", maxTryStackLookups, stacks)
+	}
+	if len(tryStackFailures) != 0 {
+		t.Errorf("tryStackFailures = %v after falling back; want empty", tryStackFailures)
+	}
+
+	// Failures of items no longer waiting are forgotten.
+	tryStacks(context.Background(), waiting)
+	tryStacks(context.Background(), nil)
+	if len(tryStackFailures) != 0 {
+		t.Errorf("tryStackFailures = %v with no waiting work; want empty", tryStackFailures)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+// Code related to reusing TryBot results across patch sets.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/build/internal/lru"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+)
+
+// tryResults records which builds passed in recent try runs, so that a
+// later patch set with the same code (per the work item's
+// EquivalentCommit) doesn't need to run them again.
+//
+// The cache is keyed by tryKey, and its values are *passedBuilds.
+var tryResults = lru.New(2000)
+
+// passedBuilds is the set of builds, by buildStatus.NameAndBranch,
+// which passed in a try run.
+type passedBuilds struct {
+	mu     sync.Mutex
+	passed map[string]bool
+}
+
+// reusedResult is a passing build result carried over from an earlier
+// patch set with the same code.
+type reusedResult struct {
+	name   string // buildStatus.NameAndBranch
+	commit string // commit of the earlier patch set
+}
+
+// recordTryResult records whether the build called name passed in the
+// try run of key.
+func recordTryResult(key tryKey, name string, passed bool) {
+	v, ok := tryResults.Get(key)
+	if !ok {
+		v = &passedBuilds{passed: make(map[string]bool)}
+		tryResults.Add(key, v)
+	}
+	pb := v.(*passedBuilds)
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	if passed {
+		pb.passed[name] = true
+	} else {
+		delete(pb.passed, name)
+	}
+}
+
+// reusableTryResults returns the builds which passed for a patch set
+// equivalent to the one of work, mapped to the commit of the newest such
+// patch set.
+func reusableTryResults(work *apipb.GerritTryWorkItem) map[string]string {
+	reusable := make(map[string]string)
+	for _, commit := range work.EquivalentCommit {
+		key := tryWorkItemKey(work)
+		key.Commit = commit
+		v, ok := tryResults.Get(key)
+		if !ok {
+			continue
+		}
+		pb := v.(*passedBuilds)
+		pb.mu.Lock()
+		for name := range pb.passed {
+			if _, ok := reusable[name]; !ok {
+				reusable[name] = commit
+			}
+		}
+		pb.mu.Unlock()
+	}
+	return reusable
+}
+
+// writeReused writes the list of reused results in a Gerrit message.
+func writeReused(w io.Writer, reused []reusedResult) {
+	if len(reused) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Reused passing results from earlier patch sets with the same code:\n")
+	for _, r := range reused {
+		fmt.Fprintf(w, "* %s (from %s)\n", r.name, r.commit[:8])
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+// Code related to saving trybot state, so that a restarted coordinator
+// resumes the try runs of the previous process.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/coordinator/pool"
+	"golang.org/x/build/internal/coordinator/state"
+)
+
+var (
+	// tryStateStore saves the state of try sets. If nil, try sets
+	// aren't saved and a restart starts them over.
+	tryStateStore state.Store
+
+	// savedTrySets are the try sets saved by an earlier coordinator
+	// process which haven't been resumed or discarded yet. It's
+	// guarded by statusMu.
+	savedTrySets map[tryKey]*state.TrySet
+
+	// reserveBuildlet and releaseBuildlet reserve buildlet VMs of
+	// saved try sets so they aren't deleted before they're reattached,
+	// and give them up if they won't be. They're replaced by tests.
+	reserveBuildlet = func(instName string) { pool.NewGCEConfiguration().BuildletPool().Reserve(instName) }
+	releaseBuildlet = func(instName string) { pool.NewGCEConfiguration().BuildletPool().Release(instName) }
+
+	// reattachBuildlet returns a client for the buildlet VM instName
+	// at ipPort, left running by an earlier coordinator process.
+	reattachBuildlet = func(ctx context.Context, instName, ipPort string) (buildlet.Client, error) {
+		return pool.NewGCEConfiguration().BuildletPool().Reattach(ctx, instName, ipPort)
+	}
+)
+
+// loadTryState loads the try sets saved by the previous coordinator
+// process, to be resumed by findTryWork, and reserves their buildlets.
+// It must be called before findTryWork is first run.
+func loadTryState(ctx context.Context) error {
+	sets, err := tryStateStore.ListTrySets(ctx)
+	if err != nil {
+		return err
+	}
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	savedTrySets = make(map[tryKey]*state.TrySet)
+	for _, saved := range sets {
+		key := tryKey{Project: saved.Project, Branch: saved.Branch, ChangeID: saved.ChangeID, Commit: saved.Commit}
+		savedTrySets[key] = saved
+		for _, b := range saved.Builds {
+			if !b.Done && b.Instance != "" {
+				reserveBuildlet(b.Instance)
+			}
+		}
+	}
+	log.Printf("Loaded %d saved trybot sets to resume", len(sets))
+	return nil
+}
+
+// discardSavedTrySets discards the saved try sets which weren't
+// resumed by the first findTryWork, because they're no longer wanted.
+//
+// Must hold statusMu.
+func discardSavedTrySets() {
+	for key, saved := range savedTrySets {
+		log.Printf("Discarding saved trybot set for %v", key)
+		for _, b := range saved.Builds {
+			if !b.Done && b.Instance != "" {
+				releaseBuildlet(b.Instance)
+			}
+		}
+		go deleteTryState(tryStateStore, saved.Key())
+	}
+	savedTrySets = nil
+}
+
+// takeSavedTrySet returns and forgets the saved state of the try set
+// for key, or nil if there is none.
+//
+// Must hold statusMu.
+func takeSavedTrySet(key tryKey) *state.TrySet {
+	saved := savedTrySets[key]
+	delete(savedTrySets, key)
+	return saved
+}
+
+// savedBuild returns the saved state of the build of brev, or nil.
+func savedBuild(saved *state.TrySet, brev buildgo.BuilderRev) *state.Build {
+	if saved == nil {
+		return nil
+	}
+	for i, b := range saved.Builds {
+		if b.Name == brev.Name && b.Rev == brev.Rev && b.SubName == brev.SubName && b.SubRev == brev.SubRev {
+			return &saved.Builds[i]
+		}
+	}
+	return nil
+}
+
+// resumeBuild sets up bs, a build of a resumed try set, from its saved
+// state. It reports whether the build was already done.
+func resumeBuild(ts *trySet, bs *buildStatus, b *state.Build) (done bool) {
+	if !b.Done {
+		if b.Instance != "" {
+			bs.reattach = b
+		}
+		return false
+	}
+	bs.mu.Lock()
+	bs.buildID = b.BuildID
+	bs.done = time.Now()
+	bs.succeeded = b.Succeeded
+	bs.logURL = b.LogURL
+	bs.mu.Unlock()
+	if !b.Succeeded {
+		ts.failed = append(ts.failed, bs.NameAndBranch())
+		fmt.Fprintf(&ts.errMsg, "Failed on %s: %s\n", bs.NameAndBranch(), b.LogURL)
+	}
+	return true
+}
+
+// reattaches reports whether a build of the try set will try to
+// reattach to the buildlet instName.
+//
+// Must hold statusMu, or be called before the try set is shared.
+func (ts *trySet) reattaches(instName string) bool {
+	for _, bs := range ts.builds {
+		if bs.reattach != nil && bs.reattach.Instance == instName {
+			return true
+		}
+	}
+	return false
+}
+
+// getReattachedBuildlet returns the buildlet of the resumed build st
+// if it's still alive, with its work directory cleaned up. It returns
+// nil if the build needs a new buildlet.
+func (st *buildStatus) getReattachedBuildlet() buildlet.Client {
+	b := st.reattach
+	if b == nil {
+		return nil
+	}
+	sp := st.CreateSpan("reattach_buildlet", b.Instance)
+	bc, err := reattachBuildlet(st.ctx, b.Instance, b.IPPort)
+	if err == nil {
+		if err = bc.RemoveAll(st.ctx, "."); err != nil {
+			bc.Close()
+		}
+	}
+	sp.Done(err)
+	if err != nil {
+		log.Printf("%s: requeueing build; reattaching buildlet %s: %v", st.BuilderRev, b.Instance, err)
+		return nil
+	}
+	return bc
+}
+
+// saveState saves the state of the try set to tryStateStore.
+func (ts *trySet) saveState() {
+	if tryStateStore == nil {
+		return
+	}
+	// Saves are serialized so that a snapshot is never overwritten
+	// by an older one.
+	ts.saveMu.Lock()
+	defer ts.saveMu.Unlock()
+
+	ts.mu.Lock()
+	if ts.canceled {
+		ts.mu.Unlock()
+		return
+	}
+	saved := &state.TrySet{
+		Project:  ts.Project,
+		Branch:   ts.Branch,
+		ChangeID: ts.ChangeID,
+		Commit:   ts.Commit,
+		TryID:    ts.tryID,
+		Updated:  time.Now(),
+	}
+	for _, bs := range ts.builds {
+		bs.mu.Lock()
+		b := state.Build{
+			Name:    bs.Name,
+			Rev:     bs.Rev,
+			SubName: bs.SubName,
+			SubRev:  bs.SubRev,
+			BuildID: bs.buildID,
+			// A build is only done once its result has been
+			// reported, which is when its permanent log is written.
+			Done:      bs.logURL != "",
+			Succeeded: bs.succeeded,
+			LogURL:    bs.logURL,
+		}
+		if bc := bs.bc; bc != nil && !b.Done {
+			b.Instance = bc.InstanceName()
+			b.IPPort = bc.IPPort()
+		}
+		bs.mu.Unlock()
+		saved.Builds = append(saved.Builds, b)
+	}
+	ts.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := tryStateStore.PutTrySet(ctx, saved); err != nil {
+		log.Printf("Error saving trybot state of %v: %v", ts.tryKey, err)
+	}
+}
+
+// deleteState deletes the saved state of the try set, once it's
+// finished or no longer wanted.
+func (ts *trySet) deleteState() {
+	if tryStateStore == nil {
+		return
+	}
+	ts.saveMu.Lock()
+	defer ts.saveMu.Unlock()
+	deleteTryState(tryStateStore, state.Key(ts.Project, ts.Branch, ts.ChangeID, ts.Commit))
+}
+
+func deleteTryState(s state.Store, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.DeleteTrySet(ctx, key); err != nil {
+		log.Printf("Error deleting trybot state %s: %v", key, err)
+	}
+}
+
+// notifyResumed runs in its own goroutine and replies to the try
+// set's existing Gerrit thread that a restarted coordinator resumed
+// the try run.
+func (ts *trySet) notifyResumed(reattached int) {
+	tss := ts.state()
+	msg := fmt.Sprintf("The build coordinator restarted; resuming the %d of %d builds that weren't done. Status page: %s\n",
+		tss.remain, len(tss.builds), ts.statusPage())
+	if reattached > 0 {
+		msg += fmt.Sprintf("%d of them will reuse their buildlets if they're still running.\n", reattached)
+	}
+	ts.replyToBeginning(tryBotsTag("progress"), msg, 0)
+}
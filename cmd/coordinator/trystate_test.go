@@ -0,0 +1,133 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.16 && (linux || darwin)
+// +build go1.16
+// +build linux darwin
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/buildgo"
+	"golang.org/x/build/internal/coordinator/state"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+)
+
+func TestResumeTrySet(t *testing.T) {
+	testingKnobSkipBuilds = true
+	defer func(old state.Store) { tryStateStore = old }(tryStateStore)
+	tryStateStore = state.NewMemory()
+	reserved := make(map[string]bool)
+	defer func(reserve, release func(string)) { reserveBuildlet, releaseBuildlet = reserve, release }(reserveBuildlet, releaseBuildlet)
+	reserveBuildlet = func(instName string) { reserved[instName] = true }
+	releaseBuildlet = func(instName string) { delete(reserved, instName) }
+
+	work := &apipb.GerritTryWorkItem{
+		Project:   "go",
+		Branch:    "master",
+		ChangeId:  "I1b6e8f4a2d3c5e7f9a0b1c2d3e4f5a6b7c8d9e0f",
+		Commit:    "3c9d6a1e5b7f2d4a8c0e6b1f3d5a7c9e2b4d6f8a",
+		GoCommit:  []string{"9995c6b50aa55c1cc1236d1d688929df512dad53"},
+		GoBranch:  []string{"master"},
+		GoVersion: []*apipb.MajorMinor{{Major: 1, Minor: 17}},
+	}
+	first := newTrySet(work, tryStack{})
+	if len(first.builds) < 3 {
+		t.Fatalf("got %d builds, want at least 3", len(first.builds))
+	}
+	// One build passed, one failed, and one is running on a buildlet.
+	passed, failed, running := first.builds[0], first.builds[1], first.builds[2]
+	passed.logURL, passed.succeeded = "https://storage.googleapis.com/passed.log", true
+	failed.logURL = "https://storage.googleapis.com/failed.log"
+	const instName = "buildlet-linux-amd64-rn1"
+	bc := new(buildlet.FakeClient)
+	bc.SetInstanceName(instName)
+	running.bc = bc
+	first.saveState()
+
+	// A try set which is no longer wanted was also saved.
+	stale := &state.TrySet{
+		Project:  "go",
+		Branch:   "master",
+		ChangeID: "I0000000000000000000000000000000000000000",
+		Commit:   "0000000000000000000000000000000000000000",
+		Builds:   []state.Build{{Name: "linux-amd64", Rev: "0000000000000000000000000000000000000000", Instance: "buildlet-linux-amd64-rn2"}},
+	}
+	if err := tryStateStore.PutTrySet(context.Background(), stale); err != nil {
+		t.Fatal(err)
+	}
+
+	// The coordinator restarts.
+	if err := loadTryState(context.Background()); err != nil {
+		t.Fatalf("loadTryState() = %v", err)
+	}
+	if !reserved[instName] || !reserved["buildlet-linux-amd64-rn2"] {
+		t.Errorf("loadTryState reserved %v; want both saved buildlets", reserved)
+	}
+	statusMu.Lock()
+	second := newTrySet(work, tryStack{})
+	discardSavedTrySets()
+	statusMu.Unlock()
+
+	if second.tryID != first.tryID {
+		t.Errorf("resumed try ID = %q; want %q", second.tryID, first.tryID)
+	}
+	if got, want := second.remain, len(first.builds)-2; got != want {
+		t.Errorf("%d builds remain after resuming; want %d", got, want)
+	}
+	if len(second.failed) != 1 || second.failed[0] != failed.NameAndBranch() {
+		t.Errorf("failed builds after resuming = %q; want [%q]", second.failed, failed.NameAndBranch())
+	}
+	if !strings.Contains(second.errMsg.String(), failed.logURL) {
+		t.Errorf("error message %q does not link to the failed log %s", second.errMsg.String(), failed.logURL)
+	}
+	var reattach []string
+	for _, bs := range second.builds {
+		if bs.reattach != nil {
+			reattach = append(reattach, bs.Name+" "+bs.reattach.Instance)
+		}
+	}
+	if len(reattach) != 1 || reattach[0] != running.Name+" "+instName {
+		t.Errorf("builds to reattach = %q; want [%q]", reattach, running.Name+" "+instName)
+	}
+	if !reserved[instName] || reserved["buildlet-linux-amd64-rn2"] {
+		t.Errorf("reserved buildlets after resuming = %v; want only %s", reserved, instName)
+	}
+}
+
+func TestGetReattachedBuildlet(t *testing.T) {
+	defer func(old func(context.Context, string, string) (buildlet.Client, error)) { reattachBuildlet = old }(reattachBuildlet)
+	st, err := newBuild(buildgo.BuilderRev{Name: "linux-amd64", Rev: "3c9d6a1e5b7f2d4a8c0e6b1f3d5a7c9e2b4d6f8a"}, commitDetail{RevBranch: "master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bc := st.getReattachedBuildlet(); bc != nil {
+		t.Errorf("getReattachedBuildlet() of a new build = %v; want nil", bc)
+	}
+
+	st.reattach = &state.Build{Instance: "buildlet-linux-amd64-rn1", IPPort: "10.240.0.7:80"}
+	reattachBuildlet = func(ctx context.Context, instName, ipPort string) (buildlet.Client, error) {
+		return nil, errors.New("instance not found")
+	}
+	if bc := st.getReattachedBuildlet(); bc != nil {
+		t.Errorf("getReattachedBuildlet() of a dead buildlet = %v; want nil", bc)
+	}
+
+	fake := new(buildlet.FakeClient)
+	reattachBuildlet = func(ctx context.Context, instName, ipPort string) (buildlet.Client, error) {
+		if instName != st.reattach.Instance || ipPort != st.reattach.IPPort {
+			t.Errorf("reattachBuildlet(%q, %q); want (%q, %q)", instName, ipPort, st.reattach.Instance, st.reattach.IPPort)
+		}
+		return fake, nil
+	}
+	if bc := st.getReattachedBuildlet(); bc != fake {
+		t.Errorf("getReattachedBuildlet() = %v; want the reattached buildlet", bc)
+	}
+}
@@ -360,7 +360,11 @@ var (
 )
 
 func getGoProject(ctx context.Context) (*maintner.GerritProject, error) {
-	corpus, err := godata.Get(ctx)
+	corpus, err := godata.GetFiltered(ctx, maintner.Filter{
+		GitHubRepos:    []maintner.GitHubRepoID{},
+		GerritProjects: []string{"go.googlesource.com/go"},
+		GitRepos:       []string{},
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -33,9 +33,11 @@ To list the subcommands, run "gomote" without arguments:
 	  put        put files on a buildlet
 	  put14      put Go 1.4 in place
 	  puttar     extract a tar.gz to a buildlet
+	  recordings list and replay your recorded ssh sessions
 	  rm         delete files or directories
 	  rdp        RDP (Remote Desktop Protocol) to a Windows buildlet
 	  run        run a command on a buildlet
+	  share      grant another user access to a buildlet
 	  ssh        ssh to a buildlet
 
 To list all the builder types available, run "create" with no arguments:
@@ -216,8 +218,10 @@ func registerCommands() {
 	registerCommand("putbootstrap", "put bootstrap toolchain in place", putBootstrap)
 	registerCommand("puttar", "extract a tar.gz to a buildlet", putTar)
 	registerCommand("rdp", "Unimplimented: RDP (Remote Desktop Protocol) to a Windows buildlet", rdp)
+	registerCommand("recordings", "list and replay your recorded ssh sessions", recordings)
 	registerCommand("rm", "delete files or directories", rm)
 	registerCommand("run", "run a command on a buildlet", run)
+	registerCommand("share", "grant another user access to a buildlet", share)
 	registerCommand("ssh", "ssh to a buildlet", ssh)
 }
 
@@ -52,7 +52,24 @@ func list(args []string) error {
 		if groupList.Len() != 0 {
 			groupList.WriteString(")")
 		}
-		fmt.Printf("%s%s\t%s\t%s\texpires in %v\n", inst.GetGomoteId(), groupList.String(), inst.GetBuilderType(), inst.GetHostType(), time.Unix(inst.GetExpires(), 0).Sub(time.Now()))
+		fmt.Printf("%s%s\t%s\t%s\texpires in %v%s\n", inst.GetGomoteId(), groupList.String(), inst.GetBuilderType(), inst.GetHostType(), time.Unix(inst.GetExpires(), 0).Sub(time.Now()), sharing(inst))
 	}
 	return nil
 }
+
+// sharing describes the access granted to an instance for display by list.
+func sharing(inst *protos.Instance) string {
+	if l := inst.GetSharedAccess(); l != protos.AccessGrant_UNSPECIFIED {
+		return fmt.Sprintf("\tshared with you (%s access)", strings.ToLower(l.String()))
+	}
+	var sb strings.Builder
+	for i, g := range inst.GetGrants() {
+		if i == 0 {
+			sb.WriteString("\tshared with ")
+		} else {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s (%s access, expires in %v)", g.GetGrantee(), strings.ToLower(g.GetLevel().String()), time.Until(time.Unix(g.GetExpires(), 0)).Round(time.Minute))
+	}
+	return sb.String()
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func recordings(args []string) error {
+	cm := map[string]struct {
+		run  func([]string) error
+		desc string
+	}{
+		"list":   {listRecordings, "list your recorded ssh sessions"},
+		"replay": {replayRecording, "replay a recorded ssh session"},
+	}
+	if len(args) == 0 {
+		var cmds []string
+		for cmd := range cm {
+			cmds = append(cmds, cmd)
+		}
+		sort.Strings(cmds)
+		fmt.Fprintf(os.Stderr, "Usage of gomote recordings: gomote [global-flags] recordings <cmd> [cmd-flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Commands:\n\n")
+		for _, name := range cmds {
+			fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, cm[name].desc)
+		}
+		fmt.Fprintln(os.Stderr)
+		os.Exit(1)
+	}
+	subCmd := args[0]
+	sc, ok := cm[subCmd]
+	if !ok {
+		return fmt.Errorf("unknown sub-command %q\n", subCmd)
+	}
+	return sc.run(args[1:])
+}
+
+func listRecordings(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "recordings list usage: gomote recordings list")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+	}
+	ctx := context.Background()
+	client := gomoteServerClient(ctx)
+	resp, err := client.ListSessionRecordings(ctx, &protos.ListSessionRecordingsRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list session recordings: %w", err)
+	}
+	for _, r := range resp.GetRecordings() {
+		fmt.Printf("%s\t%s\t%s\t%d bytes\n", r.GetRecordingId(), r.GetGomoteId(), time.Unix(r.GetStarted(), 0).Format(time.RFC3339), r.GetSize())
+	}
+	return nil
+}
+
+func replayRecording(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "recordings replay usage: gomote recordings replay [replay-opts] <recording-id>")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var speed float64
+	fs.Float64Var(&speed, "speed", 1, "playback speed multiplier")
+	var maxIdle time.Duration
+	fs.DurationVar(&maxIdle, "max-idle", 2*time.Second, "limit pauses between output to this duration; 0 means no limit")
+	var raw bool
+	fs.BoolVar(&raw, "raw", false, "write the recording in asciicast v2 format instead of replaying it")
+	fs.Parse(args)
+	if fs.NArg() != 1 || speed <= 0 {
+		fs.Usage()
+	}
+	ctx := context.Background()
+	client := gomoteServerClient(ctx)
+	stream, err := client.ReadSessionRecording(ctx, &protos.ReadSessionRecordingRequest{
+		RecordingId: fs.Arg(0),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read session recording: %w", err)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := pw.Write(resp.GetData()); err != nil {
+				return
+			}
+		}
+	}()
+	defer pr.Close()
+	if raw {
+		_, err := io.Copy(os.Stdout, pr)
+		return err
+	}
+	return replayCast(pr, os.Stdout, speed, maxIdle, time.Sleep)
+}
+
+// replayCast writes the terminal output recorded in the asciicast v2
+// stream r to w, pausing between events as they occurred in the session.
+func replayCast(r io.Reader, w io.Writer, speed float64, maxIdle time.Duration, sleep func(time.Duration)) error {
+	br := bufio.NewReader(r)
+	if _, err := br.ReadBytes('\n'); err != nil { // header
+		return fmt.Errorf("reading recording header: %w", err)
+	}
+	dec := json.NewDecoder(br)
+	var last float64
+	for {
+		var ev []interface{}
+		if err := dec.Decode(&ev); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("reading recording: %w", err)
+		}
+		if len(ev) != 3 {
+			continue
+		}
+		t, _ := ev[0].(float64)
+		typ, _ := ev[1].(string)
+		data, _ := ev[2].(string)
+		if typ != "o" {
+			continue
+		}
+		pause := time.Duration((t - last) / speed * float64(time.Second))
+		if maxIdle > 0 && pause > maxIdle {
+			pause = maxIdle
+		}
+		if pause > 0 {
+			sleep(pause)
+		}
+		last = t
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReplayCast(t *testing.T) {
+	const cast = `{"version":2,"width":80,"height":24,"timestamp":1666100000}
+[0.5,"o","$ "]
+[1.0,"i","ls\r"]
+[1.5,"o","ls\r\n"]
+[11.5,"o","go\r\n"]
+`
+	var out strings.Builder
+	var pauses []time.Duration
+	sleep := func(d time.Duration) { pauses = append(pauses, d) }
+	if err := replayCast(strings.NewReader(cast), &out, 2, 2*time.Second, sleep); err != nil {
+		t.Fatalf("replayCast(...) = %v; want no error", err)
+	}
+	if got, want := out.String(), "$ ls\r\ngo\r\n"; got != want {
+		t.Errorf("replayCast output = %q; want %q", got, want)
+	}
+	want := []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, 2 * time.Second}
+	if diff := cmp.Diff(want, pauses); diff != "" {
+		t.Errorf("replayCast pauses mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/build/internal/gomote/protos"
+)
+
+func share(args []string) error {
+	fs := flag.NewFlagSet("share", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "share usage: gomote share [share-opts] <email> [instance]")
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, "Grants the user with the given email access to the instance.")
+		fmt.Fprintln(os.Stderr, "Read access allows ls and gettar. Full access also allows run, put, rm and ssh.")
+		fmt.Fprintln(os.Stderr, "Instance name is optional if a group is specified.")
+		fs.PrintDefaults()
+		os.Exit(1)
+	}
+	var level string
+	fs.StringVar(&level, "access", "read", "level of access to grant: read or full")
+	var d time.Duration
+	fs.DurationVar(&d, "for", 24*time.Hour, "how long to grant access for")
+	var revoke bool
+	fs.BoolVar(&revoke, "revoke", false, "revoke any access previously granted to the user")
+	fs.Parse(args)
+
+	var grantee string
+	var shareSet []string
+	if fs.NArg() == 2 {
+		grantee, shareSet = fs.Arg(0), []string{fs.Arg(1)}
+	} else if fs.NArg() == 1 && activeGroup != nil {
+		grantee = fs.Arg(0)
+		shareSet = append(shareSet, activeGroup.Instances...)
+	} else {
+		fs.Usage()
+	}
+	lv, ok := protos.AccessGrant_Level_value[strings.ToUpper(level)]
+	if !revoke && (!ok || lv == int32(protos.AccessGrant_UNSPECIFIED)) {
+		return fmt.Errorf("invalid access level %q", level)
+	}
+
+	ctx := context.Background()
+	client := gomoteServerClient(ctx)
+	for _, inst := range shareSet {
+		if revoke {
+			_, err := client.UnshareInstance(ctx, &protos.UnshareInstanceRequest{
+				GomoteId: inst,
+				Grantee:  grantee,
+			})
+			if err != nil {
+				return fmt.Errorf("unable to revoke access to %s: %w", inst, err)
+			}
+			fmt.Fprintf(os.Stderr, "%s: access revoked for %s\n", inst, grantee)
+			continue
+		}
+		resp, err := client.ShareInstance(ctx, &protos.ShareInstanceRequest{
+			GomoteId:        inst,
+			Grantee:         grantee,
+			Level:           protos.AccessGrant_Level(lv),
+			DurationSeconds: int64(d / time.Second),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to share %s: %w", inst, err)
+		}
+		g := resp.GetGrant()
+		fmt.Fprintf(os.Stderr, "%s: shared with %s (%s access) until %s\n", inst, g.GetGrantee(), strings.ToLower(g.GetLevel().String()), time.Unix(g.GetExpires(), 0).Format(time.RFC3339))
+	}
+	return nil
+}
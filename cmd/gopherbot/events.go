@@ -0,0 +1,229 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// changeEventsKept is how many change events the corpus keeps in
+// --events mode. If more happen between two runs of the tasks, such as
+// after a long outage, all tasks run over the whole corpus instead.
+const changeEventsKept = 100000
+
+// doEventTasks runs the tasks in --events mode.
+//
+// The first time, every --sweep-interval, and whenever the change
+// events since the previous run are no longer kept, it runs all tasks
+// over the whole corpus, like doTasks. That is when the tasks without
+// events run, and it catches anything missed by failed event runs.
+// Otherwise it only runs the tasks that handle the new change events,
+// each over the issues and CLs of those events.
+func (b *gopherbot) doEventTasks(ctx context.Context) []error {
+	now := time.Now()
+	events, last, err := b.corpus.Changes(b.lastMutation)
+	if err != nil && err != maintner.ErrChangesExpired {
+		return []error{err}
+	}
+	b.lastMutation = last
+	if err != nil || b.lastSweep.IsZero() || now.Sub(b.lastSweep) >= *sweepInterval {
+		if err != nil {
+			log.Printf("Change events since the last run expired; running all tasks.")
+		}
+		b.lastSweep = now
+		return b.doTasks(ctx)
+	}
+	log.Printf("Handling %d change events.", len(events))
+	return b.runEventTasks(ctx, events)
+}
+
+// runEventTasks runs, in sequence, each task that handles some of the
+// events, over the issues and CLs they're about. Like doTasks, it
+// doesn't stop if a task fails, but reports errors at the end.
+func (b *gopherbot) runEventTasks(ctx context.Context, events []maintner.ChangeEvent) []error {
+	var errs []error
+	for _, task := range tasks {
+		if *onlyRun != "" && task.name != *onlyRun || len(task.events) == 0 {
+			continue
+		}
+		scope := newEventScope(events, task.events)
+		if scope.empty() {
+			continue
+		}
+		b.scope = scope
+		err := task.fn(b, ctx)
+		b.scope = nil
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", task.name, err))
+		}
+	}
+	return errs
+}
+
+// An eventScope is the issues and CLs of the change events a task
+// handles. A nil *eventScope is the whole corpus.
+type eventScope struct {
+	// github and gerrit report whether the task handles events
+	// about GitHub issues and Gerrit CLs. The task visits all of
+	// the others.
+	github, gerrit bool
+
+	issues map[maintner.GitHubRepoID]map[int32]bool
+	cls    map[string]map[int32]bool // keyed by Gerrit project, like "go.googlesource.com/go"
+}
+
+// newEventScope returns the scope of the events of the given kinds.
+func newEventScope(events []maintner.ChangeEvent, kinds []maintner.ChangeKind) *eventScope {
+	s := &eventScope{
+		issues: map[maintner.GitHubRepoID]map[int32]bool{},
+		cls:    map[string]map[int32]bool{},
+	}
+	want := map[maintner.ChangeKind]bool{}
+	for _, k := range kinds {
+		want[k] = true
+		if k.IsGitHub() {
+			s.github = true
+		} else {
+			s.gerrit = true
+		}
+	}
+	for _, e := range events {
+		if !want[e.Kind] {
+			continue
+		}
+		if e.Kind.IsGitHub() {
+			if s.issues[e.GitHubRepo] == nil {
+				s.issues[e.GitHubRepo] = map[int32]bool{}
+			}
+			s.issues[e.GitHubRepo][e.Number] = true
+		} else {
+			if s.cls[e.GerritProject] == nil {
+				s.cls[e.GerritProject] = map[int32]bool{}
+			}
+			s.cls[e.GerritProject][e.Number] = true
+		}
+	}
+	return s
+}
+
+// empty reports whether s has no issues or CLs.
+func (s *eventScope) empty() bool {
+	return len(s.issues) == 0 && len(s.cls) == 0
+}
+
+// foreachIssue calls fn for each issue of gr in s, in increasing order.
+func (s *eventScope) foreachIssue(gr *maintner.GitHubRepo, fn func(*maintner.GitHubIssue) error) error {
+	if s == nil || !s.github {
+		return gr.ForeachIssue(fn)
+	}
+	for _, num := range sortedNumbers(s.issues[gr.ID()]) {
+		gi := gr.Issue(num)
+		if gi == nil {
+			continue
+		}
+		if err := fn(gi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// foreachCL calls fn for each CL of gp in s, or for each open one,
+// as visited by ForeachOpenCL, if onlyOpen is set.
+func (s *eventScope) foreachCL(gp *maintner.GerritProject, onlyOpen bool, fn func(*maintner.GerritCL) error) error {
+	if s == nil || !s.gerrit {
+		if onlyOpen {
+			return gp.ForeachOpenCL(fn)
+		}
+		return gp.ForeachCLUnsorted(fn)
+	}
+	for _, num := range sortedNumbers(s.cls[gp.ServerSlashProject()]) {
+		cl := gp.CL(num)
+		if cl == nil || onlyOpen && (cl.Status != "new" || cl.Private) {
+			continue
+		}
+		if err := fn(cl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedNumbers(m map[int32]bool) []int32 {
+	nums := make([]int32, 0, len(m))
+	for n := range m {
+		nums = append(nums, n)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	return nums
+}
+
+// replay loads the mutations of src into a new corpus, tracking the
+// change events of those after the first n, and runs the tasks that
+// handle them, as in --events mode. The tasks see the corpus as of the
+// last mutation.
+func (b *gopherbot) replay(ctx context.Context, src maintner.MutationSource, n int) []error {
+	var muts []*maintpb.Mutation
+	for e := range src.GetMutations(ctx) {
+		if e.Err != nil {
+			return []error{fmt.Errorf("reading mutations to replay: %v", e.Err)}
+		}
+		if e.End {
+			break
+		}
+		muts = append(muts, e.Mutation)
+	}
+	if n < 0 || n > len(muts) {
+		return []error{fmt.Errorf("can't load %d mutations before replaying; the log has %d", n, len(muts))}
+	}
+
+	corpus := new(maintner.Corpus)
+	rs := &replaySource{muts: muts, split: n}
+	if err := corpus.Initialize(ctx, rs); err != nil {
+		return []error{err}
+	}
+	after := corpus.TrackChanges(changeEventsKept)
+	if err := corpus.Update(ctx); err != nil {
+		return []error{err}
+	}
+	events, _, err := corpus.Changes(after)
+	if err != nil {
+		return []error{err}
+	}
+	if err := b.setCorpus(corpus); err != nil {
+		return []error{err}
+	}
+	log.Printf("Replaying %d change events of %d mutations.", len(events), len(muts)-n)
+	return b.runEventTasks(ctx, events)
+}
+
+// replaySource is a MutationSource that sends its mutations before
+// split to the first call of GetMutations, and the rest to the next.
+type replaySource struct {
+	muts   []*maintpb.Mutation
+	split  int
+	loaded bool // whether the mutations before split were sent
+}
+
+func (s *replaySource) GetMutations(ctx context.Context) <-chan maintner.MutationStreamEvent {
+	muts := s.muts[s.split:]
+	if !s.loaded {
+		muts = s.muts[:s.split]
+		s.loaded = true
+	}
+	ch := make(chan maintner.MutationStreamEvent, len(muts)+1)
+	for _, m := range muts {
+		ch <- maintner.MutationStreamEvent{Mutation: m}
+	}
+	ch <- maintner.MutationStreamEvent{End: true}
+	return ch
+}
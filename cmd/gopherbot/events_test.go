@@ -0,0 +1,122 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// labelCommandLog returns a recorded mutation log of golang/go issues
+// with commands to gopherbot to add labels: issue 1 has one in its
+// body, and issue 2 has one in a comment made by the fourth and last
+// mutation.
+func labelCommandLog(t *testing.T) *maintner.DiskMutationLogger {
+	t.Helper()
+	ts := func(day int) *timestamp.Timestamp {
+		tp, err := ptypes.TimestampProto(time.Date(2023, 6, day, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tp
+	}
+	gopher := &maintpb.GithubUser{Id: 1, Login: "gopher"}
+	issue := func(num int32, body string) *maintpb.Mutation {
+		return &maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner: "golang", Repo: "go", Number: num, Id: 100 + int64(num),
+			User: gopher, Title: "crash", Body: body, Created: ts(1), Updated: ts(1),
+		}}
+	}
+	log := maintner.NewDiskMutationLogger(t.TempDir())
+	for _, m := range []*maintpb.Mutation{
+		{Github: &maintpb.GithubMutation{Owner: "golang", Repo: "go", Labels: []*maintpb.GithubLabel{
+			{Id: 1, Name: "NeedsFix"}, {Id: 2, Name: "NeedsInvestigation"},
+		}}},
+		issue(1, "@gopherbot please add label NeedsFix"),
+		issue(2, "It crashes."),
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 2, Updated: ts(2),
+			Comment: []*maintpb.GithubIssueCommentMutation{
+				{Id: 1, User: gopher, Body: "@gopherbot please add label NeedsInvestigation", Created: ts(2), Updated: ts(2)},
+			}}},
+	} {
+		if err := log.Log(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return log
+}
+
+func TestReplay(t *testing.T) {
+	// Other tasks handling new issues need GitHub and maintner APIs.
+	defer func(old string) { *onlyRun = old }(*onlyRun)
+	*onlyRun = "apply labels from comments"
+
+	for _, tt := range []struct {
+		name  string
+		after int
+		want  map[int][]string
+	}{
+		{"all", 0, map[int][]string{1: {"NeedsFix"}, 2: {"NeedsInvestigation"}}},
+		// Only issue 2 was commented on, so the command
+		// in the body of issue 1 is left alone.
+		{"comment", 3, map[int][]string{2: {"NeedsInvestigation"}}},
+		{"none", 4, map[int][]string{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			is := &fakeIssuesService{labels: map[int][]string{}}
+			b := &gopherbot{is: is}
+			if errs := b.replay(context.Background(), labelCommandLog(t), tt.after); len(errs) > 0 {
+				t.Fatalf("replay: %v", errs)
+			}
+			if diff := cmp.Diff(tt.want, is.labels); diff != "" {
+				t.Errorf("labels added differ: (-want, +got)\n%s", diff)
+			}
+			if b.scope != nil {
+				t.Errorf("scope still set after replay")
+			}
+		})
+	}
+
+	b := &gopherbot{}
+	if errs := b.replay(context.Background(), labelCommandLog(t), 5); len(errs) == 0 {
+		t.Errorf("replay after 5 of 4 mutations succeeded; want error")
+	}
+}
+
+func TestEventScope(t *testing.T) {
+	goRepo := maintner.GitHubRepoID{Owner: "golang", Repo: "go"}
+	events := []maintner.ChangeEvent{
+		{Kind: maintner.IssueCommented, GitHubRepo: goRepo, Number: 3},
+		{Kind: maintner.IssueCreated, GitHubRepo: goRepo, Number: 2},
+		{Kind: maintner.IssueCommented, GitHubRepo: goRepo, Number: 1},
+		{Kind: maintner.CLVote, GerritProject: "go.googlesource.com/go", Number: 1001},
+	}
+
+	s := newEventScope(events, onIssueComment)
+	if !s.github || s.gerrit {
+		t.Errorf("scope of issue comments restricts github %v, gerrit %v; want true, false", s.github, s.gerrit)
+	}
+	want := map[maintner.GitHubRepoID]map[int32]bool{goRepo: {1: true, 2: true, 3: true}}
+	if diff := cmp.Diff(want, s.issues); diff != "" {
+		t.Errorf("issues differ: (-want, +got)\n%s", diff)
+	}
+	if len(s.cls) != 0 {
+		t.Errorf("scope of issue comments has CLs %v", s.cls)
+	}
+
+	if s := newEventScope(events, onCLStatus); !s.empty() || s.github || !s.gerrit {
+		t.Errorf("scope of CL status changes = %+v; want empty, restricting only gerrit", s)
+	}
+	if s := newEventScope(events, onCLVote); s.empty() || len(s.cls["go.googlesource.com/go"]) != 1 {
+		t.Errorf("scope of CL votes = %+v; want CL 1001", s)
+	}
+}
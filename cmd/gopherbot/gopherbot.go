@@ -31,6 +31,7 @@ import (
 	"cloud.google.com/go/compute/metadata"
 	"github.com/google/go-github/github"
 	"go4.org/strutil"
+	"golang.org/x/build/cmd/gopherbot/internal/rules"
 	"golang.org/x/build/devapp/owners"
 	"golang.org/x/build/gerrit"
 	"golang.org/x/build/internal/foreach"
@@ -56,6 +57,13 @@ var (
 	gerritTokenFile = flag.String("gerrit-token-file", filepath.Join(os.Getenv("HOME"), "keys", "gerrit-gobot"), `File to load Gerrit token from. File should be of form <git-email>:<token>`)
 
 	onlyRun = flag.String("only-run", "", "if non-empty, the name of a task to run. Mostly for debugging, but tasks (like 'kicktrain') may choose to only run in explicit mode")
+
+	events        = flag.Bool("events", false, "in daemon mode, after each corpus update, run only the tasks handling the change events of the new mutations, such as issues opened or CLs voted on, and only over the issues and CLs they're about, instead of all tasks over the whole corpus")
+	sweepInterval = flag.Duration("sweep-interval", time.Hour, "with --events, how often to run all tasks over the whole corpus, including those without change events, like tasks acting once some time has passed")
+	replay        = flag.String("replay", "", "if non-empty, a directory holding a recorded mutation log to replay: the tasks run in dry-run mode for the change events of its mutations after the first --replay-after, then gopherbot exits")
+	replayAfter   = flag.Int("replay-after", 0, "with --replay, the number of mutations to load before tracking change events")
+
+	rulesFile = flag.String("rules", "", "if non-empty, a file of issue rules for the 'apply rules' task to use instead of the built-in rules.txt")
 )
 
 func init() {
@@ -64,9 +72,13 @@ func init() {
 		fmt.Fprintf(output, "gopherbot runs Go's gopherbot role account on GitHub and Gerrit.\n\n")
 		flag.PrintDefaults()
 		fmt.Fprintln(output, "")
-		fmt.Fprintln(output, "Tasks (can be used for the --only-run flag):")
+		fmt.Fprintln(output, "Tasks (can be used for the --only-run flag), with the change events they handle in --events mode:")
 		for _, t := range tasks {
-			fmt.Fprintf(output, "  %q\n", t.name)
+			if len(t.events) == 0 {
+				fmt.Fprintf(output, "  %q (sweep)\n", t.name)
+				continue
+			}
+			fmt.Fprintf(output, "  %q %v\n", t.name, t.events)
 		}
 	}
 }
@@ -96,12 +108,8 @@ const (
 
 // GitHub Milestone numbers for the golang/go repo.
 var (
-	proposal      = milestone{30, "Proposal"}
-	unreleased    = milestone{22, "Unreleased"}
-	unplanned     = milestone{6, "Unplanned"}
-	gccgo         = milestone{23, "Gccgo"}
-	vgo           = milestone{71, "vgo"}
-	vulnUnplanned = milestone{288, "vuln/unplanned"}
+	proposal  = milestone{30, "Proposal"}
+	unplanned = milestone{6, "Unplanned"}
 )
 
 // GitHub Milestone numbers for the golang/vscode-go repo.
@@ -112,6 +120,13 @@ type milestone struct {
 	Name   string
 }
 
+// timeNow is the clock the tasks use to decide whether an issue or CL is
+// old enough to act on. Tests replaying a recorded corpus pin it.
+var timeNow = time.Now
+
+// ownersURL is the endpoint of the dev.golang.org owners service.
+var ownersURL = "https://dev.golang.org/owners/"
+
 func getGithubToken(ctx context.Context, sc *secret.Client) (string, error) {
 	if metadata.OnGCE() && sc != nil {
 		ctxSc, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -366,11 +381,30 @@ func main() {
 			{vscode, 1402}: true,
 		},
 	}
+	if bot.rules, err = loadRules(); err != nil {
+		log.Fatal(err)
+	}
+	if *replay != "" {
+		*dryRun = true
+		taskErrors := bot.replay(ctx, maintner.NewDiskMutationLogger(*replay), *replayAfter)
+		for _, err := range taskErrors {
+			log.Print(err)
+		}
+		if len(taskErrors) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
 	bot.initCorpus()
 
 	for {
 		t0 := time.Now()
-		taskErrors := bot.doTasks(ctx)
+		var taskErrors []error
+		if *events {
+			taskErrors = bot.doEventTasks(ctx)
+		} else {
+			taskErrors = bot.doTasks(ctx)
+		}
 		for _, err := range taskErrors {
 			log.Print(err)
 		}
@@ -414,6 +448,7 @@ type gopherbot struct {
 	gorepo *maintner.GitHubRepo
 	is     issuesService
 
+	rules             *rules.Script // issue rules; see loadRules
 	knownContributors map[string]bool
 
 	// Until golang.org/issue/22635 is fixed, keep a map of changes and issues
@@ -427,51 +462,70 @@ type gopherbot struct {
 		major      []string          // Last two releases and the next upcoming release, like: "1.9", "1.10", "1.11".
 		nextMinor  map[string]string // Key is a major release like "1.9", value is its next minor release like "1.9.7".
 	}
+
+	// For --events mode. See doEventTasks.
+	scope        *eventScope // if non-nil, the issues and CLs the running task visits
+	lastMutation int64       // number of the last mutation whose change events were handled
+	lastSweep    time.Time   // when all tasks last ran over the whole corpus; zero before the first run
 }
 
-var tasks = []struct {
+// A botTask is one of gopherbot's tasks.
+type botTask struct {
 	name string
 	fn   func(*gopherbot, context.Context) error
-}{
+
+	// events are the kinds of change events the task handles in
+	// --events mode, where it only visits the issues and CLs of
+	// those events. Tasks without events, such as those that act
+	// once some time has passed, only run in the periodic sweeps
+	// over the whole corpus.
+	events []maintner.ChangeKind
+}
+
+// Kinds of change events that tasks handle.
+var (
+	noEvents        []maintner.ChangeKind // the task only runs in sweeps over the whole corpus
+	onIssueCreated  = []maintner.ChangeKind{maintner.IssueCreated}
+	onIssueTriage   = []maintner.ChangeKind{maintner.IssueCreated, maintner.IssueLabeled, maintner.IssueUnlabeled}
+	onIssueComment  = []maintner.ChangeKind{maintner.IssueCreated, maintner.IssueCommented}
+	onIssueLabels   = []maintner.ChangeKind{maintner.IssueLabeled, maintner.IssueUnlabeled}
+	onBackportReply = []maintner.ChangeKind{maintner.IssueCommented, maintner.IssueLabeled}
+	onCLUpload      = []maintner.ChangeKind{maintner.CLCreated, maintner.CLPatchSet}
+	onCLVote        = []maintner.ChangeKind{maintner.CLVote}
+	onCLStatus      = []maintner.ChangeKind{maintner.CLStatus}
+)
+
+var tasks = []botTask{
 	// Tasks that are specific to the golang/go repo.
-	{"kicktrain", (*gopherbot).getOffKickTrain},
-	{"unwait-release", (*gopherbot).unwaitRelease},
-	{"ping-early-issues", (*gopherbot).pingEarlyIssues},
-	{"label build issues", (*gopherbot).labelBuildIssues},
-	{"label compiler/runtime issues", (*gopherbot).labelCompilerRuntimeIssues},
-	{"label mobile issues", (*gopherbot).labelMobileIssues},
-	{"label tools issues", (*gopherbot).labelToolsIssues},
-	{"label website issues", (*gopherbot).labelWebsiteIssues},
-	{"label pkgsite issues", (*gopherbot).labelPkgsiteIssues},
-	{"label proxy.golang.org issues", (*gopherbot).labelProxyIssues},
-	{"label vulncheck or vulndb issues", (*gopherbot).labelVulnIssues},
-	{"label proposals", (*gopherbot).labelProposals},
-	{"handle gopls issues", (*gopherbot).handleGoplsIssues},
-	{"open cherry pick issues", (*gopherbot).openCherryPickIssues},
-	{"close cherry pick issues", (*gopherbot).closeCherryPickIssues},
-	{"set subrepo milestones", (*gopherbot).setSubrepoMilestones},
-	{"set misc milestones", (*gopherbot).setMiscMilestones},
-	{"apply minor release milestones", (*gopherbot).setMinorMilestones},
-	{"update needs", (*gopherbot).updateNeeds},
+	{"kicktrain", (*gopherbot).getOffKickTrain, noEvents},
+	{"unwait-release", (*gopherbot).unwaitRelease, noEvents},
+	{"ping-early-issues", (*gopherbot).pingEarlyIssues, noEvents},
+	{"apply rules", (*gopherbot).applyRules, onIssueTriage},
+	{"label proposals", (*gopherbot).labelProposals, onIssueCreated},
+	{"handle gopls issues", (*gopherbot).handleGoplsIssues, onIssueCreated},
+	{"open cherry pick issues", (*gopherbot).openCherryPickIssues, onBackportReply},
+	{"close cherry pick issues", (*gopherbot).closeCherryPickIssues, onCLStatus},
+	{"apply minor release milestones", (*gopherbot).setMinorMilestones, onIssueCreated},
+	{"update needs", (*gopherbot).updateNeeds, onIssueLabels},
 
 	// Tasks that can be applied to many repos.
-	{"freeze old issues", (*gopherbot).freezeOldIssues},
-	{"label documentation issues", (*gopherbot).labelDocumentationIssues},
-	{"close stale WaitingForInfo", (*gopherbot).closeStaleWaitingForInfo},
-	{"apply labels from comments", (*gopherbot).applyLabelsFromComments},
+	{"freeze old issues", (*gopherbot).freezeOldIssues, noEvents},
+	{"label documentation issues", (*gopherbot).labelDocumentationIssues, onIssueCreated},
+	{"close stale WaitingForInfo", (*gopherbot).closeStaleWaitingForInfo, noEvents},
+	{"apply labels from comments", (*gopherbot).applyLabelsFromComments, onIssueComment},
 
 	// Gerrit tasks are applied to all projects by default.
-	{"abandon scratch reviews", (*gopherbot).abandonScratchReviews},
-	{"assign reviewers to CLs", (*gopherbot).assignReviewersToCLs},
-	{"auto-submit CLs", (*gopherbot).autoSubmitCLs},
+	{"abandon scratch reviews", (*gopherbot).abandonScratchReviews, noEvents},
+	{"assign reviewers to CLs", (*gopherbot).assignReviewersToCLs, noEvents},
+	{"auto-submit CLs", (*gopherbot).autoSubmitCLs, onCLVote},
 
 	// Tasks that are specific to the golang/vscode-go repo.
-	{"set vscode-go milestones", (*gopherbot).setVSCodeGoMilestones},
+	{"set vscode-go milestones", (*gopherbot).setVSCodeGoMilestones, onIssueCreated},
 
-	{"access", (*gopherbot).whoNeedsAccess},
-	{"cl2issue", (*gopherbot).cl2issue},
-	{"congratulate new contributors", (*gopherbot).congratulateNewContributors},
-	{"un-wait CLs", (*gopherbot).unwaitCLs},
+	{"access", (*gopherbot).whoNeedsAccess, noEvents},
+	{"cl2issue", (*gopherbot).cl2issue, onCLUpload},
+	{"congratulate new contributors", (*gopherbot).congratulateNewContributors, noEvents},
+	{"un-wait CLs", (*gopherbot).unwaitCLs, noEvents},
 }
 
 // gardenIssues reports whether GopherBot should perform general issue
@@ -493,14 +547,26 @@ func (b *gopherbot) initCorpus() {
 	if err != nil {
 		log.Fatalf("godata.Get: %v", err)
 	}
+	if *events {
+		// The first run of all tasks covers everything so far.
+		b.lastMutation = corpus.TrackChanges(changeEventsKept)
+		b.lastSweep = time.Time{}
+	}
+	if err := b.setCorpus(corpus); err != nil {
+		log.Fatal(err)
+	}
+}
 
+// setCorpus makes the bot work on corpus.
+func (b *gopherbot) setCorpus(corpus *maintner.Corpus) error {
 	repo := corpus.GitHub().Repo("golang", "go")
 	if repo == nil {
-		log.Fatal("Failed to find Go repo in Corpus.")
+		return errors.New("Failed to find Go repo in Corpus.")
 	}
 
 	b.corpus = corpus
 	b.gorepo = repo
+	return nil
 }
 
 // doTasks performs tasks in sequence. It doesn't stop if
@@ -961,7 +1027,7 @@ func (b *gopherbot) pingEarlyIssues(ctx context.Context) error {
 // causes the updated time to bump, which means the bot wouldn't try to lock it
 // again for another year.
 func (b *gopherbot) freezeOldIssues(ctx context.Context) error {
-	tooOld := time.Now().Add(-365 * 24 * time.Hour)
+	tooOld := timeNow().Add(-365 * 24 * time.Hour)
 	return b.corpus.GitHub().ForeachRepo(func(repo *maintner.GitHubRepo) error {
 		if !gardenIssues(repo) {
 			return nil
@@ -1053,62 +1119,6 @@ func isGo2Issue(gi *maintner.GitHubIssue) bool {
 	return strings.Contains(gi.Title, "Go 2") || strings.Contains(gi.Title, "go2") || strings.Contains(gi.Title, "Go2")
 }
 
-func (b *gopherbot) setSubrepoMilestones(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		if !gi.Milestone.IsNone() || gi.HasEvent("demilestoned") || gi.HasEvent("milestoned") {
-			return nil
-		}
-		if !strings.HasPrefix(gi.Title, "x/") {
-			return nil
-		}
-		pkg := gi.Title
-		if colon := strings.IndexByte(pkg, ':'); colon >= 0 {
-			pkg = pkg[:colon]
-		}
-		if sp := strings.IndexByte(pkg, ' '); sp >= 0 {
-			pkg = pkg[:sp]
-		}
-		switch pkg {
-		case "",
-			"x/arch",
-			"x/crypto/chacha20poly1305",
-			"x/crypto/curve25519",
-			"x/crypto/poly1305",
-			"x/net/http2",
-			"x/net/idna",
-			"x/net/lif",
-			"x/net/proxy",
-			"x/net/route",
-			"x/text/unicode/norm",
-			"x/text/width":
-			// These get vendored in. Don't mess with them.
-			return nil
-		case "x/vgo":
-			// Handled by setMiscMilestones
-			return nil
-		}
-		return b.setMilestone(ctx, b.gorepo.ID(), gi, unreleased)
-	})
-}
-
-func (b *gopherbot) setMiscMilestones(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		if !gi.Milestone.IsNone() || gi.HasEvent("demilestoned") || gi.HasEvent("milestoned") {
-			return nil
-		}
-		if strings.Contains(gi.Title, "gccgo") { // TODO: better gccgo bug report heuristic?
-			return b.setMilestone(ctx, b.gorepo.ID(), gi, gccgo)
-		}
-		if strings.HasPrefix(gi.Title, "x/vgo") {
-			return b.setMilestone(ctx, b.gorepo.ID(), gi, vgo)
-		}
-		if strings.HasPrefix(gi.Title, "x/vuln") {
-			return b.setMilestone(ctx, b.gorepo.ID(), gi, vulnUnplanned)
-		}
-		return nil
-	})
-}
-
 func (b *gopherbot) setVSCodeGoMilestones(ctx context.Context) error {
 	vscode := b.corpus.GitHub().Repo("golang", "vscode-go")
 	if vscode == nil {
@@ -1119,68 +1129,13 @@ func (b *gopherbot) setVSCodeGoMilestones(ctx context.Context) error {
 			return nil
 		}
 		// Work-around golang/go#40640 by only milestoning new issues.
-		if time.Since(gi.Created) > 24*time.Hour {
+		if timeNow().Sub(gi.Created) > 24*time.Hour {
 			return nil
 		}
 		return b.setMilestone(ctx, vscode.ID(), gi, vscodeUntriaged)
 	})
 }
 
-func (b *gopherbot) labelBuildIssues(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		if !strings.HasPrefix(gi.Title, "x/build") || gi.HasLabel("Builders") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		return b.addLabel(ctx, b.gorepo.ID(), gi, "Builders")
-	})
-}
-
-func (b *gopherbot) labelCompilerRuntimeIssues(ctx context.Context) error {
-	entries, err := getAllCodeOwners(ctx)
-	if err != nil {
-		return err
-	}
-	// Filter out any entries that don't contain compiler/runtime owners into
-	// a set of compiler/runtime-owned packages whose names match the names
-	// used in the issue tracker.
-	crtPackages := make(map[string]struct{}) // Key is issue title prefix, like "cmd/compile" or "x/sys/unix."
-	for pkg, entry := range entries {
-		for _, owner := range entry.Primary {
-			name := owner.GitHubUsername
-			if name == "golang/compiler" || name == "golang/runtime" {
-				crtPackages[owners.TranslatePathForIssues(pkg)] = struct{}{}
-				break
-			}
-		}
-	}
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		if gi.HasLabel("compiler/runtime") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		components := strings.SplitN(gi.Title, ":", 2)
-		if len(components) != 2 {
-			return nil
-		}
-		for _, p := range strings.Split(strings.TrimSpace(components[0]), ",") {
-			if _, ok := crtPackages[strings.TrimSpace(p)]; !ok {
-				continue
-			}
-			// TODO(mknyszek): Add this issue to the GitHub project as well.
-			return b.addLabel(ctx, b.gorepo.ID(), gi, "compiler/runtime")
-		}
-		return nil
-	})
-}
-
-func (b *gopherbot) labelMobileIssues(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		if !strings.HasPrefix(gi.Title, "x/mobile") || gi.HasLabel("mobile") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		return b.addLabel(ctx, b.gorepo.ID(), gi, "mobile")
-	})
-}
-
 func (b *gopherbot) labelDocumentationIssues(ctx context.Context) error {
 	const documentation = "Documentation"
 	return b.corpus.GitHub().ForeachRepo(func(repo *maintner.GitHubRepo) error {
@@ -1199,56 +1154,6 @@ func (b *gopherbot) labelDocumentationIssues(ctx context.Context) error {
 	})
 }
 
-func (b *gopherbot) labelToolsIssues(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		if !strings.HasPrefix(gi.Title, "x/tools") || gi.HasLabel("Tools") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		return b.addLabel(ctx, b.gorepo.ID(), gi, "Tools")
-	})
-}
-
-func (b *gopherbot) labelWebsiteIssues(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		hasWebsiteTitle := strings.HasPrefix(gi.Title, "x/website:")
-		if !hasWebsiteTitle || gi.HasLabel("website") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		return b.addLabel(ctx, b.gorepo.ID(), gi, "website")
-	})
-}
-
-func (b *gopherbot) labelPkgsiteIssues(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		hasPkgsiteTitle := strings.HasPrefix(gi.Title, "x/pkgsite:")
-		if !hasPkgsiteTitle || gi.HasLabel("pkgsite") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		return b.addLabel(ctx, b.gorepo.ID(), gi, "pkgsite")
-	})
-}
-
-func (b *gopherbot) labelProxyIssues(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		hasProxyTitle := strings.Contains(gi.Title, "proxy.golang.org") || strings.Contains(gi.Title, "sum.golang.org") || strings.Contains(gi.Title, "index.golang.org")
-		if !hasProxyTitle || gi.HasLabel("proxy.golang.org") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		return b.addLabel(ctx, b.gorepo.ID(), gi, "proxy.golang.org")
-	})
-}
-
-func (b *gopherbot) labelVulnIssues(ctx context.Context) error {
-	return b.foreachIssue(b.gorepo, open, func(gi *maintner.GitHubIssue) error {
-		hasVulnTitle := strings.HasPrefix(gi.Title, "x/vuln:") || strings.HasPrefix(gi.Title, "x/vuln/") ||
-			strings.HasPrefix(gi.Title, "x/vulndb:") || strings.HasPrefix(gi.Title, "x/vulndb/")
-		if !hasVulnTitle || gi.HasLabel("vulncheck or vulndb") || gi.HasEvent("unlabeled") {
-			return nil
-		}
-		return b.addLabel(ctx, b.gorepo.ID(), gi, "vulncheck or vulndb")
-	})
-}
-
 // handleGoplsIssues labels and asks for additional information on gopls issues.
 //
 // This is necessary because gopls issues often require additional information to diagnose,
@@ -1264,7 +1169,7 @@ func (b *gopherbot) handleGoplsIssues(ctx context.Context) error {
 
 func (b *gopherbot) closeStaleWaitingForInfo(ctx context.Context) error {
 	const waitingForInfo = "WaitingForInfo"
-	now := time.Now()
+	now := timeNow()
 	return b.corpus.GitHub().ForeachRepo(func(repo *maintner.GitHubRepo) error {
 		if !gardenIssues(repo) {
 			return nil
@@ -1328,12 +1233,12 @@ func (b *gopherbot) closeStaleWaitingForInfo(ctx context.Context) error {
 // cl2issue writes "Change https://go.dev/cl/NNNN mentions this issue"
 // and the change summary on GitHub when a new Gerrit change references a GitHub issue.
 func (b *gopherbot) cl2issue(ctx context.Context) error {
-	monthAgo := time.Now().Add(-30 * 24 * time.Hour)
+	monthAgo := timeNow().Add(-30 * 24 * time.Hour)
 	return b.corpus.Gerrit().ForeachProjectUnsorted(func(gp *maintner.GerritProject) error {
 		if gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		return gp.ForeachCLUnsorted(func(cl *maintner.GerritCL) error {
+		return b.foreachCL(gp, false, func(cl *maintner.GerritCL) error {
 			if cl.Meta.Commit.AuthorTime.Before(monthAgo) {
 				// If the CL was last updated over a
 				// month ago, assume (as an
@@ -1504,7 +1409,7 @@ func (b *gopherbot) congratulateNewContributors(ctx context.Context) error {
 		if gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		return gp.ForeachCLUnsorted(func(cl *maintner.GerritCL) error {
+		return b.foreachCL(gp, false, func(cl *maintner.GerritCL) error {
 			// CLs can be returned by maintner in any order. Note also that
 			// Gerrit CL numbers are sparse (CL N does not guarantee that CL N-1
 			// exists) and Gerrit issues CL's out of order - it may issue CL N,
@@ -1591,7 +1496,7 @@ func (b *gopherbot) unwaitCLs(ctx context.Context) error {
 		if gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		return gp.ForeachOpenCL(func(cl *maintner.GerritCL) error {
+		return b.foreachCL(gp, true, func(cl *maintner.GerritCL) error {
 			tags := cl.Meta.Hashtags()
 			if tags.Len() == 0 {
 				return nil
@@ -1681,7 +1586,7 @@ func (b *gopherbot) fetchReleases(ctx context.Context) (major []string, nextMino
 	b.releases.Lock()
 	defer b.releases.Unlock()
 
-	if expiry := b.releases.lastUpdate.Add(10 * time.Minute); time.Now().Before(expiry) {
+	if expiry := b.releases.lastUpdate.Add(10 * time.Minute); timeNow().Before(expiry) {
 		return b.releases.major, b.releases.nextMinor, nil
 	}
 
@@ -1709,7 +1614,7 @@ func (b *gopherbot) fetchReleases(ctx context.Context) (major []string, nextMino
 
 	b.releases.major = major
 	b.releases.nextMinor = nextMinor
-	b.releases.lastUpdate = time.Now()
+	b.releases.lastUpdate = timeNow()
 
 	return major, nextMinor, nil
 }
@@ -1834,12 +1739,12 @@ func (b *gopherbot) closeCherryPickIssues(ctx context.Context) error {
 		cherryPickIssues[gi.Number] = gi
 		return nil
 	})
-	monthAgo := time.Now().Add(-30 * 24 * time.Hour)
+	monthAgo := timeNow().Add(-30 * 24 * time.Hour)
 	return b.corpus.Gerrit().ForeachProjectUnsorted(func(gp *maintner.GerritProject) error {
 		if gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		return gp.ForeachCLUnsorted(func(cl *maintner.GerritCL) error {
+		return b.foreachCL(gp, false, func(cl *maintner.GerritCL) error {
 			if cl.Commit.CommitTime.Before(monthAgo) {
 				// If the CL was last updated over a month ago, assume (as an
 				// optimization) that gopherbot already processed this CL.
@@ -2095,8 +2000,8 @@ func (b *gopherbot) assignReviewersToCLs(ctx context.Context) error {
 		if gp.Project() == "scratch" || gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		gp.ForeachOpenCL(func(cl *maintner.GerritCL) error {
-			if cl.Private || cl.WorkInProgress() || time.Since(cl.Created) < 10*time.Minute {
+		b.foreachCL(gp, true, func(cl *maintner.GerritCL) error {
+			if cl.Private || cl.WorkInProgress() || timeNow().Sub(cl.Created) < 10*time.Minute {
 				return nil
 			}
 			if assignReviewersOptOut[cl.Owner().Email()] {
@@ -2236,12 +2141,12 @@ outer:
 
 // abandonScratchReviews abandons Gerrit CLs in the "scratch" project if they've been open for over a week.
 func (b *gopherbot) abandonScratchReviews(ctx context.Context) error {
-	tooOld := time.Now().Add(-24 * time.Hour * 7)
+	tooOld := timeNow().Add(-24 * time.Hour * 7)
 	return b.corpus.Gerrit().ForeachProjectUnsorted(func(gp *maintner.GerritProject) error {
 		if gp.Project() != "scratch" || gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		return gp.ForeachOpenCL(func(cl *maintner.GerritCL) error {
+		return b.foreachCL(gp, true, func(cl *maintner.GerritCL) error {
 			if b.deletedChanges[gerritChange{gp.Project(), cl.Number}] || !cl.Meta.Commit.CommitTime.Before(tooOld) {
 				return nil
 			}
@@ -2281,13 +2186,13 @@ func (b *gopherbot) whoNeedsAccess(ctx context.Context) error {
 		level[ai.NumericID] = 2
 	}
 
-	quarterAgo := time.Now().Add(-90 * 24 * time.Hour)
+	quarterAgo := timeNow().Add(-90 * 24 * time.Hour)
 	missing := map[string]int{} // "only level N: $WHO" -> number of CLs for that user
 	err = b.corpus.Gerrit().ForeachProjectUnsorted(func(gp *maintner.GerritProject) error {
 		if gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		return gp.ForeachCLUnsorted(func(cl *maintner.GerritCL) error {
+		return b.foreachCL(gp, false, func(cl *maintner.GerritCL) error {
 			if cl.Meta.Commit.AuthorTime.Before(quarterAgo) {
 				return nil
 			}
@@ -2406,7 +2311,7 @@ func (b *gopherbot) autoSubmitCLs(ctx context.Context) error {
 		if gp.Server() != "go.googlesource.com" {
 			return nil
 		}
-		return gp.ForeachOpenCL(func(cl *maintner.GerritCL) error {
+		return b.foreachCL(gp, true, func(cl *maintner.GerritCL) error {
 			gc := gerritChange{gp.Project(), cl.Number}
 			if b.deletedChanges[gc] {
 				return nil
@@ -2528,6 +2433,8 @@ const (
 )
 
 // foreachIssue calls fn for each issue in repo gr as controlled by flags.
+// When the running task handles change events, only the issues the
+// events are about are visited.
 //
 // If fn returns an error, iteration ends and foreachIssue returns
 // with that error.
@@ -2535,7 +2442,7 @@ const (
 // The fn function is called serially, with increasingly numbered
 // issues.
 func (b *gopherbot) foreachIssue(gr *maintner.GitHubRepo, flags issueFlags, fn func(*maintner.GitHubIssue) error) error {
-	return gr.ForeachIssue(func(gi *maintner.GitHubIssue) error {
+	return b.scope.foreachIssue(gr, func(gi *maintner.GitHubIssue) error {
 		switch {
 		case (flags&open == 0) && !gi.Closed,
 			(flags&closed == 0) && gi.Closed,
@@ -2549,6 +2456,16 @@ func (b *gopherbot) foreachIssue(gr *maintner.GitHubRepo, flags issueFlags, fn f
 	})
 }
 
+// foreachCL calls fn for each CL in gp, or for each open CL, as
+// visited by ForeachOpenCL, if onlyOpen is set. When the running task
+// handles change events, only the CLs the events are about are visited.
+//
+// If fn returns an error, iteration ends and foreachCL returns with
+// that error.
+func (b *gopherbot) foreachCL(gp *maintner.GerritProject, onlyOpen bool, fn func(*maintner.GerritCL) error) error {
+	return b.scope.foreachCL(gp, onlyOpen, fn)
+}
+
 // reviewerRe extracts the reviewer's Gerrit ID from a line that looks like:
 //
 //	Reviewer: Rebecca Stambler <16140@62eb7196-b449-3ce5-99f1-c037f21e1705>
@@ -2625,7 +2542,7 @@ func fetchCodeOwners(ctx context.Context, oReq *owners.Request) (*owners.Respons
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", "https://dev.golang.org/owners/", bytes.NewReader(b))
+	req, err := http.NewRequest("POST", ownersURL, bytes.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,143 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rules implements gopherbot's issue rules language.
+// A rules file is a sequence of rules of the form “action <- pattern”,
+// meaning apply action to the issues matching pattern.
+// An action is one of:
+//
+//	label "name"      add the label
+//	milestone "name"  set the milestone
+//	comment "text"    post the comment
+//	close             close the issue
+//
+// A pattern compares the fields of an issue with quoted strings, using
+// ==, !=, <, <=, > and >=, or matches them against backquoted regular
+// expressions, using ~ and !~. Comparisons are combined with &&, ||, !
+// and parentheses. A backquoted regular expression on its own matches
+// the title. A field may have several values, such as the labels of an
+// issue: ==, <, <=, >, >= and ~ are true if any value matches, while
+// != and !~ are true if no value matches. A field without values
+// compares as the empty string.
+//
+// Comments start with # and run to the end of the line. A rule
+// continues on the next line after <-, &&, || and other operators.
+//
+// For example:
+//
+//	# Label x/build issues.
+//	label "Builders" <- title ~ `^x/build` && label != "Builders"
+//
+// The language is that of golang.org/x/build/internal/script, with
+// action arguments and fields with several values.
+package rules
+
+import (
+	"golang.org/x/build/internal/script"
+)
+
+// A Script is a parsed rules file.
+type Script struct {
+	*script.Script
+}
+
+// A Rule is a single Action <- Pattern rule.
+type Rule = script.Rule
+
+// A SyntaxError reports a syntax error in a parsed rules file.
+type SyntaxError = script.SyntaxError
+
+// actions maps the known actions to whether they take an argument.
+var actions = map[string]bool{
+	"label":     true,
+	"milestone": true,
+	"comment":   true,
+	"close":     false,
+}
+
+// Parse parses text as a rules file, with patterns that may refer to
+// the given fields, returning the parsed form and any parse errors
+// found, as script.Parse does.
+func Parse(file, text string, fields []string) (*Script, []*SyntaxError) {
+	lang := &script.Language{Fields: fields, Actions: actions, BareField: "title"}
+	s, errs := lang.Parse(file, text)
+	return &Script{s}, errs
+}
+
+// Match returns the rules of the script whose patterns match record,
+// in order.
+func (s *Script) Match(record Record) []*Rule {
+	var rules []*Rule
+	for _, r := range s.Rules {
+		if match(r.Pattern, record) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// Uses reports whether any pattern of the script refers to field.
+func (s *Script) Uses(field string) bool {
+	for _, r := range s.Rules {
+		if uses(r.Pattern, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func uses(x script.Expr, field string) bool {
+	switch x := x.(type) {
+	case *script.CmpExpr:
+		return x.Field == field
+	case *script.RegExpr:
+		return x.Field == field
+	case *script.NotExpr:
+		return uses(x.X, field)
+	case *script.AndExpr:
+		return uses(x.X, field) || uses(x.Y, field)
+	case *script.OrExpr:
+		return uses(x.X, field) || uses(x.Y, field)
+	}
+	return false
+}
+
+// A Record is a set of fields, each with any number of values.
+type Record map[string][]string
+
+// values returns the values of the field, or the empty string if it has none.
+func (r Record) values(field string) []string {
+	if v := r[field]; len(v) > 0 {
+		return v
+	}
+	return []string{""}
+}
+
+// match reports whether the pattern x matches record.
+func match(x script.Expr, record Record) bool {
+	switch x := x.(type) {
+	case *script.CmpExpr:
+		return matchValues(x, x.Field, x.Op == "!=", record)
+	case *script.RegExpr:
+		return matchValues(x, x.Field, x.Not, record)
+	case *script.NotExpr:
+		return !match(x.X, record)
+	case *script.AndExpr:
+		return match(x.X, record) && match(x.Y, record)
+	case *script.OrExpr:
+		return match(x.X, record) || match(x.Y, record)
+	}
+	return false
+}
+
+// matchValues reports whether the comparison x of field matches any
+// value of the field in record, or, if x is negated, all of them.
+func matchValues(x script.Expr, field string, negated bool, record Record) bool {
+	for _, v := range record.values(field) {
+		if x.Match(script.Record{field: v}) != negated {
+			return !negated
+		}
+	}
+	return negated
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var fields = []string{"title", "label", "dir"}
+
+func TestParse(t *testing.T) {
+	s, errs := Parse("rules.txt", `
+# Comment.
+label "Builders" <- title ~ `+"`^x/build`"+` && label != "Builders"
+milestone "Unreleased" <-
+	dir ~ `+"`^x/`"+` &&
+	!(dir == "x/net/http2" || dir == "x/text/width")
+close <- label == "Spam"
+comment "Please fill in the template." <- `+"`^$`"+`
+`, fields)
+	if len(errs) > 0 {
+		t.Fatalf("Parse: %v", errs)
+	}
+	want := []string{
+		"3: label \"Builders\" <- title ~ `(?m)^x/build` && label != \"Builders\"",
+		"4: milestone \"Unreleased\" <- dir ~ `(?m)^x/` && !(dir == \"x/net/http2\" || dir == \"x/text/width\")",
+		"7: close <- label == \"Spam\"",
+		"8: comment \"Please fill in the template.\" <- title ~ `(?m)^$`",
+	}
+	if len(s.Rules) != len(want) {
+		t.Fatalf("got %d rules; want %d", len(s.Rules), len(want))
+	}
+	for i, r := range s.Rules {
+		if got := fmt.Sprintf("%d: %v", r.Line, r); got != want[i] {
+			t.Errorf("rule %d = %s\nwant %s", i, got, want[i])
+		}
+	}
+	if !s.Uses("dir") || s.Uses("author") {
+		t.Errorf("Uses(dir), Uses(author) = %v, %v; want true, false", s.Uses("dir"), s.Uses("author"))
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct{ in, err string }{
+		{`tweet "hi" <- title == "x"`, "rules.txt:1.1: unknown action tweet"},
+		{`label <- title == "x"`, "rules.txt:1.7: label requires quoted string"},
+		{`label "" <- title == "x"`, "rules.txt:1.7: label requires non-empty string"},
+		{`close "now" <- title == "x"`, "rules.txt:1.7: unexpected quoted string now"},
+		{`close <- author == "x"`, "rules.txt:1.10: unknown field author"},
+		{`close <- title ~ "x"`, "rules.txt:1.18: ~ requires backquoted regexp"},
+		{`close <- (title == "x"`, "rules.txt:1.23: missing close paren"},
+	} {
+		_, errs := Parse("rules.txt", tt.in, fields)
+		if len(errs) != 1 || errs[0].Error() != tt.err {
+			t.Errorf("Parse(%q) errors = %v; want %s", tt.in, errs, tt.err)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	s, errs := Parse("rules.txt", `
+label "Builders" <- title ~ `+"`^x/build`"+` && label != "Builders"
+label "NeedsFix" <- label !~ `+"`^Needs`"+`
+label "cmd" <- dir == "cmd/go"
+`, fields)
+	if len(errs) > 0 {
+		t.Fatalf("Parse: %v", errs)
+	}
+	for _, tt := range []struct {
+		record Record
+		want   []string
+	}{
+		{Record{"title": {"x/build: flaky"}}, []string{"Builders", "NeedsFix"}},
+		{Record{"title": {"x/build: flaky"}, "label": {"Builders", "NeedsInvestigation"}}, nil},
+		{Record{"title": {"cmd/go, cmd/link: bug"}, "dir": {"cmd/link", "cmd/go"}, "label": {"Tools"}}, []string{"NeedsFix", "cmd"}},
+	} {
+		var got []string
+		for _, r := range s.Match(tt.record) {
+			got = append(got, r.Arg)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Match(%v) = %v; want %v", tt.record, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,448 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/github"
+	"golang.org/x/build/devapp/owners"
+	"golang.org/x/build/gerrit"
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+	"golang.org/x/build/maintner/maintpb"
+	"google.golang.org/grpc"
+)
+
+var updateFlag = flag.Bool("update", false, "Update golden files.")
+
+// replayTime is when the tasks run over the snapshot.
+var replayTime = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+// TestReplayTasks runs all tasks over the mutation log snapshot in
+// testdata/snapshot.txt, with GitHub, Gerrit and the maintner and
+// owners APIs replaced by fakes, and compares what the tasks print and
+// the requests they make to change issues and CLs with those in
+// testdata/replay.golden. Each task runs as if named by --only-run, so
+// that tasks run only on request, like access, are replayed too.
+//
+// Changes to the behavior of tasks show up as a diff of the golden
+// file, which is rewritten by running:
+//
+//	go test -run=TestReplayTasks -update
+func TestReplayTasks(t *testing.T) {
+	defer func(old bool) { *dryRun = old }(*dryRun)
+	defer func(old string) { *onlyRun = old }(*onlyRun)
+	defer func(old func() time.Time) { timeNow = old }(timeNow)
+	defer func(old string) { ownersURL = old }(ownersURL)
+	defer func(old string) { lastTask = old }(lastTask)
+	*dryRun, lastTask = false, ""
+	timeNow = func() time.Time { return replayTime }
+
+	muts, err := readSnapshot(filepath.Join("testdata", "snapshot.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &gopherbot{
+		mc:                fakeMaintner{},
+		knownContributors: map[string]bool{},
+		deletedChanges:    map[gerritChange]bool{},
+		deletedIssues:     map[githubIssue]bool{},
+	}
+	if b.rules, err = loadRules(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.setCorpus(newTestCorpus(t, muts)); err != nil {
+		t.Fatal(err)
+	}
+	// Visit the CLs in increasing order, as tasks handling events do,
+	// so that the tasks make their requests in the same order each run.
+	b.scope = allCLsScope(b.corpus)
+
+	rec := new(requestRecorder)
+	gh := httptest.NewServer(rec.handler(fakeGitHub{b.corpus}.serve))
+	defer gh.Close()
+	b.ghc = github.NewClient(gh.Client())
+	b.ghc.BaseURL, _ = url.Parse(gh.URL + "/")
+	b.is = b.ghc.Issues
+	gs := httptest.NewServer(rec.handler(fakeGerrit{b.corpus}.serve))
+	defer gs.Close()
+	b.gerrit = gerrit.NewClient(gs.URL, gerrit.NoAuth)
+	ows := httptest.NewServer(http.HandlerFunc(serveFakeOwners))
+	defer ows.Close()
+	ownersURL = ows.URL
+
+	var got bytes.Buffer
+	ctx := context.Background()
+	for _, task := range tasks {
+		*onlyRun = task.name
+		out, err := captureStdout(t, func() error { return task.fn(b, ctx) })
+		reqs := rec.take()
+		if err == nil && out == "" && len(reqs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&got, "# %s\n", task.name)
+		if err != nil {
+			fmt.Fprintf(&got, "error: %v\n", err)
+		}
+		for _, line := range strings.SplitAfter(out, "\n") {
+			if line != "" {
+				fmt.Fprintf(&got, "> %s", line)
+			}
+		}
+		for _, r := range reqs {
+			fmt.Fprintln(&got, r)
+		}
+	}
+
+	golden := filepath.Join("testdata", "replay.golden")
+	if *updateFlag {
+		if err := os.WriteFile(golden, got.Bytes(), 0666); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), got.String()); diff != "" {
+		t.Errorf("task requests differ from %s: (-want, +got)\n%s\nRun with -update if the change is intended.", golden, diff)
+	}
+}
+
+// allCLsScope returns the scope of all the CLs of c.
+func allCLsScope(c *maintner.Corpus) *eventScope {
+	s := &eventScope{gerrit: true, cls: map[string]map[int32]bool{}}
+	c.Gerrit().ForeachProjectUnsorted(func(gp *maintner.GerritProject) error {
+		nums := map[int32]bool{}
+		gp.ForeachCLUnsorted(func(cl *maintner.GerritCL) error {
+			nums[cl.Number] = true
+			return nil
+		})
+		s.cls[gp.ServerSlashProject()] = nums
+		return nil
+	})
+	return s
+}
+
+// captureStdout returns what f prints to standard output, like the
+// reports of tasks, along with the error f returns.
+func captureStdout(t *testing.T, f func() error) (string, error) {
+	t.Helper()
+	tmp, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmp.Close()
+	stdout := os.Stdout
+	os.Stdout = tmp
+	err = f()
+	os.Stdout = stdout
+	out, rerr := os.ReadFile(tmp.Name())
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	return string(out), err
+}
+
+// readSnapshot reads the mutations of a snapshot file, written in
+// protobuf text format and separated by lines of "---".
+func readSnapshot(file string) ([]*maintpb.Mutation, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var muts []*maintpb.Mutation
+	for i, text := range regexp.MustCompile(`(?m)^---$`).Split(string(data), -1) {
+		m := new(maintpb.Mutation)
+		if err := proto.UnmarshalText(text, m); err != nil {
+			return nil, fmt.Errorf("%s: mutation %d: %v", file, i, err)
+		}
+		muts = append(muts, m)
+	}
+	return muts, nil
+}
+
+// A requestRecorder records the requests made to fake servers that
+// would change something, like adding a comment.
+type requestRecorder struct {
+	mu   sync.Mutex
+	reqs []string
+}
+
+// handler returns a handler recording the requests other than GETs
+// before serving them with h.
+func (rec *requestRecorder) handler(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			s := r.Method + " " + r.URL.Path
+			var buf bytes.Buffer
+			if json.Compact(&buf, body) == nil && buf.Len() > 0 {
+				s += " " + buf.String()
+			}
+			rec.mu.Lock()
+			rec.reqs = append(rec.reqs, s)
+			rec.mu.Unlock()
+		}
+		h(w, r)
+	})
+}
+
+// take returns the requests recorded since the last call.
+func (rec *requestRecorder) take() []string {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	reqs := rec.reqs
+	rec.reqs = nil
+	return reqs
+}
+
+// fakeGitHub serves the GitHub API for the issues of a corpus.
+type fakeGitHub struct {
+	corpus *maintner.Corpus
+}
+
+var issueLabelsPath = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/issues/(\d+)/labels$`)
+
+func (f fakeGitHub) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == "GET" && issueLabelsPath.MatchString(r.URL.Path):
+		m := issueLabelsPath.FindStringSubmatch(r.URL.Path)
+		num, _ := strconv.Atoi(m[3])
+		var names []string
+		if repo := f.corpus.GitHub().Repo(m[1], m[2]); repo != nil {
+			if gi := repo.Issue(int32(num)); gi != nil {
+				for _, l := range gi.Labels {
+					names = append(names, l.Name)
+				}
+			}
+		}
+		sort.Strings(names)
+		labels := []*github.Label{}
+		for _, name := range names {
+			labels = append(labels, &github.Label{Name: github.String(name)})
+		}
+		json.NewEncoder(w).Encode(labels)
+	case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/issues"):
+		// Number the created issues past those of the snapshot.
+		json.NewEncoder(w).Encode(&github.Issue{Number: github.Int(1000)})
+	case r.Method == "PUT" || r.Method == "DELETE":
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasSuffix(r.URL.Path, "/labels"),
+		r.Method == "GET" && (strings.HasSuffix(r.URL.Path, "/comments") || strings.HasSuffix(r.URL.Path, "/issues")):
+		io.WriteString(w, "[]")
+	default:
+		io.WriteString(w, "{}")
+	}
+}
+
+// fakeGerrit serves the Gerrit API for the CLs of a corpus, with the
+// accounts and groups of the snapshot.
+type fakeGerrit struct {
+	corpus *maintner.Corpus
+}
+
+// gerritAccounts are the Gerrit accounts of the snapshot, by ID.
+var gerritAccounts = map[int64]gerrit.AccountInfo{
+	1001: {NumericID: 1001, Name: "Gopher", Email: "gopher@golang.org"},
+	1002: {NumericID: 1002, Name: "Maintainer", Email: "maintainer@golang.org"},
+	1003: {NumericID: 1003, Name: "New Gopher", Email: "newgopher@example.com"},
+}
+
+// gerritGroups are the IDs of the members of Gerrit groups, by name.
+var gerritGroups = map[string][]int64{
+	"may-start-trybots": {1001, 1002},
+	"approvers":         {1002},
+}
+
+var (
+	changePath          = regexp.MustCompile(`^/changes/([^/]+)(?:/detail)?$`)
+	changeFilesPath     = regexp.MustCompile(`^/changes/([^/]+)/revisions/[^/]+/files$`)
+	changeReviewersPath = regexp.MustCompile(`^/changes/([^/]+)/reviewers$`)
+	groupMembersPath    = regexp.MustCompile(`^/groups/([^/]+)/members$`)
+
+	// metaReviewer matches the footers of meta commits adding
+	// reviewers and CCs, like "Reviewer: Gopher <1001@server-id>".
+	metaReviewer = regexp.MustCompile(`(?m)^(?:Reviewer|CC): .* <(\d+)@`)
+)
+
+func (f fakeGerrit) serve(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimSuffix(r.URL.Path, "/")
+	var resp interface{}
+	switch {
+	case r.Method == "GET" && changePath.MatchString(p):
+		cl := f.change(changePath.FindStringSubmatch(p)[1])
+		if cl == nil {
+			http.NotFound(w, r)
+			return
+		}
+		resp = changeInfo(cl)
+	case r.Method == "GET" && changeFilesPath.MatchString(p):
+		cl := f.change(changeFilesPath.FindStringSubmatch(p)[1])
+		if cl == nil {
+			http.NotFound(w, r)
+			return
+		}
+		files := map[string]gerrit.FileInfo{"/COMMIT_MSG": {Status: "A"}}
+		for _, f := range cl.Commit.Files {
+			files[f.File] = gerrit.FileInfo{LinesInserted: int(f.Added), LinesDeleted: int(f.Deleted)}
+		}
+		resp = files
+	case r.Method == "GET" && changeReviewersPath.MatchString(p):
+		cl := f.change(changeReviewersPath.FindStringSubmatch(p)[1])
+		if cl == nil {
+			http.NotFound(w, r)
+			return
+		}
+		reviewers := []gerrit.ReviewerInfo{}
+		seen := map[int64]bool{}
+		for _, m := range cl.Metas {
+			for _, sm := range metaReviewer.FindAllStringSubmatch(m.Commit.Msg, -1) {
+				id, _ := strconv.ParseInt(sm[1], 10, 64)
+				if !seen[id] {
+					seen[id] = true
+					reviewers = append(reviewers, gerrit.ReviewerInfo{AccountInfo: gerritAccounts[id]})
+				}
+			}
+		}
+		resp = reviewers
+	case r.Method == "GET" && groupMembersPath.MatchString(p):
+		members := []gerrit.AccountInfo{}
+		for _, id := range gerritGroups[groupMembersPath.FindStringSubmatch(p)[1]] {
+			members = append(members, gerritAccounts[id])
+		}
+		resp = members
+	case p == "/changes" || strings.HasSuffix(p, "/hashtags"):
+		resp = []string{}
+	default:
+		resp = struct{}{}
+	}
+	io.WriteString(w, ")]}'\n")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// change returns the CL with the given change ID, which is a CL
+// number, "project~number" or a Change-Id, or nil if there is none.
+func (f fakeGerrit) change(id string) *maintner.GerritCL {
+	project, num := "", id
+	if i := strings.Index(id, "~"); i >= 0 {
+		project, num = id[:i], id[i+1:]
+	}
+	var found *maintner.GerritCL
+	f.corpus.Gerrit().ForeachProjectUnsorted(func(gp *maintner.GerritProject) error {
+		if project != "" && gp.Project() != project {
+			return nil
+		}
+		return gp.ForeachCLUnsorted(func(cl *maintner.GerritCL) error {
+			if fmt.Sprint(cl.Number) == num || cl.ChangeID() == id {
+				found = cl
+				return errStopIteration
+			}
+			return nil
+		})
+	})
+	return found
+}
+
+// changeInfo returns the Gerrit API view of cl, with its messages and
+// current revision.
+func changeInfo(cl *maintner.GerritCL) *gerrit.ChangeInfo {
+	ci := &gerrit.ChangeInfo{
+		ID:              fmt.Sprintf("%s~%s~%s", cl.Project.Project(), cl.Branch(), cl.ChangeID()),
+		ChangeNumber:    int(cl.Number),
+		ChangeID:        cl.ChangeID(),
+		Project:         cl.Project.Project(),
+		Branch:          cl.Branch(),
+		Subject:         cl.Subject(),
+		Status:          strings.ToUpper(cl.Status),
+		Created:         gerrit.TimeStamp(cl.Created),
+		Updated:         gerrit.TimeStamp(cl.Meta.Commit.CommitTime),
+		CurrentRevision: cl.Commit.Hash.String(),
+	}
+	for _, m := range cl.Messages {
+		ci.Messages = append(ci.Messages, gerrit.ChangeMessageInfo{
+			Time:           gerrit.TimeStamp(m.Date),
+			Message:        m.Message,
+			RevisionNumber: int(m.Version),
+		})
+	}
+	return ci
+}
+
+// ownerEntries are the owners of a few packages. Only those of fmt
+// have a Gerrit account and can be assigned as reviewers.
+var ownerEntries = map[string]*owners.Entry{
+	"go/src/cmd/compile": {Primary: []owners.Owner{{GitHubUsername: "golang/compiler"}}},
+	"go/src/cmd/go":      {Primary: []owners.Owner{{GitHubUsername: "golang/tools-team"}}},
+	"go/src/fmt":         {Primary: []owners.Owner{{GitHubUsername: "maintainer", GerritEmail: "maintainer@golang.org"}}},
+	"go/src/net/http":    {Primary: []owners.Owner{{GitHubUsername: "gopher"}}},
+}
+
+// serveFakeOwners serves the dev.golang.org owners API with the
+// ownerEntries.
+func serveFakeOwners(w http.ResponseWriter, r *http.Request) {
+	var req owners.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var resp owners.Response
+	if req.Payload.All {
+		resp.Payload.Entries = ownerEntries
+	} else {
+		// Like the real API, answer with the entry of the deepest
+		// directory containing each path.
+		resp.Payload.Entries = make(map[string]*owners.Entry)
+		for _, path := range req.Payload.Paths {
+			var dir string
+			for d := range ownerEntries {
+				if strings.HasPrefix(path, d+"/") && len(d) > len(dir) {
+					dir = d
+				}
+			}
+			resp.Payload.Entries[path] = ownerEntries[dir]
+		}
+	}
+	json.NewEncoder(w).Encode(&resp)
+}
+
+// fakeMaintner serves the maintner API with Go 1.20 and 1.19 as the
+// supported releases.
+type fakeMaintner struct {
+	apipb.MaintnerServiceClient
+}
+
+func (fakeMaintner) ListGoReleases(context.Context, *apipb.ListGoReleasesRequest, ...grpc.CallOption) (*apipb.ListGoReleasesResponse, error) {
+	return &apipb.ListGoReleasesResponse{Releases: []*apipb.GoRelease{
+		{Major: 1, Minor: 20, Patch: 5, TagName: "go1.20.5", BranchName: "release-branch.go1.20"},
+		{Major: 1, Minor: 19, Patch: 10, TagName: "go1.19.10", BranchName: "release-branch.go1.19"},
+	}}, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/build/cmd/gopherbot/internal/rules"
+	"golang.org/x/build/devapp/owners"
+	"golang.org/x/build/maintner"
+)
+
+// defaultRules are the issue rules used unless --rules is set.
+//
+//go:embed rules.txt
+var defaultRules string
+
+// ruleFields are the fields of issues that rules can match, as set by
+// issueRecord. They're documented in rules.txt.
+var ruleFields = []string{"repo", "title", "body", "author", "label", "milestone", "dir", "owner"}
+
+// loadRules parses the issue rules of the --rules file, or the
+// built-in ones.
+func loadRules() (*rules.Script, error) {
+	file, text := "rules.txt", defaultRules
+	if *rulesFile != "" {
+		data, err := os.ReadFile(*rulesFile)
+		if err != nil {
+			return nil, err
+		}
+		file, text = *rulesFile, string(data)
+	}
+	s, errs := rules.Parse(file, text, ruleFields)
+	if len(errs) > 0 {
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return nil, fmt.Errorf("parsing issue rules:\n%s", strings.Join(msgs, "\n"))
+	}
+	return s, nil
+}
+
+// errNoOpenMilestone is returned by applyRule for a milestone rule whose
+// milestone isn't open in the repo of the issue.
+var errNoOpenMilestone = errors.New("no open milestone")
+
+// applyRules applies the issue rules to the open issues of the repos
+// that gopherbot gardens. Rules whose milestone isn't open in a repo
+// are skipped there, so that they don't stop the other rules.
+func (b *gopherbot) applyRules(ctx context.Context) error {
+	var dirOwners map[string][]string
+	if b.rules.Uses("owner") {
+		var err error
+		dirOwners, err = primaryOwnersByDir(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return b.corpus.GitHub().ForeachRepo(func(repo *maintner.GitHubRepo) error {
+		if !gardenIssues(repo) {
+			return nil
+		}
+		skipped := make(map[*rules.Rule]bool) // rules without an open milestone in repo
+		return b.foreachIssue(repo, open, func(gi *maintner.GitHubIssue) error {
+			for _, r := range b.issueRules(repo, gi, dirOwners) {
+				if skipped[r] {
+					continue
+				}
+				err := b.applyRule(ctx, repo, gi, r)
+				if errors.Is(err, errNoOpenMilestone) {
+					log.Printf("%s:%d: skipping rule in %v: %v", b.rules.File, r.Line, repo.ID(), err)
+					skipped[r] = true
+					continue
+				} else if err != nil {
+					return fmt.Errorf("%s:%d: %v#%d: %v", b.rules.File, r.Line, repo.ID(), gi.Number, err)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// issueRules returns the rules to apply to the issue gi: those
+// matching it, except for the milestone rules after the first.
+// dirOwners is as for issueRecord.
+func (b *gopherbot) issueRules(repo *maintner.GitHubRepo, gi *maintner.GitHubIssue, dirOwners map[string][]string) []*rules.Rule {
+	var rs []*rules.Rule
+	milestoned := false
+	for _, r := range b.rules.Match(issueRecord(repo, gi, dirOwners)) {
+		if r.Action == "milestone" {
+			if milestoned {
+				continue
+			}
+			milestoned = true
+		}
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+// applyRule applies the action of rule r to the issue gi, unless that
+// would undo the work of a human.
+func (b *gopherbot) applyRule(ctx context.Context, repo *maintner.GitHubRepo, gi *maintner.GitHubIssue, r *rules.Rule) error {
+	switch r.Action {
+	case "label":
+		if gi.HasLabel(r.Arg) || gi.HasEvent("unlabeled") {
+			return nil
+		}
+		return b.addLabel(ctx, repo.ID(), gi, r.Arg)
+	case "milestone":
+		if !gi.Milestone.IsNone() || gi.HasEvent("milestoned") || gi.HasEvent("demilestoned") {
+			return nil
+		}
+		m, ok := openMilestone(repo, r.Arg)
+		if !ok {
+			return fmt.Errorf("%w %q", errNoOpenMilestone, r.Arg)
+		}
+		return b.setMilestone(ctx, repo.ID(), gi, m)
+	case "comment":
+		printIssue("comment", repo.ID(), gi)
+		return b.addGitHubComment(ctx, repo, gi.Number, r.Arg)
+	case "close":
+		if gi.HasEvent("reopened") {
+			return nil
+		}
+		printIssue("close", repo.ID(), gi)
+		return b.closeGitHubIssue(ctx, repo.ID(), gi.Number)
+	}
+	return fmt.Errorf("unknown action %q", r.Action)
+}
+
+// issueRecord returns the fields of gi for matching issue rules.
+// dirOwners maps package paths as used in issue titles to the GitHub
+// usernames of their primary owners.
+func issueRecord(repo *maintner.GitHubRepo, gi *maintner.GitHubIssue, dirOwners map[string][]string) rules.Record {
+	r := rules.Record{
+		"repo":  {repo.ID().String()},
+		"title": {gi.Title},
+		"body":  {gi.Body},
+		"dir":   titleDirs(gi.Title),
+	}
+	if gi.User != nil {
+		r["author"] = []string{gi.User.Login}
+	}
+	for _, l := range gi.Labels {
+		r["label"] = append(r["label"], l.Name)
+	}
+	sort.Strings(r["label"])
+	if !gi.Milestone.IsNone() && !gi.Milestone.IsUnknown() {
+		r["milestone"] = []string{gi.Milestone.Title}
+	}
+	for _, dir := range r["dir"] {
+		r["owner"] = append(r["owner"], dirOwners[dir]...)
+	}
+	return r
+}
+
+// titleDirs returns the comma-separated package paths before the colon
+// in an issue title, like "cmd/go" and "cmd/link" for
+// "cmd/go, cmd/link: bad flags".
+func titleDirs(title string) []string {
+	components := strings.SplitN(title, ":", 2)
+	if len(components) != 2 {
+		return nil
+	}
+	var dirs []string
+	for _, p := range strings.Split(strings.TrimSpace(components[0]), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			dirs = append(dirs, p)
+		}
+	}
+	return dirs
+}
+
+// primaryOwnersByDir returns the GitHub usernames of the primary owners
+// of each package, keyed by the package path as used in issue titles.
+func primaryOwnersByDir(ctx context.Context) (map[string][]string, error) {
+	entries, err := getAllCodeOwners(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]string)
+	for pkg, entry := range entries {
+		dir := owners.TranslatePathForIssues(pkg)
+		for _, owner := range entry.Primary {
+			if owner.GitHubUsername != "" {
+				m[dir] = append(m[dir], owner.GitHubUsername)
+			}
+		}
+	}
+	return m, nil
+}
+
+// openMilestone returns the open milestone of repo with the given title.
+func openMilestone(repo *maintner.GitHubRepo, title string) (m milestone, ok bool) {
+	repo.ForeachMilestone(func(gm *maintner.GitHubMilestone) error {
+		if gm.Title == title && !gm.Closed {
+			m, ok = milestone{int(gm.Number), gm.Title}, true
+			return errStopIteration
+		}
+		return nil
+	})
+	return m, ok
+}
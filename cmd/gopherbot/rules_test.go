@@ -0,0 +1,169 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/cmd/gopherbot/internal/rules"
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// newTestCorpus returns a corpus holding the mutations muts.
+func newTestCorpus(t *testing.T, muts []*maintpb.Mutation) *maintner.Corpus {
+	t.Helper()
+	c := new(maintner.Corpus)
+	if err := c.Initialize(context.Background(), &replaySource{muts: muts, split: len(muts)}); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// TestDefaultRules checks that the built-in rules label and milestone
+// issues as the tasks they replaced did.
+func TestDefaultRules(t *testing.T) {
+	s, err := loadRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &gopherbot{rules: s}
+
+	titles := []string{
+		"x/build/cmd/coordinator: flaky builder",
+		"x/tools/gopls: crash on save",
+		"x/vuln: wrong module version",
+		"x/net/http2: data race",
+		"x/net/http2/h2c: bad upgrade",
+		"cmd/compile, cmd/go: ICE",
+		"x/sys/unix: gccgo build fails",
+		"proxy.golang.org: 404 for module",
+		"x/website: broken link",
+		"x/pkgsite: slow search",
+		"x/mobile: gomobile bind fails",
+		"net/http: timeout",
+		"x/vgo: bad version",
+	}
+	created, _ := ptypes.TimestampProto(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	var muts []*maintpb.Mutation
+	for _, repo := range []string{"go", "vscode-go"} {
+		muts = append(muts, &maintpb.Mutation{Github: &maintpb.GithubMutation{Owner: "golang", Repo: repo, Milestones: []*maintpb.GithubMilestone{
+			{Id: 1, Number: 22, Title: "Unreleased"},
+			{Id: 2, Number: 23, Title: "Gccgo"},
+			{Id: 3, Number: 288, Title: "vuln/unplanned"},
+			{Id: 4, Number: 71, Title: "vgo"},
+		}}})
+		for i, title := range titles {
+			muts = append(muts, &maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+				Owner: "golang", Repo: repo, Number: int32(i + 1), Id: int64(i + 1), Title: title,
+				Created: created, Updated: created,
+			}})
+		}
+	}
+	corpus := newTestCorpus(t, muts)
+	dirOwners := map[string][]string{
+		"cmd/compile": {"golang/compiler"},
+		"cmd/go":      {"gopher"},
+	}
+
+	want := map[int32][]string{
+		1:  {`label "Builders"`, `milestone "Unreleased"`},
+		2:  {`label "Tools"`, `milestone "Unreleased"`},
+		3:  {`label "vulncheck or vulndb"`, `milestone "vuln/unplanned"`},
+		4:  nil,
+		5:  {`milestone "Unreleased"`},
+		6:  {`label "compiler/runtime"`},
+		7:  {`milestone "Gccgo"`},
+		8:  {`label "proxy.golang.org"`},
+		9:  {`label "website"`, `milestone "Unreleased"`},
+		10: {`label "pkgsite"`, `milestone "Unreleased"`},
+		11: {`label "mobile"`, `milestone "Unreleased"`},
+		12: nil,
+		13: {`milestone "vgo"`},
+	}
+	for _, repo := range []string{"go", "vscode-go"} {
+		gr := corpus.GitHub().Repo("golang", repo)
+		got := map[int32][]string{}
+		gr.ForeachIssue(func(gi *maintner.GitHubIssue) error {
+			got[gi.Number] = nil
+			for _, r := range b.issueRules(gr, gi, dirOwners) {
+				got[gi.Number] = append(got[gi.Number], fmt.Sprintf("%s %q", r.Action, r.Arg))
+			}
+			return nil
+		})
+		wantRepo := want
+		if repo != "go" {
+			// The rules are all for the main repo.
+			wantRepo = map[int32][]string{}
+			for num := range want {
+				wantRepo[num] = nil
+			}
+		}
+		if diff := cmp.Diff(wantRepo, got); diff != "" {
+			t.Errorf("golang/%s rules differ: (-want, +got)\n%s", repo, diff)
+		}
+		if m, ok := openMilestone(gr, "Unreleased"); !ok || m.Number != 22 {
+			t.Errorf("openMilestone(golang/%s, Unreleased) = %v, %v; want 22", repo, m, ok)
+		}
+	}
+}
+
+func TestTitleDirs(t *testing.T) {
+	for _, tt := range []struct {
+		title string
+		want  []string
+	}{
+		{"cmd/go: bad flag", []string{"cmd/go"}},
+		{"cmd/go, cmd/link : bad flags", []string{"cmd/go", "cmd/link"}},
+		{"x/tools/gopls: crash: again", []string{"x/tools/gopls"}},
+		{"no package", nil},
+	} {
+		if got := titleDirs(tt.title); !cmp.Equal(got, tt.want) {
+			t.Errorf("titleDirs(%q) = %q; want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+// TestApplyRulesMissingMilestone checks that a rule whose milestone
+// isn't open is skipped without stopping the other rules.
+func TestApplyRulesMissingMilestone(t *testing.T) {
+	defer func(old bool) { *dryRun = old }(*dryRun)
+	defer func(old string) { lastTask = old }(lastTask)
+	*dryRun, lastTask = true, ""
+
+	s, errs := rules.Parse("test.txt", `
+milestone "Go1.99" <- title ~ `+"`^x/net`"+`
+label "NeedsFix" <- title ~ `+"`^x/net`"+`
+`, ruleFields)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	created, _ := ptypes.TimestampProto(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	var muts []*maintpb.Mutation
+	for i, title := range []string{"x/net/http2: data race", "x/net/html: bad parse"} {
+		muts = append(muts, &maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{
+			Owner: "golang", Repo: "go", Number: int32(i + 1), Id: int64(i + 1), Title: title,
+			Created: created, Updated: created,
+		}})
+	}
+	b := &gopherbot{rules: s, deletedIssues: map[githubIssue]bool{}}
+	if err := b.setCorpus(newTestCorpus(t, muts)); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := captureStdout(t, func() error { return b.applyRules(context.Background()) })
+	if err != nil {
+		t.Fatalf("applyRules: %v", err)
+	}
+	want := "label-NeedsFix [dry-run]\n\thttps://go.dev/issue/1  x/net/http2: data race\n\thttps://go.dev/issue/2  x/net/html: bad parse\n"
+	if out != want {
+		t.Errorf("applyRules printed:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -133,7 +133,10 @@ func main() {
 		log.Fatal(err)
 	}
 
-	corpus, err := godata.Get(context.Background())
+	corpus, err := godata.GetFiltered(context.Background(), maintner.Filter{
+		GitHubRepos: []maintner.GitHubRepoID{{Owner: "golang", Repo: "go"}},
+		GitRepos:    []string{},
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -7,6 +7,7 @@
 package dashboard
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sort"
@@ -594,42 +595,51 @@ func gh(githubUsername string) *gophers.Person {
 
 func init() {
 	for key, c := range Hosts {
-		if key == "" {
-			panic("empty string key in Hosts")
+		if err := initHost(key, c); err != nil {
+			panic(err)
 		}
-		if c.HostType == "" {
-			c.HostType = key
-		}
-		if c.HostType != key {
-			panic(fmt.Sprintf("HostType %q != key %q", c.HostType, key))
-		}
-		if c.HostArch == "" {
-			f := strings.Split(c.HostType, "-")
-			if len(f) < 3 {
-				panic(fmt.Sprintf("invalid HostType %q", c.HostType))
-			}
-			c.HostArch = f[1] + "-" + f[2] // "linux-amd64"
-			if f[2] == "arm" {
-				c.HostArch += "-7" // assume newer ARM
-			}
-		}
-		if c.GoBootstrap == "" {
-			c.GoBootstrap = GoBootstrap
-		}
-		nSet := 0
-		if c.VMImage != "" {
-			nSet++
-		}
-		if c.ContainerImage != "" && !c.isEC2 {
-			nSet++
-		}
-		if c.IsReverse {
-			nSet++
+	}
+}
+
+// initHost sets the defaults of the fields of c, the host config of
+// key in a hosts map, and checks that it's valid.
+func initHost(key string, c *HostConfig) error {
+	if key == "" {
+		return errors.New("empty string key in Hosts")
+	}
+	if c.HostType == "" {
+		c.HostType = key
+	}
+	if c.HostType != key {
+		return fmt.Errorf("HostType %q != key %q", c.HostType, key)
+	}
+	if c.HostArch == "" {
+		f := strings.Split(c.HostType, "-")
+		if len(f) < 3 {
+			return fmt.Errorf("invalid HostType %q", c.HostType)
 		}
-		if nSet != 1 {
-			panic(fmt.Sprintf("exactly one of VMImage, ContainerImage, IsReverse must be set for host %q; got %v", key, nSet))
+		c.HostArch = f[1] + "-" + f[2] // "linux-amd64"
+		if f[2] == "arm" {
+			c.HostArch += "-7" // assume newer ARM
 		}
 	}
+	if c.GoBootstrap == "" {
+		c.GoBootstrap = GoBootstrap
+	}
+	nSet := 0
+	if c.VMImage != "" {
+		nSet++
+	}
+	if c.ContainerImage != "" && !c.isEC2 {
+		nSet++
+	}
+	if c.IsReverse {
+		nSet++
+	}
+	if nSet != 1 {
+		return fmt.Errorf("exactly one of VMImage, ContainerImage, IsReverse must be set for host %q; got %v", key, nSet)
+	}
+	return nil
 }
 
 // CosArch defines the diffrent COS images types used.
@@ -876,6 +886,7 @@ type BuildConfig struct {
 	allScriptArgs  []string // extra args to pass to the all.bash-equivalent script
 
 	testHostConf *HostConfig // override HostConfig for testing, at least for now
+	hostConf     *HostConfig // if non-nil, the host from the config read by LoadConfig
 
 	// isRestricted marks if a builder should be restricted to a subset of users.
 	isRestricted bool
@@ -1002,6 +1013,9 @@ func (c *BuildConfig) HostConfig() *HostConfig {
 	if c.testHostConf != nil {
 		return c.testHostConf
 	}
+	if c.hostConf != nil {
+		return c.hostConf
+	}
 	if c, ok := Hosts[c.HostType]; ok {
 		return c
 	}
@@ -3173,41 +3187,50 @@ func init() {
 
 // addBuilder adds c to the Builders map after doing some checks.
 func addBuilder(c BuildConfig) {
-	if c.Name == "" {
-		panic("empty name")
-	}
-	if c.HostType == "" {
-		panic(fmt.Sprintf("missing HostType for builder %q", c.Name))
+	if err := checkBuilder(&c, Hosts); err != nil {
+		panic(err)
 	}
 	if _, dup := Builders[c.Name]; dup {
 		panic("dup name " + c.Name)
 	}
-	if c.HostConfig().GoogleReverse && !c.IsReverse() {
-		panic("GoogleReverse is set but the builder isn't reverse")
+	Builders[c.Name] = &c
+}
+
+// checkBuilder checks that the builder c is valid and consistent with
+// its host config in hosts.
+func checkBuilder(c *BuildConfig, hosts map[string]*HostConfig) error {
+	if c.Name == "" {
+		return errors.New("empty name")
+	}
+	if c.HostType == "" {
+		return fmt.Errorf("missing HostType for builder %q", c.Name)
 	}
-	if _, ok := Hosts[c.HostType]; !ok {
-		panic(fmt.Sprintf("undefined HostType %q for builder %q", c.HostType, c.Name))
+	hc, ok := hosts[c.HostType]
+	if !ok {
+		return fmt.Errorf("undefined HostType %q for builder %q", c.HostType, c.Name)
+	}
+	if hc.GoogleReverse && !hc.IsReverse {
+		return errors.New("GoogleReverse is set but the builder isn't reverse")
 	}
 	if c.SkipSnapshot && (c.numTestHelpers > 0 || c.numTryTestHelpers > 0) {
-		panic(fmt.Sprintf("config %q's SkipSnapshot is not compatible with sharded test helpers", c.Name))
+		return fmt.Errorf("config %q's SkipSnapshot is not compatible with sharded test helpers", c.Name)
 	}
 	for i, issue := range c.KnownIssues {
 		if issue == 0 {
-			panic(fmt.Errorf("config %q's KnownIssues slice has a zero issue at index %d", c.Name, i))
+			return fmt.Errorf("config %q's KnownIssues slice has a zero issue at index %d", c.Name, i)
 		}
 	}
 
 	types := 0
-	for _, fn := range []func() bool{c.IsReverse, c.IsContainer, c.IsVM} {
-		if fn() {
+	for _, b := range []bool{hc.IsReverse, hc.IsContainer(), hc.IsVM()} {
+		if b {
 			types++
 		}
 	}
 	if types != 1 {
-		panic(fmt.Sprintf("build config %q host type inconsistent (must be Reverse, Image, or VM)", c.Name))
+		return fmt.Errorf("build config %q host type inconsistent (must be Reverse, Image, or VM)", c.Name)
 	}
-
-	Builders[c.Name] = &c
+	return nil
 }
 
 // tryNewMiscCompile is an intermediate step towards adding a real addMiscCompile TryBot.
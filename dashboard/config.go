@@ -0,0 +1,542 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/build/internal/gophers"
+	"golang.org/x/build/types"
+)
+
+// This file implements the declarative form of the Hosts and Builders
+// maps, in which the policy functions of builders are expressed as data.
+//
+// builders.json is the current configuration in that form. Until the
+// Go definitions in builders.go are removed, it's generated from them
+// with "go test -run=TestConfig -update", and TestConfig checks that
+// LoadConfig turns it back into the same Hosts and Builders.
+
+// A Config is the declarative form of the Hosts and Builders maps,
+// as read by LoadConfig.
+type Config struct {
+	// RepoPolicies are named repo policies, which the BuildsRepo and
+	// TryBot policies of builders may refer to.
+	RepoPolicies map[string][]RepoRule `json:"repoPolicies,omitempty"`
+	// TestPolicies are named dist test policies, which the DistTests
+	// policies of builders may refer to.
+	TestPolicies map[string]*TestRules `json:"testPolicies,omitempty"`
+
+	Hosts    []*HostSpec    `json:"hosts"`
+	Builders []*BuilderSpec `json:"builders"`
+}
+
+// A HostSpec is the declarative form of a HostConfig. Its fields are
+// those of HostConfig; see there for their meaning.
+type HostSpec struct {
+	HostType            string   `json:"hostType"`
+	HostArch            string   `json:"hostArch,omitempty"`
+	GoBootstrap         string   `json:"goBootstrap,omitempty"`
+	VMImage             string   `json:"vmImage,omitempty"`
+	ContainerImage      string   `json:"containerImage,omitempty"`
+	IsReverse           bool     `json:"isReverse,omitempty"`
+	MachineType         string   `json:"machineType,omitempty"`
+	RegularDisk         bool     `json:"regularDisk,omitempty"`
+	MinCPUPlatform      string   `json:"minCPUPlatform,omitempty"`
+	CosArchitecture     CosArch  `json:"cosArchitecture,omitempty"`
+	IsEC2               bool     `json:"isEC2,omitempty"`
+	CustomDeleteTimeout string   `json:"customDeleteTimeout,omitempty"` // in time.ParseDuration form
+	ExpectNum           int      `json:"expectNum,omitempty"`
+	HermeticReverse     bool     `json:"hermeticReverse,omitempty"`
+	GoogleReverse       bool     `json:"googleReverse,omitempty"`
+	NestedVirt          bool     `json:"nestedVirt,omitempty"`
+	KonletVMImage       string   `json:"konletVMImage,omitempty"`
+	Env                 []string `json:"env,omitempty"`
+	Owners              []string `json:"owners,omitempty"` // GitHub usernames
+	Notes               string   `json:"notes,omitempty"`
+	SSHUsername         string   `json:"sshUsername,omitempty"`
+	RootDriveSizeGB     int64    `json:"rootDriveSizeGB,omitempty"`
+}
+
+// A BuilderSpec is the declarative form of a BuildConfig. Its fields
+// are those of BuildConfig; see there for their meaning.
+type BuilderSpec struct {
+	Name        string `json:"name"`
+	HostType    string `json:"hostType"`
+	KnownIssues []int  `json:"knownIssues,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+
+	// BuildsRepo is the policy of which repos and branches are built.
+	// If nil, the repos built by default are built on all branches.
+	BuildsRepo *RepoPolicy `json:"buildsRepo,omitempty"`
+	// TryBot is the policy of which repos and branches the builder
+	// is a trybot for. If nil, it's not a trybot.
+	TryBot *RepoPolicy `json:"tryBot,omitempty"`
+	// DistTests adjusts the default cmd/dist test policy. If nil,
+	// the default policy is used.
+	DistTests *TestPolicy `json:"distTests,omitempty"`
+
+	TryOnly             bool     `json:"tryOnly,omitempty"`
+	CompileOnly         bool     `json:"compileOnly,omitempty"`
+	FlakyNet            bool     `json:"flakyNet,omitempty"`
+	RunBench            bool     `json:"runBench,omitempty"`
+	MinimumGoVersion    string   `json:"minimumGoVersion,omitempty"` // such as "go1.20"
+	SkipSnapshot        bool     `json:"skipSnapshot,omitempty"`
+	StopAfterMake       bool     `json:"stopAfterMake,omitempty"`
+	PrivateGoProxy      bool     `json:"privateGoProxy,omitempty"`
+	InstallRacePackages []string `json:"installRacePackages,omitempty"`
+	GoDeps              []string `json:"goDeps,omitempty"`
+	NumTestHelpers      int      `json:"numTestHelpers,omitempty"`
+	NumTryTestHelpers   int      `json:"numTryTestHelpers,omitempty"`
+	Env                 []string `json:"env,omitempty"`
+	MakeScriptArgs      []string `json:"makeScriptArgs,omitempty"`
+	AllScriptArgs       []string `json:"allScriptArgs,omitempty"`
+	IsRestricted        bool     `json:"isRestricted,omitempty"`
+}
+
+// A RepoPolicy is the declarative form of a buildsRepo or tryBot
+// policy function. In JSON, it's either the name of a policy in
+// Config.RepoPolicies, or a list of rules.
+type RepoPolicy struct {
+	Name  string     // name of a policy in Config.RepoPolicies
+	Rules []RepoRule // if Name is empty
+}
+
+func (p RepoPolicy) MarshalJSON() ([]byte, error) {
+	if p.Name != "" {
+		return json.Marshal(p.Name)
+	}
+	if p.Rules == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p.Rules)
+}
+
+func (p *RepoPolicy) UnmarshalJSON(b []byte) error {
+	*p = RepoPolicy{}
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		return json.Unmarshal(b, &p.Name)
+	}
+	return unmarshalStrict(b, &p.Rules)
+}
+
+// A RepoRule is a rule of a repo policy.
+//
+// The first rule of a policy whose Repos include a repo decides
+// whether that repo is built: it is if the rule isn't Skip and the
+// branches satisfy all of its predicates. Repos which no rule includes
+// aren't built.
+type RepoRule struct {
+	// Repos are the repos ("go", "net", etc.) the rule is for.
+	// "default" stands for the repos built by default, as reported
+	// by buildRepoByDefault, and "*" stands for all repos.
+	Repos []string `json:"repos"`
+
+	// Skip means the repos aren't built.
+	Skip bool `json:"skip,omitempty"`
+
+	// Branches and GoBranches, if non-empty, are patterns one of which
+	// the branch of the repo or the Go branch, respectively, must
+	// match. A '*' in a pattern matches any string.
+	Branches   []string `json:"branches,omitempty"`
+	GoBranches []string `json:"goBranches,omitempty"`
+
+	// MinGo, if non-zero, is the oldest Go 1.N release branch the Go
+	// branch may be. The master and dev.* branches are newer than all
+	// releases. MaxGo, if non-zero, is the newest Go 1.N release
+	// branch the Go branch may be; it excludes all other branches.
+	MinGo int `json:"minGo,omitempty"`
+	MaxGo int `json:"maxGo,omitempty"`
+}
+
+// A TestPolicy is the declarative form of a distTestAdjust policy
+// function. In JSON, it's either the name of a policy in
+// Config.TestPolicies, or a TestRules object.
+type TestPolicy struct {
+	Name  string     // name of a policy in Config.TestPolicies
+	Rules *TestRules // if Name is empty
+}
+
+func (p TestPolicy) MarshalJSON() ([]byte, error) {
+	if p.Name != "" {
+		return json.Marshal(p.Name)
+	}
+	return json.Marshal(p.Rules)
+}
+
+func (p *TestPolicy) UnmarshalJSON(b []byte) error {
+	*p = TestPolicy{}
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		return json.Unmarshal(b, &p.Name)
+	}
+	return unmarshalStrict(b, &p.Rules)
+}
+
+// TestRules adjust the default cmd/dist test policy by skipping tests.
+// A '*' in a pattern matches any string.
+type TestRules struct {
+	Skip    []string `json:"skip,omitempty"`    // patterns of tests which aren't run
+	SkipTry []string `json:"skipTry,omitempty"` // patterns of tests which normal trybots don't run
+}
+
+// LoadConfig reads a configuration in the JSON form of Config from r
+// and returns its hosts and builders, in the form of the Hosts and
+// Builders maps. It returns an error if the configuration is
+// inconsistent, such as a builder of an undefined host type or a
+// policy rule which can never apply.
+//
+// The HostConfig method of the returned builders returns their host
+// from the returned hosts, so a configuration may define new hosts or
+// redefine those of the Hosts map.
+func LoadConfig(r io.Reader) (hosts map[string]*HostConfig, builders map[string]*BuildConfig, err error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cfg Config
+	if err := unmarshalStrict(b, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("decoding config: %v", err)
+	}
+	return cfg.load()
+}
+
+// unmarshalStrict is like json.Unmarshal, but fails on unknown fields.
+func unmarshalStrict(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
+
+func (cfg *Config) load() (map[string]*HostConfig, map[string]*BuildConfig, error) {
+	for name, rules := range cfg.RepoPolicies {
+		if err := checkRepoRules(rules); err != nil {
+			return nil, nil, fmt.Errorf("repo policy %q: %v", name, err)
+		}
+	}
+	for name, tr := range cfg.TestPolicies {
+		if err := checkTestRules(tr); err != nil {
+			return nil, nil, fmt.Errorf("test policy %q: %v", name, err)
+		}
+	}
+
+	hosts := make(map[string]*HostConfig)
+	for _, hs := range cfg.Hosts {
+		hc, err := hs.hostConfig()
+		if err != nil {
+			return nil, nil, fmt.Errorf("host %q: %v", hs.HostType, err)
+		}
+		if _, dup := hosts[hc.HostType]; dup {
+			return nil, nil, fmt.Errorf("duplicate host %q", hc.HostType)
+		}
+		hosts[hc.HostType] = hc
+	}
+
+	builders := make(map[string]*BuildConfig)
+	for _, bs := range cfg.Builders {
+		c, err := cfg.buildConfig(bs)
+		if err == nil {
+			err = checkBuilder(c, hosts)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("builder %q: %v", bs.Name, err)
+		}
+		if _, dup := builders[c.Name]; dup {
+			return nil, nil, fmt.Errorf("duplicate builder %q", c.Name)
+		}
+		c.hostConf = hosts[c.HostType]
+		builders[c.Name] = c
+	}
+	return hosts, builders, nil
+}
+
+func (hs *HostSpec) hostConfig() (*HostConfig, error) {
+	hc := &HostConfig{
+		HostType:        hs.HostType,
+		HostArch:        hs.HostArch,
+		GoBootstrap:     hs.GoBootstrap,
+		VMImage:         hs.VMImage,
+		ContainerImage:  hs.ContainerImage,
+		IsReverse:       hs.IsReverse,
+		machineType:     hs.MachineType,
+		RegularDisk:     hs.RegularDisk,
+		MinCPUPlatform:  hs.MinCPUPlatform,
+		cosArchitecture: hs.CosArchitecture,
+		isEC2:           hs.IsEC2,
+		ExpectNum:       hs.ExpectNum,
+		HermeticReverse: hs.HermeticReverse,
+		GoogleReverse:   hs.GoogleReverse,
+		NestedVirt:      hs.NestedVirt,
+		KonletVMImage:   hs.KonletVMImage,
+		env:             hs.Env,
+		Notes:           hs.Notes,
+		SSHUsername:     hs.SSHUsername,
+		RootDriveSizeGB: hs.RootDriveSizeGB,
+	}
+	if hs.HostType == "" {
+		return nil, errors.New("missing hostType")
+	}
+	switch hs.CosArchitecture {
+	case "", CosArchAMD64, CosArchARM64:
+	default:
+		return nil, fmt.Errorf("unknown cosArchitecture %q", hs.CosArchitecture)
+	}
+	if hs.CustomDeleteTimeout != "" {
+		d, err := time.ParseDuration(hs.CustomDeleteTimeout)
+		if err != nil {
+			return nil, err
+		}
+		hc.CustomDeleteTimeout = d
+	}
+	if hs.ExpectNum != 0 && !hs.IsReverse {
+		return nil, errors.New("expectNum is set but the host isn't reverse")
+	}
+	for _, u := range hs.Owners {
+		p := gophers.GetPerson("@" + u)
+		if p == nil {
+			return nil, fmt.Errorf("owner with GitHub username %q does not exist in the golang.org/x/build/internal/gophers package", u)
+		}
+		hc.Owners = append(hc.Owners, p)
+	}
+	if err := initHost(hs.HostType, hc); err != nil {
+		return nil, err
+	}
+	return hc, nil
+}
+
+func (cfg *Config) buildConfig(bs *BuilderSpec) (*BuildConfig, error) {
+	c := &BuildConfig{
+		Name:                bs.Name,
+		HostType:            bs.HostType,
+		KnownIssues:         bs.KnownIssues,
+		Notes:               bs.Notes,
+		tryOnly:             bs.TryOnly,
+		CompileOnly:         bs.CompileOnly,
+		FlakyNet:            bs.FlakyNet,
+		RunBench:            bs.RunBench,
+		SkipSnapshot:        bs.SkipSnapshot,
+		StopAfterMake:       bs.StopAfterMake,
+		privateGoProxy:      bs.PrivateGoProxy,
+		InstallRacePackages: bs.InstallRacePackages,
+		GoDeps:              bs.GoDeps,
+		numTestHelpers:      bs.NumTestHelpers,
+		numTryTestHelpers:   bs.NumTryTestHelpers,
+		env:                 bs.Env,
+		makeScriptArgs:      bs.MakeScriptArgs,
+		allScriptArgs:       bs.AllScriptArgs,
+		isRestricted:        bs.IsRestricted,
+	}
+	if bs.MinimumGoVersion != "" {
+		var v types.MajorMinor
+		if n, err := fmt.Sscanf(bs.MinimumGoVersion, "go%d.%d", &v.Major, &v.Minor); err != nil || n != 2 || fmt.Sprintf("go%d.%d", v.Major, v.Minor) != bs.MinimumGoVersion {
+			return nil, fmt.Errorf("minimumGoVersion %q is not of the form go1.N", bs.MinimumGoVersion)
+		}
+		c.MinimumGoVersion = v
+	}
+	var err error
+	if bs.BuildsRepo != nil {
+		if c.buildsRepo, err = cfg.repoPolicyFunc(bs.BuildsRepo); err != nil {
+			return nil, fmt.Errorf("buildsRepo: %v", err)
+		}
+	}
+	if bs.TryBot != nil {
+		if c.tryBot, err = cfg.repoPolicyFunc(bs.TryBot); err != nil {
+			return nil, fmt.Errorf("tryBot: %v", err)
+		}
+	}
+	if bs.DistTests != nil {
+		if c.distTestAdjust, err = cfg.testPolicyFunc(bs.DistTests); err != nil {
+			return nil, fmt.Errorf("distTests: %v", err)
+		}
+	}
+	return c, nil
+}
+
+// repoPolicyFunc returns the policy function of p.
+func (cfg *Config) repoPolicyFunc(p *RepoPolicy) (func(repo, branch, goBranch string) bool, error) {
+	rules := p.Rules
+	if p.Name != "" {
+		if p.Rules != nil {
+			return nil, fmt.Errorf("policy %q also has rules", p.Name)
+		}
+		var ok bool
+		if rules, ok = cfg.RepoPolicies[p.Name]; !ok {
+			return nil, fmt.Errorf("undefined repo policy %q", p.Name)
+		}
+	} else if err := checkRepoRules(rules); err != nil {
+		return nil, err
+	}
+	return func(repo, branch, goBranch string) bool {
+		for _, r := range rules {
+			if r.includes(repo) {
+				return !r.Skip && r.allows(branch, goBranch)
+			}
+		}
+		return false
+	}, nil
+}
+
+// testPolicyFunc returns the distTestAdjust function of p.
+func (cfg *Config) testPolicyFunc(p *TestPolicy) (func(run bool, distTest string, isNormalTry bool) bool, error) {
+	tr := p.Rules
+	if p.Name != "" {
+		if p.Rules != nil {
+			return nil, fmt.Errorf("policy %q also has rules", p.Name)
+		}
+		var ok bool
+		if tr, ok = cfg.TestPolicies[p.Name]; !ok {
+			return nil, fmt.Errorf("undefined test policy %q", p.Name)
+		}
+	} else if err := checkTestRules(tr); err != nil {
+		return nil, err
+	}
+	return func(run bool, distTest string, isNormalTry bool) bool {
+		if matchAny(tr.Skip, distTest) || isNormalTry && matchAny(tr.SkipTry, distTest) {
+			return false
+		}
+		return run
+	}, nil
+}
+
+// includes reports whether the rule is for repo.
+func (r *RepoRule) includes(repo string) bool {
+	for _, x := range r.Repos {
+		if x == repo || x == "*" || x == "default" && buildRepoByDefault(repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// allows reports whether the branches satisfy the predicates of r.
+func (r *RepoRule) allows(branch, goBranch string) bool {
+	if len(r.Branches) > 0 && !matchAny(r.Branches, branch) {
+		return false
+	}
+	if len(r.GoBranches) > 0 && !matchAny(r.GoBranches, goBranch) {
+		return false
+	}
+	if r.MinGo != 0 && !atLeastGo1(goBranch, r.MinGo) {
+		return false
+	}
+	if r.MaxGo != 0 && !atMostGo1(goBranch, r.MaxGo) {
+		return false
+	}
+	return true
+}
+
+// checkRepoRules checks that each of the rules is well formed and
+// applies to some repo.
+func checkRepoRules(rules []RepoRule) error {
+	seen := make(map[string]bool) // repos, "default" and "*" included so far
+	for i, r := range rules {
+		if len(r.Repos) == 0 {
+			return fmt.Errorf("rule %d has no repos", i)
+		}
+		if r.Skip && (len(r.Branches) > 0 || len(r.GoBranches) > 0 || r.MinGo != 0 || r.MaxGo != 0) {
+			return fmt.Errorf("rule %d skips its repos but has branch predicates", i)
+		}
+		if r.MinGo < 0 || r.MaxGo < 0 {
+			return fmt.Errorf("rule %d has a negative Go version", i)
+		}
+		if r.MinGo != 0 && r.MaxGo != 0 && r.MinGo > r.MaxGo {
+			return fmt.Errorf("rule %d has minGo %d greater than maxGo %d, so it never builds", i, r.MinGo, r.MaxGo)
+		}
+		if err := checkPatterns(r.Branches); err != nil {
+			return fmt.Errorf("rule %d branches: %v", i, err)
+		}
+		if err := checkPatterns(r.GoBranches); err != nil {
+			return fmt.Errorf("rule %d goBranches: %v", i, err)
+		}
+		for _, repo := range r.Repos {
+			switch {
+			case repo == "":
+				return fmt.Errorf("rule %d has an empty repo", i)
+			case seen[repo] || seen["*"] || seen["default"] && repo != "*" && buildRepoByDefault(repo):
+				return fmt.Errorf("repo %q of rule %d is already included", repo, i)
+			}
+			seen[repo] = true
+		}
+	}
+	return nil
+}
+
+// checkTestRules checks that the test rules are well formed.
+func checkTestRules(tr *TestRules) error {
+	if tr == nil {
+		return errors.New("missing test rules")
+	}
+	if len(tr.Skip) == 0 && len(tr.SkipTry) == 0 {
+		return errors.New("test rules don't skip any test")
+	}
+	if err := checkPatterns(tr.Skip); err != nil {
+		return fmt.Errorf("skip: %v", err)
+	}
+	if err := checkPatterns(tr.SkipTry); err != nil {
+		return fmt.Errorf("skipTry: %v", err)
+	}
+	for _, p := range tr.SkipTry {
+		if matchAny(tr.Skip, p) {
+			return fmt.Errorf("skipTry pattern %q is already skipped for all builds", p)
+		}
+	}
+	return nil
+}
+
+func checkPatterns(patterns []string) error {
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		if p == "" {
+			return errors.New("empty pattern")
+		}
+		if seen[p] {
+			return fmt.Errorf("duplicate pattern %q", p)
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
+// matchAny reports whether s matches any of the patterns.
+func matchAny(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if match(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether s matches pattern, in which '*' matches any
+// string and all other bytes match themselves.
+func match(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, p := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, p)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(p):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,758 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/build/repos"
+)
+
+var updateConfig = flag.Bool("update", false, "update builders.json from the Go definitions of Hosts and Builders")
+
+// TestConfig checks that builders.json is up to date with the Go
+// definitions of Hosts and Builders.
+func TestConfig(t *testing.T) {
+	cfg, err := genConfig()
+	if err != nil {
+		t.Fatalf("generating config: %v", err)
+	}
+	got, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+	if *updateConfig {
+		if err := os.WriteFile("builders.json", got, 0644); err != nil {
+			t.Fatalf("updating builders.json: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile("builders.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("builders.json is out of date with builders.go (-old +new):\n%s\nRun 'go test -run=TestConfig -update' to update it.", diff)
+	}
+}
+
+// TestLoadConfig checks that LoadConfig turns builders.json into the
+// same Hosts and Builders as the Go definitions.
+func TestLoadConfig(t *testing.T) {
+	f, err := os.Open("builders.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	hosts, builders, err := LoadConfig(f)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	if diff := cmp.Diff(Hosts, hosts, cmp.AllowUnexported(HostConfig{}), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("loaded hosts differ from Hosts (-want +got):\n%s", diff)
+	}
+	for name := range builders {
+		if _, ok := Builders[name]; !ok {
+			t.Errorf("loaded builder %q isn't in Builders", name)
+		}
+	}
+	withoutPolicies := func(c *BuildConfig) BuildConfig {
+		c2 := *c
+		c2.tryBot, c2.buildsRepo, c2.distTestAdjust, c2.hostConf = nil, nil, nil, nil
+		return c2
+	}
+	for name, want := range Builders {
+		got, ok := builders[name]
+		if !ok {
+			t.Errorf("builder %q wasn't loaded", name)
+			continue
+		}
+		if diff := cmp.Diff(withoutPolicies(want), withoutPolicies(got), cmp.AllowUnexported(BuildConfig{}, HostConfig{}), cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("loaded builder %q differs (-want +got):\n%s", name, diff)
+		}
+		if got.HostConfig() != hosts[got.HostType] {
+			t.Errorf("%s: loaded HostConfig isn't the loaded host %q", name, got.HostType)
+		}
+		for _, repo := range configRepos() {
+			for _, p := range checkPoints(repo) {
+				if w, g := want.BuildsRepoPostSubmit(repo, p.branch, p.goBranch), got.BuildsRepoPostSubmit(repo, p.branch, p.goBranch); w != g {
+					t.Errorf("%s: loaded BuildsRepoPostSubmit(%q, %q, %q) = %v; want %v", name, repo, p.branch, p.goBranch, g, w)
+				}
+				if w, g := want.BuildsRepoTryBot(repo, p.branch, p.goBranch), got.BuildsRepoTryBot(repo, p.branch, p.goBranch); w != g {
+					t.Errorf("%s: loaded BuildsRepoTryBot(%q, %q, %q) = %v; want %v", name, repo, p.branch, p.goBranch, g, w)
+				}
+			}
+		}
+		for _, test := range checkDistTests {
+			for _, try := range []bool{false, true} {
+				if w, g := want.ShouldRunDistTest(test, try), got.ShouldRunDistTest(test, try); w != g {
+					t.Errorf("%s: loaded ShouldRunDistTest(%q, %v) = %v; want %v", name, test, try, g, w)
+				}
+			}
+		}
+	}
+}
+
+// TestLoadConfigHosts checks that loaded builders use the hosts of
+// their config, rather than those of Hosts.
+func TestLoadConfigHosts(t *testing.T) {
+	const config = `{
+	"hosts": [
+		{"hostType": "host-linux-amd64-bullseye", "vmImage": "debian-bullseye-vmx"},
+		{"hostType": "host-linux-amd64-new", "containerImage": "linux-x86-new:latest"}
+	],
+	"builders": [
+		{"name": "linux-amd64", "hostType": "host-linux-amd64-bullseye"},
+		{"name": "linux-amd64-new", "hostType": "host-linux-amd64-new"}
+	]
+}`
+	if !Hosts["host-linux-amd64-bullseye"].IsContainer() {
+		t.Fatal("host-linux-amd64-bullseye isn't a container host in Hosts")
+	}
+	hosts, builders, err := LoadConfig(strings.NewReader(config))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if c := builders["linux-amd64"]; c.HostConfig() != hosts["host-linux-amd64-bullseye"] || !c.IsVM() {
+		t.Errorf("linux-amd64: HostConfig = %+v; want the redefined VM host", c.HostConfig())
+	}
+	if c := builders["linux-amd64-new"]; c.HostConfig() != hosts["host-linux-amd64-new"] || !c.IsContainer() || c.GOARCH() != "amd64" {
+		t.Errorf("linux-amd64-new: HostConfig = %+v; want the new container host", c.HostConfig())
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	const host = `{"hostType": "host-linux-amd64-bullseye", "containerImage": "linux-x86-bullseye:latest"}`
+	tests := []struct {
+		name   string
+		config string
+		want   string // in the error
+	}{
+		{"unknown field", `{"hosts": [], "builders": [], "bulders": []}`, "unknown field"},
+		{"dup host", `{"hosts": [` + host + `,` + host + `]}`, `duplicate host "host-linux-amd64-bullseye"`},
+		{"no image", `{"hosts": [{"hostType": "host-linux-amd64-bullseye"}]}`, "exactly one of VMImage, ContainerImage, IsReverse"},
+		{"bad owner", `{"hosts": [{"hostType": "host-linux-amd64-bullseye", "isReverse": true, "owners": ["no-such-gopher-1234"]}]}`, "does not exist"},
+		{"undefined host", `{"builders": [{"name": "linux-amd64", "hostType": "host-linux-amd64-nope"}]}`, `undefined HostType "host-linux-amd64-nope"`},
+		{"dup builder", `{"hosts": [` + host + `], "builders": [{"name": "linux-amd64", "hostType": "host-linux-amd64-bullseye"}, {"name": "linux-amd64", "hostType": "host-linux-amd64-bullseye"}]}`, `duplicate builder "linux-amd64"`},
+		{"undefined policy", `{"hosts": [` + host + `], "builders": [{"name": "linux-amd64", "hostType": "host-linux-amd64-bullseye", "buildsRepo": "nope"}]}`, `undefined repo policy "nope"`},
+		{"min above max", `{"repoPolicies": {"p": [{"repos": ["go"], "minGo": 21, "maxGo": 20}]}}`, "greater than maxGo"},
+		{"skip with predicates", `{"repoPolicies": {"p": [{"repos": ["go"], "skip": true, "branches": ["master"]}]}}`, "has branch predicates"},
+		{"unreachable repo", `{"repoPolicies": {"p": [{"repos": ["default"]}, {"repos": ["net"], "minGo": 20}]}}`, `repo "net" of rule 1 is already included`},
+		{"unreachable all", `{"repoPolicies": {"p": [{"repos": ["*"]}, {"repos": ["exp"]}]}}`, `repo "exp" of rule 1 is already included`},
+		{"no repos", `{"repoPolicies": {"p": [{"branches": ["master"]}]}}`, "has no repos"},
+		{"skipTry in skip", `{"testPolicies": {"p": {"skip": ["test:*"], "skipTry": ["test:0_5"]}}}`, "already skipped"},
+		{"bad minimumGoVersion", `{"hosts": [` + host + `], "builders": [{"name": "linux-amd64", "hostType": "host-linux-amd64-bullseye", "minimumGoVersion": "1.20"}]}`, "not of the form go1.N"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := LoadConfig(strings.NewReader(tt.config))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadConfig error = %v; want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"reboot", "reboot", true},
+		{"reboot", "reboots", false},
+		{"test:*", "test:0_5", true},
+		{"test:*", "go_test:net", false},
+		{"*/internal/*", "go_test:cmd/internal/obj", true},
+		{"*/internal/*", "go_test:internal/abi", false},
+		{"release-branch.*", "release-branch.go1.20", true},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "acb", false},
+		{"*", "", true},
+	}
+	for _, tt := range tests {
+		if got := match(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("match(%q, %q) = %v; want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
+
+// The policies of builders are compared on the following repos,
+// branches and dist tests.
+
+func configRepos() []string {
+	var rs []string
+	for proj := range repos.ByGerritProject {
+		rs = append(rs, proj)
+	}
+	sort.Strings(rs)
+	return rs
+}
+
+var configGoBranches = func() []string {
+	bs := []string{"master", "dev.boringcrypto", "dev.boringcrypto.go1.20", "dev.typeparams"}
+	for n := 10; n <= 22; n++ {
+		bs = append(bs, fmt.Sprintf("release-branch.go1.%d", n))
+	}
+	return bs
+}()
+
+var configBranches = []string{"master", "release-branch.go1.20", "gopls-release-branch.0.11", "internal-branch.go1.20-vendor"}
+
+var configDistTests = []string{
+	"api", "reboot", "test:0_5", "test:4_5", "race", "moved_goroot", "codewalk", "doc_progs", "wiki",
+	"bench_go1", "runtime:cpu124", "nolibgcc:crypto/x509", "cgo_test", "osusergo",
+	"go_test:cmd/go", "go_test:net", "go_test:runtime", "go_test:internal/abi", "go_test:cmd/internal/obj",
+	"go_test:cmd/vendor/golang.org/x/arch/x86/x86asm",
+}
+
+// The loaded policies of builders are checked on the following
+// branches and dist tests, which include many that genConfig doesn't
+// sample, so that TestLoadConfig doesn't just check builders.json
+// against the points it was generated from.
+
+var checkGoBranches = func() []string {
+	bs := []string{"master", "dev.boringcrypto", "dev.fuzz", "dev.link", "dev.regabi", "dev.typeparams", "dev.unified"}
+	for n := 4; n <= 30; n++ {
+		bs = append(bs, fmt.Sprintf("release-branch.go1.%d", n), fmt.Sprintf("dev.boringcrypto.go1.%d", n))
+	}
+	return bs
+}()
+
+var checkBranches = func() []string {
+	bs := []string{"master", "dev.go2go", "internal-branch.go1.20-vendor", "internal-branch.go1.21-vendor"}
+	for n := 16; n <= 24; n++ {
+		bs = append(bs, fmt.Sprintf("release-branch.go1.%d", n))
+	}
+	for n := 8; n <= 14; n++ {
+		bs = append(bs, fmt.Sprintf("gopls-release-branch.0.%d", n))
+	}
+	return bs
+}()
+
+var checkDistTests = func() []string {
+	ts := []string{
+		"api", "reboot", "race", "moved_goroot", "codewalk", "doc_progs", "wiki", "bench_go1",
+		"runtime:cpu124", "nolibgcc:crypto/x509", "nolibgcc:net", "cgo_test", "cgo_stdio", "cgo_life",
+		"cgo_errors", "cgo_fortran", "osusergo", "testcarchive", "testcshared", "testshared", "testplugin",
+		"testsanitizers", "cmd_go_test_terminal", "test:0_1", "go_test_bench:net", "go_test_bench:runtime",
+	}
+	for n := 1; n <= 10; n++ {
+		for i := 0; i < n; i++ {
+			ts = append(ts, fmt.Sprintf("test:%d_%d", i, n))
+		}
+	}
+	for _, pkg := range []string{
+		"archive/tar", "bufio", "bytes", "context", "crypto/internal/boring", "crypto/tls", "crypto/x509",
+		"encoding/json", "fmt", "go/types", "internal/abi", "internal/fuzz", "internal/testenv", "math/big",
+		"net", "net/http", "os", "os/exec", "reflect", "runtime", "runtime/internal/atomic", "runtime/pprof",
+		"sync", "syscall", "testing", "time",
+		"cmd/api", "cmd/asm/internal/asm", "cmd/compile/internal/ssa", "cmd/compile/internal/types2",
+		"cmd/go", "cmd/internal/moddeps", "cmd/internal/obj", "cmd/link", "cmd/vet",
+		"cmd/vendor/golang.org/x/arch/arm64/arm64asm", "cmd/vendor/golang.org/x/arch/x86/x86asm",
+		"cmd/vendor/golang.org/x/tools/go/analysis",
+	} {
+		ts = append(ts, "go_test:"+pkg)
+	}
+	return ts
+}()
+
+type configPoint struct{ branch, goBranch string }
+
+// configPoints returns the branches and Go branches of repo to compare.
+func configPoints(repo string) []configPoint {
+	var ps []configPoint
+	for _, gb := range configGoBranches {
+		if repo == "go" {
+			ps = append(ps, configPoint{gb, gb})
+			continue
+		}
+		for _, b := range configBranches {
+			ps = append(ps, configPoint{b, gb})
+		}
+	}
+	return ps
+}
+
+// checkPoints returns the branches and Go branches of repo on which
+// TestLoadConfig checks the loaded policies.
+func checkPoints(repo string) []configPoint {
+	var ps []configPoint
+	for _, gb := range checkGoBranches {
+		if repo == "go" {
+			ps = append(ps, configPoint{gb, gb})
+			continue
+		}
+		for _, b := range checkBranches {
+			ps = append(ps, configPoint{b, gb})
+		}
+	}
+	return ps
+}
+
+// genConfig returns the declarative form of Hosts and Builders.
+func genConfig() (*Config, error) {
+	cfg := &Config{
+		RepoPolicies: make(map[string][]RepoRule),
+		TestPolicies: make(map[string]*TestRules),
+	}
+	var hostTypes []string
+	for ht := range Hosts {
+		hostTypes = append(hostTypes, ht)
+	}
+	sort.Strings(hostTypes)
+	for _, ht := range hostTypes {
+		cfg.Hosts = append(cfg.Hosts, genHostSpec(Hosts[ht]))
+	}
+
+	var names []string
+	for name := range Builders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		bs, err := cfg.genBuilderSpec(Builders[name])
+		if err != nil {
+			return nil, fmt.Errorf("builder %q: %v", name, err)
+		}
+		cfg.Builders = append(cfg.Builders, bs)
+	}
+	return cfg, nil
+}
+
+func genHostSpec(hc *HostConfig) *HostSpec {
+	hs := &HostSpec{
+		HostType:        hc.HostType,
+		HostArch:        hc.HostArch,
+		GoBootstrap:     hc.GoBootstrap,
+		VMImage:         hc.VMImage,
+		ContainerImage:  hc.ContainerImage,
+		IsReverse:       hc.IsReverse,
+		MachineType:     hc.machineType,
+		RegularDisk:     hc.RegularDisk,
+		MinCPUPlatform:  hc.MinCPUPlatform,
+		CosArchitecture: hc.cosArchitecture,
+		IsEC2:           hc.isEC2,
+		ExpectNum:       hc.ExpectNum,
+		HermeticReverse: hc.HermeticReverse,
+		GoogleReverse:   hc.GoogleReverse,
+		NestedVirt:      hc.NestedVirt,
+		KonletVMImage:   hc.KonletVMImage,
+		Env:             hc.env,
+		Notes:           hc.Notes,
+		SSHUsername:     hc.SSHUsername,
+		RootDriveSizeGB: hc.RootDriveSizeGB,
+	}
+	// Leave out the values initHost sets by default.
+	dflt := &HostConfig{IsReverse: true}
+	if initHost(hc.HostType, dflt) == nil && dflt.HostArch == hc.HostArch {
+		hs.HostArch = ""
+	}
+	if hc.GoBootstrap == GoBootstrap {
+		hs.GoBootstrap = ""
+	}
+	if hc.CustomDeleteTimeout != 0 {
+		hs.CustomDeleteTimeout = hc.CustomDeleteTimeout.String()
+	}
+	for _, p := range hc.Owners {
+		hs.Owners = append(hs.Owners, p.GitHub)
+	}
+	return hs
+}
+
+func (cfg *Config) genBuilderSpec(c *BuildConfig) (*BuilderSpec, error) {
+	bs := &BuilderSpec{
+		Name:                c.Name,
+		HostType:            c.HostType,
+		KnownIssues:         c.KnownIssues,
+		Notes:               c.Notes,
+		TryOnly:             c.tryOnly,
+		CompileOnly:         c.CompileOnly,
+		FlakyNet:            c.FlakyNet,
+		RunBench:            c.RunBench,
+		SkipSnapshot:        c.SkipSnapshot,
+		StopAfterMake:       c.StopAfterMake,
+		PrivateGoProxy:      c.privateGoProxy,
+		InstallRacePackages: c.InstallRacePackages,
+		GoDeps:              c.GoDeps,
+		NumTestHelpers:      c.numTestHelpers,
+		NumTryTestHelpers:   c.numTryTestHelpers,
+		Env:                 c.env,
+		MakeScriptArgs:      c.makeScriptArgs,
+		AllScriptArgs:       c.allScriptArgs,
+		IsRestricted:        c.isRestricted,
+	}
+	if v := c.MinimumGoVersion; v.Major != 0 {
+		bs.MinimumGoVersion = fmt.Sprintf("go%d.%d", v.Major, v.Minor)
+	}
+	var err error
+	if c.buildsRepo != nil {
+		// Where buildsRepoAtAll returns false regardless of the
+		// policy, it doesn't matter what the policy says.
+		probe := *c
+		probe.buildsRepo = func(repo, branch, goBranch string) bool { return true }
+		tab := newRepoTable(c.buildsRepoAtAll, probe.buildsRepoAtAll)
+		if bs.BuildsRepo, err = cfg.genRepoPolicy(tab, buildsRepoPolicies); err != nil {
+			return nil, fmt.Errorf("buildsRepo: %v", err)
+		}
+	}
+	if c.tryBot != nil {
+		tab := newRepoTable(c.BuildsRepoTryBot, c.buildsRepoAtAll)
+		if !tab.allFalse() {
+			if bs.TryBot, err = cfg.genRepoPolicy(tab, tryBotPolicies); err != nil {
+				return nil, fmt.Errorf("tryBot: %v", err)
+			}
+		}
+	}
+	if c.distTestAdjust != nil {
+		if bs.DistTests, err = cfg.genTestPolicy(c.distTestAdjust); err != nil {
+			return nil, fmt.Errorf("distTestAdjust: %v", err)
+		}
+	}
+	return bs, nil
+}
+
+type namedRepoPolicy struct {
+	name string
+	f    func(repo, branch, goBranch string) bool
+}
+
+// buildsRepoPolicies and tryBotPolicies are the Go policy functions
+// which have a named policy in builders.json, in order of preference.
+var (
+	buildsRepoPolicies = []namedRepoPolicy{
+		{"onlyGo", onlyGo},
+		{"onlyMasterDefault", onlyMasterDefault},
+		{"plan9Default", plan9Default},
+		{"mipsBuildsRepoPolicy", mipsBuildsRepoPolicy},
+		{"defaultPlusExp", defaultPlusExp},
+		{"defaultPlusExpBuild", defaultPlusExpBuild},
+		{"defaultPlusExpBuildVulnDB", defaultPlusExpBuildVulnDB},
+		{"disabledBuilder", disabledBuilder},
+	}
+	tryBotPolicies = []namedRepoPolicy{
+		{"defaultTrySet", defaultTrySet()},
+	}
+)
+
+// A repoTable is the value of a repo policy for the configPoints of
+// configRepos. The value at a point only matters if care is set.
+type repoTable struct {
+	val, care map[string][]bool
+}
+
+func newRepoTable(f, care func(repo, branch, goBranch string) bool) *repoTable {
+	tab := &repoTable{val: make(map[string][]bool), care: make(map[string][]bool)}
+	for _, repo := range configRepos() {
+		for _, p := range configPoints(repo) {
+			tab.val[repo] = append(tab.val[repo], f(repo, p.branch, p.goBranch))
+			c := true
+			if care != nil {
+				c = care(repo, p.branch, p.goBranch)
+			}
+			tab.care[repo] = append(tab.care[repo], c)
+		}
+	}
+	return tab
+}
+
+// matches reports whether vals agree with the table for repo.
+func (tab *repoTable) matches(repo string, vals []bool) bool {
+	for i, v := range tab.val[repo] {
+		if tab.care[repo][i] && vals[i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// never reports whether repo isn't built at any point that matters.
+func (tab *repoTable) never(repo string) bool {
+	return tab.matches(repo, make([]bool, len(tab.val[repo])))
+}
+
+func (tab *repoTable) allFalse() bool {
+	for repo := range tab.val {
+		if !tab.never(repo) {
+			return false
+		}
+	}
+	return true
+}
+
+// genRepoPolicy returns the declarative form of the repo policy whose
+// values are tab, preferring one of the named policies.
+func (cfg *Config) genRepoPolicy(tab *repoTable, named []namedRepoPolicy) (*RepoPolicy, error) {
+	for _, np := range named {
+		nt := newRepoTable(np.f, nil)
+		ok := true
+		for _, repo := range configRepos() {
+			ok = ok && tab.matches(repo, nt.val[repo])
+		}
+		if !ok {
+			continue
+		}
+		if _, ok := cfg.RepoPolicies[np.name]; !ok {
+			rules, err := genRepoRules(nt)
+			if err != nil {
+				return nil, fmt.Errorf("policy %s: %v", np.name, err)
+			}
+			cfg.RepoPolicies[np.name] = rules
+		}
+		return &RepoPolicy{Name: np.name}, nil
+	}
+	rules, err := genRepoRules(tab)
+	if err != nil {
+		return nil, err
+	}
+	return &RepoPolicy{Rules: rules}, nil
+}
+
+// ruleCandidates are the predicates of RepoRules genRepoRules chooses
+// from, simplest first.
+var ruleCandidates = func() []RepoRule {
+	patterns := [][]string{nil, {"master"}, {"release-branch.*"}}
+	// Leave out the newest release of configGoBranches, so that a
+	// MaxGo never stands for all releases.
+	versions := []int{0}
+	for n := 11; n <= 21; n++ {
+		versions = append(versions, n)
+	}
+	var rs []RepoRule
+	for _, gb := range patterns {
+		for _, b := range patterns {
+			for _, min := range versions {
+				for _, max := range versions {
+					if min != 0 && max != 0 && min > max {
+						continue
+					}
+					rs = append(rs, RepoRule{Branches: b, GoBranches: gb, MinGo: min, MaxGo: max})
+				}
+			}
+		}
+	}
+	cost := func(r RepoRule) (n int) {
+		for _, set := range []bool{r.Branches != nil, r.GoBranches != nil, r.MinGo != 0, r.MaxGo != 0} {
+			if set {
+				n++
+			}
+		}
+		return n
+	}
+	sort.SliceStable(rs, func(i, j int) bool { return cost(rs[i]) < cost(rs[j]) })
+	return rs
+}()
+
+// genRepoRules returns the simplest rules whose policy has the values
+// of tab, counting each rule as two listed repos.
+func genRepoRules(tab *repoTable) ([]RepoRule, error) {
+	// The values of each candidate, for the "go" repo and the others.
+	cands := make([]map[bool][]bool, len(ruleCandidates))
+	for i, r := range ruleCandidates {
+		cands[i] = make(map[bool][]bool)
+		for _, isGo := range []bool{false, true} {
+			repo := "net"
+			if isGo {
+				repo = "go"
+			}
+			for _, p := range configPoints(repo) {
+				cands[i][isGo] = append(cands[i][isGo], r.allows(p.branch, p.goBranch))
+			}
+		}
+	}
+	matches := func(repo string, cand int) bool { return tab.matches(repo, cands[cand][repo == "go"]) }
+	// bestCand returns the candidate which matches the most of repos.
+	bestCand := func(repos []string) (best int, n int) {
+		for i := range cands {
+			m := 0
+			for _, repo := range repos {
+				if matches(repo, i) {
+					m++
+				}
+			}
+			if m > n {
+				best, n = i, m
+			}
+		}
+		return best, n
+	}
+	rule := func(cand int, repos []string) RepoRule {
+		r := ruleCandidates[cand]
+		r.Repos = repos
+		return r
+	}
+
+	var best []RepoRule
+	bestCost := -1
+	for _, tail := range []string{"", "default", "*"} {
+		inTail := func(repo string) bool { return tail == "*" || tail == "default" && buildRepoByDefault(repo) }
+		var tailRule int
+		if tail != "" {
+			var tailRepos []string
+			for _, repo := range configRepos() {
+				if inTail(repo) && !tab.never(repo) {
+					tailRepos = append(tailRepos, repo)
+				}
+			}
+			var n int
+			if tailRule, n = bestCand(tailRepos); n == 0 {
+				continue
+			}
+		}
+		var skip, extra, rest []string
+		for _, repo := range configRepos() {
+			switch {
+			case inTail(repo) && matches(repo, tailRule):
+			case tab.never(repo):
+				if inTail(repo) {
+					skip = append(skip, repo)
+				}
+			case tail != "" && matches(repo, tailRule):
+				extra = append(extra, repo)
+			default:
+				rest = append(rest, repo)
+			}
+		}
+		var rules []RepoRule
+		if len(skip) > 0 {
+			rules = append(rules, RepoRule{Repos: skip, Skip: true})
+		}
+		for len(rest) > 0 {
+			cand, n := bestCand(rest)
+			if n == 0 {
+				return nil, fmt.Errorf("no rule matches the policy for repo %q", rest[0])
+			}
+			var covered, left []string
+			for _, repo := range rest {
+				if matches(repo, cand) {
+					covered = append(covered, repo)
+				} else {
+					left = append(left, repo)
+				}
+			}
+			rules = append(rules, rule(cand, covered))
+			rest = left
+		}
+		if tail != "" {
+			rules = append(rules, rule(tailRule, append([]string{tail}, extra...)))
+		}
+		cost := 2 * len(rules)
+		for _, r := range rules {
+			cost += len(r.Repos)
+		}
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = rules, cost
+		}
+	}
+	if best == nil {
+		best = []RepoRule{} // never build
+	}
+	return best, nil
+}
+
+// testPatterns are the patterns genTestRules uses in place of all the
+// dist tests they match.
+var testPatterns = []string{"test:*", "*/internal/*", "*vendor/golang.org/x/arch*"}
+
+type namedTestPolicy struct {
+	name string
+	f    func(run bool, distTest string, isNormalTry bool) bool
+}
+
+// testPolicies are the Go dist test policy functions which have a
+// named policy in builders.json.
+var testPolicies = []namedTestPolicy{
+	{"fasterTrybots", fasterTrybots},
+	{"noTestDirAndNoReboot", noTestDirAndNoReboot},
+	{"ppc64DistTestPolicy", ppc64DistTestPolicy},
+	{"mipsDistTestPolicy", mipsDistTestPolicy},
+	{"macTestPolicy", macTestPolicy},
+}
+
+// genTestPolicy returns the declarative form of the dist test policy
+// function f, preferring one of the named policies.
+func (cfg *Config) genTestPolicy(f func(run bool, distTest string, isNormalTry bool) bool) (*TestPolicy, error) {
+	tr, err := genTestRules(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, np := range testPolicies {
+		ntr, err := genTestRules(np.f)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s: %v", np.name, err)
+		}
+		if reflect.DeepEqual(tr, ntr) {
+			cfg.TestPolicies[np.name] = ntr
+			return &TestPolicy{Name: np.name}, nil
+		}
+	}
+	return &TestPolicy{Rules: tr}, nil
+}
+
+func genTestRules(f func(run bool, distTest string, isNormalTry bool) bool) (*TestRules, error) {
+	var skip, skipTry []string
+	for _, test := range configDistTests {
+		post, try := f(true, test, false), f(true, test, true)
+		switch {
+		case f(false, test, false) || f(false, test, true):
+			return nil, fmt.Errorf("policy runs %q although the default policy doesn't", test)
+		case !post && try:
+			return nil, fmt.Errorf("policy runs %q only for trybots", test)
+		case !post:
+			skip = append(skip, test)
+		case !try:
+			skipTry = append(skipTry, test)
+		}
+	}
+	tr := &TestRules{Skip: compactTests(skip), SkipTry: compactTests(skipTry)}
+	if len(tr.Skip) == 0 && len(tr.SkipTry) == 0 {
+		return nil, fmt.Errorf("policy doesn't skip any test")
+	}
+	return tr, nil
+}
+
+// compactTests replaces the tests matching one of testPatterns with the
+// pattern, if all the tests it matches are in tests.
+func compactTests(tests []string) []string {
+	in := make(map[string]bool)
+	for _, t := range tests {
+		in[t] = true
+	}
+	var out []string
+	for _, p := range testPatterns {
+		var matched []string
+		for _, t := range configDistTests {
+			if match(p, t) {
+				matched = append(matched, t)
+			}
+		}
+		all := len(matched) > 0
+		for _, t := range matched {
+			all = all && in[t]
+		}
+		if all {
+			out = append(out, p)
+			for _, t := range matched {
+				delete(in, t)
+			}
+		}
+	}
+	for _, t := range tests {
+		if in[t] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/build/maintner/maintnerd/maintapi/version"
+	"golang.org/x/build/types"
+)
+
+// A CoverageQuery selects the builds of a repo and branch whose
+// coverage by builders is evaluated by CoverageOf.
+type CoverageQuery struct {
+	Repo   string // "go", "net", etc.
+	Branch string // branch of Repo, such as "master" or "release-branch.go1.20"
+	// GoBranch is the branch of Go to build Repo with. It's the same
+	// as Branch if Repo is "go".
+	GoBranch string
+	// TryTerms are the terms of a TRY= comment requesting SlowBots,
+	// such as "linux-arm64" or "darwin".
+	TryTerms []string `json:",omitempty"`
+	// DistTests are the cmd/dist tests, such as "go_test:net", whose
+	// policies are evaluated for the "go" repo.
+	DistTests []string `json:",omitempty"`
+}
+
+// A Coverage is the matrix of which builders build the repo and branch
+// of a query, and which dist tests they run, as decided by the policies
+// of the builders.
+type Coverage struct {
+	CoverageQuery
+	Builders []BuilderCoverage // builders which build the query in some mode, sorted by name
+}
+
+// A BuilderCoverage is the coverage of a query by a builder.
+type BuilderCoverage struct {
+	Name       string
+	PostSubmit bool // whether it's a post-submit build, on build.golang.org
+	TryBot     bool // whether it's in the default TryBot set
+	SlowBot    bool // whether it's a SlowBot requested by the TryTerms
+
+	// SkippedTests and SkippedTryTests are the DistTests which the
+	// builder doesn't run post-submit and in normal TryBot runs,
+	// respectively.
+	SkippedTests    []string `json:",omitempty"`
+	SkippedTryTests []string `json:",omitempty"`
+}
+
+// CoverageOf returns the coverage of q by builders, which are in the
+// form of the Builders map.
+//
+// It mirrors how the coordinator chooses the builders of post-submit
+// and TryBot runs, except for x/ repos requested by TRY= terms.
+func CoverageOf(builders map[string]*BuildConfig, q CoverageQuery) *Coverage {
+	if q.GoBranch == "" {
+		q.GoBranch = "master"
+		if q.Repo == "go" {
+			q.GoBranch = q.Branch
+		}
+	}
+	// TryBots and SlowBots are skipped on Go versions older than the
+	// MinimumGoVersion of the builder.
+	var goVersion types.MajorMinor
+	if maj, min, ok := version.ParseReleaseBranch(q.GoBranch); ok {
+		goVersion = types.MajorMinor{Major: maj, Minor: min}
+	}
+	cov := &Coverage{CoverageQuery: q}
+	for _, bc := range builders {
+		tooOld := goVersion.Major != 0 && goVersion.Less(bc.MinimumGoVersion)
+		b := BuilderCoverage{
+			Name:       bc.Name,
+			PostSubmit: bc.BuildsRepoPostSubmit(q.Repo, q.Branch, q.GoBranch),
+			TryBot:     !tooOld && bc.BuildsRepoTryBot(q.Repo, q.Branch, q.GoBranch),
+		}
+		for _, term := range q.TryTerms {
+			b.SlowBot = b.SlowBot || !tooOld && bc.MatchesSlowBotTerm(term)
+		}
+		if !b.PostSubmit && !b.TryBot && !b.SlowBot {
+			continue
+		}
+		if q.Repo == "go" {
+			for _, test := range q.DistTests {
+				if !bc.ShouldRunDistTest(test, false) {
+					b.SkippedTests = append(b.SkippedTests, test)
+				}
+				if !bc.ShouldRunDistTest(test, true) {
+					b.SkippedTryTests = append(b.SkippedTryTests, test)
+				}
+			}
+		}
+		cov.Builders = append(cov.Builders, b)
+	}
+	sort.Slice(cov.Builders, func(i, j int) bool { return cov.Builders[i].Name < cov.Builders[j].Name })
+	return cov
+}
+
+// DiffCoverage returns the differences from the coverage old to new of
+// the same query, one line per difference in a builder, such as
+// "linux-amd64: TryBot true -> false" or "linux-386: SkippedTests +api".
+func DiffCoverage(old, new *Coverage) []string {
+	byName := func(c *Coverage) map[string]BuilderCoverage {
+		m := make(map[string]BuilderCoverage)
+		for _, b := range c.Builders {
+			m[b.Name] = b
+		}
+		return m
+	}
+	oldBuilders, newBuilders := byName(old), byName(new)
+	var names []string
+	for name := range oldBuilders {
+		names = append(names, name)
+	}
+	for name := range newBuilders {
+		if _, ok := oldBuilders[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []string
+	for _, name := range names {
+		// A builder which doesn't build the query is left out of
+		// the coverage, which is the same as its zero value.
+		o, inOld := oldBuilders[name]
+		n, inNew := newBuilders[name]
+		for _, f := range []struct {
+			field    string
+			old, new bool
+		}{
+			{"PostSubmit", o.PostSubmit, n.PostSubmit},
+			{"TryBot", o.TryBot, n.TryBot},
+			{"SlowBot", o.SlowBot, n.SlowBot},
+		} {
+			if f.old != f.new {
+				diffs = append(diffs, fmt.Sprintf("%s: %s %v -> %v", name, f.field, f.old, f.new))
+			}
+		}
+		if !inOld || !inNew {
+			continue
+		}
+		if d := diffTests(o.SkippedTests, n.SkippedTests); d != "" {
+			diffs = append(diffs, fmt.Sprintf("%s: SkippedTests %s", name, d))
+		}
+		if d := diffTests(o.SkippedTryTests, n.SkippedTryTests); d != "" {
+			diffs = append(diffs, fmt.Sprintf("%s: SkippedTryTests %s", name, d))
+		}
+	}
+	return diffs
+}
+
+// diffTests returns the tests added to and removed from old in new, in
+// the form "+added -removed", or the empty string if there are none.
+func diffTests(old, new []string) string {
+	in := func(tests []string, t string) bool {
+		for _, x := range tests {
+			if x == t {
+				return true
+			}
+		}
+		return false
+	}
+	var d []string
+	for _, t := range new {
+		if !in(old, t) {
+			d = append(d, "+"+t)
+		}
+	}
+	for _, t := range old {
+		if !in(new, t) {
+			d = append(d, "-"+t)
+		}
+	}
+	return strings.Join(d, " ")
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCoverageOf(t *testing.T) {
+	cov := CoverageOf(Builders, CoverageQuery{
+		Repo:      "go",
+		Branch:    "master",
+		TryTerms:  []string{"darwin"},
+		DistTests: []string{"api", "reboot"},
+	})
+	if cov.GoBranch != "master" {
+		t.Errorf("GoBranch = %q; want master", cov.GoBranch)
+	}
+	got := make(map[string]BuilderCoverage)
+	for _, b := range cov.Builders {
+		got[b.Name] = b
+	}
+	want := map[string]BuilderCoverage{
+		"linux-amd64":        {Name: "linux-amd64", PostSubmit: true, TryBot: true},
+		"freebsd-amd64-12_3": {Name: "freebsd-amd64-12_3", PostSubmit: true, TryBot: true, SkippedTryTests: []string{"api", "reboot"}},
+		"darwin-amd64-13":    {Name: "darwin-amd64-13", PostSubmit: true, SlowBot: true, SkippedTests: []string{"api", "reboot"}, SkippedTryTests: []string{"api", "reboot"}},
+	}
+	for name, w := range want {
+		if diff := cmp.Diff(w, got[name]); diff != "" {
+			t.Errorf("coverage of %s (-want +got):\n%s", name, diff)
+		}
+	}
+	if _, ok := got["linux-amd64-androidemu"]; ok {
+		t.Errorf("linux-amd64-androidemu, which only builds x/mobile, covers the go repo")
+	}
+
+	// Builders which require a newer Go aren't TryBots or SlowBots
+	// on older release branches.
+	cov = CoverageOf(Builders, CoverageQuery{Repo: "go", Branch: "release-branch.go1.19", TryTerms: []string{"misc-compile-freebsd-riscv64-go1.20"}})
+	for _, b := range cov.Builders {
+		if b.Name == "misc-compile-freebsd-riscv64-go1.20" && (b.TryBot || b.SlowBot) {
+			t.Errorf("%s is a TryBot or SlowBot on Go 1.19; want neither", b.Name)
+		}
+	}
+}
+
+func TestDiffCoverage(t *testing.T) {
+	q := CoverageQuery{Repo: "go", Branch: "master", DistTests: []string{"api", "reboot"}}
+	old := CoverageOf(Builders, q)
+	if diffs := DiffCoverage(old, old); len(diffs) != 0 {
+		t.Errorf("DiffCoverage of the same coverage = %q; want none", diffs)
+	}
+
+	builders := make(map[string]*BuildConfig)
+	for name, bc := range Builders {
+		builders[name] = bc
+	}
+	amd64 := *Builders["linux-amd64"]
+	amd64.tryBot = nil
+	amd64.distTestAdjust = noTestDirAndNoReboot
+	builders["linux-amd64"] = &amd64
+	delete(builders, "linux-386")
+
+	want := []string{
+		"linux-386: PostSubmit true -> false",
+		"linux-386: TryBot true -> false",
+		"linux-amd64: TryBot true -> false",
+		"linux-amd64: SkippedTests +reboot",
+		"linux-amd64: SkippedTryTests +reboot",
+	}
+	if diff := cmp.Diff(want, DiffCoverage(old, CoverageOf(builders, q))); diff != "" {
+		t.Errorf("DiffCoverage (-want +got):\n%s", diff)
+	}
+}
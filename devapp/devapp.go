@@ -22,6 +22,7 @@ var (
 	staticDir   = flag.String("static-dir", "./static/", "location of static directory relative to binary location")
 	templateDir = flag.String("template-dir", "./templates/", "location of templates directory relative to binary location")
 	reload      = flag.Bool("reload", false, "reload content on each page load")
+	asOf        = flag.String("asof", "", "if set, a date such as 2023-01-10 or an RFC 3339 time to show the dashboards as of, instead of the present")
 )
 
 func init() {
@@ -37,11 +38,32 @@ func main() {
 	rand.Seed(time.Now().UnixNano())
 
 	s := newServer(http.NewServeMux(), *staticDir, *templateDir, *reload)
+	if *asOf != "" {
+		t, err := time.Parse(time.RFC3339, *asOf)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", *asOf); err != nil {
+				log.Fatalf("Invalid -asof %q: want a date such as 2023-01-10 or an RFC 3339 time", *asOf)
+			}
+		}
+		s.asOf = t
+	}
 	ctx := context.Background()
 	if err := s.initCorpus(ctx); err != nil {
 		log.Fatalf("Could not init corpus: %v", err)
 	}
-	go s.corpusUpdateLoop(ctx)
+	if s.asOf.IsZero() {
+		go s.corpusUpdateLoop(ctx)
+	} else {
+		// A view of the past can't be updated, so compute the
+		// dashboards once.
+		s.updateHelpWantedIssues()
+		s.updateActivities()
+		s.cMu.Lock()
+		s.data.release.dirty = true
+		s.data.reviews.dirty = true
+		s.data.stats.dirty = true
+		s.cMu.Unlock()
+	}
 
 	log.Fatalln(https.ListenAndServe(ctx, s))
 }
@@ -293,7 +293,7 @@ func (s *server) updateReleaseData() {
 	})
 
 	bd := burndownData{Milestone: curMilestoneTitle}
-	for t, now := curMilestoneStart, time.Now(); t.Before(now); t = t.Add(24 * time.Hour) {
+	for t, now := curMilestoneStart, s.now(); t.Before(now); t = t.Add(24 * time.Hour) {
 		var e burndownEntry
 		for _, issue := range curMilestoneIssues {
 			if issue.Created.After(t) || (issue.Closed && issue.ClosedAt.Before(t)) {
@@ -319,7 +319,11 @@ func (s *server) updateReleaseData() {
 	s.appendPendingProposals(issueToCLs)
 	s.appendClosedIssues()
 	s.data.release.CurMilestone = curMilestoneTitle
-	s.data.release.LastUpdated = time.Now().UTC().Format(time.UnixDate)
+	if s.asOf.IsZero() {
+		s.data.release.LastUpdated = time.Now().UTC().Format(time.UnixDate)
+	} else {
+		s.data.release.LastUpdated = "As of " + s.asOf.UTC().Format(time.UnixDate)
+	}
 	s.data.release.dirty = false
 }
 
@@ -462,7 +466,7 @@ func (s *server) appendPendingProposals(issueToCLs map[int32][]*gerritCL) {
 func (s *server) appendClosedIssues() {
 	var (
 		closed   group
-		lastWeek = time.Now().Add(-(7*24 + 12) * time.Hour)
+		lastWeek = s.now().Add(-(7*24 + 12) * time.Hour)
 	)
 	s.repo.ForeachIssue(func(issue *maintner.GitHubIssue) error {
 		if !issue.Closed {
@@ -31,6 +31,10 @@ type server struct {
 	templateDir string
 	reloadTmpls bool
 
+	// asOf, if non-zero, is the time of the past the server shows the
+	// corpus as of. The corpus isn't updated then.
+	asOf time.Time
+
 	cMu              sync.RWMutex // Used to protect the fields below.
 	corpus           *maintner.Corpus
 	repo             *maintner.GitHubRepo    // The golang/go repo.
@@ -92,7 +96,13 @@ func (s *server) withTemplate(tmpl string, fn func(*template.Template, http.Resp
 func (s *server) initCorpus(ctx context.Context) error {
 	s.cMu.Lock()
 	defer s.cMu.Unlock()
-	corpus, err := godata.Get(ctx)
+	var corpus *maintner.Corpus
+	var err error
+	if s.asOf.IsZero() {
+		corpus, err = godata.Get(ctx)
+	} else {
+		corpus, err = godata.GetAsOf(ctx, maintner.Filter{}, maintner.AsOf{Time: s.asOf})
+	}
 	if err != nil {
 		return fmt.Errorf("godata.Get: %v", err)
 	}
@@ -108,6 +118,15 @@ func (s *server) initCorpus(ctx context.Context) error {
 	return nil
 }
 
+// now returns the current time, or the time the server shows the
+// corpus as of.
+func (s *server) now() time.Time {
+	if !s.asOf.IsZero() {
+		return s.asOf
+	}
+	return time.Now()
+}
+
 // corpusUpdateLoop continuously updates the server’s corpus until ctx’s Done
 // channel is closed.
 func (s *server) corpusUpdateLoop(ctx context.Context) {
@@ -32,8 +32,10 @@ func (s *server) handleStats(t *template.Template, w http.ResponseWriter, r *htt
 	defer s.cMu.RUnlock()
 	data := struct {
 		DataJSON interface{}
+		AsOf     time.Time
 	}{
 		DataJSON: s.data.stats,
+		AsOf:     s.asOf,
 	}
 	if err := t.Execute(&buf, data); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -77,7 +79,7 @@ func (s *server) updateStatsData() {
 	defer s.cMu.Unlock()
 
 	var (
-		windowStart = time.Now().Add(-1 * 365 * 24 * time.Hour)
+		windowStart = s.now().Add(-1 * 365 * 24 * time.Hour)
 		intervals   []*clInterval
 	)
 	s.corpus.Gerrit().ForeachProjectUnsorted(filterProjects(func(p *maintner.GerritProject) error {
@@ -95,7 +97,7 @@ func (s *server) updateStatsData() {
 	}))
 
 	var chartData [][]interface{}
-	for t0, t1 := windowStart, windowStart.Add(24*time.Hour); t0.Before(time.Now()); t0, t1 = t0.Add(24*time.Hour), t1.Add(24*time.Hour) {
+	for t0, t1 := windowStart, windowStart.Add(24*time.Hour); t0.Before(s.now()); t0, t1 = t0.Add(24*time.Hour), t1.Add(24*time.Hour) {
 		var (
 			open       int
 			withIssues int
@@ -1140,6 +1140,12 @@ type RelatedChangeAndCommitInfo struct {
 	ChangeNumber int32      `json:"_change_number"`
 	Commit       CommitInfo `json:"commit"`
 	Status       string     `json:"status"`
+
+	// RevisionNumber is the patch set number of Commit, and
+	// CurrentRevisionNumber is the number of the current patch set
+	// of the change. They differ if Commit is outdated.
+	RevisionNumber        int32 `json:"_revision_number"`
+	CurrentRevisionNumber int32 `json:"_current_revision_number"`
 }
 
 // RelatedChangesInfo contains information about a set of related changes.
@@ -432,3 +432,96 @@ GROUP BY 1, 2
 	}
 	return ts, nil
 }
+
+// HoursPerWeek is the number of hours in a week.
+const HoursPerWeek = 7 * 24
+
+// HourOfWeek returns the hour of the week of t in UTC, starting from
+// midnight on Sunday.
+func HourOfWeek(t time.Time) int {
+	t = t.UTC()
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+// BuildletDemand describes how many buildlets were requested for each
+// builder in each hour of the week, over a number of recent weeks.
+type BuildletDemand struct {
+	// AsOf is the time that the demand was queried from BigQuery.
+	AsOf time.Time
+
+	// Weeks is how many weeks of history the counts cover.
+	Weeks int
+
+	// Requests maps from a builder name to the number of buildlets
+	// requested for it in each hour of the week, indexed by HourOfWeek.
+	Requests map[string]*[HoursPerWeek]int
+}
+
+// Rate returns the average number of buildlets requested per hour for
+// builder during the hour of the week containing t.
+func (d *BuildletDemand) Rate(builder string, t time.Time) float64 {
+	if d == nil || d.Weeks == 0 {
+		return 0
+	}
+	r, ok := d.Requests[builder]
+	if !ok {
+		return 0
+	}
+	return float64(r[HourOfWeek(t)]) / float64(d.Weeks)
+}
+
+// QueryBuildletDemand returns how many buildlets each builder requested
+// over the past weeks, using the get_buildlet and get_helper spans
+// synced to BigQuery by SyncSpans.
+func QueryBuildletDemand(ctx context.Context, env *buildenv.Environment, weeks int) (*BuildletDemand, error) {
+	d := &BuildletDemand{
+		AsOf:     time.Now(),
+		Weeks:    weeks,
+		Requests: map[string]*[HoursPerWeek]int{},
+	}
+	bq, err := bigquery.NewClient(ctx, env.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	defer bq.Close()
+	q := bq.Query(`
+SELECT
+    Builder, EXTRACT(DAYOFWEEK FROM StartTime) AS Day, EXTRACT(HOUR FROM StartTime) AS Hour, COUNT(*) AS N
+FROM
+    builds.Spans
+WHERE
+    StartTime > TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL @hours HOUR)
+    AND Event IN ('get_buildlet', 'get_helper')
+GROUP BY 1, 2, 3
+`)
+	q.Parameters = []bigquery.QueryParameter{{Name: "hours", Value: weeks * HoursPerWeek}}
+	it, err := q.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var row struct {
+			Builder string
+			Day     int // 1 is Sunday
+			Hour    int
+			N       int
+		}
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row.Day < 1 || row.Day > 7 || row.Hour < 0 || row.Hour > 23 {
+			return nil, fmt.Errorf("unexpected day %d, hour %d for builder %q", row.Day, row.Hour, row.Builder)
+		}
+		r := d.Requests[row.Builder]
+		if r == nil {
+			r = new([HoursPerWeek]int)
+			d.Requests[row.Builder] = r
+		}
+		r[(row.Day-1)*24+row.Hour] += row.N
+	}
+	return d, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildstats
+
+import (
+	"sync"
+	"time"
+)
+
+// A DurationEstimator estimates how long cmd/dist tests take, learning
+// from the durations observed in recent builds. Tests which haven't
+// been observed fall back to the TestStats queried from BigQuery,
+// which may be stale or missing for new tests.
+//
+// A DurationEstimator is safe for concurrent use.
+type DurationEstimator struct {
+	mu       sync.Mutex
+	stats    *TestStats
+	observed map[builderTest]time.Duration // smoothed observed durations
+	totals   map[string]time.Duration      // builder -> smoothed serial test time
+}
+
+type builderTest struct {
+	builder, test string
+}
+
+// NewDurationEstimator returns a DurationEstimator with no observations.
+func NewDurationEstimator() *DurationEstimator {
+	return &DurationEstimator{
+		observed: make(map[builderTest]time.Duration),
+		totals:   make(map[string]time.Duration),
+	}
+}
+
+// SetTestStats sets the stats used for tests which haven't been observed.
+func (e *DurationEstimator) SetTestStats(ts *TestStats) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats = ts
+}
+
+// Duration returns the estimated time to run testName on builder.
+// It is always non-zero.
+func (e *DurationEstimator) Duration(builder, testName string) time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if d, ok := e.observed[builderTest{builder, testName}]; ok && d > 0 {
+		return d
+	}
+	return e.stats.Duration(builder, testName)
+}
+
+// Observe records that the tests, run together on builder, passed
+// in d. The time is divided between the tests in proportion to their
+// current estimates.
+func (e *DurationEstimator) Observe(builder string, tests []string, d time.Duration) {
+	if len(tests) == 0 || d <= 0 {
+		return
+	}
+	est := make([]time.Duration, len(tests))
+	var sum time.Duration
+	for i, name := range tests {
+		est[i] = e.Duration(builder, name)
+		sum += est[i]
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, name := range tests {
+		k := builderTest{builder, name}
+		share := time.Duration(float64(d) * float64(est[i]) / float64(sum))
+		e.observed[k] = smooth(e.observed[k], share)
+	}
+}
+
+// ObserveTotal records that running all the tests of a build on
+// builder took d, if run serially.
+func (e *DurationEstimator) ObserveTotal(builder string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.totals[builder] = smooth(e.totals[builder], d)
+}
+
+// Total returns the estimated serial time to run all the tests of a
+// build on builder. ok is false if no build of builder was observed.
+func (e *DurationEstimator) Total(builder string) (d time.Duration, ok bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	d, ok = e.totals[builder]
+	return d, ok
+}
+
+// smooth returns the exponentially weighted moving average of the old
+// estimate and a new observation, with new observations weighing a
+// third. A zero old estimate means there is none.
+func smooth(old, new time.Duration) time.Duration {
+	if old == 0 {
+		return new
+	}
+	return old + (new-old)/3
+}
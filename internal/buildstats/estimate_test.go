@@ -0,0 +1,56 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildstats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationEstimator(t *testing.T) {
+	e := NewDurationEstimator()
+	e.SetTestStats(&TestStats{BuilderTestStats: map[string]*BuilderTestStats{
+		"linux-amd64": {MedianDuration: map[string]time.Duration{
+			"go_test:a": 10 * time.Second,
+			"go_test:b": 30 * time.Second,
+		}},
+	}})
+
+	if got, want := e.Duration("linux-amd64", "go_test:a"), 10*time.Second; got != want {
+		t.Errorf("Duration(a) from stats = %v; want %v", got, want)
+	}
+	if got := e.Duration("linux-amd64", "go_test:new"); got <= 0 {
+		t.Errorf("Duration of unknown test = %v; want non-zero default", got)
+	}
+
+	// a and b ran together in 20s, so they get a quarter and three
+	// quarters of it.
+	e.Observe("linux-amd64", []string{"go_test:a", "go_test:b"}, 20*time.Second)
+	if got, want := e.Duration("linux-amd64", "go_test:a"), 5*time.Second; got != want {
+		t.Errorf("Duration(a) after first observation = %v; want %v", got, want)
+	}
+	if got, want := e.Duration("linux-amd64", "go_test:b"), 15*time.Second; got != want {
+		t.Errorf("Duration(b) after first observation = %v; want %v", got, want)
+	}
+
+	// Later observations are smoothed.
+	e.Observe("linux-amd64", []string{"go_test:a"}, 8*time.Second)
+	if got, want := e.Duration("linux-amd64", "go_test:a"), 6*time.Second; got != want {
+		t.Errorf("Duration(a) after second observation = %v; want %v", got, want)
+	}
+	// Other builders are unaffected.
+	if got, want := e.Duration("linux-386", "go_test:a"), e.stats.Duration("linux-386", "go_test:a"); got != want {
+		t.Errorf("Duration on other builder = %v; want %v", got, want)
+	}
+
+	if _, ok := e.Total("linux-amd64"); ok {
+		t.Errorf("Total before any build was observed: ok = true")
+	}
+	e.ObserveTotal("linux-amd64", 9*time.Minute)
+	e.ObserveTotal("linux-amd64", 12*time.Minute)
+	if got, ok := e.Total("linux-amd64"); !ok || got != 10*time.Minute {
+		t.Errorf("Total = %v, %v; want %v, true", got, ok, 10*time.Minute)
+	}
+}
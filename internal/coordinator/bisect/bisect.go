@@ -0,0 +1,136 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bisect finds the commit which broke a builder by binary
+// search over a range of commits.
+package bisect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/build/internal/lru"
+)
+
+// A Job describes a bisection.
+type Job struct {
+	Builder string // builder name, e.g. "linux-amd64-race"
+	Good    string // commit known to pass
+	Bad     string // commit known to fail
+
+	// Test holds the arguments of a go command run in $GOROOT/src
+	// after make.bash, such as ["test", "-run=TestFoo", "net/http"].
+	// Empty means only make.bash is run.
+	Test []string
+}
+
+// key returns the cache key of the result of testing commit for j.
+func (j Job) key(commit string) string {
+	return j.Builder + "\x00" + strings.Join(j.Test, "\x00") + "\x00" + commit
+}
+
+// A Result is the outcome of testing one commit.
+type Result struct {
+	Commit   string
+	Passed   bool
+	Log      string        // output of make.bash and the test
+	Duration time.Duration // time taken to test the commit
+	Cached   bool          // the result is from an earlier bisection
+}
+
+// A TestFunc builds and tests commit for a Job.
+// A non-nil error means the commit could not be tested, as
+// opposed to failing the test.
+type TestFunc func(ctx context.Context, commit string) (Result, error)
+
+// ErrNotReproduced is returned by Bisect when the bad commit passes.
+var ErrNotReproduced = errors.New("bisect: bad commit passed; failure not reproduced")
+
+// Cache holds results of testing commits, keyed by builder, test and
+// commit. It is safe for concurrent use.
+type Cache struct {
+	lru *lru.Cache
+}
+
+// NewCache returns a Cache holding up to size results.
+func NewCache(size int) *Cache {
+	return &Cache{lru: lru.New(size)}
+}
+
+func (c *Cache) get(j Job, commit string) (Result, bool) {
+	v, ok := c.lru.Get(j.key(commit))
+	if !ok {
+		return Result{}, false
+	}
+	r := v.(Result)
+	r.Cached = true
+	return r, true
+}
+
+func (c *Cache) add(j Job, r Result) {
+	c.lru.Add(j.key(r.Commit), r)
+}
+
+// Bisect finds the first failing commit in commits, which are the
+// commits after j.Good up to and including j.Bad, from oldest to newest.
+// It first checks that j.Bad fails, then binary searches the rest.
+//
+// Results are looked up in and added to cache, which may be nil.
+// If progress is non-nil, it is called with each result as it
+// becomes known.
+func Bisect(ctx context.Context, j Job, commits []string, test TestFunc, cache *Cache, progress func(Result)) (culprit Result, err error) {
+	if len(commits) == 0 || commits[len(commits)-1] != j.Bad {
+		return Result{}, fmt.Errorf("bisect: commit range does not end in bad commit %s", j.Bad)
+	}
+	run := func(commit string) (Result, error) {
+		if cache != nil {
+			if r, ok := cache.get(j, commit); ok {
+				if progress != nil {
+					progress(r)
+				}
+				return r, nil
+			}
+		}
+		r, err := test(ctx, commit)
+		if err != nil {
+			return Result{}, fmt.Errorf("testing %s: %w", commit, err)
+		}
+		r.Commit = commit
+		if cache != nil {
+			cache.add(j, r)
+		}
+		if progress != nil {
+			progress(r)
+		}
+		return r, nil
+	}
+
+	// lo is the index of the newest known passing commit, with -1
+	// meaning j.Good; hi is the oldest known failing one.
+	lo, hi := -1, len(commits)-1
+	last, err := run(commits[hi])
+	if err != nil {
+		return Result{}, err
+	}
+	if last.Passed {
+		return Result{}, ErrNotReproduced
+	}
+	culprit = last
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		r, err := run(commits[mid])
+		if err != nil {
+			return Result{}, err
+		}
+		if r.Passed {
+			lo = mid
+		} else {
+			hi, culprit = mid, r
+		}
+	}
+	return culprit, nil
+}
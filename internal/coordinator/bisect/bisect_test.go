@@ -0,0 +1,105 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bisect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBisect(t *testing.T) {
+	var commits []string
+	for i := 0; i < 20; i++ {
+		commits = append(commits, fmt.Sprintf("c%02d", i))
+	}
+	job := Job{Builder: "linux-amd64", Good: "good", Bad: commits[len(commits)-1], Test: []string{"test", "runtime"}}
+	ctx := context.Background()
+
+	for _, culprit := range []int{0, 1, 7, 18, 19} {
+		var tested []string
+		test := func(ctx context.Context, commit string) (Result, error) {
+			tested = append(tested, commit)
+			return Result{Passed: commit < commits[culprit]}, nil
+		}
+		got, err := Bisect(ctx, job, commits, test, nil, nil)
+		if err != nil {
+			t.Errorf("culprit %d: Bisect() = _, %v; want no error", culprit, err)
+			continue
+		}
+		if got.Commit != commits[culprit] || got.Passed {
+			t.Errorf("culprit %d: Bisect() = %+v; want failing %s", culprit, got, commits[culprit])
+		}
+		if len(tested) > 6 {
+			t.Errorf("culprit %d: tested %d commits; want at most 6", culprit, len(tested))
+		}
+	}
+}
+
+func TestBisectCache(t *testing.T) {
+	commits := []string{"c0", "c1", "c2", "c3"}
+	job := Job{Builder: "linux-amd64", Good: "good", Bad: "c3"}
+	cache := NewCache(10)
+	ctx := context.Background()
+
+	var tested int
+	test := func(ctx context.Context, commit string) (Result, error) {
+		tested++
+		return Result{Passed: commit < "c2"}, nil
+	}
+	if _, err := Bisect(ctx, job, commits, test, cache, nil); err != nil {
+		t.Fatalf("Bisect() = _, %v; want no error", err)
+	}
+	first := tested
+
+	// A second bisection of the same job only uses cached results.
+	var cached int
+	progress := func(r Result) {
+		if r.Cached {
+			cached++
+		}
+	}
+	got, err := Bisect(ctx, job, commits, test, cache, progress)
+	if err != nil {
+		t.Fatalf("second Bisect() = _, %v; want no error", err)
+	}
+	if got.Commit != "c2" {
+		t.Errorf("second Bisect() culprit = %s; want c2", got.Commit)
+	}
+	if tested != first || cached != first {
+		t.Errorf("second Bisect() tested %d more commits and used %d cached results; want 0 and %d", tested-first, cached, first)
+	}
+
+	// A different test command doesn't share results.
+	job.Test = []string{"vet", "std"}
+	if _, err := Bisect(ctx, job, commits, test, cache, nil); err != nil {
+		t.Fatalf("third Bisect() = _, %v; want no error", err)
+	}
+	if tested == first {
+		t.Errorf("results were shared between different test commands")
+	}
+}
+
+func TestBisectErrors(t *testing.T) {
+	commits := []string{"c0", "c1"}
+	job := Job{Builder: "linux-amd64", Good: "good", Bad: "c1"}
+	ctx := context.Background()
+
+	pass := func(ctx context.Context, commit string) (Result, error) { return Result{Passed: true}, nil }
+	if _, err := Bisect(ctx, job, commits, pass, nil, nil); err != ErrNotReproduced {
+		t.Errorf("Bisect() with passing bad commit = _, %v; want ErrNotReproduced", err)
+	}
+
+	errBroken := errors.New("no buildlet")
+	broken := func(ctx context.Context, commit string) (Result, error) { return Result{}, errBroken }
+	if _, err := Bisect(ctx, job, commits, broken, nil, nil); !errors.Is(err, errBroken) {
+		t.Errorf("Bisect() with failing tester = _, %v; want %v", err, errBroken)
+	}
+
+	if _, err := Bisect(ctx, job, commits[:1], pass, nil, nil); err == nil {
+		t.Errorf("Bisect() with range not ending in bad commit succeeded; want error")
+	}
+}
@@ -0,0 +1,413 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package logindex indexes the failures in build logs, so they can be
+// searched by term or regular expression, and by builder, repo and
+// date, without downloading every log.
+//
+// Entries are kept in memory and stored as JSON files in an fs.FS from
+// package gcsfs, which is either a GCS bucket or a local directory.
+// Files are written and removed in the background, so that indexing a
+// build doesn't wait for storage.
+package logindex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/build/internal/gcsfs"
+	"golang.org/x/build/internal/logparser"
+)
+
+// An Entry is the failures of a failed build.
+type Entry struct {
+	ID      string // build ID, such as "B0123456789"
+	Builder string // "linux-amd64", etc.
+	Repo    string // "go", "tools", etc.
+	Branch  string `json:",omitempty"`
+	Rev     string // commit of Repo
+	GoRev   string `json:",omitempty"` // Go commit of x/ repo builds
+	TryBot  bool   `json:",omitempty"` // whether it's a build of a try run
+	Time    time.Time
+	LogURL  string
+	Fails   []Fail
+}
+
+// A Fail is a failure in a build log, as found by logparser.Parse.
+type Fail struct {
+	Section string `json:",omitempty"`
+	Pkg     string `json:",omitempty"`
+	Test    string `json:",omitempty"`
+	Mode    string `json:",omitempty"`
+	Snippet string
+}
+
+// FailsOf returns the failures in the build log.
+func FailsOf(buildLog string) []Fail {
+	var fails []Fail
+	for _, f := range logparser.Parse(buildLog) {
+		fails = append(fails, Fail{Section: f.Section, Pkg: f.Pkg, Test: f.Test, Mode: f.Mode, Snippet: f.Snippet})
+	}
+	return fails
+}
+
+// A failRef refers to the failure Fail of the entry ID.
+type failRef struct {
+	ID   string
+	Fail int
+}
+
+// An Index is a searchable index of build log failures.
+// Its methods are safe for concurrent use.
+type Index struct {
+	fsys      fs.FS
+	retention time.Duration
+	ops       chan fileOp // to the goroutine running store
+
+	mu       sync.RWMutex
+	entries  map[string]*Entry    // by ID
+	postings map[string][]failRef // by token, in the order entries were added
+}
+
+// maxPendingOps is how many file operations may be waiting to be done
+// before Add and Prune block.
+const maxPendingOps = 1000
+
+// A fileOp writes data to file, or removes file if data is nil. If done
+// is set, the op only closes done, once the ops before it are done.
+type fileOp struct {
+	file string
+	data []byte
+	done chan struct{}
+}
+
+// Open returns the index of the entries stored in fsys, which must be
+// a gcsfs.CreateFS and gcsfs.RemoveFS, such as one from gcsfs.FromURL.
+// Entries older than retention are removed by Prune. If retention is
+// zero, entries are kept forever.
+func Open(ctx context.Context, fsys fs.FS, retention time.Duration) (*Index, error) {
+	x := &Index{
+		fsys:      fsys,
+		retention: retention,
+		entries:   make(map[string]*Entry),
+		postings:  make(map[string][]failRef),
+	}
+	days, err := fs.ReadDir(fsys, "entries")
+	if errors.Is(err, fs.ErrNotExist) {
+		days = nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		files, err := fs.ReadDir(fsys, path.Join("entries", day.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			data, err := fs.ReadFile(fsys, path.Join("entries", day.Name(), f.Name()))
+			if err != nil {
+				return nil, err
+			}
+			e := new(Entry)
+			if err := json.Unmarshal(data, e); err != nil {
+				log.Printf("logindex: skipping corrupt entry %s/%s: %v", day.Name(), f.Name(), err)
+				continue
+			}
+			if old := x.entries[e.ID]; old != nil {
+				// A stale file of a replaced entry: keep the newer one.
+				if e.Time.Before(old.Time) {
+					continue
+				}
+				x.remove([]*Entry{old})
+			}
+			x.add(e)
+		}
+	}
+	x.ops = make(chan fileOp, maxPendingOps)
+	go x.store()
+	return x, nil
+}
+
+// store does the file operations sent to x.ops, in order, logging the
+// errors.
+func (x *Index) store() {
+	for op := range x.ops {
+		switch {
+		case op.done != nil:
+			close(op.done)
+		case op.data != nil:
+			if err := gcsfs.WriteFile(x.fsys, op.file, op.data); err != nil {
+				log.Printf("logindex: writing %s: %v", op.file, err)
+			}
+		default:
+			if err := gcsfs.Remove(x.fsys, op.file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Printf("logindex: removing %s: %v", op.file, err)
+			}
+		}
+	}
+}
+
+// Flush waits until the files of the entries added or pruned so far
+// have been written or removed.
+func (x *Index) Flush() {
+	done := make(chan struct{})
+	x.ops <- fileOp{done: done}
+	<-done
+}
+
+// entryFile returns the name of the file of e, in a directory of the
+// entries of its date.
+func entryFile(e *Entry) string {
+	return path.Join("entries", e.Time.UTC().Format("2006-01-02"), e.ID+".json")
+}
+
+// Add indexes e and stores it in the background. It replaces any entry
+// with the same ID, removing its file if it was stored under another
+// date.
+func (x *Index) Add(e *Entry) error {
+	if e.ID == "" || strings.ContainsAny(e.ID, "/.") {
+		return fmt.Errorf("invalid entry ID %q", e.ID)
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	x.mu.Lock()
+	old := x.entries[e.ID]
+	if old != nil {
+		x.remove([]*Entry{old})
+	}
+	x.add(e)
+	x.mu.Unlock()
+
+	x.ops <- fileOp{file: entryFile(e), data: data}
+	if old != nil && entryFile(old) != entryFile(e) {
+		x.ops <- fileOp{file: entryFile(old)}
+	}
+	return nil
+}
+
+// add indexes e. x.mu must be held, or x must not be shared yet.
+func (x *Index) add(e *Entry) {
+	x.entries[e.ID] = e
+	for i, f := range e.Fails {
+		for tok := range tokenSet(f) {
+			x.postings[tok] = append(x.postings[tok], failRef{e.ID, i})
+		}
+	}
+}
+
+// remove drops the entries es and their postings from the index,
+// leaving the postings of other entries alone. x.mu must be held, or x
+// must not be shared yet.
+func (x *Index) remove(es []*Entry) {
+	ids := make(map[string]bool)
+	toks := make(map[string]bool)
+	for _, e := range es {
+		delete(x.entries, e.ID)
+		ids[e.ID] = true
+		for _, f := range e.Fails {
+			for tok := range tokenSet(f) {
+				toks[tok] = true
+			}
+		}
+	}
+	for tok := range toks {
+		var kept []failRef
+		for _, r := range x.postings[tok] {
+			if !ids[r.ID] {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == 0 {
+			delete(x.postings, tok)
+		} else {
+			x.postings[tok] = kept
+		}
+	}
+}
+
+// Len returns the number of entries in the index.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.entries)
+}
+
+// Prune removes the entries older than the retention of the index, as
+// of now, and returns how many it removed. Their files are removed in
+// the background.
+func (x *Index) Prune(now time.Time) int {
+	if x.retention == 0 {
+		return 0
+	}
+	cutoff := now.Add(-x.retention)
+	x.mu.Lock()
+	var old []*Entry
+	for _, e := range x.entries {
+		if e.Time.Before(cutoff) {
+			old = append(old, e)
+		}
+	}
+	x.remove(old)
+	x.mu.Unlock()
+
+	for _, e := range old {
+		x.ops <- fileOp{file: entryFile(e)}
+	}
+	return len(old)
+}
+
+// A Query selects failures from an index. Its zero value matches all
+// failures.
+type Query struct {
+	// Terms are words which are all in the snippet, package, test or
+	// section of a matching failure, ignoring case.
+	Terms []string
+	// Regexp, if non-nil, matches the snippet of a matching failure.
+	Regexp *regexp.Regexp
+
+	// Builder and Repo, if non-empty, are patterns of the builder and
+	// repo of a matching failure, in the syntax of path.Match, such as
+	// "linux-*".
+	Builder string
+	Repo    string
+	// Since and Until, if non-zero, limit the time of the build of a
+	// matching failure to [Since, Until).
+	Since, Until time.Time
+	// TryBot is whether to include failures of try runs.
+	TryBot bool
+
+	// Limit, if positive, is the most results to return.
+	Limit int
+}
+
+// A Result is a failure matching a query.
+type Result struct {
+	*Entry
+	Fail Fail
+}
+
+// Search returns the failures matching q, newest first.
+func (x *Index) Search(q Query) []Result {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var refs []failRef
+	var terms []string
+	for _, t := range q.Terms {
+		terms = append(terms, tokens(t)...)
+	}
+	if len(terms) == 0 {
+		for _, e := range x.entries {
+			for i := range e.Fails {
+				refs = append(refs, failRef{e.ID, i})
+			}
+		}
+	} else {
+		// Intersect the postings of the terms, starting with the
+		// shortest.
+		sort.Slice(terms, func(i, j int) bool { return len(x.postings[terms[i]]) < len(x.postings[terms[j]]) })
+		refs = x.postings[terms[0]]
+		for _, t := range terms[1:] {
+			in := make(map[failRef]bool)
+			for _, r := range x.postings[t] {
+				in[r] = true
+			}
+			var both []failRef
+			for _, r := range refs {
+				if in[r] {
+					both = append(both, r)
+				}
+			}
+			refs = both
+		}
+	}
+
+	var results []Result
+	for _, r := range refs {
+		e := x.entries[r.ID]
+		if !q.matches(e) {
+			continue
+		}
+		f := e.Fails[r.Fail]
+		if q.Regexp != nil && !q.Regexp.MatchString(f.Snippet) {
+			continue
+		}
+		results = append(results, Result{e, f})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].Time.Equal(results[j].Time) {
+			return results[i].Time.After(results[j].Time)
+		}
+		return results[i].ID < results[j].ID
+	})
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results
+}
+
+// matches reports whether the entry e matches the filters of q.
+func (q Query) matches(e *Entry) bool {
+	if e.TryBot && !q.TryBot {
+		return false
+	}
+	if q.Builder != "" {
+		if ok, _ := path.Match(q.Builder, e.Builder); !ok {
+			return false
+		}
+	}
+	if q.Repo != "" {
+		if ok, _ := path.Match(q.Repo, e.Repo); !ok {
+			return false
+		}
+	}
+	if !q.Since.IsZero() && e.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !e.Time.Before(q.Until) {
+		return false
+	}
+	return true
+}
+
+// tokenSet returns the set of tokens of the failure f.
+func tokenSet(f Fail) map[string]bool {
+	set := make(map[string]bool)
+	for _, s := range []string{f.Section, f.Pkg, f.Test, f.Snippet} {
+		for _, tok := range tokens(s) {
+			set[tok] = true
+		}
+	}
+	return set
+}
+
+// tokens splits s into lowercase words of letters, digits and
+// underscores. Single characters aren't words.
+func tokens(s string) []string {
+	var toks []string
+	for _, w := range strings.FieldsFunc(s, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_'
+	}) {
+		if len(w) > 1 {
+			toks = append(toks, strings.ToLower(w))
+		}
+	}
+	return toks
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package logindex
+
+import (
+	"context"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/gcsfs"
+)
+
+var day = time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+var testEntries = []*Entry{
+	{
+		ID: "B1", Builder: "linux-amd64", Repo: "go", Rev: "1111", Time: day,
+		Fails: []Fail{
+			{Section: "go_test:net/http", Pkg: "net/http", Test: "TestServerTimeouts", Snippet: "--- FAIL: TestServerTimeouts (1.00s)\n    serve_test.go:123: read tcp: i/o timeout"},
+			{Section: "go_test:os", Pkg: "os", Test: "TestRemoveAll", Snippet: "--- FAIL: TestRemoveAll (0.01s)\n    permission denied"},
+		},
+	},
+	{
+		ID: "B2", Builder: "windows-amd64-2016", Repo: "go", Rev: "2222", Time: day.Add(time.Hour),
+		Fails: []Fail{
+			{Section: "go_test:net/http", Pkg: "net/http", Test: "TestServerTimeouts", Snippet: "--- FAIL: TestServerTimeouts (2.00s)\n    serve_test.go:123: i/o timeout"},
+		},
+	},
+	{
+		ID: "B3", Builder: "linux-arm64", Repo: "tools", Rev: "3333", GoRev: "1111", Time: day.Add(48 * time.Hour),
+		Fails: []Fail{
+			{Pkg: "golang.org/x/tools/gopls", Snippet: "panic: runtime error: index out of range [3] with length 3"},
+		},
+	},
+	{
+		ID: "T4", Builder: "linux-amd64", Repo: "go", Rev: "4444", TryBot: true, Time: day.Add(72 * time.Hour),
+		Fails: []Fail{
+			{Pkg: "net/http", Test: "TestServerTimeouts", Snippet: "--- FAIL: TestServerTimeouts\n    i/o timeout"},
+		},
+	},
+}
+
+func newTestIndex(t *testing.T, retention time.Duration) (*Index, fs.FS) {
+	t.Helper()
+	fsys := gcsfs.DirFS(t.TempDir())
+	x, err := Open(context.Background(), fsys, retention)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range testEntries {
+		if err := x.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	x.Flush()
+	return x, fsys
+}
+
+// ids returns the IDs and packages of the results, such as "B1 net/http".
+func ids(results []Result) []string {
+	var s []string
+	for _, r := range results {
+		s = append(s, r.ID+" "+r.Fail.Pkg)
+	}
+	return s
+}
+
+func TestSearch(t *testing.T) {
+	x, _ := newTestIndex(t, 0)
+	tests := []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{"all", Query{}, []string{"B3 golang.org/x/tools/gopls", "B2 net/http", "B1 net/http", "B1 os"}},
+		{"trybots", Query{TryBot: true, Limit: 2}, []string{"T4 net/http", "B3 golang.org/x/tools/gopls"}},
+		{"term", Query{Terms: []string{"TIMEOUT"}}, []string{"B2 net/http", "B1 net/http"}},
+		{"terms", Query{Terms: []string{"i/o timeout", "tcp"}}, []string{"B1 net/http"}},
+		{"test name", Query{Terms: []string{"TestRemoveAll"}}, []string{"B1 os"}},
+		{"unknown term", Query{Terms: []string{"timeout", "nonexistent"}}, nil},
+		{"regexp", Query{Regexp: regexp.MustCompile(`index out of range \[\d+\]`)}, []string{"B3 golang.org/x/tools/gopls"}},
+		{"builder", Query{Terms: []string{"timeout"}, Builder: "windows-*"}, []string{"B2 net/http"}},
+		{"repo", Query{Repo: "tools"}, []string{"B3 golang.org/x/tools/gopls"}},
+		{"dates", Query{Since: day.Add(time.Minute), Until: day.Add(24 * time.Hour)}, []string{"B2 net/http"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(x.Search(tt.q)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%+v) = %q; want %q", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAndReplace(t *testing.T) {
+	_, fsys := newTestIndex(t, 0)
+	x, err := Open(context.Background(), fsys, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != len(testEntries) {
+		t.Errorf("reopened index has %d entries; want %d", x.Len(), len(testEntries))
+	}
+	if got, want := ids(x.Search(Query{Terms: []string{"timeout"}})), []string{"B2 net/http", "B1 net/http"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reopened index: Search = %q; want %q", got, want)
+	}
+
+	// Replacing an entry drops the tokens of its old failures.
+	b2 := *testEntries[1]
+	b2.Fails = []Fail{{Pkg: "runtime", Snippet: "fatal error: out of memory"}}
+	if err := x.Add(&b2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(x.Search(Query{Terms: []string{"timeout"}})), []string{"B1 net/http"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after replacing B2: Search(timeout) = %q; want %q", got, want)
+	}
+	if got, want := ids(x.Search(Query{Terms: []string{"memory"}})), []string{"B2 runtime"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after replacing B2: Search(memory) = %q; want %q", got, want)
+	}
+
+	// Replacing an entry with one of another date removes the old
+	// file, so the old entry doesn't come back on reopening.
+	moved := b2
+	moved.Time = b2.Time.Add(48 * time.Hour)
+	if err := x.Add(&moved); err != nil {
+		t.Fatal(err)
+	}
+	x.Flush()
+	if _, err := fs.Stat(fsys, entryFile(testEntries[1])); err == nil {
+		t.Errorf("after moving B2 to another date: %s still exists", entryFile(testEntries[1]))
+	}
+	if got, want := ids(x.Search(Query{Terms: []string{"memory"}})), []string{"B2 runtime"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after moving B2: Search(memory) = %q; want %q", got, want)
+	}
+	x, err = Open(context.Background(), fsys, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != len(testEntries) {
+		t.Errorf("reopened index has %d entries; want %d", x.Len(), len(testEntries))
+	}
+	if got := x.Search(Query{Terms: []string{"memory"}}); len(got) != 1 || !got[0].Time.Equal(moved.Time) {
+		t.Errorf("reopened index: Search(memory) = %v; want B2 at %v", got, moved.Time)
+	}
+
+	if err := x.Add(&Entry{ID: "../B5", Time: day}); err == nil {
+		t.Errorf("Add of entry with ID %q succeeded; want error", "../B5")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	x, fsys := newTestIndex(t, 36*time.Hour)
+	n := x.Prune(day.Add(72 * time.Hour))
+	x.Flush()
+	if n != 2 {
+		t.Errorf("Prune removed %d entries; want 2", n)
+	}
+	if got, want := ids(x.Search(Query{TryBot: true})), []string{"T4 net/http", "B3 golang.org/x/tools/gopls"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after Prune: Search = %q; want %q", got, want)
+	}
+	if got := ids(x.Search(Query{Terms: []string{"TestRemoveAll"}})); got != nil {
+		t.Errorf("after Prune: Search(TestRemoveAll) = %q; want none", got)
+	}
+	if _, err := fs.Stat(fsys, entryFile(testEntries[0])); err == nil {
+		t.Errorf("after Prune: %s still exists", entryFile(testEntries[0]))
+	}
+
+	// Pruned entries stay gone when the index is reopened.
+	x, err := Open(context.Background(), fsys, 36*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != 2 {
+		t.Errorf("reopened index has %d entries; want 2", x.Len())
+	}
+}
+
+func TestFailsOf(t *testing.T) {
+	const buildLog = `##### Testing packages.
+ok  	archive/tar	0.025s
+--- FAIL: TestServerTimeouts (1.00s)
+    serve_test.go:123: read tcp: i/o timeout
+FAIL
+FAIL	net/http	1.234s
+`
+	fails := FailsOf(buildLog)
+	if len(fails) != 1 {
+		t.Fatalf("FailsOf found %d failures; want 1: %+v", len(fails), fails)
+	}
+	if f := fails[0]; f.Pkg != "net/http" || f.Test != "TestServerTimeouts" {
+		t.Errorf("FailsOf = %+v; want TestServerTimeouts in net/http", f)
+	}
+}
@@ -152,8 +152,31 @@ func NewEC2Buildlet(client *cloud.AWSClient, buildEnv *buildenv.Environment, hos
 	return b, nil
 }
 
-// GetBuildlet retrieves a buildlet client for a newly created buildlet.
+// GetBuildlet retrieves a buildlet client for a newly created buildlet,
+// or a pre-warmed one if there is one.
 func (eb *EC2Buildlet) GetBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (buildlet.Client, error) {
+	if bc := prewarmer.take(hostType, lg); bc != nil {
+		return bc, nil
+	}
+	return eb.newBuildlet(ctx, hostType, lg, si)
+}
+
+// numCPU returns the number of vCPUs of a buildlet of hostType.
+func (eb *EC2Buildlet) numCPU(hostType string) int {
+	hconf, ok := eb.hosts[hostType]
+	if !ok {
+		return 0
+	}
+	eb.ledger.mu.RLock()
+	defer eb.ledger.mu.RUnlock()
+	if it, ok := eb.ledger.types[hconf.MachineType()]; ok {
+		return int(it.CPU)
+	}
+	return 0
+}
+
+// newBuildlet creates a new buildlet VM.
+func (eb *EC2Buildlet) newBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (buildlet.Client, error) {
 	hconf, ok := eb.hosts[hostType]
 	if !ok {
 		return nil, fmt.Errorf("ec2 pool: unknown host type %q", hostType)
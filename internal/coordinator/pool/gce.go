@@ -259,6 +259,12 @@ func (c *GCEConfiguration) GerritClient() *gerrit.Client {
 	return gerritClient
 }
 
+// SetGerritClient sets the Gerrit client. This is primarily reserved
+// for testing purposes.
+func (c *GCEConfiguration) SetGerritClient(gc *gerrit.Client) {
+	gerritClient = gc
+}
+
 // GKENodeHostname retrieves the GKE node hostname.
 func (c *GCEConfiguration) GKENodeHostname() string {
 	return gkeNodeHostname
@@ -436,7 +442,21 @@ func (p *GCEBuildlet) SetEnabled(enabled bool) {
 }
 
 // GetBuildlet retrieves a buildlet client for an available buildlet.
-func (p *GCEBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (bc buildlet.Client, err error) {
+// A pre-warmed buildlet is used if there is one.
+func (p *GCEBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (buildlet.Client, error) {
+	if bc := prewarmer.take(hostType, lg); bc != nil {
+		return bc, nil
+	}
+	return p.newBuildlet(ctx, hostType, lg, si)
+}
+
+// numCPU returns the number of vCPUs of a buildlet of hostType.
+func (p *GCEBuildlet) numCPU(hostType string) int {
+	return GCENumCPU(dashboard.Hosts[hostType].MachineType())
+}
+
+// newBuildlet creates a new buildlet VM.
+func (p *GCEBuildlet) newBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (bc buildlet.Client, err error) {
 	if p.disabled {
 		return nil, errors.New("pool disabled by configuration")
 	}
@@ -523,6 +543,79 @@ func (p *GCEBuildlet) GetBuildlet(ctx context.Context, hostType string, lg Logge
 	return bc, nil
 }
 
+// Reserve marks instName, a buildlet VM created by an earlier
+// coordinator process, as in use, so CleanUpOldVMs doesn't delete it
+// before it can be reattached. Reservations which aren't reattached
+// must be given up with Release.
+func (p *GCEBuildlet) Reserve(instName string) {
+	p.setInstanceUsed(instName, true)
+}
+
+// Release gives up the reservation of instName made by Reserve. The
+// VM is deleted by the next CleanUpOldVMs pass.
+func (p *GCEBuildlet) Release(instName string) {
+	p.setInstanceUsed(instName, false)
+}
+
+// Reattach returns a client for the running buildlet VM instName at
+// ipPort, created by an earlier coordinator process. Closing the
+// client deletes the VM, as it does for VMs from GetBuildlet. The VM
+// is charged to the instance and CPU quotas, as VMs from GetBuildlet
+// are. If the VM can't be reattached, its reservation is released.
+func (p *GCEBuildlet) Reattach(ctx context.Context, instName, ipPort string) (bc buildlet.Client, err error) {
+	defer func() {
+		if err != nil {
+			p.Release(instName)
+		}
+	}()
+	if p.disabled {
+		return nil, errors.New("pool disabled by configuration")
+	}
+	if computeService == nil {
+		return nil, errors.New("no compute service")
+	}
+	var zone, machineType string
+	for _, z := range buildEnv.VMZones {
+		gceAPIGate()
+		inst, err := computeService.Instances.Get(buildEnv.ProjectName, z, instName).Context(ctx).Do()
+		if apiErr, ok := err.(*googleapi.Error); ok && apiErr.Code == http.StatusNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if inst.Status != "RUNNING" {
+			return nil, fmt.Errorf("instance %q is %s", instName, inst.Status)
+		}
+		zone, machineType = z, path.Base(inst.MachineType)
+		break
+	}
+	if zone == "" {
+		return nil, fmt.Errorf("instance %q not found", instName)
+	}
+	p.setInstanceUsed(instName, true)
+
+	bc = buildlet.NewClient(ipPort, buildlet.NoKeyPair)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := bc.Status(ctx); err != nil {
+		bc.Close()
+		deleteVM(zone, instName)
+		return nil, fmt.Errorf("buildlet %q not responding: %v", instName, err)
+	}
+	instItem := p.instQueue.Take(1)
+	cpuItem := p.queueForMachineType(machineType).Take(GCENumCPU(machineType))
+	log.Printf("Reattached GCE VM %q at %s", instName, zone)
+	bc.SetDescription("GCE VM: " + instName + " (reattached)")
+	bc.SetInstanceName(instName)
+	bc.SetOnHeartbeatFailure(func() {
+		deleteVM(zone, instName)
+		instItem.ReturnQuota()
+		cpuItem.ReturnQuota()
+		p.setInstanceUsed(instName, false)
+	})
+	return bc, nil
+}
+
 // WriteHTMLStatus writes the status of the buildlet pool to an io.Writer.
 func (p *GCEBuildlet) WriteHTMLStatus(w io.Writer) {
 	fmt.Fprintf(w, "<b>GCE pool</b> capacity: %s", p.capacityString())
@@ -0,0 +1,439 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package pool
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/build/buildenv"
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/dashboard"
+	"golang.org/x/build/internal"
+	"golang.org/x/build/internal/buildstats"
+	"golang.org/x/build/internal/coordinator/pool/queue"
+	"golang.org/x/build/internal/spanlog"
+)
+
+// PrewarmConfig configures a Prewarmer.
+type PrewarmConfig struct {
+	// MinRate is the number of buildlet requests per hour, averaged
+	// over past weeks, below which no buildlets of a host type are
+	// kept warm during an hour of the week.
+	MinRate float64
+	// StartDuration is how long a cloud buildlet takes to start.
+	// Enough buildlets are kept warm to serve the requests expected
+	// to arrive in that time.
+	StartDuration time.Duration
+	// MaxPerHostType is the most buildlets kept warm for a host type.
+	MaxPerHostType int
+	// MaxIdle is how long a warm buildlet waits for work before it
+	// is destroyed.
+	MaxIdle time.Duration
+	// MaxCPUs caps the total vCPUs of warm and starting buildlets.
+	MaxCPUs int
+	// MaxIdleCPUHoursPerDay caps the vCPU-hours that warm buildlets
+	// may spend idle each day (in UTC). Once it is reached, no more
+	// buildlets are warmed until the next day.
+	MaxIdleCPUHoursPerDay float64
+}
+
+// DefaultPrewarmConfig is a conservative PrewarmConfig.
+var DefaultPrewarmConfig = PrewarmConfig{
+	MinRate:               20,
+	StartDuration:         2 * time.Minute,
+	MaxPerHostType:        2,
+	MaxIdle:               15 * time.Minute,
+	MaxCPUs:               64,
+	MaxIdleCPUHoursPerDay: 100,
+}
+
+// PrewarmStats are the cumulative statistics of a Prewarmer for a host type.
+type PrewarmStats struct {
+	Hits         int64   // requests served by a warm buildlet
+	Misses       int64   // requests which found no warm buildlet while warming was on
+	Created      int64   // buildlets warmed
+	Expired      int64   // warm buildlets destroyed without being used
+	IdleCPUHours float64 // vCPU-hours spent by warm buildlets waiting for work
+}
+
+// HitRate returns the fraction of requests served by warm buildlets.
+func (s PrewarmStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// warmablePool is a pool of cloud buildlets which a Prewarmer can
+// create ahead of demand.
+type warmablePool interface {
+	// newBuildlet creates a buildlet, without using warm buildlets.
+	newBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (buildlet.Client, error)
+	// numCPU returns the number of vCPUs of a buildlet of hostType.
+	numCPU(hostType string) int
+}
+
+// warmBuildlet is an idle buildlet kept by a Prewarmer.
+type warmBuildlet struct {
+	bc    buildlet.Client
+	ready time.Time // when it started waiting for work
+	since time.Time // when its idle time was last accounted for
+	cpus  int
+}
+
+// Prewarmer keeps a few cloud buildlets started ahead of demand for the
+// host types and hours of the week which have historically been busy,
+// so that builds do not have to wait for a VM to start.
+type Prewarmer struct {
+	cfg        PrewarmConfig
+	poolFor    func(hostType string) warmablePool
+	hostTypeOf func(builder string) string
+	loadDemand func(context.Context) (*buildstats.BuildletDemand, error)
+	now        func() time.Time
+
+	mu           sync.Mutex                                   // guards all following
+	demand       map[string]*[buildstats.HoursPerWeek]float64 // host type -> requests per hour
+	warm         map[string][]*warmBuildlet                   // host type -> idle buildlets, oldest first
+	starting     map[string]int                               // host type -> buildlets being started
+	startingCPUs int
+	stats        map[string]*PrewarmStats
+	idleDay      time.Time // UTC day that idleCPUHours is for
+	idleCPUHours float64
+}
+
+// prewarmer is the Prewarmer used by the GCE and EC2 pools, if any.
+var prewarmer *Prewarmer
+
+// StartPrewarmer starts pre-warming GCE and EC2 buildlets according to
+// the historical demand in env, until ctx is done.
+func StartPrewarmer(ctx context.Context, env *buildenv.Environment, cfg PrewarmConfig) *Prewarmer {
+	p := newPrewarmer(cfg, warmablePoolForHost, builderHostType, func(ctx context.Context) (*buildstats.BuildletDemand, error) {
+		return buildstats.QueryBuildletDemand(ctx, env, 4)
+	})
+	prewarmer = p
+	go internal.PeriodicallyDo(ctx, time.Hour, func(ctx context.Context, _ time.Time) {
+		if err := p.updateDemand(ctx); err != nil {
+			log.Printf("prewarm: updating buildlet demand: %v", err)
+		}
+	})
+	go internal.PeriodicallyDo(ctx, time.Minute, func(ctx context.Context, _ time.Time) {
+		p.refill(ctx)
+	})
+	return p
+}
+
+func newPrewarmer(cfg PrewarmConfig, poolFor func(string) warmablePool, hostTypeOf func(string) string, loadDemand func(context.Context) (*buildstats.BuildletDemand, error)) *Prewarmer {
+	return &Prewarmer{
+		cfg:        cfg,
+		poolFor:    poolFor,
+		hostTypeOf: hostTypeOf,
+		loadDemand: loadDemand,
+		now:        time.Now,
+		demand:     make(map[string]*[buildstats.HoursPerWeek]float64),
+		warm:       make(map[string][]*warmBuildlet),
+		starting:   make(map[string]int),
+		stats:      make(map[string]*PrewarmStats),
+	}
+}
+
+// warmablePoolForHost returns the pool which creates buildlets of
+// hostType, or nil if they cannot be pre-warmed.
+func warmablePoolForHost(hostType string) warmablePool {
+	hconf, ok := dashboard.Hosts[hostType]
+	if !ok {
+		return nil
+	}
+	switch {
+	case hconf.IsEC2():
+		if ec2Buildlet == nil || ec2Buildlet.buildletClient == nil {
+			return nil
+		}
+		return ec2Buildlet
+	case hconf.IsVM(), hconf.IsContainer():
+		if gcePool.disabled || computeService == nil {
+			return nil
+		}
+		return gcePool
+	}
+	return nil
+}
+
+func builderHostType(builder string) string {
+	if bc, ok := dashboard.Builders[builder]; ok {
+		return bc.HostType
+	}
+	return ""
+}
+
+// updateDemand reloads the historical demand for buildlets and sums it
+// by host type.
+func (p *Prewarmer) updateDemand(ctx context.Context) error {
+	d, err := p.loadDemand(ctx)
+	if err != nil {
+		return err
+	}
+	demand := make(map[string]*[buildstats.HoursPerWeek]float64)
+	for builder, reqs := range d.Requests {
+		hostType := p.hostTypeOf(builder)
+		if hostType == "" || d.Weeks == 0 {
+			continue
+		}
+		rates := demand[hostType]
+		if rates == nil {
+			rates = new([buildstats.HoursPerWeek]float64)
+			demand[hostType] = rates
+		}
+		for h, n := range reqs {
+			rates[h] += float64(n) / float64(d.Weeks)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.demand = demand
+	return nil
+}
+
+// targetLocked returns how many buildlets of hostType to keep warm at t.
+func (p *Prewarmer) targetLocked(hostType string, t time.Time) int {
+	rates, ok := p.demand[hostType]
+	if !ok {
+		return 0
+	}
+	rate := rates[buildstats.HourOfWeek(t)]
+	if rate < p.cfg.MinRate {
+		return 0
+	}
+	n := int(math.Ceil(rate * p.cfg.StartDuration.Hours()))
+	if n > p.cfg.MaxPerHostType {
+		n = p.cfg.MaxPerHostType
+	}
+	return n
+}
+
+// accountIdleLocked adds the idle time of the warm buildlets up to now
+// to the daily idle budget, starting a new day if needed.
+func (p *Prewarmer) accountIdleLocked(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(p.idleDay) {
+		p.idleDay, p.idleCPUHours = day, 0
+	}
+	for hostType, wbs := range p.warm {
+		for _, wb := range wbs {
+			p.chargeLocked(hostType, wb, now)
+		}
+	}
+}
+
+// chargeLocked accounts for the idle time of wb up to now.
+func (p *Prewarmer) chargeLocked(hostType string, wb *warmBuildlet, now time.Time) {
+	h := float64(wb.cpus) * now.Sub(wb.since).Hours()
+	if h <= 0 {
+		return
+	}
+	wb.since = now
+	p.idleCPUHours += h
+	p.statsLocked(hostType).IdleCPUHours += h
+}
+
+func (p *Prewarmer) statsLocked(hostType string) *PrewarmStats {
+	s, ok := p.stats[hostType]
+	if !ok {
+		s = new(PrewarmStats)
+		p.stats[hostType] = s
+	}
+	return s
+}
+
+func (p *Prewarmer) cpusLocked() int {
+	n := p.startingCPUs
+	for _, wbs := range p.warm {
+		for _, wb := range wbs {
+			n += wb.cpus
+		}
+	}
+	return n
+}
+
+// refill destroys warm buildlets which have waited too long and starts
+// new ones for the host types which are expected to be busy.
+func (p *Prewarmer) refill(ctx context.Context) {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.accountIdleLocked(now)
+
+	for hostType, wbs := range p.warm {
+		kept := wbs[:0]
+		for _, wb := range wbs {
+			if now.Sub(wb.ready) < p.cfg.MaxIdle && !wb.bc.IsBroken() {
+				kept = append(kept, wb)
+				continue
+			}
+			p.statsLocked(hostType).Expired++
+			go wb.bc.Close()
+		}
+		p.warm[hostType] = kept
+	}
+
+	hostTypes := make([]string, 0, len(p.demand))
+	for hostType := range p.demand {
+		hostTypes = append(hostTypes, hostType)
+	}
+	sort.Strings(hostTypes)
+	for _, hostType := range hostTypes {
+		need := p.targetLocked(hostType, now) - len(p.warm[hostType]) - p.starting[hostType]
+		if need <= 0 {
+			continue
+		}
+		wp := p.poolFor(hostType)
+		if wp == nil {
+			continue
+		}
+		cpus := wp.numCPU(hostType)
+		for ; need > 0; need-- {
+			if p.idleCPUHours >= p.cfg.MaxIdleCPUHoursPerDay || p.cpusLocked()+cpus > p.cfg.MaxCPUs {
+				return
+			}
+			p.starting[hostType]++
+			p.startingCPUs += cpus
+			go p.start(ctx, wp, hostType, cpus)
+		}
+	}
+}
+
+// start creates a buildlet of hostType and adds it to the warm buildlets.
+func (p *Prewarmer) start(ctx context.Context, wp warmablePool, hostType string, cpus int) {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.MaxIdle)
+	defer cancel()
+	si := &queue.SchedItem{
+		HostType:    hostType,
+		RequestTime: p.now(),
+		// Sort after all other batch work, so that pre-warming only
+		// takes quota that nothing else is waiting for.
+		CommitTime: time.Unix(1, 0),
+	}
+	bc, err := wp.newBuildlet(ctx, hostType, prewarmLogger{hostType}, si)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.starting[hostType]--
+	p.startingCPUs -= cpus
+	if err != nil {
+		log.Printf("prewarm: starting %s buildlet: %v", hostType, err)
+		return
+	}
+	now := p.now()
+	p.statsLocked(hostType).Created++
+	p.warm[hostType] = append(p.warm[hostType], &warmBuildlet{bc: bc, ready: now, since: now, cpus: cpus})
+}
+
+// take returns a warm buildlet of hostType, or nil if there is none.
+// It is safe to call on a nil Prewarmer.
+func (p *Prewarmer) take(hostType string, lg Logger) buildlet.Client {
+	if p == nil {
+		return nil
+	}
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	wbs := p.warm[hostType]
+	for len(wbs) > 0 {
+		// Use the newest buildlet, so that the older ones expire.
+		wb := wbs[len(wbs)-1]
+		wbs = wbs[:len(wbs)-1]
+		p.warm[hostType] = wbs
+		p.chargeLocked(hostType, wb, now)
+		if wb.bc.IsBroken() || now.Sub(wb.ready) >= p.cfg.MaxIdle {
+			p.statsLocked(hostType).Expired++
+			go wb.bc.Close()
+			continue
+		}
+		p.statsLocked(hostType).Hits++
+		lg.LogEventTime("using_prewarmed_buildlet", fmt.Sprintf("%s, warm for %v", wb.bc.InstanceName(), friendlyDuration(now.Sub(wb.ready))))
+		return wb.bc
+	}
+	if p.targetLocked(hostType, now) > 0 {
+		p.statsLocked(hostType).Misses++
+	}
+	return nil
+}
+
+// Ready reports whether a warm buildlet of hostType is available.
+// It is safe to call on a nil Prewarmer.
+func (p *Prewarmer) Ready(hostType string) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.warm[hostType]) > 0
+}
+
+// Stats returns a copy of the statistics for each host type.
+func (p *Prewarmer) Stats() map[string]PrewarmStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.accountIdleLocked(p.now())
+	m := make(map[string]PrewarmStats, len(p.stats))
+	for hostType, s := range p.stats {
+		m[hostType] = *s
+	}
+	return m
+}
+
+// WriteHTMLStatus writes the status of the Prewarmer to w.
+func (p *Prewarmer) WriteHTMLStatus(w io.Writer) {
+	stats := p.Stats()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	fmt.Fprintf(w, "<b>Pre-warmed buildlets</b>: %d vCPUs of %d; %.1f of %.1f idle vCPU-hours used today<ul>",
+		p.cpusLocked(), p.cfg.MaxCPUs, p.idleCPUHours, p.cfg.MaxIdleCPUHoursPerDay)
+	hostTypes := make([]string, 0, len(stats))
+	for hostType := range stats {
+		hostTypes = append(hostTypes, hostType)
+	}
+	for hostType := range p.demand {
+		if _, ok := stats[hostType]; !ok && p.targetLocked(hostType, now) > 0 {
+			hostTypes = append(hostTypes, hostType)
+		}
+	}
+	sort.Strings(hostTypes)
+	for _, hostType := range hostTypes {
+		s := stats[hostType]
+		fmt.Fprintf(w, "<li>%s: %d warm, %d starting, target %d; hit rate %.0f%% (%d/%d), %d expired, %.1f idle vCPU-hours</li>\n",
+			html.EscapeString(hostType), len(p.warm[hostType]), p.starting[hostType], p.targetLocked(hostType, now),
+			100*s.HitRate(), s.Hits, s.Hits+s.Misses, s.Expired, s.IdleCPUHours)
+	}
+	fmt.Fprintf(w, "</ul>")
+}
+
+// prewarmLogger logs the creation of warm buildlets, which belong to no build.
+type prewarmLogger struct {
+	hostType string
+}
+
+func (l prewarmLogger) LogEventTime(event string, optText ...string) {
+	log.Printf("prewarm %s: %s %q", l.hostType, event, optText)
+}
+
+func (l prewarmLogger) CreateSpan(event string, optText ...string) spanlog.Span {
+	return prewarmSpan{}
+}
+
+type prewarmSpan struct{}
+
+func (prewarmSpan) Done(err error) error { return err }
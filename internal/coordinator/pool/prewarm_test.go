@@ -0,0 +1,166 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package pool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/build/buildlet"
+	"golang.org/x/build/internal/buildstats"
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+type fakeWarmablePool struct {
+	mu      sync.Mutex
+	created int
+	si      *queue.SchedItem
+}
+
+func (fp *fakeWarmablePool) newBuildlet(ctx context.Context, hostType string, lg Logger, si *queue.SchedItem) (buildlet.Client, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.created++
+	fp.si = si
+	bc := &buildlet.FakeClient{}
+	bc.SetInstanceName(instanceName(hostType, 7))
+	return bc, nil
+}
+
+func (fp *fakeWarmablePool) numCPU(hostType string) int { return 4 }
+
+func TestPrewarmer(t *testing.T) {
+	// Sunday 10:00 UTC is busy for host-linux-fast, and never for host-linux-slow.
+	now := time.Date(2023, time.January, 1, 10, 30, 0, 0, time.UTC)
+	demand := &buildstats.BuildletDemand{
+		Weeks: 2,
+		Requests: map[string]*[buildstats.HoursPerWeek]int{
+			"linux-fast":      new([buildstats.HoursPerWeek]int),
+			"linux-fast-race": new([buildstats.HoursPerWeek]int),
+			"linux-slow":      new([buildstats.HoursPerWeek]int),
+		},
+	}
+	demand.Requests["linux-fast"][10] = 120
+	demand.Requests["linux-fast-race"][10] = 60
+	demand.Requests["linux-slow"][10] = 10
+	hostTypes := map[string]string{
+		"linux-fast":      "host-linux-fast",
+		"linux-fast-race": "host-linux-fast",
+		"linux-slow":      "host-linux-slow",
+	}
+
+	fp := new(fakeWarmablePool)
+	cfg := DefaultPrewarmConfig
+	cfg.MaxPerHostType = 5
+	p := newPrewarmer(cfg,
+		func(string) warmablePool { return fp },
+		func(builder string) string { return hostTypes[builder] },
+		func(context.Context) (*buildstats.BuildletDemand, error) { return demand, nil })
+	p.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if err := p.updateDemand(ctx); err != nil {
+		t.Fatalf("updateDemand() = %v; want no error", err)
+	}
+	// 90 requests an hour and 2 minutes to start a buildlet.
+	p.mu.Lock()
+	fast, slow := p.targetLocked("host-linux-fast", now), p.targetLocked("host-linux-slow", now)
+	p.mu.Unlock()
+	if fast != 3 || slow != 0 {
+		t.Errorf("targets = %d, %d; want 3, 0", fast, slow)
+	}
+
+	p.refill(ctx)
+	waitForWarm(t, p, "host-linux-fast", 3)
+	if fp.si.Less(&queue.SchedItem{HostType: "host-linux-fast", RequestTime: now}) {
+		t.Errorf("pre-warming SchedItem sorts before other batch work")
+	}
+
+	lg := noopEventTimeLogger{}
+	if bc := p.take("host-linux-fast", lg); bc == nil {
+		t.Errorf("take(%q) = nil; want a warm buildlet", "host-linux-fast")
+	}
+	if bc := p.take("host-linux-slow", lg); bc != nil {
+		t.Errorf("take(%q) = %v; want nil", "host-linux-slow", bc)
+	}
+
+	// After an hour, the two remaining warm buildlets expire and are
+	// charged for their idle time.
+	now = now.Add(time.Hour)
+	p.refill(ctx)
+	stats := p.Stats()["host-linux-fast"]
+	if stats.Hits != 1 || stats.Misses != 0 || stats.Expired != 2 || stats.Created != 3 {
+		t.Errorf("stats = %+v; want 1 hit, 0 misses, 2 expired, 3 created", stats)
+	}
+	if want := 2 * 4 * 1.0; stats.IdleCPUHours != want {
+		t.Errorf("IdleCPUHours = %v; want %v", stats.IdleCPUHours, want)
+	}
+	if p.Ready("host-linux-fast") {
+		t.Errorf("Ready(%q) = true outside of busy hours", "host-linux-fast")
+	}
+	if bc := p.take("host-linux-fast", lg); bc != nil {
+		t.Errorf("take(%q) = %v outside of busy hours; want nil", "host-linux-fast", bc)
+	}
+}
+
+func TestPrewarmerCostCap(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 10, 30, 0, 0, time.UTC)
+	demand := &buildstats.BuildletDemand{
+		Weeks:    1,
+		Requests: map[string]*[buildstats.HoursPerWeek]int{"linux-fast": new([buildstats.HoursPerWeek]int)},
+	}
+	demand.Requests["linux-fast"][10] = 1000
+
+	fp := new(fakeWarmablePool)
+	cfg := DefaultPrewarmConfig
+	cfg.MaxPerHostType = 10
+	cfg.MaxCPUs = 8
+	cfg.MaxIdleCPUHoursPerDay = 1
+	p := newPrewarmer(cfg,
+		func(string) warmablePool { return fp },
+		func(string) string { return "host-linux-fast" },
+		func(context.Context) (*buildstats.BuildletDemand, error) { return demand, nil })
+	p.now = func() time.Time { return now }
+	ctx := context.Background()
+	if err := p.updateDemand(ctx); err != nil {
+		t.Fatalf("updateDemand() = %v; want no error", err)
+	}
+
+	// Only two 4 vCPU buildlets fit in MaxCPUs.
+	p.refill(ctx)
+	waitForWarm(t, p, "host-linux-fast", 2)
+	p.refill(ctx)
+	if fp.created != 2 {
+		t.Errorf("created %d buildlets; want 2", fp.created)
+	}
+
+	// Ten idle minutes use up the daily budget, so expired buildlets are
+	// not replaced.
+	now = now.Add(10 * time.Minute)
+	p.refill(ctx)
+	now = now.Add(cfg.MaxIdle)
+	p.refill(ctx)
+	if p.Ready("host-linux-fast") || fp.created != 2 {
+		t.Errorf("buildlets warmed after the idle budget was used up")
+	}
+}
+
+func waitForWarm(t *testing.T, p *Prewarmer, hostType string, n int) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		p.mu.Lock()
+		got, starting := len(p.warm[hostType]), p.starting[hostType]
+		p.mu.Unlock()
+		if got == n && starting == 0 {
+			return
+		}
+	}
+	t.Fatalf("%d buildlets of %s never became warm", n, hostType)
+}
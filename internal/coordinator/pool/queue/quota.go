@@ -142,6 +142,25 @@ func (q *Quota) Enqueue(cost int, si *SchedItem) *Item {
 	return item
 }
 
+// Take charges cost to the quota without waiting for it to be
+// available, for resources which are already in use, such as
+// buildlets created by an earlier coordinator process. The caller must
+// call ReturnQuota on the returned Item to release the quota.
+func (q *Quota) Take(cost int) *Item {
+	item := &Item{
+		cost:    cost,
+		release: func() { q.ReturnQuota(cost) },
+		popped:  make(chan struct{}),
+		index:   -1,
+	}
+	item.cancel = func() {}
+	close(item.popped)
+	q.mu.Lock()
+	q.used += cost
+	q.mu.Unlock()
+	return item
+}
+
 // AwaitQueue enqueues a build and returns once the item is unblocked
 // by quota, by order of minimum priority.
 //
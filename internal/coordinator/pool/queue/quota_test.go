@@ -34,6 +34,22 @@ func TestQueueReturnQuotas(t *testing.T) {
 	}
 }
 
+func TestQueueTake(t *testing.T) {
+	q := NewQuota()
+	q.UpdateLimit(4)
+	item := q.Take(6)
+	if usage := q.Quotas(); usage.Used != 6 {
+		t.Errorf("q.Quotas().Used = %d after Take(6) over the limit, wanted 6", usage.Used)
+	}
+	if err := item.Await(context.Background()); err != nil {
+		t.Errorf("item.Await() = %v, wanted nil", err)
+	}
+	item.ReturnQuota()
+	if usage := q.Quotas(); usage.Used != 0 {
+		t.Errorf("q.Quotas().Used = %d after ReturnQuota(), wanted 0", usage.Used)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	q := NewQuota()
 	q.UpdateQuotas(14, 16)
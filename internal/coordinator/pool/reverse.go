@@ -43,6 +43,8 @@ import (
 	"net"
 	"net/http"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -58,6 +60,7 @@ var (
 	reversePool = &ReverseBuildletPool{
 		hostLastGood: make(map[string]time.Time),
 		hostQueue:    make(map[string]*queue.Quota),
+		draining:     make(map[string]bool),
 	}
 
 	builderMasterKey []byte
@@ -76,7 +79,7 @@ func ReversePool() *ReverseBuildletPool {
 
 // ReverseBuildletPool manages the pool of reverse buildlet pools.
 type ReverseBuildletPool struct {
-	// mu guards all 5 fields below and also fields of
+	// mu guards all fields below and also fields of
 	// *reverseBuildlet in buildlets
 	mu sync.Mutex
 
@@ -103,6 +106,11 @@ type ReverseBuildletPool struct {
 	// machines as both POWER8 and POWER9 host types, but with the
 	// same names).
 	hostLastGood map[string]time.Time
+
+	// draining is the set of hostnames of buildlets which are not
+	// given new work. Like hostLastGood, it is keyed by hostname so
+	// that it survives reconnects.
+	draining map[string]bool
 }
 
 // BuildletLastSeen gives the last time a buildlet was connected to the pool. If
@@ -124,7 +132,7 @@ func (p *ReverseBuildletPool) tryToGrab(hostType string) (bc buildlet.Client, bu
 	defer p.mu.Unlock()
 	defer p.updateQuotasLocked()
 	for _, b := range p.buildlets {
-		if b.hostType != hostType {
+		if !b.supports(hostType) || p.draining[b.hostname] {
 			continue
 		}
 		if b.inUse {
@@ -297,25 +305,40 @@ func (p *ReverseBuildletPool) WriteHTMLStatus(w io.Writer) {
 	p.mu.Lock()
 	buildlets := append([]*reverseBuildlet(nil), p.buildlets...)
 	sort.Sort(byTypeThenHostname(buildlets))
-	numInUse := 0
+	numInUse, numDraining := 0, 0
 	for _, b := range buildlets {
 		machStatus := "<i>idle</i>"
 		if b.inUse {
 			machStatus = "working"
 			numInUse++
 		}
-		fmt.Fprintf(&buf, "<li>%s (%s) version %s, %s: connected %s, %s for %s</li>\n",
+		if p.draining[b.hostname] {
+			numDraining++
+			if b.inUse && !b.inHealthCheck {
+				machStatus = "<b>draining</b>, working"
+			} else {
+				machStatus = "<b>drained</b>"
+			}
+		}
+		var extra string
+		if len(b.extraHostTypes) > 0 {
+			extra = " (also " + strings.Join(b.extraHostTypes, ", ") + ")"
+		}
+		fmt.Fprintf(&buf, "<li>%s (%s) version %s, %s%s%s: connected %s, %s for %s</li>\n",
 			b.hostname,
 			b.conn.RemoteAddr(),
 			b.version,
 			b.hostType,
+			extra,
+			b.caps.summary(),
 			friendlyDuration(time.Since(b.regTime)),
 			machStatus,
 			friendlyDuration(time.Since(b.inUseTime)))
-		total[b.hostType]++
-		if b.inUse && !b.inHealthCheck {
-
-			inUse[b.hostType]++
+		for _, typ := range b.hostTypes() {
+			total[typ]++
+			if b.inUse && !b.inHealthCheck {
+				inUse[typ]++
+			}
 		}
 	}
 	numConnected := len(buildlets)
@@ -330,6 +353,7 @@ func (p *ReverseBuildletPool) WriteHTMLStatus(w io.Writer) {
 	io.WriteString(w, "<b>Reverse pool stats</b><ul>\n")
 	fmt.Fprintf(w, "<li>Buildlets connected: %d</li>\n", numConnected)
 	fmt.Fprintf(w, "<li>Buildlets in use: %d</li>\n", numInUse)
+	fmt.Fprintf(w, "<li>Buildlets draining or drained: %d</li>\n", numDraining)
 	io.WriteString(w, "</ul>")
 
 	io.WriteString(w, "<b>Reverse pool by host type</b> (in use / total)<ul>\n")
@@ -365,7 +389,9 @@ func (p *ReverseBuildletPool) HostTypeCount() map[string]int {
 	total := map[string]int{}
 	p.mu.Lock()
 	for _, b := range p.buildlets {
-		total[b.hostType]++
+		for _, typ := range b.hostTypes() {
+			total[typ]++
+		}
 	}
 	p.mu.Unlock()
 	return total
@@ -378,7 +404,7 @@ func (p *ReverseBuildletPool) SingleHostTypeCount(hostType string) int {
 	defer p.mu.Unlock()
 	n := 0
 	for _, b := range p.buildlets {
-		if b.hostType == hostType {
+		if b.supports(hostType) {
 			n++
 		}
 	}
@@ -402,12 +428,13 @@ func (p *ReverseBuildletPool) HostTypes() (types []string) {
 }
 
 // CanBuild reports whether the pool has a machine capable of building mode,
-// even if said machine isn't currently idle.
+// even if said machine isn't currently idle. Draining machines are not
+// considered capable.
 func (p *ReverseBuildletPool) CanBuild(hostType string) bool {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	for _, b := range p.buildlets {
-		if b.hostType == hostType {
+		if b.supports(hostType) && !p.draining[b.hostname] {
 			return true
 		}
 	}
@@ -424,9 +451,19 @@ func (p *ReverseBuildletPool) updateQuotasLocked() {
 	limits := make(map[string]int)
 	used := make(map[string]int)
 	for _, b := range p.buildlets {
-		limits[b.hostType] += 1
-		if b.inUse {
-			used[b.hostType] += 1
+		draining := p.draining[b.hostname]
+		for _, typ := range b.hostTypes() {
+			if _, ok := limits[typ]; !ok {
+				limits[typ] = 0
+			}
+			if draining {
+				// Draining buildlets take no new work.
+				continue
+			}
+			limits[typ] += 1
+			if b.inUse {
+				used[typ] += 1
+			}
 		}
 	}
 	for hostType, limit := range limits {
@@ -444,6 +481,61 @@ func (p *ReverseBuildletPool) addBuildlet(b *reverseBuildlet) {
 	go p.healthCheckBuildletLoop(b)
 }
 
+// SetDraining sets whether the reverse buildlets with the hostname are
+// draining. Draining buildlets finish their current build but are not
+// given new work. It reports whether all of the buildlets are idle.
+func (p *ReverseBuildletPool) SetDraining(hostname string, drain bool) (idle bool, err error) {
+	p.mu.Lock()
+	defer p.updateQuotas()
+	defer p.mu.Unlock()
+
+	idle = true
+	found := false
+	for _, b := range p.buildlets {
+		if b.hostname != hostname {
+			continue
+		}
+		found = true
+		if b.inUse && !b.inHealthCheck {
+			idle = false
+		}
+	}
+	if !found {
+		return false, fmt.Errorf("no reverse buildlet with hostname %q is connected", hostname)
+	}
+	if drain {
+		p.draining[hostname] = true
+	} else {
+		delete(p.draining, hostname)
+	}
+	return idle, nil
+}
+
+// Draining reports whether the reverse buildlets with the hostname are draining.
+func (p *ReverseBuildletPool) Draining(hostname string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.draining[hostname]
+}
+
+// ValidBuilderKey reports whether key is the builder key for a host type
+// served by a connected reverse buildlet with the hostname.
+func (p *ReverseBuildletPool) ValidBuilderKey(hostname, key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, b := range p.buildlets {
+		if b.hostname != hostname {
+			continue
+		}
+		for _, typ := range b.hostTypes() {
+			if want := builderKey(typ); want != "" && hmac.Equal([]byte(key), []byte(want)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // BuildletHostnames returns a slice of reverse buildlet hostnames.
 func (p *ReverseBuildletPool) BuildletHostnames() []string {
 	p.mu.Lock()
@@ -475,6 +567,11 @@ type reverseBuildlet struct {
 	// hostType is the configuration of this machine.
 	// It is the key into the dashboard.Hosts map.
 	hostType string
+	// extraHostTypes are other configurations the machine can
+	// also be used for.
+	extraHostTypes []string
+	// caps is the capacity the machine reported when it connected.
+	caps capabilities
 
 	// inUseAs signifies that the buildlet is in use.
 	// inUseTime is when it entered that state.
@@ -485,6 +582,102 @@ type reverseBuildlet struct {
 	inHealthCheck bool
 }
 
+// supports reports whether b can be used for hostType.
+func (b *reverseBuildlet) supports(hostType string) bool {
+	for _, typ := range b.hostTypes() {
+		if typ == hostType {
+			return true
+		}
+	}
+	return false
+}
+
+// hostTypes returns all the host types b can be used for.
+func (b *reverseBuildlet) hostTypes() []string {
+	return append([]string{b.hostType}, b.extraHostTypes...)
+}
+
+// capabilities is the capacity of a reverse buildlet machine, as
+// reported in its registration request. Zero values are unknown.
+type capabilities struct {
+	cpus   int
+	memory int64 // bytes
+	disk   int64 // bytes available in the work directory
+}
+
+// summary returns a description of c for the status page.
+func (c capabilities) summary() string {
+	var parts []string
+	if c.cpus > 0 {
+		parts = append(parts, fmt.Sprintf("%d CPUs", c.cpus))
+	}
+	if c.memory > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f GB memory", float64(c.memory)/(1<<30)))
+	}
+	if c.disk > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f GB disk", float64(c.disk)/(1<<30)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+// parseCapabilities parses the capabilities reported in the headers of
+// a reverse buildlet registration request.
+func parseCapabilities(h http.Header) (capabilities, error) {
+	var c capabilities
+	for _, f := range []struct {
+		header string
+		dst    *int64
+	}{
+		{"X-Go-Builder-Memory", &c.memory},
+		{"X-Go-Builder-Disk", &c.disk},
+	} {
+		if v := h.Get(f.header); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				return capabilities{}, fmt.Errorf("invalid %s header %q", f.header, v)
+			}
+			*f.dst = n
+		}
+	}
+	if v := h.Get("X-Go-Builder-CPUs"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return capabilities{}, fmt.Errorf("invalid X-Go-Builder-CPUs header %q", v)
+		}
+		c.cpus = n
+	}
+	return c, nil
+}
+
+// parseExtraHostTypes returns the additional host types a reverse
+// buildlet asked to serve in its registration request. Each
+// X-Go-Extra-Host-Type header value is a host type and its builder key
+// separated by a space. Host types not in dashboard.Hosts are rejected.
+func parseExtraHostTypes(h http.Header, hostType string) ([]string, error) {
+	var types []string
+	for _, v := range h.Values("X-Go-Extra-Host-Type") {
+		typ, key, ok := strings.Cut(v, " ")
+		if !ok || typ == "" {
+			return nil, fmt.Errorf("invalid X-Go-Extra-Host-Type header %q", v)
+		}
+		if _, ok := dashboard.Hosts[typ]; !ok {
+			return nil, fmt.Errorf("unknown extra host type %q", typ)
+		}
+		if key != builderKey(typ) {
+			return nil, fmt.Errorf("invalid build key for extra host type %q", typ)
+		}
+		if typ == hostType {
+			continue
+		}
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
 // HandleReverse handles reverse buildlet connections.
 func HandleReverse(w http.ResponseWriter, r *http.Request) {
 	if r.TLS == nil {
@@ -525,6 +718,16 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid build key", http.StatusPreconditionFailed)
 		return
 	}
+	extraHostTypes, err := parseExtraHostTypes(r.Header, hostType)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+	caps, err := parseCapabilities(r.Header)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	conn, _, err := w.(http.Hijacker).Hijack()
 	if err != nil {
@@ -538,8 +741,8 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Printf("Registering reverse buildlet %q (%s) for host type %v; buildletVersion=%v",
-		hostname, r.RemoteAddr, hostType, buildletVersion)
+	log.Printf("Registering reverse buildlet %q (%s) for host type %v (extra host types %v, capabilities%s); buildletVersion=%v",
+		hostname, r.RemoteAddr, hostType, extraHostTypes, caps.summary(), buildletVersion)
 
 	revDialer := revdial.NewDialer(conn, "/revdial")
 	revDialerDone := revDialer.Done()
@@ -596,13 +799,15 @@ func HandleReverse(w http.ResponseWriter, r *http.Request) {
 
 	now := time.Now()
 	b := &reverseBuildlet{
-		hostname:  hostname,
-		version:   buildletVersion,
-		hostType:  hostType,
-		client:    client,
-		conn:      conn,
-		inUseTime: now,
-		regTime:   now,
+		hostname:       hostname,
+		version:        buildletVersion,
+		hostType:       hostType,
+		extraHostTypes: extraHostTypes,
+		caps:           caps,
+		client:         client,
+		conn:           conn,
+		inUseTime:      now,
+		regTime:        now,
 	}
 	reversePool.addBuildlet(b)
 }
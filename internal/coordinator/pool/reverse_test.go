@@ -0,0 +1,127 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package pool
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/build/internal/coordinator/pool/queue"
+)
+
+func newTestReversePool(buildlets ...*reverseBuildlet) *ReverseBuildletPool {
+	return &ReverseBuildletPool{
+		buildlets:    buildlets,
+		hostLastGood: make(map[string]time.Time),
+		hostQueue:    make(map[string]*queue.Quota),
+		draining:     make(map[string]bool),
+	}
+}
+
+func TestReversePoolDraining(t *testing.T) {
+	a := &reverseBuildlet{hostname: "a", hostType: "host-darwin-amd64", extraHostTypes: []string{"host-darwin-arm64"}}
+	b := &reverseBuildlet{hostname: "b", hostType: "host-darwin-amd64", inUse: true}
+	p := newTestReversePool(a, b)
+
+	if _, err := p.SetDraining("nonexistent", true); err == nil {
+		t.Errorf("SetDraining(%q) = _, nil; want error", "nonexistent")
+	}
+	idle, err := p.SetDraining("b", true)
+	if err != nil {
+		t.Fatalf("SetDraining(%q) = _, %v; want no error", "b", err)
+	}
+	if idle {
+		t.Errorf("SetDraining(%q) reported idle for a busy buildlet", "b")
+	}
+	if !p.Draining("b") {
+		t.Errorf("Draining(%q) = false; want true", "b")
+	}
+
+	// Only a may be handed out, for either of its host types.
+	if _, busy := p.tryToGrab("host-darwin-arm64"); busy != 0 || !a.inUse {
+		t.Fatalf("tryToGrab(%q) did not grab the buildlet with the extra host type", "host-darwin-arm64")
+	}
+	b.inUse = false
+	if _, busy := p.tryToGrab("host-darwin-amd64"); busy != 1 || b.inUse {
+		t.Errorf("tryToGrab(%q) = _, %d, in use %v; want busy 1 and the draining buildlet left idle", "host-darwin-amd64", busy, b.inUse)
+	}
+
+	if !p.CanBuild("host-darwin-arm64") {
+		t.Errorf("CanBuild(%q) = false; want true", "host-darwin-arm64")
+	}
+	if _, err := p.SetDraining("a", true); err != nil {
+		t.Fatalf("SetDraining(%q) = _, %v; want no error", "a", err)
+	}
+	if p.CanBuild("host-darwin-amd64") {
+		t.Errorf("CanBuild(%q) = true with all buildlets draining; want false", "host-darwin-amd64")
+	}
+	if got := p.SingleHostTypeCount("host-darwin-arm64"); got != 1 {
+		t.Errorf("SingleHostTypeCount(%q) = %d; want 1", "host-darwin-arm64", got)
+	}
+
+	if _, err := p.SetDraining("b", false); err != nil {
+		t.Fatalf("SetDraining(%q, false) = _, %v; want no error", "b", err)
+	}
+	if _, busy := p.tryToGrab("host-darwin-amd64"); busy != 0 || !b.inUse {
+		t.Errorf("tryToGrab(%q) did not grab the undrained buildlet", "host-darwin-amd64")
+	}
+}
+
+func TestParseCapabilities(t *testing.T) {
+	h := http.Header{}
+	h.Set("X-Go-Builder-CPUs", "8")
+	h.Set("X-Go-Builder-Memory", "17179869184")
+	got, err := parseCapabilities(h)
+	if err != nil {
+		t.Fatalf("parseCapabilities() = _, %v; want no error", err)
+	}
+	if want := (capabilities{cpus: 8, memory: 16 << 30}); got != want {
+		t.Errorf("parseCapabilities() = %+v; want %+v", got, want)
+	}
+	if want := " [8 CPUs, 16.0 GB memory]"; got.summary() != want {
+		t.Errorf("summary() = %q; want %q", got.summary(), want)
+	}
+
+	h.Set("X-Go-Builder-Disk", "-1")
+	if _, err := parseCapabilities(h); err == nil {
+		t.Errorf("parseCapabilities() with negative disk = _, nil; want error")
+	}
+}
+
+func TestParseExtraHostTypes(t *testing.T) {
+	defer SetBuilderMasterKey(builderMasterKey)
+	SetBuilderMasterKey([]byte("secret"))
+
+	const (
+		hostA = "host-linux-ppc64le-osu"
+		hostB = "host-linux-ppc64le-power9-osu"
+	)
+	h := http.Header{}
+	h.Add("X-Go-Extra-Host-Type", hostB+" "+builderKey(hostB))
+	h.Add("X-Go-Extra-Host-Type", hostA+" "+builderKey(hostA))
+	got, err := parseExtraHostTypes(h, hostA)
+	if err != nil {
+		t.Fatalf("parseExtraHostTypes() = _, %v; want no error", err)
+	}
+	if len(got) != 1 || got[0] != hostB {
+		t.Errorf("parseExtraHostTypes() = %q; want [%s]", got, hostB)
+	}
+
+	bad := h.Clone()
+	bad.Add("X-Go-Extra-Host-Type", "host-linux-ppc64-sid "+builderKey(hostB))
+	if _, err := parseExtraHostTypes(bad, hostA); err == nil {
+		t.Errorf("parseExtraHostTypes() with a bad key = _, nil; want error")
+	}
+
+	unknown := h.Clone()
+	unknown.Add("X-Go-Extra-Host-Type", "host-unknown "+builderKey("host-unknown"))
+	if _, err := parseExtraHostTypes(unknown, hostA); err == nil {
+		t.Errorf("parseExtraHostTypes() with an unknown host type = _, nil; want error")
+	}
+}
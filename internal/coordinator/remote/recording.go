@@ -0,0 +1,408 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/build/internal"
+	"golang.org/x/build/internal/gcsfs"
+)
+
+const (
+	// recordingSuffix is the file extension used for session recordings.
+	// Recordings are written in the asciicast v2 format.
+	recordingSuffix = ".cast"
+
+	recordingCleanInterval = time.Hour
+)
+
+// AuditEvent is a single entry in the SSH audit log.
+type AuditEvent struct {
+	Time       time.Time `json:"time"`
+	Kind       string    `json:"kind"` // "connect", "command", or "disconnect"
+	OwnerID    string    `json:"owner_id"`
+	GomoteID   string    `json:"gomote_id"`
+	Grantee    string    `json:"grantee,omitempty"`     // set when the user is not the owner
+	SSHSession string    `json:"ssh_session"`           // SSH session identifier
+	RemoteAddr string    `json:"remote_addr,omitempty"` // set for connect events
+	Command    string    `json:"command,omitempty"`     // set for command events
+	Recording  string    `json:"recording,omitempty"`   // recording ID, if the session is recorded
+}
+
+// Recording describes a stored recording of an SSH session.
+type Recording struct {
+	ID       string // unique identifier: "<gomote ID>-<unix start time>"
+	GomoteID string
+	Start    time.Time
+	Size     int64
+}
+
+// SessionRecorder records interactive SSH sessions to gomote instances and
+// writes an audit log of the commands run in them.
+//
+// Recordings are stored in a filesystem created with gcsfs, which allows
+// them to live either on local disk or in GCS. Recordings are grouped by
+// owner so that users can only list and read their own sessions.
+type SessionRecorder struct {
+	fsys      fs.FS // nil if recording is disabled
+	retention time.Duration
+
+	auditMu sync.Mutex
+	audit   io.Writer
+
+	once       sync.Once
+	pollWait   sync.WaitGroup
+	cancelPoll context.CancelFunc
+}
+
+// NewSessionRecorder creates a session recorder which writes audit events
+// as JSON lines to audit. If fsys is non-nil, PTY sessions are recorded to
+// it and recordings older than retention are periodically removed. fsys must
+// support file creation and removal, as the filesystems returned by
+// gcsfs.FromURL do. Either cancelling the context or calling Close will
+// stop the retention cleanup.
+func NewSessionRecorder(ctx context.Context, fsys fs.FS, retention time.Duration, audit io.Writer) *SessionRecorder {
+	ctx, cancel := context.WithCancel(ctx)
+	sr := &SessionRecorder{
+		fsys:       fsys,
+		retention:  retention,
+		audit:      audit,
+		cancelPoll: cancel,
+	}
+	if fsys != nil && retention > 0 {
+		sr.pollWait.Add(1)
+		go func() {
+			internal.PeriodicallyDo(ctx, recordingCleanInterval, func(ctx context.Context, t time.Time) {
+				if err := sr.removeExpired(t); err != nil {
+					log.Printf("remote: removing expired session recordings: %s", err)
+				}
+			})
+			sr.pollWait.Done()
+		}()
+	}
+	return sr
+}
+
+// Close stops the retention cleanup performed by the recorder. It waits for
+// the cleanup to conclude before returning.
+func (sr *SessionRecorder) Close() {
+	sr.once.Do(func() {
+		sr.cancelPoll()
+		sr.pollWait.Wait()
+	})
+}
+
+// Audit writes an event to the audit log.
+func (sr *SessionRecorder) Audit(ev AuditEvent) {
+	if sr.audit == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	b, err := json.Marshal(ev)
+	if err != nil {
+		log.Printf("remote: unable to marshal audit event: %s", err)
+		return
+	}
+	sr.auditMu.Lock()
+	defer sr.auditMu.Unlock()
+	if _, err := sr.audit.Write(append(b, '\n')); err != nil {
+		log.Printf("remote: unable to write audit event: %s", err)
+	}
+}
+
+// ownerDir returns the directory which holds the recordings of an owner.
+// Owner IDs are hashed to keep email addresses out of object names.
+func ownerDir(ownerID string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(ownerID)))[:32]
+}
+
+// recordingID returns the recording ID for a session started at t.
+// The start time is in nanoseconds so that sessions started on one
+// gomote in the same second don't share a recording.
+func recordingID(gomoteID string, t time.Time) string {
+	return fmt.Sprintf("%s-%d", gomoteID, t.UnixNano())
+}
+
+// parseRecordingID splits a recording ID into its gomote ID and start time.
+func parseRecordingID(id string) (gomoteID string, start time.Time, err error) {
+	i := strings.LastIndex(id, "-")
+	if i < 1 {
+		return "", time.Time{}, fmt.Errorf("invalid recording ID %q", id)
+	}
+	nsec, err := strconv.ParseInt(id[i+1:], 10, 64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid recording ID %q", id)
+	}
+	return id[:i], time.Unix(0, nsec), nil
+}
+
+// StartRecording begins recording a PTY session with the given terminal
+// dimensions. It returns a nil *SessionRecording if recording is disabled.
+func (sr *SessionRecorder) StartRecording(ownerID, gomoteID, term string, width, height int) (*SessionRecording, error) {
+	if sr.fsys == nil {
+		return nil, nil
+	}
+	now := time.Now()
+	id := recordingID(gomoteID, now)
+	f, err := gcsfs.Create(sr.fsys, path.Join(ownerDir(ownerID), id+recordingSuffix))
+	if err != nil {
+		return nil, err
+	}
+	rec := &SessionRecording{
+		ID:    id,
+		start: now,
+		f:     f,
+		w:     bufio.NewWriter(f),
+	}
+	hdr, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: now.Unix(),
+		Title:     gomoteID,
+		Env:       map[string]string{"TERM": term},
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	rec.w.Write(hdr)
+	rec.w.WriteByte('\n')
+	return rec, nil
+}
+
+// Recordings returns the recordings owned by ownerID, sorted by start time.
+func (sr *SessionRecorder) Recordings(ownerID string) ([]*Recording, error) {
+	if sr.fsys == nil {
+		return nil, nil
+	}
+	ents, err := fs.ReadDir(sr.fsys, ownerDir(ownerID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var recs []*Recording
+	for _, ent := range ents {
+		id := strings.TrimSuffix(ent.Name(), recordingSuffix)
+		if ent.IsDir() || id == ent.Name() {
+			continue
+		}
+		gomoteID, start, err := parseRecordingID(id)
+		if err != nil {
+			continue
+		}
+		r := &Recording{ID: id, GomoteID: gomoteID, Start: start}
+		if fi, err := ent.Info(); err == nil {
+			r.Size = fi.Size()
+		}
+		recs = append(recs, r)
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].Start.Before(recs[j].Start) })
+	return recs, nil
+}
+
+// OpenRecording opens the recording with the given ID owned by ownerID.
+func (sr *SessionRecorder) OpenRecording(ownerID, id string) (fs.File, error) {
+	if sr.fsys == nil {
+		return nil, errors.New("session recording is not enabled")
+	}
+	if _, _, err := parseRecordingID(id); err != nil || strings.Contains(id, "/") {
+		return nil, fmt.Errorf("invalid recording ID %q", id)
+	}
+	return sr.fsys.Open(path.Join(ownerDir(ownerID), id+recordingSuffix))
+}
+
+// removeExpired removes all recordings which started before now minus the
+// retention period.
+func (sr *SessionRecorder) removeExpired(now time.Time) error {
+	owners, err := fs.ReadDir(sr.fsys, ".")
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-sr.retention)
+	for _, owner := range owners {
+		if !owner.IsDir() {
+			continue
+		}
+		ents, err := fs.ReadDir(sr.fsys, owner.Name())
+		if err != nil {
+			return err
+		}
+		for _, ent := range ents {
+			_, start, err := parseRecordingID(strings.TrimSuffix(ent.Name(), recordingSuffix))
+			if err != nil || !start.Before(cutoff) {
+				continue
+			}
+			name := path.Join(owner.Name(), ent.Name())
+			if err := gcsfs.Remove(sr.fsys, name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// castHeader is the header line of an asciicast v2 file.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// SessionRecording is an in-progress recording of a PTY session.
+// It is safe for concurrent use.
+type SessionRecording struct {
+	ID string
+
+	start time.Time
+
+	mu      sync.Mutex
+	f       gcsfs.WriterFile
+	w       *bufio.Writer
+	pending map[string][]byte // incomplete UTF-8 sequences, keyed by event type
+	closed  bool
+	err     error
+}
+
+// event appends an event of the given type to the recording.
+func (r *SessionRecording) event(typ string, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.err != nil {
+		return
+	}
+	if r.pending == nil {
+		r.pending = make(map[string][]byte)
+	}
+	// Hold back a trailing partial rune so it can be combined with the
+	// next write rather than being replaced with U+FFFD.
+	data = append(r.pending[typ], data...)
+	n := len(data)
+	for i := 1; i < utf8.UTFMax && i <= n; i++ {
+		if utf8.RuneStart(data[n-i]) {
+			if !utf8.FullRune(data[n-i:]) {
+				n -= i
+			}
+			break
+		}
+	}
+	r.pending[typ] = append([]byte(nil), data[n:]...)
+	if n == 0 {
+		return
+	}
+	b, err := json.Marshal([]interface{}{time.Since(r.start).Seconds(), typ, string(data[:n])})
+	if err != nil {
+		r.err = err
+		return
+	}
+	if _, err := r.w.Write(append(b, '\n')); err != nil {
+		r.err = err
+	}
+}
+
+// Output returns a writer that records data sent to the terminal.
+func (r *SessionRecording) Output() io.Writer {
+	return recordingWriter{r, "o"}
+}
+
+// Input returns a writer that records data typed by the user.
+func (r *SessionRecording) Input() io.Writer {
+	return recordingWriter{r, "i"}
+}
+
+// Resize records a change in the terminal dimensions.
+func (r *SessionRecording) Resize(width, height int) {
+	r.event("r", []byte(fmt.Sprintf("%dx%d", width, height)))
+}
+
+// Close finishes the recording and stores it.
+func (r *SessionRecording) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return r.err
+	}
+	r.closed = true
+	if err := r.w.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.f.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+type recordingWriter struct {
+	r   *SessionRecording
+	typ string
+}
+
+func (w recordingWriter) Write(p []byte) (int, error) {
+	w.r.event(w.typ, p)
+	return len(p), nil
+}
+
+// commandWriter reconstructs command lines from keystrokes typed into a
+// terminal. Each completed line is passed to the emit function. It is a
+// best effort: line editing beyond backspace and escape sequences such as
+// arrow keys are dropped.
+type commandWriter struct {
+	emit  func(string)
+	line  []rune
+	inEsc bool
+}
+
+func (w *commandWriter) Write(p []byte) (int, error) {
+	for _, r := range string(p) {
+		switch {
+		case w.inEsc:
+			// CSI sequences end with a byte in the range 0x40-0x7e,
+			// excluding the introducing '['.
+			if r >= 0x40 && r <= 0x7e && r != '[' {
+				w.inEsc = false
+			}
+		case r == 0x1b:
+			w.inEsc = true
+		case r == '\r' || r == '\n':
+			if cmd := strings.TrimSpace(string(w.line)); cmd != "" {
+				w.emit(cmd)
+			}
+			w.line = w.line[:0]
+		case r == 0x7f || r == '\b':
+			if len(w.line) > 0 {
+				w.line = w.line[:len(w.line)-1]
+			}
+		case r == 0x03 || r == 0x15: // ^C, ^U
+			w.line = w.line[:0]
+		case r < 0x20:
+			// Ignore other control characters.
+		default:
+			w.line = append(w.line, r)
+		}
+	}
+	return len(p), nil
+}
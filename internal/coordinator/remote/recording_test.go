@@ -0,0 +1,157 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/internal/gcsfs"
+)
+
+func TestSessionRecording(t *testing.T) {
+	sr := NewSessionRecorder(context.Background(), gcsfs.DirFS(t.TempDir()), 0, nil)
+	defer sr.Close()
+
+	ownerID := "accounts.google.com:userIDvalue"
+	rec, err := sr.StartRecording(ownerID, "user-maria-linux-amd64-0", "xterm", 80, 24)
+	if err != nil {
+		t.Fatalf("StartRecording(...) = _, %s; want no error", err)
+	}
+	io.WriteString(rec.Input(), "ls\r")
+	// Split a multi-byte rune across writes.
+	io.WriteString(rec.Output(), "caf\xc3")
+	io.WriteString(rec.Output(), "\xa9\r\n")
+	rec.Resize(100, 30)
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close() = %s; want no error", err)
+	}
+
+	recs, err := sr.Recordings(ownerID)
+	if err != nil {
+		t.Fatalf("Recordings(%q) = _, %s; want no error", ownerID, err)
+	}
+	if len(recs) != 1 || recs[0].ID != rec.ID || recs[0].GomoteID != "user-maria-linux-amd64-0" {
+		t.Fatalf("Recordings(%q) = %+v; want one recording with ID %q", ownerID, recs, rec.ID)
+	}
+	if recs, err := sr.Recordings("accounts.google.com:otherUser"); err != nil || len(recs) != 0 {
+		t.Errorf("Recordings(other owner) = %v, %v; want no recordings", recs, err)
+	}
+	if _, err := sr.OpenRecording("accounts.google.com:otherUser", rec.ID); err == nil {
+		t.Errorf("OpenRecording(other owner, %q) = _, nil; want error", rec.ID)
+	}
+
+	f, err := sr.OpenRecording(ownerID, rec.ID)
+	if err != nil {
+		t.Fatalf("OpenRecording(%q) = _, %s; want no error", rec.ID, err)
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	var hdr castHeader
+	if err := json.Unmarshal([]byte(lines[0]), &hdr); err != nil {
+		t.Fatalf("unable to decode header %q: %s", lines[0], err)
+	}
+	if hdr.Version != 2 || hdr.Width != 80 || hdr.Height != 24 || hdr.Env["TERM"] != "xterm" {
+		t.Errorf("header = %+v; want version 2, 80x24, TERM=xterm", hdr)
+	}
+	var got [][]string
+	for _, line := range lines[1:] {
+		var ev []interface{}
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("unable to decode event %q: %s", line, err)
+		}
+		got = append(got, []string{ev[1].(string), ev[2].(string)})
+	}
+	want := [][]string{{"i", "ls\r"}, {"o", "caf"}, {"o", "é\r\n"}, {"r", "100x30"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSessionRecordingSameSecond(t *testing.T) {
+	start := time.Unix(1680000000, 0)
+	a, b := recordingID("inst", start), recordingID("inst", start.Add(time.Millisecond))
+	if a == b {
+		t.Fatalf("recordingID for sessions 1ms apart = %q for both; want different IDs", a)
+	}
+	gomoteID, got, err := parseRecordingID(b)
+	if err != nil || gomoteID != "inst" || !got.Equal(start.Add(time.Millisecond)) {
+		t.Errorf("parseRecordingID(%q) = %q, %v, %v; want inst, %v, nil", b, gomoteID, got, err, start.Add(time.Millisecond))
+	}
+}
+
+func TestSessionRecordingDisabled(t *testing.T) {
+	sr := NewSessionRecorder(context.Background(), nil, 0, nil)
+	defer sr.Close()
+	rec, err := sr.StartRecording("owner", "inst", "xterm", 80, 24)
+	if rec != nil || err != nil {
+		t.Errorf("StartRecording(...) = %v, %v; want nil, nil", rec, err)
+	}
+}
+
+func TestSessionRecorderRemoveExpired(t *testing.T) {
+	fsys := gcsfs.DirFS(t.TempDir())
+	sr := NewSessionRecorder(context.Background(), fsys, 24*time.Hour, nil)
+	defer sr.Close()
+
+	now := time.Now()
+	old := recordingID("inst", now.Add(-48*time.Hour))
+	recent := recordingID("inst", now.Add(-time.Hour))
+	for _, id := range []string{old, recent} {
+		if err := gcsfs.WriteFile(fsys, ownerDir("owner")+"/"+id+recordingSuffix, []byte("{}\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sr.removeExpired(now); err != nil {
+		t.Fatalf("removeExpired() = %s; want no error", err)
+	}
+	recs, err := sr.Recordings("owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || recs[0].ID != recent {
+		t.Errorf("Recordings after removeExpired = %+v; want only %q", recs, recent)
+	}
+}
+
+func TestSessionRecorderAudit(t *testing.T) {
+	var buf bytes.Buffer
+	sr := NewSessionRecorder(context.Background(), nil, 0, &buf)
+	defer sr.Close()
+
+	var cw io.Writer = &commandWriter{emit: func(cmd string) {
+		sr.Audit(AuditEvent{Kind: "command", OwnerID: "owner", GomoteID: "inst", Command: cmd})
+	}}
+	// Typing "lx", backspace, "s -l", an arrow key and enter, then an empty line.
+	io.WriteString(cw, "lx\x7fs -l\x1b[A\r")
+	io.WriteString(cw, "\r")
+	io.WriteString(cw, "go test\r")
+
+	var got []string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var ev AuditEvent
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatalf("unable to decode audit event: %s", err)
+		}
+		if ev.Time.IsZero() || ev.OwnerID != "owner" || ev.GomoteID != "inst" {
+			t.Errorf("audit event = %+v; want time, owner and gomote set", ev)
+		}
+		got = append(got, ev.Command)
+	}
+	if diff := cmp.Diff([]string{"ls -l", "go test"}, got); diff != "" {
+		t.Errorf("audited commands mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -30,6 +30,70 @@ type Session struct {
 	ID          string // unique identifier for instance "user-bradfitz-linux-amd64-0"
 	OwnerID     string // identity aware proxy user id: "accounts.google.com:userIDvalue"
 	buildlet    buildlet.Client
+
+	// Grants holds the access granted to users other than the owner, sorted by grantee.
+	Grants []Grant
+}
+
+// AccessLevel is the level of access a user has to a remote buildlet session.
+type AccessLevel int
+
+const (
+	AccessNone  AccessLevel = iota
+	AccessRead              // list the contents of and retrieve files from the instance
+	AccessFull              // AccessRead plus run commands, write files and connect via SSH
+	AccessOwner             // AccessFull plus destroy the instance and manage grants
+)
+
+// String returns the name of the access level.
+func (l AccessLevel) String() string {
+	switch l {
+	case AccessNone:
+		return "none"
+	case AccessRead:
+		return "read"
+	case AccessFull:
+		return "full"
+	case AccessOwner:
+		return "owner"
+	}
+	return fmt.Sprintf("AccessLevel(%d)", int(l))
+}
+
+// Grant gives a user other than the owner access to a remote buildlet session
+// until it expires.
+type Grant struct {
+	Grantee string // identity aware proxy user email: "accounts.google.com:example@gmail.com"
+	Level   AccessLevel
+	Expires time.Time
+}
+
+// Access returns the level of access the user with the identity aware proxy
+// user id and email has to the session.
+func (s *Session) Access(userID, email string) AccessLevel {
+	if userID != "" && userID == s.OwnerID {
+		return AccessOwner
+	}
+	now := time.Now()
+	for _, g := range s.Grants {
+		if g.Grantee == email && now.Before(g.Expires) {
+			return g.Level
+		}
+	}
+	return AccessNone
+}
+
+// activeGrants returns a copy of the grants which have not expired.
+// The SessionPool lock should be held before calling.
+func (s *Session) activeGrants() []Grant {
+	var gs []Grant
+	now := time.Now()
+	for _, g := range s.Grants {
+		if now.Before(g.Expires) {
+			gs = append(gs, g)
+		}
+	}
+	return gs
 }
 
 // renew extends the expiration timestamp for a session.
@@ -169,6 +233,7 @@ func (sp *SessionPool) List() []*Session {
 			ID:          s.ID,
 			OwnerID:     s.OwnerID,
 			Created:     s.Created,
+			Grants:      s.activeGrants(),
 		})
 	}
 	sort.Slice(ss, func(i, j int) bool { return ss[i].ID < ss[j].ID })
@@ -196,11 +261,55 @@ func (sp *SessionPool) Session(buildletName string) (*Session, error) {
 			HostType:    s.HostType,
 			ID:          s.ID,
 			OwnerID:     s.OwnerID,
+			Grants:      s.activeGrants(),
 		}, nil
 	}
 	return nil, fmt.Errorf("remote buildlet does not exist=%s", buildletName)
 }
 
+// GrantAccess gives the grantee in g access to the session until g expires.
+// Any existing grant for the same grantee is replaced.
+func (sp *SessionPool) GrantAccess(buildletName string, g Grant) error {
+	if g.Grantee == "" || g.Level != AccessRead && g.Level != AccessFull {
+		return fmt.Errorf("invalid grant for %q with access level %s", g.Grantee, g.Level)
+	}
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	s, ok := sp.m[buildletName]
+	if !ok {
+		return fmt.Errorf("remote buildlet does not exist=%s", buildletName)
+	}
+	gs := []Grant{g}
+	for _, og := range s.activeGrants() {
+		if og.Grantee != g.Grantee {
+			gs = append(gs, og)
+		}
+	}
+	sort.Slice(gs, func(i, j int) bool { return gs[i].Grantee < gs[j].Grantee })
+	s.Grants = gs
+	return nil
+}
+
+// RevokeAccess removes any access granted to the grantee for the session.
+func (sp *SessionPool) RevokeAccess(buildletName, grantee string) error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	s, ok := sp.m[buildletName]
+	if !ok {
+		return fmt.Errorf("remote buildlet does not exist=%s", buildletName)
+	}
+	var gs []Grant
+	for _, g := range s.activeGrants() {
+		if g.Grantee != grantee {
+			gs = append(gs, g)
+		}
+	}
+	s.Grants = gs
+	return nil
+}
+
 // BuildletClient returns the buildlet client associated with the Session.
 func (sp *SessionPool) BuildletClient(buildletName string) (buildlet.Client, error) {
 	sp.mu.RLock()
@@ -115,3 +115,59 @@ func TestRenewTimeoutError(t *testing.T) {
 		t.Errorf("SessionPool.RenewTimeout(%q) = %s; want error", name, err)
 	}
 }
+
+func TestSessionPoolGrantAccess(t *testing.T) {
+	sp := NewSessionPool(context.Background())
+	defer sp.Close()
+
+	const (
+		ownerID = "accounts.google.com:user-xyz-124"
+		reader  = "accounts.google.com:reader@example.com"
+		writer  = "accounts.google.com:writer@example.com"
+	)
+	name := sp.AddSession(ownerID, "user-x", "builder", "host", &buildlet.FakeClient{})
+	if err := sp.GrantAccess(name, Grant{Grantee: reader, Level: AccessRead, Expires: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("SessionPool.GrantAccess(%q, read) = %s; want no error", name, err)
+	}
+	if err := sp.GrantAccess(name, Grant{Grantee: writer, Level: AccessFull, Expires: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatalf("SessionPool.GrantAccess(%q, full) = %s; want no error", name, err)
+	}
+	if err := sp.GrantAccess(name, Grant{Grantee: writer, Level: AccessOwner, Expires: time.Now().Add(time.Hour)}); err == nil {
+		t.Errorf("SessionPool.GrantAccess(%q, owner) = nil; want error", name)
+	}
+	if err := sp.GrantAccess(name+"-wrong", Grant{Grantee: writer, Level: AccessRead, Expires: time.Now().Add(time.Hour)}); err == nil {
+		t.Errorf("SessionPool.GrantAccess(%q) = nil; want error", name+"-wrong")
+	}
+	s, err := sp.Session(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		userID, email string
+		want          AccessLevel
+	}{
+		{ownerID, "", AccessOwner},
+		{"accounts.google.com:reader-id", reader, AccessRead},
+		{"accounts.google.com:writer-id", writer, AccessNone}, // expired
+		{"accounts.google.com:other-id", "accounts.google.com:other@example.com", AccessNone},
+	}
+	for _, tc := range testCases {
+		if got := s.Access(tc.userID, tc.email); got != tc.want {
+			t.Errorf("Session.Access(%q, %q) = %s; want %s", tc.userID, tc.email, got, tc.want)
+		}
+	}
+	if len(s.Grants) != 1 || s.Grants[0].Grantee != reader {
+		t.Errorf("Session.Grants = %+v; want only the grant for %q", s.Grants, reader)
+	}
+
+	if err := sp.RevokeAccess(name, reader); err != nil {
+		t.Fatalf("SessionPool.RevokeAccess(%q, %q) = %s; want no error", name, reader, err)
+	}
+	s, err = sp.Session(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Access("accounts.google.com:reader-id", reader); got != AccessNone {
+		t.Errorf("Session.Access(reader) after revoke = %s; want %s", got, AccessNone)
+	}
+}
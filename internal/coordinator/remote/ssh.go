@@ -40,6 +40,7 @@ import (
 
 // SignPublicSSHKey signs a public SSH key using the certificate authority. These keys are intended for use with the specified gomote and owner.
 // The public SSH are intended to be used in OpenSSH certificate authentication with the gomote SSH server.
+// For users who have been granted access to the gomote, ownerID should be the grantee's identity aware proxy email.
 func SignPublicSSHKey(ctx context.Context, caPriKey ssh.Signer, rawPubKey []byte, sessionID, ownerID string, d time.Duration) ([]byte, error) {
 	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(rawPubKey)
 	if err != nil {
@@ -99,10 +100,12 @@ type SSHServer struct {
 	privateHostKeyFile string
 	server             *gssh.Server
 	sessionPool        *SessionPool
+	recorder           *SessionRecorder
 }
 
 // NewSSHServer creates an SSH server used to access remote buildlet sessions.
-func NewSSHServer(addr string, hostPrivateKey, gomotePublicKey, caPrivateKey []byte, sp *SessionPool) (*SSHServer, error) {
+// If rec is non-nil, it is used to audit and record the sessions.
+func NewSSHServer(addr string, hostPrivateKey, gomotePublicKey, caPrivateKey []byte, sp *SessionPool, rec *SessionRecorder) (*SSHServer, error) {
 	hostSigner, err := ssh.ParsePrivateKey(hostPrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse SSH host key: %v; not configuring SSH server", err)
@@ -122,6 +125,7 @@ func NewSSHServer(addr string, hostPrivateKey, gomotePublicKey, caPrivateKey []b
 		gomotePublicKey:    string(gomotePublicKey),
 		privateHostKeyFile: privateHostKeyFile,
 		sessionPool:        sp,
+		recorder:           rec,
 		server: &gssh.Server{
 			Addr:             addr,
 			PublicKeyHandler: handleCertificateAuthFunc(sp, CASigner),
@@ -152,6 +156,7 @@ func (ss *SSHServer) serve(l net.Listener) error {
 // Sessions for session management.
 func (ss *SSHServer) HandleIncomingSSHPostAuth(s gssh.Session) {
 	inst := s.User()
+	var commands io.Writer = io.Discard
 	ptyReq, winCh, isPty := s.Pty()
 	if !isPty {
 		fmt.Fprintf(s, "scp etc not yet supported; https://golang.org/issue/21140\n")
@@ -173,6 +178,46 @@ func (ss *SSHServer) HandleIncomingSSHPostAuth(s gssh.Session) {
 		return
 	}
 
+	var rec *SessionRecording
+	if ss.recorder != nil {
+		rec, err = ss.recorder.StartRecording(rs.OwnerID, inst, ptyReq.Term, ptyReq.Window.Width, ptyReq.Window.Height)
+		if err != nil {
+			log.Printf("ssh: unable to start recording of session=%s: %s", inst, err)
+			fmt.Fprintf(s, "unable to start session recording for instance %q\n", inst)
+			return
+		}
+		ev := AuditEvent{
+			OwnerID:    rs.OwnerID,
+			GomoteID:   inst,
+			SSHSession: fmt.Sprint(s.Context().Value(gssh.ContextKeySessionID)),
+		}
+		if grantee, ok := s.Context().Value(granteeContextKey{}).(string); ok {
+			ev.Grantee = grantee
+		}
+		if rec != nil {
+			ev.Recording = rec.ID
+			defer func() {
+				if err := rec.Close(); err != nil {
+					log.Printf("ssh: unable to store recording %s: %s", rec.ID, err)
+				}
+			}()
+		}
+		connect := ev
+		connect.Kind, connect.RemoteAddr = "connect", s.RemoteAddr().String()
+		ss.recorder.Audit(connect)
+		defer func() {
+			disconnect := ev
+			disconnect.Kind = "disconnect"
+			ss.recorder.Audit(disconnect)
+		}()
+		ev.Kind = "command"
+		commands = &commandWriter{emit: func(cmd string) {
+			ev := ev
+			ev.Command = cmd
+			ss.recorder.Audit(ev)
+		}}
+	}
+
 	ctx, cancel := context.WithCancel(s.Context())
 	defer cancel()
 	if err := ss.sessionPool.KeepAlive(ctx, inst); err != nil {
@@ -285,16 +330,24 @@ func (ss *SSHServer) HandleIncomingSSHPostAuth(s gssh.Session) {
 		return
 	}
 	defer f.Close()
+	stdin, stdout := io.Writer(f), io.Writer(s)
+	if rec != nil {
+		stdin = io.MultiWriter(f, rec.Input())
+		stdout = io.MultiWriter(s, rec.Output())
+	}
 	go func() {
 		for win := range winCh {
 			setWinsize(f, win.Width, win.Height)
+			if rec != nil {
+				rec.Resize(win.Width, win.Height)
+			}
 		}
 	}()
 	go func() {
 		ss.setupRemoteSSHEnv(bconf, workDir, f)
-		io.Copy(f, s) // stdin
+		io.Copy(io.MultiWriter(stdin, commands), s) // stdin
 	}()
-	io.Copy(s, f) // stdout
+	io.Copy(stdout, f) // stdout
 	cmd.Process.Kill()
 	cmd.Wait()
 }
@@ -343,9 +396,13 @@ func WriteSSHPrivateKeyToTempFile(key []byte) (path string, err error) {
 	return tf.Name(), tf.Close()
 }
 
+// granteeContextKey is the SSH context key for the identity of a user who was
+// granted access to the session by its owner.
+type granteeContextKey struct{}
+
 // handleCertificateAuthFunc creates a function that authenticates the session using OpenSSH certificate
 // authentication. The passed in certificate is tested to ensure it is valid, signed by the CA and
-// corresponds to an existing session.
+// corresponds to an existing session owned by, or shared with full access to, one of its principals.
 func handleCertificateAuthFunc(sp *SessionPool, caKeySigner ssh.Signer) gssh.PublicKeyHandler {
 	return func(ctx gssh.Context, key gssh.PublicKey) bool {
 		sessionID := ctx.User()
@@ -375,11 +432,15 @@ func handleCertificateAuthFunc(sp *SessionPool, caKeySigner ssh.Signer) gssh.Pub
 			return false
 		}
 		for _, principal := range cert.ValidPrincipals {
-			if principal == ses.OwnerID {
+			switch ses.Access(principal, principal) {
+			case AccessOwner:
+				return true
+			case AccessFull:
+				ctx.SetValue(granteeContextKey{}, principal)
 				return true
 			}
 		}
-		log.Printf("HandleCertificateAuth: unable to verify ownerID in certificate principals")
+		log.Printf("HandleCertificateAuth: unable to verify ownerID or grantee in certificate principals")
 		return false
 	}
 }
@@ -270,7 +270,7 @@ func setupSSHServer(t *testing.T, ctx context.Context) (addr string, sp *Session
 		t.Fatalf("nettest.NewLocalListener(tcp) = _, %s; want no error", err)
 	}
 	addr = l.Addr().String()
-	s, err = NewSSHServer(addr, []byte(devCertAlternateClientPrivate), []byte(devCertCAPublic), []byte(devCertCAPrivate), sp)
+	s, err = NewSSHServer(addr, []byte(devCertAlternateClientPrivate), []byte(devCertCAPublic), []byte(devCertCAPrivate), sp, nil)
 	if err != nil {
 		t.Fatalf("NewSSHServer(...) = %s; want no error", err)
 	}
@@ -326,3 +326,57 @@ W+qSe4RkGQBx6IEe424RAAAAF3Rlc3RfZGlzY2FyZEBnb2xhbmcub3JnAQIDBAUG
 	// devCertAlternateClientPublic is a public SSH to be used for development.
 	devCertAlternateClientPublic = `ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIM6PwraVsJK/4uiM1ytR/RcfW+qSe4RkGQBx6IEe424R test_discard@golang.org`
 )
+
+func TestHandleCertificateAuthFuncGrantee(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		level   AccessLevel
+		wantErr bool
+	}{
+		{"full access", AccessFull, false},
+		{"read access", AccessRead, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			addr, sp, s := setupSSHServer(t, ctx)
+			defer s.Close()
+
+			grantee := "accounts.google.com:alice@example.com"
+			sessionID := sp.AddSession("accounts.google.com:userIDvalue", "maria", "linux-amd64", "xyz", &buildlet.FakeClient{})
+			if err := sp.GrantAccess(sessionID, Grant{Grantee: grantee, Level: tc.level, Expires: time.Now().Add(time.Hour)}); err != nil {
+				t.Fatalf("GrantAccess(...) = %s; want no error", err)
+			}
+			certSigner := parsePrivateKey(t, []byte(devCertCAPrivate))
+			clientPubKey, err := SignPublicSSHKey(ctx, certSigner, []byte(devCertClientPublic), sessionID, grantee, time.Minute)
+			if err != nil {
+				t.Fatalf("SignPublicSSHKey(...) = _, %s; want no error", err)
+			}
+			pubKey, _, _, _, err := ssh.ParseAuthorizedKey(clientPubKey)
+			if err != nil {
+				t.Fatalf("ParsePublicKey(...) = _, %s; want no error", err)
+			}
+			clientSigner, err := ssh.NewCertSigner(pubKey.(*ssh.Certificate), parsePrivateKey(t, []byte(devCertClientPrivate)))
+			if err != nil {
+				t.Fatalf("NewCertSigner(...) = _, %s; want no error", err)
+			}
+			clientConfig := &ssh.ClientConfig{
+				User: sessionID,
+				Auth: []ssh.AuthMethod{
+					ssh.PublicKeys(clientSigner),
+				},
+				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+				Timeout:         5 * time.Second,
+			}
+			client, err := ssh.Dial("tcp", addr, clientConfig)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Dial(...) = _, %v; want error %t", err, tc.wantErr)
+			}
+			if err == nil {
+				client.Close()
+			}
+		})
+	}
+}
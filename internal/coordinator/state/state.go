@@ -0,0 +1,168 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package state persists the coordinator's in-progress trybot work,
+// so a restarted coordinator can pick up where the previous process
+// left off instead of starting every try run over.
+package state
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/googleapis/google-cloud-go-testing/datastore/dsiface"
+)
+
+// A TrySet is the saved state of a trybot run of one commit of a
+// Gerrit change.
+type TrySet struct {
+	Project  string // "go", "net", etc
+	Branch   string // master
+	ChangeID string // I1a27695838409259d1586a0adfa9f92bccf7ceba
+	Commit   string // ecf3dffc81dc21408fb02159af352651882a8383
+	TryID    string // "T" + 9 random hex
+
+	Updated time.Time // when the state was saved
+
+	Builds []Build `datastore:",noindex"`
+}
+
+// Key returns the key identifying ts in a Store.
+func (ts *TrySet) Key() string {
+	return Key(ts.Project, ts.Branch, ts.ChangeID, ts.Commit)
+}
+
+// Key returns the key identifying the TrySet of a commit of a change.
+func Key(project, branch, changeID, commit string) string {
+	return fmt.Sprintf("%s~%s~%s~%s", project, branch, changeID, commit)
+}
+
+// Remain returns the number of builds of ts which aren't done.
+func (ts *TrySet) Remain() int {
+	n := 0
+	for _, b := range ts.Builds {
+		if !b.Done {
+			n++
+		}
+	}
+	return n
+}
+
+// A Build is the saved state of one build of a TrySet.
+type Build struct {
+	// Name, Rev, SubName and SubRev are the build's buildgo.BuilderRev.
+	Name    string
+	Rev     string
+	SubName string
+	SubRev  string
+
+	BuildID string // "B" + 9 random hex
+
+	// Instance and IPPort identify the buildlet running the build, if
+	// it has one. Instance is empty for buildlets which can't be
+	// reattached by name, such as reverse buildlets.
+	Instance string
+	IPPort   string
+
+	Done      bool
+	Succeeded bool
+	LogURL    string // if non-empty, permanent URL of the log of a done build
+}
+
+// A Store saves TrySets.
+type Store interface {
+	// PutTrySet saves ts, replacing any TrySet with the same key.
+	PutTrySet(ctx context.Context, ts *TrySet) error
+	// DeleteTrySet deletes the TrySet with the given key. Deleting a
+	// TrySet which doesn't exist is not an error.
+	DeleteTrySet(ctx context.Context, key string) error
+	// ListTrySets returns all saved TrySets, sorted by key.
+	ListTrySets(ctx context.Context) ([]*TrySet, error)
+}
+
+// trySetKind is the Datastore kind of saved TrySets.
+const trySetKind = "CoordinatorTrySet"
+
+// Datastore is a Store backed by Cloud Datastore.
+type Datastore struct {
+	client dsiface.Client
+}
+
+// NewDatastore returns a Store which saves TrySets using client.
+func NewDatastore(client dsiface.Client) *Datastore {
+	return &Datastore{client: client}
+}
+
+var _ Store = (*Datastore)(nil)
+
+func (d *Datastore) PutTrySet(ctx context.Context, ts *TrySet) error {
+	_, err := d.client.Put(ctx, datastore.NameKey(trySetKind, ts.Key(), nil), ts)
+	return err
+}
+
+func (d *Datastore) DeleteTrySet(ctx context.Context, key string) error {
+	return d.client.Delete(ctx, datastore.NameKey(trySetKind, key, nil))
+}
+
+func (d *Datastore) ListTrySets(ctx context.Context) ([]*TrySet, error) {
+	var sets []*TrySet
+	if _, err := d.client.GetAll(ctx, datastore.NewQuery(trySetKind), &sets); err != nil {
+		return nil, err
+	}
+	sortTrySets(sets)
+	return sets, nil
+}
+
+// Memory is a Store which keeps TrySets in memory, for tests and
+// development.
+type Memory struct {
+	mu   sync.Mutex
+	sets map[string]*TrySet
+}
+
+// NewMemory returns an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{sets: make(map[string]*TrySet)}
+}
+
+var _ Store = (*Memory)(nil)
+
+func (m *Memory) PutTrySet(ctx context.Context, ts *TrySet) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sets[ts.Key()] = ts.clone()
+	return nil
+}
+
+func (m *Memory) DeleteTrySet(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sets, key)
+	return nil
+}
+
+func (m *Memory) ListTrySets(ctx context.Context) ([]*TrySet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sets := make([]*TrySet, 0, len(m.sets))
+	for _, ts := range m.sets {
+		sets = append(sets, ts.clone())
+	}
+	sortTrySets(sets)
+	return sets, nil
+}
+
+func (ts *TrySet) clone() *TrySet {
+	c := *ts
+	c.Builds = append([]Build(nil), ts.Builds...)
+	return &c
+}
+
+func sortTrySets(sets []*TrySet) {
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Key() < sets[j].Key() })
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/build/internal/datastore/fake"
+)
+
+func TestStores(t *testing.T) {
+	for name, s := range map[string]Store{
+		"Memory":    NewMemory(),
+		"Datastore": NewDatastore(&fake.Client{}),
+	} {
+		t.Run(name, func(t *testing.T) { testStore(t, s) })
+	}
+}
+
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+	updated := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	a := &TrySet{
+		Project:  "go",
+		Branch:   "master",
+		ChangeID: "I1a27695838409259d1586a0adfa9f92bccf7ceba",
+		Commit:   "ecf3dffc81dc21408fb02159af352651882a8383",
+		TryID:    "T0123456789",
+		Updated:  updated,
+		Builds: []Build{
+			{Name: "linux-amd64", Rev: "ecf3dffc81dc21408fb02159af352651882a8383", BuildID: "B1", Done: true, Succeeded: true, LogURL: "https://storage.googleapis.com/a.log"},
+			{Name: "windows-amd64-2016", Rev: "ecf3dffc81dc21408fb02159af352651882a8383", BuildID: "B2", Instance: "buildlet-windows-amd64-2016-rn1", IPPort: "10.240.0.7:80"},
+		},
+	}
+	b := &TrySet{
+		Project:  "net",
+		Branch:   "master",
+		ChangeID: "I2a27695838409259d1586a0adfa9f92bccf7ceba",
+		Commit:   "fcf3dffc81dc21408fb02159af352651882a8383",
+		TryID:    "T9876543210",
+		Updated:  updated,
+		Builds:   []Build{{Name: "linux-amd64", Rev: "1111111111111111111111111111111111111111", SubName: "net", SubRev: "fcf3dffc81dc21408fb02159af352651882a8383"}},
+	}
+
+	if got, err := s.ListTrySets(ctx); err != nil || len(got) != 0 {
+		t.Fatalf("ListTrySets() on empty store = %v, %v; want none", got, err)
+	}
+	for _, ts := range []*TrySet{b, a} {
+		if err := s.PutTrySet(ctx, ts); err != nil {
+			t.Fatalf("PutTrySet(%s) = %v", ts.Key(), err)
+		}
+	}
+	got, err := s.ListTrySets(ctx)
+	if err != nil {
+		t.Fatalf("ListTrySets() = _, %v", err)
+	}
+	if diff := cmp.Diff([]*TrySet{a, b}, got); diff != "" {
+		t.Errorf("ListTrySets() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Saving again replaces the earlier state.
+	a.Builds[1].Done = true
+	if err := s.PutTrySet(ctx, a); err != nil {
+		t.Fatalf("PutTrySet(%s) = %v", a.Key(), err)
+	}
+	if err := s.DeleteTrySet(ctx, b.Key()); err != nil {
+		t.Fatalf("DeleteTrySet(%s) = %v", b.Key(), err)
+	}
+	if err := s.DeleteTrySet(ctx, b.Key()); err != nil {
+		t.Errorf("DeleteTrySet(%s) of deleted set = %v; want no error", b.Key(), err)
+	}
+	got, err = s.ListTrySets(ctx)
+	if err != nil {
+		t.Fatalf("ListTrySets() = _, %v", err)
+	}
+	if diff := cmp.Diff([]*TrySet{a}, got); diff != "" {
+		t.Errorf("ListTrySets() after update and delete mismatch (-want +got):\n%s", diff)
+	}
+	if n := got[0].Remain(); n != 0 {
+		t.Errorf("Remain() = %d; want 0", n)
+	}
+}
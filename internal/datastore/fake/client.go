@@ -44,9 +44,13 @@ func (f *Client) Count(context.Context, *datastore.Query) (n int, err error) {
 	panic("unimplemented")
 }
 
-// Delete is unimplemented and panics.
-func (f *Client) Delete(context.Context, *datastore.Key) error {
-	panic("unimplemented")
+// Delete deletes the entity for the given key. Deleting a key which
+// doesn't exist is not an error.
+func (f *Client) Delete(_ context.Context, key *datastore.Key) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	delete(f.db[key.Kind], key.Encode())
+	return nil
 }
 
 // DeleteMulti is unimplemented and panics.
@@ -171,6 +171,23 @@ func TestClientPut(t *testing.T) {
 	}
 }
 
+func TestClientDelete(t *testing.T) {
+	cl := &Client{}
+	key := datastore.NameKey("Author", "The Trial", nil)
+	if err := cl.Delete(context.Background(), key); err != nil {
+		t.Fatalf("cl.Delete(_, %v) on empty client = %q, wanted no error", key, err)
+	}
+	if _, err := cl.Put(context.Background(), key, &author{Name: "Kafka"}); err != nil {
+		t.Fatalf("cl.Put(_, %v, _) = %q, wanted no error", key, err)
+	}
+	if err := cl.Delete(context.Background(), key); err != nil {
+		t.Fatalf("cl.Delete(_, %v) = %q, wanted no error", key, err)
+	}
+	if err := cl.Get(context.Background(), key, new(author)); err != datastore.ErrNoSuchEntity {
+		t.Errorf("cl.Get(_, %v, _) after Delete = %v, wanted %v", key, err, datastore.ErrNoSuchEntity)
+	}
+}
+
 // gobEncode encodes src with gob, returning the encoded byte slice.
 // It will report errors on the provided testing.T.
 func gobEncode(t *testing.T, src interface{}) []byte {
@@ -7,6 +7,7 @@ package gcsfs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -65,6 +66,21 @@ type WriterFile interface {
 	io.Writer
 }
 
+// Remove removes the named file from fsys, which must be a RemoveFS.
+func Remove(fsys fs.FS, name string) error {
+	rfs, ok := fsys.(RemoveFS)
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fmt.Errorf("not implemented on type %T", fsys)}
+	}
+	return rfs.Remove(name)
+}
+
+// RemoveFS is an fs.FS that supports removing files.
+type RemoveFS interface {
+	fs.FS
+	Remove(string) error
+}
+
 // WriteFile is like os.WriteFile for CreateFSs.
 func WriteFile(fsys fs.FS, filename string, contents []byte) error {
 	f, err := Create(fsys, filename)
@@ -88,6 +104,7 @@ type gcsFS struct {
 
 var _ = fs.FS((*gcsFS)(nil))
 var _ = CreateFS((*gcsFS)(nil))
+var _ = RemoveFS((*gcsFS)(nil))
 var _ = fs.SubFS((*gcsFS)(nil))
 
 // NewFS creates a new fs.FS that uses ctx for all of its operations.
@@ -131,6 +148,21 @@ func (fsys *gcsFS) Create(name string) (WriterFile, error) {
 	return f.(*GCSFile), nil
 }
 
+// Remove removes the named file.
+func (fsys *gcsFS) Remove(name string) error {
+	if !validPath(name) || name == "." {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+	err := fsys.object(name).Delete(fsys.ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		err = fs.ErrNotExist
+	}
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+	return nil
+}
+
 func (fsys *gcsFS) Sub(dir string) (fs.FS, error) {
 	copy := *fsys
 	copy.prefix = path.Join(fsys.prefix, dir)
@@ -14,6 +14,7 @@ import (
 
 var _ = fs.FS((*dirFS)(nil))
 var _ = CreateFS((*dirFS)(nil))
+var _ = RemoveFS((*dirFS)(nil))
 
 // DirFS is a variant of os.DirFS that supports file creation and is a suitable
 // test fake for the GCS FS.
@@ -77,6 +78,13 @@ func (dir dirFS) Create(name string) (WriterFile, error) {
 	return &atomicWriteFile{temp, finalize}, nil
 }
 
+func (dir dirFS) Remove(name string) error {
+	if !fs.ValidPath(name) || runtime.GOOS == "windows" && containsAny(name, `\:`) {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+	return os.Remove(path.Join(string(dir), name))
+}
+
 type atomicWriteFile struct {
 	*os.File
 	finalize func() error
@@ -57,12 +57,14 @@ type Server struct {
 	bucket                  bucketHandle
 	buildlets               *remote.SessionPool
 	gceBucketName           string
+	recorder                *remote.SessionRecorder
 	scheduler               scheduler
 	sshCertificateAuthority ssh.Signer
 }
 
 // New creates a gomote server. If the rawCAPriKey is invalid, the program will exit.
-func New(rsp *remote.SessionPool, sched *schedule.Scheduler, rawCAPriKey []byte, gomoteGCSBucket string, storageClient *storage.Client) *Server {
+// The recorder provides access to recorded SSH sessions and may be nil.
+func New(rsp *remote.SessionPool, sched *schedule.Scheduler, rawCAPriKey []byte, gomoteGCSBucket string, storageClient *storage.Client, recorder *remote.SessionRecorder) *Server {
 	signer, err := ssh.ParsePrivateKey(rawCAPriKey)
 	if err != nil {
 		log.Fatalf("unable to parse raw certificate authority private key into signer=%s", err)
@@ -71,6 +73,7 @@ func New(rsp *remote.SessionPool, sched *schedule.Scheduler, rawCAPriKey []byte,
 		bucket:                  storageClient.Bucket(gomoteGCSBucket),
 		buildlets:               rsp,
 		gceBucketName:           gomoteGCSBucket,
+		recorder:                recorder,
 		scheduler:               sched,
 		sshCertificateAuthority: signer,
 	}
@@ -84,7 +87,7 @@ func (s *Server) AddBootstrap(ctx context.Context, req *protos.AddBootstrapReque
 		log.Printf("AddBootstrap access.IAPFromContext(ctx) = nil, %s", err)
 		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
 	}
-	ses, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	ses, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds, remote.AccessFull)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -210,7 +213,7 @@ func (s *Server) InstanceAlive(ctx context.Context, req *protos.InstanceAliveReq
 	if req.GetGomoteId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
 	}
-	_, err = s.session(req.GetGomoteId(), creds.ID)
+	_, err = s.session(req.GetGomoteId(), creds, remote.AccessRead)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -230,7 +233,7 @@ func (s *Server) ListDirectory(ctx context.Context, req *protos.ListDirectoryReq
 	if req.GetGomoteId() == "" || req.GetDirectory() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid arguments")
 	}
-	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds, remote.AccessRead)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -251,7 +254,7 @@ func (s *Server) ListDirectory(ctx context.Context, req *protos.ListDirectoryReq
 	}, nil
 }
 
-// ListInstances will list the gomote instances owned by the requester. The requester must be authenticated.
+// ListInstances will list the gomote instances owned by or shared with the requester. The requester must be authenticated.
 func (s *Server) ListInstances(ctx context.Context, req *protos.ListInstancesRequest) (*protos.ListInstancesResponse, error) {
 	creds, err := access.IAPFromContext(ctx)
 	if err != nil {
@@ -260,19 +263,89 @@ func (s *Server) ListInstances(ctx context.Context, req *protos.ListInstancesReq
 	}
 	res := &protos.ListInstancesResponse{}
 	for _, s := range s.buildlets.List() {
-		if s.OwnerID != creds.ID {
-			continue
-		}
-		res.Instances = append(res.Instances, &protos.Instance{
+		inst := &protos.Instance{
 			GomoteId:    s.ID,
 			BuilderType: s.BuilderType,
 			HostType:    s.HostType,
 			Expires:     s.Expires.Unix(),
+		}
+		switch level := s.Access(creds.ID, creds.Email); level {
+		case remote.AccessNone:
+			continue
+		case remote.AccessOwner:
+			for _, g := range s.Grants {
+				inst.Grants = append(inst.Grants, grantToProto(g))
+			}
+		default:
+			inst.SharedAccess = accessLevelToProto(level)
+		}
+		res.Instances = append(res.Instances, inst)
+	}
+	return res, nil
+}
+
+// ListSessionRecordings will list the recorded SSH sessions owned by the requester. The requester must be authenticated.
+func (s *Server) ListSessionRecordings(ctx context.Context, req *protos.ListSessionRecordingsRequest) (*protos.ListSessionRecordingsResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("ListSessionRecordings access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if s.recorder == nil {
+		return &protos.ListSessionRecordingsResponse{}, nil
+	}
+	recs, err := s.recorder.Recordings(creds.ID)
+	if err != nil {
+		log.Printf("ListSessionRecordings recorder.Recordings(%s) = nil, %s", creds.ID, err)
+		return nil, status.Errorf(codes.Internal, "unable to list session recordings")
+	}
+	res := &protos.ListSessionRecordingsResponse{}
+	for _, r := range recs {
+		res.Recordings = append(res.Recordings, &protos.SessionRecording{
+			RecordingId: r.ID,
+			GomoteId:    r.GomoteID,
+			Started:     r.Start.Unix(),
+			Size:        r.Size,
 		})
 	}
 	return res, nil
 }
 
+// ReadSessionRecording will stream a recorded SSH session owned by the requester. The requester must be authenticated.
+func (s *Server) ReadSessionRecording(req *protos.ReadSessionRecordingRequest, stream protos.GomoteService_ReadSessionRecordingServer) error {
+	creds, err := access.IAPFromContext(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	if s.recorder == nil {
+		return status.Errorf(codes.FailedPrecondition, "session recording is not enabled")
+	}
+	f, err := s.recorder.OpenRecording(creds.ID, req.GetRecordingId())
+	if err != nil {
+		return status.Errorf(codes.NotFound, "specified session recording does not exist")
+	}
+	defer f.Close()
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&protos.ReadSessionRecordingResponse{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if errors.Is(err, fs.ErrNotExist) {
+			return status.Errorf(codes.NotFound, "specified session recording does not exist")
+		}
+		if err != nil {
+			log.Printf("ReadSessionRecording reading %s: %s", req.GetRecordingId(), err)
+			return status.Errorf(codes.Internal, "unable to read session recording")
+		}
+	}
+}
+
 // DestroyInstance will destroy a gomote instance. It will ensure that the caller is authenticated and is the owner of the instance
 // before it destroys the instance.
 func (s *Server) DestroyInstance(ctx context.Context, req *protos.DestroyInstanceRequest) (*protos.DestroyInstanceResponse, error) {
@@ -284,7 +357,7 @@ func (s *Server) DestroyInstance(ctx context.Context, req *protos.DestroyInstanc
 	if req.GetGomoteId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
 	}
-	_, err = s.session(req.GetGomoteId(), creds.ID)
+	_, err = s.session(req.GetGomoteId(), creds, remote.AccessOwner)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -302,7 +375,7 @@ func (s *Server) ExecuteCommand(req *protos.ExecuteCommandRequest, stream protos
 	if err != nil {
 		return status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
 	}
-	ses, bc, err := s.sessionAndClient(stream.Context(), req.GetGomoteId(), creds.ID)
+	ses, bc, err := s.sessionAndClient(stream.Context(), req.GetGomoteId(), creds, remote.AccessFull)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return err
@@ -360,7 +433,7 @@ func (s *Server) ReadTGZToURL(ctx context.Context, req *protos.ReadTGZToURLReque
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
 	}
-	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds, remote.AccessRead)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -405,7 +478,7 @@ func (s *Server) RemoveFiles(ctx context.Context, req *protos.RemoveFilesRequest
 	if req.GetGomoteId() == "" || len(req.GetPaths()) == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid arguments")
 	}
-	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds, remote.AccessFull)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -417,6 +490,55 @@ func (s *Server) RemoveFiles(ctx context.Context, req *protos.RemoveFilesRequest
 	return &protos.RemoveFilesResponse{}, nil
 }
 
+// ShareInstance grants another user access to a gomote instance owned by the requester until the grant expires.
+// Read access allows the grantee to list and retrieve files, while full access also allows them to run commands,
+// write files and connect via SSH. Only the owner may destroy or share the instance.
+func (s *Server) ShareInstance(ctx context.Context, req *protos.ShareInstanceRequest) (*protos.ShareInstanceResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("ShareInstance access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	grantee, err := granteeEmail(req.GetGrantee())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid grantee email")
+	}
+	var level remote.AccessLevel
+	switch req.GetLevel() {
+	case protos.AccessGrant_READ:
+		level = remote.AccessRead
+	case protos.AccessGrant_FULL:
+		level = remote.AccessFull
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid access level")
+	}
+	d := time.Duration(req.GetDurationSeconds()) * time.Second
+	if d <= 0 || d > maxShareDuration {
+		return nil, status.Errorf(codes.InvalidArgument, "duration must be positive and no more than %s", maxShareDuration)
+	}
+	if req.GetGomoteId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
+	}
+	if _, err := s.session(req.GetGomoteId(), creds, remote.AccessOwner); err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	if grantee == creds.Email {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to share gomote instance with its owner")
+	}
+	g := remote.Grant{
+		Grantee: grantee,
+		Level:   level,
+		Expires: time.Now().Add(d),
+	}
+	if err := s.buildlets.GrantAccess(req.GetGomoteId(), g); err != nil {
+		log.Printf("ShareInstance remote.GrantAccess(%s) = %s", req.GetGomoteId(), err)
+		return nil, status.Errorf(codes.NotFound, "specified gomote instance does not exist")
+	}
+	log.Printf("gomote %s shared with %s (%s access) until %s", req.GetGomoteId(), grantee, level, g.Expires)
+	return &protos.ShareInstanceResponse{Grant: grantToProto(g)}, nil
+}
+
 // SignSSHKey signs the public SSH key with a certificate. The signed public SSH key is intended for use with the gomote service SSH
 // server. It will be signed by the certificate authority of the server and will restrict access to the gomote instance that it was
 // signed for.
@@ -425,12 +547,16 @@ func (s *Server) SignSSHKey(ctx context.Context, req *protos.SignSSHKeyRequest)
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
 	}
-	session, err := s.session(req.GetGomoteId(), creds.ID)
+	session, err := s.session(req.GetGomoteId(), creds, remote.AccessFull)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
 	}
-	signedPublicKey, err := remote.SignPublicSSHKey(ctx, s.sshCertificateAuthority, req.GetPublicSshKey(), session.ID, session.OwnerID, 5*time.Minute)
+	principal := session.OwnerID
+	if session.OwnerID != creds.ID {
+		principal = creds.Email
+	}
+	signedPublicKey, err := remote.SignPublicSSHKey(ctx, s.sshCertificateAuthority, req.GetPublicSshKey(), session.ID, principal, 5*time.Minute)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "unable to sign ssh key")
 	}
@@ -439,6 +565,31 @@ func (s *Server) SignSSHKey(ctx context.Context, req *protos.SignSSHKeyRequest)
 	}, nil
 }
 
+// UnshareInstance revokes the access granted to another user for a gomote instance owned by the requester.
+func (s *Server) UnshareInstance(ctx context.Context, req *protos.UnshareInstanceRequest) (*protos.UnshareInstanceResponse, error) {
+	creds, err := access.IAPFromContext(ctx)
+	if err != nil {
+		log.Printf("UnshareInstance access.IAPFromContext(ctx) = nil, %s", err)
+		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
+	}
+	grantee, err := granteeEmail(req.GetGrantee())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid grantee email")
+	}
+	if req.GetGomoteId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid gomote ID")
+	}
+	if _, err := s.session(req.GetGomoteId(), creds, remote.AccessOwner); err != nil {
+		// the helper function returns meaningful GRPC error.
+		return nil, err
+	}
+	if err := s.buildlets.RevokeAccess(req.GetGomoteId(), grantee); err != nil {
+		log.Printf("UnshareInstance remote.RevokeAccess(%s) = %s", req.GetGomoteId(), err)
+		return nil, status.Errorf(codes.NotFound, "specified gomote instance does not exist")
+	}
+	return &protos.UnshareInstanceResponse{}, nil
+}
+
 // UploadFile creates a URL and a set of HTTP post fields which are used to upload a file to a staging GCS bucket. Uploaded files are made available to the
 // gomote instances via a subsequent call to one of the WriteFromURL endpoints.
 func (s *Server) UploadFile(ctx context.Context, req *protos.UploadFileRequest) (*protos.UploadFileResponse, error) {
@@ -494,7 +645,7 @@ func (s *Server) WriteFileFromURL(ctx context.Context, req *protos.WriteFileFrom
 		log.Printf("WriteTGZFromURL access.IAPFromContext(ctx) = nil, %s", err)
 		return nil, status.Errorf(codes.Unauthenticated, "request does not contain the required authentication")
 	}
-	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds, remote.AccessFull)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -550,7 +701,7 @@ func (s *Server) WriteTGZFromURL(ctx context.Context, req *protos.WriteTGZFromUR
 	if req.GetUrl() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "missing URL")
 	}
-	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds.ID)
+	_, bc, err := s.sessionAndClient(ctx, req.GetGomoteId(), creds, remote.AccessFull)
 	if err != nil {
 		// the helper function returns meaningful GRPC error.
 		return nil, err
@@ -572,23 +723,24 @@ func (s *Server) WriteTGZFromURL(ctx context.Context, req *protos.WriteTGZFromUR
 	return &protos.WriteTGZFromURLResponse{}, nil
 }
 
-// session is a helper function that retrieves a session associated with the gomoteID and ownerID.
-func (s *Server) session(gomoteID, ownerID string) (*remote.Session, error) {
+// session is a helper function that retrieves a session associated with the gomoteID. The caller
+// must own the session or have been granted at least the required level of access to it.
+func (s *Server) session(gomoteID string, creds *access.IAPFields, level remote.AccessLevel) (*remote.Session, error) {
 	session, err := s.buildlets.Session(gomoteID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "specified gomote instance does not exist")
 	}
-	if session.OwnerID != ownerID {
+	if session.Access(creds.ID, creds.Email) < level {
 		return nil, status.Errorf(codes.PermissionDenied, "not allowed to modify this gomote session")
 	}
 	return session, nil
 }
 
 // sessionAndClient is a helper function that retrieves a session and buildlet client for the
-// associated gomoteID and ownerID. The gomote instance timeout is renewed if the gomote id and owner id
-// are valid.
-func (s *Server) sessionAndClient(ctx context.Context, gomoteID, ownerID string) (*remote.Session, buildlet.Client, error) {
-	session, err := s.session(gomoteID, ownerID)
+// associated gomoteID. The gomote instance timeout is renewed if the caller has the required
+// level of access to the session.
+func (s *Server) sessionAndClient(ctx context.Context, gomoteID string, creds *access.IAPFields, level remote.AccessLevel) (*remote.Session, buildlet.Client, error) {
+	session, err := s.session(gomoteID, creds, level)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -622,6 +774,43 @@ func emailToUser(email string) (string, error) {
 	return email[strings.Index(email, ":")+1 : strings.LastIndex(email, "@")], nil
 }
 
+// maxShareDuration is the longest duration a gomote instance may be shared for.
+const maxShareDuration = 7 * 24 * time.Hour
+
+// granteeEmail returns the Identity Aware Proxy email string for the email address
+// of a user being granted access to an instance.
+// For example, "example@gmail.com" -> "accounts.google.com:example@gmail.com"
+func granteeEmail(email string) (string, error) {
+	if !strings.Contains(email, ":") {
+		email = "accounts.google.com:" + email
+	}
+	if !iapEmailRE.MatchString(email) {
+		return "", errors.New("invalid email format")
+	}
+	return email, nil
+}
+
+// grantToProto converts a grant to its protobuf representation. The grantee is
+// reported as a plain email address.
+func grantToProto(g remote.Grant) *protos.AccessGrant {
+	return &protos.AccessGrant{
+		Grantee: g.Grantee[strings.Index(g.Grantee, ":")+1:],
+		Level:   accessLevelToProto(g.Level),
+		Expires: g.Expires.Unix(),
+	}
+}
+
+// accessLevelToProto converts a grantable access level to its protobuf representation.
+func accessLevelToProto(l remote.AccessLevel) protos.AccessGrant_Level {
+	switch l {
+	case remote.AccessRead:
+		return protos.AccessGrant_READ
+	case remote.AccessFull:
+		return protos.AccessGrant_FULL
+	}
+	return protos.AccessGrant_UNSPECIFIED
+}
+
 // onObjectStore returns true if the the url is for an object on GCS.
 func onObjectStore(bucketName, url string) bool {
 	return strings.HasPrefix(url, fmt.Sprintf("https://storage.googleapis.com/%s/", bucketName))
@@ -14,6 +14,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"golang.org/x/build/internal/access"
 	"golang.org/x/build/internal/coordinator/remote"
 	"golang.org/x/build/internal/coordinator/schedule"
+	"golang.org/x/build/internal/gcsfs"
 	"golang.org/x/build/internal/gomote/protos"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/net/nettest"
@@ -42,19 +44,25 @@ func fakeGomoteServer(t *testing.T, ctx context.Context) protos.GomoteServiceSer
 		bucket:                  &fakeBucketHandler{bucketName: testBucketName},
 		buildlets:               remote.NewSessionPool(ctx),
 		gceBucketName:           testBucketName,
+		recorder:                remote.NewSessionRecorder(ctx, gcsfs.DirFS(t.TempDir()), 0, io.Discard),
 		scheduler:               schedule.NewFake(),
 		sshCertificateAuthority: signer,
 	}
 }
 
 func setupGomoteTest(t *testing.T, ctx context.Context) protos.GomoteServiceClient {
+	return serveGomoteTest(t, fakeGomoteServer(t, ctx))
+}
+
+// serveGomoteTest serves srv on a local listener and returns a client for it.
+func serveGomoteTest(t *testing.T, srv protos.GomoteServiceServer) protos.GomoteServiceClient {
 	lis, err := nettest.NewLocalListener("tcp")
 	if err != nil {
 		t.Fatalf("unable to create net listener: %s", err)
 	}
 	sopts := access.FakeIAPAuthInterceptorOptions()
 	s := grpc.NewServer(sopts...)
-	protos.RegisterGomoteServiceServer(s, fakeGomoteServer(t, ctx))
+	protos.RegisterGomoteServiceServer(s, srv)
 	go s.Serve(lis)
 
 	// create GRPC client
@@ -415,6 +423,118 @@ func TestListInstance(t *testing.T) {
 	}
 }
 
+func TestListSessionRecordings(t *testing.T) {
+	srv := fakeGomoteServer(t, context.Background()).(*Server)
+	client := serveGomoteTest(t, srv)
+	rec, err := srv.recorder.StartRecording(fakeIAP().ID, "user-example-linux-amd64-0", "xterm", 80, 24)
+	if err != nil {
+		t.Fatalf("StartRecording(...) = _, %s; want no error", err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	response, err := client.ListSessionRecordings(ctx, &protos.ListSessionRecordingsRequest{})
+	if err != nil {
+		t.Fatalf("client.ListSessionRecordings = nil, %s; want no error", err)
+	}
+	want := []*protos.SessionRecording{{RecordingId: rec.ID, GomoteId: "user-example-linux-amd64-0"}}
+	if diff := cmp.Diff(want, response.GetRecordings(), protocmp.Transform(), protocmp.IgnoreFields(&protos.SessionRecording{}, "started", "size")); diff != "" {
+		t.Errorf("ListSessionRecordings() mismatch (-want, +got):\n%s", diff)
+	}
+	otherCtx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("user-x", "uuid-user-x"))
+	response, err = client.ListSessionRecordings(otherCtx, &protos.ListSessionRecordingsRequest{})
+	if err != nil || len(response.GetRecordings()) != 0 {
+		t.Errorf("client.ListSessionRecordings(other user) = %v, %v; want no recordings", response, err)
+	}
+}
+
+func TestReadSessionRecording(t *testing.T) {
+	srv := fakeGomoteServer(t, context.Background()).(*Server)
+	client := serveGomoteTest(t, srv)
+	rec, err := srv.recorder.StartRecording(fakeIAP().ID, "user-example-linux-amd64-0", "xterm", 80, 24)
+	if err != nil {
+		t.Fatalf("StartRecording(...) = _, %s; want no error", err)
+	}
+	io.WriteString(rec.Output(), "hello")
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	stream, err := client.ReadSessionRecording(ctx, &protos.ReadSessionRecordingRequest{RecordingId: rec.ID})
+	if err != nil {
+		t.Fatalf("client.ReadSessionRecording = nil, %s; want no error", err)
+	}
+	var got []byte
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream.Recv() = _, %s; want no error", err)
+		}
+		got = append(got, res.GetData()...)
+	}
+	if !strings.Contains(string(got), `"o","hello"]`) {
+		t.Errorf("ReadSessionRecording() = %q; want recorded output", got)
+	}
+}
+
+func TestReadSessionRecordingError(t *testing.T) {
+	srv := fakeGomoteServer(t, context.Background()).(*Server)
+	client := serveGomoteTest(t, srv)
+	rec, err := srv.recorder.StartRecording(fakeIAP().ID, "user-example-linux-amd64-0", "xterm", 80, 24)
+	if err != nil {
+		t.Fatalf("StartRecording(...) = _, %s; want no error", err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		desc        string
+		ctx         context.Context
+		recordingID string
+		wantCode    codes.Code
+	}{
+		{
+			desc:        "unauthenticated request",
+			ctx:         context.Background(),
+			recordingID: rec.ID,
+			wantCode:    codes.Unauthenticated,
+		},
+		{
+			desc:        "non-existent recording",
+			ctx:         access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			recordingID: "user-example-linux-amd64-0-1",
+			wantCode:    codes.NotFound,
+		},
+		{
+			desc:        "invalid recording ID",
+			ctx:         access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			recordingID: "../x-1",
+			wantCode:    codes.NotFound,
+		},
+		{
+			desc:        "wrong owner",
+			ctx:         access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("user-x", "uuid-user-x")),
+			recordingID: rec.ID,
+			wantCode:    codes.NotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			stream, err := client.ReadSessionRecording(tc.ctx, &protos.ReadSessionRecordingRequest{RecordingId: tc.recordingID})
+			if err != nil {
+				t.Fatalf("client.ReadSessionRecording = nil, %s; want no error", err)
+			}
+			if _, err := stream.Recv(); status.Code(err) != tc.wantCode {
+				t.Fatalf("stream.Recv() = _, %s; want %s", err, tc.wantCode)
+			}
+		})
+	}
+}
+
 func TestDestroyInstance(t *testing.T) {
 	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
 	client := setupGomoteTest(t, context.Background())
@@ -733,6 +853,158 @@ func TestRemoveFilesError(t *testing.T) {
 	}
 }
 
+func TestShareInstance(t *testing.T) {
+	client := setupGomoteTest(t, context.Background())
+	gomoteID := mustCreateInstance(t, client, fakeIAP())
+	ownerCtx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
+	granteeCtx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("user-x", "uuid-user-x"))
+
+	share := func(level protos.AccessGrant_Level) {
+		t.Helper()
+		if _, err := client.ShareInstance(ownerCtx, &protos.ShareInstanceRequest{
+			GomoteId:        gomoteID,
+			Grantee:         "user-x@gmail.com",
+			Level:           level,
+			DurationSeconds: 3600,
+		}); err != nil {
+			t.Fatalf("client.ShareInstance(ctx, %s) = nil, %s; want no error", level, err)
+		}
+	}
+	wantCode := func(desc string, err error, want codes.Code) {
+		t.Helper()
+		if got := status.Code(err); got != want {
+			t.Errorf("%s = %s; want %s", desc, got, want)
+		}
+	}
+
+	// Without a grant.
+	_, err := client.InstanceAlive(granteeCtx, &protos.InstanceAliveRequest{GomoteId: gomoteID})
+	wantCode("unshared InstanceAlive", err, codes.PermissionDenied)
+
+	// Read access.
+	share(protos.AccessGrant_READ)
+	_, err = client.InstanceAlive(granteeCtx, &protos.InstanceAliveRequest{GomoteId: gomoteID})
+	wantCode("read InstanceAlive", err, codes.OK)
+	_, err = client.RemoveFiles(granteeCtx, &protos.RemoveFilesRequest{GomoteId: gomoteID, Paths: []string{"foo"}})
+	wantCode("read RemoveFiles", err, codes.PermissionDenied)
+	_, err = client.SignSSHKey(granteeCtx, &protos.SignSSHKeyRequest{GomoteId: gomoteID, PublicSshKey: []byte(devCertCAPublic)})
+	wantCode("read SignSSHKey", err, codes.PermissionDenied)
+
+	response, err := client.ListInstances(granteeCtx, &protos.ListInstancesRequest{})
+	if err != nil {
+		t.Fatalf("client.ListInstances = nil, %s; want no error", err)
+	}
+	want := []*protos.Instance{{GomoteId: gomoteID, BuilderType: "linux-amd64", SharedAccess: protos.AccessGrant_READ}}
+	if diff := cmp.Diff(want, response.GetInstances(), protocmp.Transform(), protocmp.IgnoreFields(&protos.Instance{}, "expires", "host_type")); diff != "" {
+		t.Errorf("grantee ListInstances() mismatch (-want, +got):\n%s", diff)
+	}
+
+	// Full access.
+	share(protos.AccessGrant_FULL)
+	_, err = client.RemoveFiles(granteeCtx, &protos.RemoveFilesRequest{GomoteId: gomoteID, Paths: []string{"foo"}})
+	wantCode("full RemoveFiles", err, codes.OK)
+	_, err = client.SignSSHKey(granteeCtx, &protos.SignSSHKeyRequest{GomoteId: gomoteID, PublicSshKey: []byte(devCertCAPublic)})
+	wantCode("full SignSSHKey", err, codes.OK)
+	_, err = client.ShareInstance(granteeCtx, &protos.ShareInstanceRequest{GomoteId: gomoteID, Grantee: "user-y@gmail.com", Level: protos.AccessGrant_READ, DurationSeconds: 60})
+	wantCode("full ShareInstance", err, codes.PermissionDenied)
+	_, err = client.DestroyInstance(granteeCtx, &protos.DestroyInstanceRequest{GomoteId: gomoteID})
+	wantCode("full DestroyInstance", err, codes.PermissionDenied)
+
+	response, err = client.ListInstances(ownerCtx, &protos.ListInstancesRequest{})
+	if err != nil {
+		t.Fatalf("client.ListInstances = nil, %s; want no error", err)
+	}
+	want = []*protos.Instance{{
+		GomoteId:    gomoteID,
+		BuilderType: "linux-amd64",
+		Grants:      []*protos.AccessGrant{{Grantee: "user-x@gmail.com", Level: protos.AccessGrant_FULL}},
+	}}
+	if diff := cmp.Diff(want, response.GetInstances(), protocmp.Transform(), protocmp.IgnoreFields(&protos.Instance{}, "expires", "host_type"), protocmp.IgnoreFields(&protos.AccessGrant{}, "expires")); diff != "" {
+		t.Errorf("owner ListInstances() mismatch (-want, +got):\n%s", diff)
+	}
+
+	// Revoked access.
+	if _, err := client.UnshareInstance(ownerCtx, &protos.UnshareInstanceRequest{GomoteId: gomoteID, Grantee: "user-x@gmail.com"}); err != nil {
+		t.Fatalf("client.UnshareInstance(ctx, req) = nil, %s; want no error", err)
+	}
+	_, err = client.InstanceAlive(granteeCtx, &protos.InstanceAliveRequest{GomoteId: gomoteID})
+	wantCode("unshared InstanceAlive", err, codes.PermissionDenied)
+}
+
+func TestShareInstanceError(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ctx      context.Context
+		req      *protos.ShareInstanceRequest
+		wantCode codes.Code
+	}{
+		{
+			desc:     "unauthenticated request",
+			ctx:      context.Background(),
+			req:      &protos.ShareInstanceRequest{Grantee: "user-x@gmail.com", Level: protos.AccessGrant_READ, DurationSeconds: 60},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			desc:     "invalid grantee",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ShareInstanceRequest{Grantee: "user-x", Level: protos.AccessGrant_READ, DurationSeconds: 60},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "unspecified access level",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ShareInstanceRequest{Grantee: "user-x@gmail.com", DurationSeconds: 60},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "no duration",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ShareInstanceRequest{Grantee: "user-x@gmail.com", Level: protos.AccessGrant_READ},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "duration too long",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ShareInstanceRequest{Grantee: "user-x@gmail.com", Level: protos.AccessGrant_READ, DurationSeconds: int64(maxShareDuration/time.Second) + 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "share with owner",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ShareInstanceRequest{Grantee: "example@gmail.com", Level: protos.AccessGrant_READ, DurationSeconds: 60},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc:     "different user",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAPWithUser("user-y", "uuid-user-y")),
+			req:      &protos.ShareInstanceRequest{Grantee: "user-x@gmail.com", Level: protos.AccessGrant_READ, DurationSeconds: 60},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			desc:     "non-existent gomote",
+			ctx:      access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP()),
+			req:      &protos.ShareInstanceRequest{GomoteId: "chucky", Grantee: "user-x@gmail.com", Level: protos.AccessGrant_READ, DurationSeconds: 60},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := setupGomoteTest(t, context.Background())
+			gomoteID := mustCreateInstance(t, client, fakeIAP())
+			if tc.req.GomoteId == "" {
+				tc.req.GomoteId = gomoteID
+			}
+			got, err := client.ShareInstance(tc.ctx, tc.req)
+			if err != nil && status.Code(err) != tc.wantCode {
+				t.Fatalf("unexpected error: %s; want %s", err, tc.wantCode)
+			}
+			if err == nil {
+				t.Fatalf("client.ShareInstance(ctx, %v) = %v, nil; want error", tc.req, got)
+			}
+		})
+	}
+}
+
 func TestSignSSHKey(t *testing.T) {
 	ctx := access.FakeContextWithOutgoingIAPAuth(context.Background(), fakeIAP())
 	client := setupGomoteTest(t, context.Background())
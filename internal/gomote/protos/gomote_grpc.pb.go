@@ -38,16 +38,24 @@ type GomoteServiceClient interface {
 	ListDirectory(ctx context.Context, in *ListDirectoryRequest, opts ...grpc.CallOption) (*ListDirectoryResponse, error)
 	// ListInstances lists all of the live gomote instances owned by the caller.
 	ListInstances(ctx context.Context, in *ListInstancesRequest, opts ...grpc.CallOption) (*ListInstancesResponse, error)
+	// ListSessionRecordings lists the recorded SSH sessions owned by the caller.
+	ListSessionRecordings(ctx context.Context, in *ListSessionRecordingsRequest, opts ...grpc.CallOption) (*ListSessionRecordingsResponse, error)
+	// ReadSessionRecording streams the contents of a recorded SSH session owned by the caller.
+	ReadSessionRecording(ctx context.Context, in *ReadSessionRecordingRequest, opts ...grpc.CallOption) (GomoteService_ReadSessionRecordingClient, error)
 	// ReadTGZToURL tars and zips a directory which exists on the gomote instance and returns a URL where it can be
 	// downloaded from.
 	ReadTGZToURL(ctx context.Context, in *ReadTGZToURLRequest, opts ...grpc.CallOption) (*ReadTGZToURLResponse, error)
 	// RemoveFiles removes files or directories from the gomote instance.
 	RemoveFiles(ctx context.Context, in *RemoveFilesRequest, opts ...grpc.CallOption) (*RemoveFilesResponse, error)
+	// ShareInstance grants another user read or full access to an instance owned by the caller.
+	ShareInstance(ctx context.Context, in *ShareInstanceRequest, opts ...grpc.CallOption) (*ShareInstanceResponse, error)
 	// SignSSHKey signs an SSH public key which can be used to SSH into instances owned by the caller.
 	SignSSHKey(ctx context.Context, in *SignSSHKeyRequest, opts ...grpc.CallOption) (*SignSSHKeyResponse, error)
 	// UploadFile generates a signed URL and associated fields to be used when uploading the object to GCS. Once uploaded
 	// the corresponding Write endpoint can be used to send the file to the gomote instance.
 	UploadFile(ctx context.Context, in *UploadFileRequest, opts ...grpc.CallOption) (*UploadFileResponse, error)
+	// UnshareInstance revokes the access granted to another user for an instance owned by the caller.
+	UnshareInstance(ctx context.Context, in *UnshareInstanceRequest, opts ...grpc.CallOption) (*UnshareInstanceResponse, error)
 	// WriteFileFromURL
 	WriteFileFromURL(ctx context.Context, in *WriteFileFromURLRequest, opts ...grpc.CallOption) (*WriteFileFromURLResponse, error)
 	// WriteTGZFromURL retrieves a tar and zipped file from a URL and expands it onto the file system of a gomote instance.
@@ -180,6 +188,47 @@ func (c *gomoteServiceClient) ListInstances(ctx context.Context, in *ListInstanc
 	return out, nil
 }
 
+func (c *gomoteServiceClient) ListSessionRecordings(ctx context.Context, in *ListSessionRecordingsRequest, opts ...grpc.CallOption) (*ListSessionRecordingsResponse, error) {
+	out := new(ListSessionRecordingsResponse)
+	err := c.cc.Invoke(ctx, "/protos.GomoteService/ListSessionRecordings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gomoteServiceClient) ReadSessionRecording(ctx context.Context, in *ReadSessionRecordingRequest, opts ...grpc.CallOption) (GomoteService_ReadSessionRecordingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GomoteService_ServiceDesc.Streams[2], "/protos.GomoteService/ReadSessionRecording", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gomoteServiceReadSessionRecordingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GomoteService_ReadSessionRecordingClient interface {
+	Recv() (*ReadSessionRecordingResponse, error)
+	grpc.ClientStream
+}
+
+type gomoteServiceReadSessionRecordingClient struct {
+	grpc.ClientStream
+}
+
+func (x *gomoteServiceReadSessionRecordingClient) Recv() (*ReadSessionRecordingResponse, error) {
+	m := new(ReadSessionRecordingResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *gomoteServiceClient) ReadTGZToURL(ctx context.Context, in *ReadTGZToURLRequest, opts ...grpc.CallOption) (*ReadTGZToURLResponse, error) {
 	out := new(ReadTGZToURLResponse)
 	err := c.cc.Invoke(ctx, "/protos.GomoteService/ReadTGZToURL", in, out, opts...)
@@ -198,6 +247,15 @@ func (c *gomoteServiceClient) RemoveFiles(ctx context.Context, in *RemoveFilesRe
 	return out, nil
 }
 
+func (c *gomoteServiceClient) ShareInstance(ctx context.Context, in *ShareInstanceRequest, opts ...grpc.CallOption) (*ShareInstanceResponse, error) {
+	out := new(ShareInstanceResponse)
+	err := c.cc.Invoke(ctx, "/protos.GomoteService/ShareInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) SignSSHKey(ctx context.Context, in *SignSSHKeyRequest, opts ...grpc.CallOption) (*SignSSHKeyResponse, error) {
 	out := new(SignSSHKeyResponse)
 	err := c.cc.Invoke(ctx, "/protos.GomoteService/SignSSHKey", in, out, opts...)
@@ -216,6 +274,15 @@ func (c *gomoteServiceClient) UploadFile(ctx context.Context, in *UploadFileRequ
 	return out, nil
 }
 
+func (c *gomoteServiceClient) UnshareInstance(ctx context.Context, in *UnshareInstanceRequest, opts ...grpc.CallOption) (*UnshareInstanceResponse, error) {
+	out := new(UnshareInstanceResponse)
+	err := c.cc.Invoke(ctx, "/protos.GomoteService/UnshareInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gomoteServiceClient) WriteFileFromURL(ctx context.Context, in *WriteFileFromURLRequest, opts ...grpc.CallOption) (*WriteFileFromURLResponse, error) {
 	out := new(WriteFileFromURLResponse)
 	err := c.cc.Invoke(ctx, "/protos.GomoteService/WriteFileFromURL", in, out, opts...)
@@ -254,16 +321,24 @@ type GomoteServiceServer interface {
 	ListDirectory(context.Context, *ListDirectoryRequest) (*ListDirectoryResponse, error)
 	// ListInstances lists all of the live gomote instances owned by the caller.
 	ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error)
+	// ListSessionRecordings lists the recorded SSH sessions owned by the caller.
+	ListSessionRecordings(context.Context, *ListSessionRecordingsRequest) (*ListSessionRecordingsResponse, error)
+	// ReadSessionRecording streams the contents of a recorded SSH session owned by the caller.
+	ReadSessionRecording(*ReadSessionRecordingRequest, GomoteService_ReadSessionRecordingServer) error
 	// ReadTGZToURL tars and zips a directory which exists on the gomote instance and returns a URL where it can be
 	// downloaded from.
 	ReadTGZToURL(context.Context, *ReadTGZToURLRequest) (*ReadTGZToURLResponse, error)
 	// RemoveFiles removes files or directories from the gomote instance.
 	RemoveFiles(context.Context, *RemoveFilesRequest) (*RemoveFilesResponse, error)
+	// ShareInstance grants another user read or full access to an instance owned by the caller.
+	ShareInstance(context.Context, *ShareInstanceRequest) (*ShareInstanceResponse, error)
 	// SignSSHKey signs an SSH public key which can be used to SSH into instances owned by the caller.
 	SignSSHKey(context.Context, *SignSSHKeyRequest) (*SignSSHKeyResponse, error)
 	// UploadFile generates a signed URL and associated fields to be used when uploading the object to GCS. Once uploaded
 	// the corresponding Write endpoint can be used to send the file to the gomote instance.
 	UploadFile(context.Context, *UploadFileRequest) (*UploadFileResponse, error)
+	// UnshareInstance revokes the access granted to another user for an instance owned by the caller.
+	UnshareInstance(context.Context, *UnshareInstanceRequest) (*UnshareInstanceResponse, error)
 	// WriteFileFromURL
 	WriteFileFromURL(context.Context, *WriteFileFromURLRequest) (*WriteFileFromURLResponse, error)
 	// WriteTGZFromURL retrieves a tar and zipped file from a URL and expands it onto the file system of a gomote instance.
@@ -299,18 +374,30 @@ func (UnimplementedGomoteServiceServer) ListDirectory(context.Context, *ListDire
 func (UnimplementedGomoteServiceServer) ListInstances(context.Context, *ListInstancesRequest) (*ListInstancesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListInstances not implemented")
 }
+func (UnimplementedGomoteServiceServer) ListSessionRecordings(context.Context, *ListSessionRecordingsRequest) (*ListSessionRecordingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessionRecordings not implemented")
+}
+func (UnimplementedGomoteServiceServer) ReadSessionRecording(*ReadSessionRecordingRequest, GomoteService_ReadSessionRecordingServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadSessionRecording not implemented")
+}
 func (UnimplementedGomoteServiceServer) ReadTGZToURL(context.Context, *ReadTGZToURLRequest) (*ReadTGZToURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadTGZToURL not implemented")
 }
 func (UnimplementedGomoteServiceServer) RemoveFiles(context.Context, *RemoveFilesRequest) (*RemoveFilesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveFiles not implemented")
 }
+func (UnimplementedGomoteServiceServer) ShareInstance(context.Context, *ShareInstanceRequest) (*ShareInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareInstance not implemented")
+}
 func (UnimplementedGomoteServiceServer) SignSSHKey(context.Context, *SignSSHKeyRequest) (*SignSSHKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignSSHKey not implemented")
 }
 func (UnimplementedGomoteServiceServer) UploadFile(context.Context, *UploadFileRequest) (*UploadFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
 }
+func (UnimplementedGomoteServiceServer) UnshareInstance(context.Context, *UnshareInstanceRequest) (*UnshareInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareInstance not implemented")
+}
 func (UnimplementedGomoteServiceServer) WriteFileFromURL(context.Context, *WriteFileFromURLRequest) (*WriteFileFromURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteFileFromURL not implemented")
 }
@@ -480,6 +567,45 @@ func _GomoteService_ListInstances_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_ListSessionRecordings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionRecordingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).ListSessionRecordings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GomoteService/ListSessionRecordings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).ListSessionRecordings(ctx, req.(*ListSessionRecordingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GomoteService_ReadSessionRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadSessionRecordingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GomoteServiceServer).ReadSessionRecording(m, &gomoteServiceReadSessionRecordingServer{stream})
+}
+
+type GomoteService_ReadSessionRecordingServer interface {
+	Send(*ReadSessionRecordingResponse) error
+	grpc.ServerStream
+}
+
+type gomoteServiceReadSessionRecordingServer struct {
+	grpc.ServerStream
+}
+
+func (x *gomoteServiceReadSessionRecordingServer) Send(m *ReadSessionRecordingResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _GomoteService_ReadTGZToURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReadTGZToURLRequest)
 	if err := dec(in); err != nil {
@@ -516,6 +642,24 @@ func _GomoteService_RemoveFiles_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_ShareInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).ShareInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GomoteService/ShareInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).ShareInstance(ctx, req.(*ShareInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_SignSSHKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignSSHKeyRequest)
 	if err := dec(in); err != nil {
@@ -552,6 +696,24 @@ func _GomoteService_UploadFile_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GomoteService_UnshareInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GomoteServiceServer).UnshareInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.GomoteService/UnshareInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GomoteServiceServer).UnshareInstance(ctx, req.(*UnshareInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GomoteService_WriteFileFromURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WriteFileFromURLRequest)
 	if err := dec(in); err != nil {
@@ -619,6 +781,10 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListInstances",
 			Handler:    _GomoteService_ListInstances_Handler,
 		},
+		{
+			MethodName: "ListSessionRecordings",
+			Handler:    _GomoteService_ListSessionRecordings_Handler,
+		},
 		{
 			MethodName: "ReadTGZToURL",
 			Handler:    _GomoteService_ReadTGZToURL_Handler,
@@ -627,6 +793,10 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveFiles",
 			Handler:    _GomoteService_RemoveFiles_Handler,
 		},
+		{
+			MethodName: "ShareInstance",
+			Handler:    _GomoteService_ShareInstance_Handler,
+		},
 		{
 			MethodName: "SignSSHKey",
 			Handler:    _GomoteService_SignSSHKey_Handler,
@@ -635,6 +805,10 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UploadFile",
 			Handler:    _GomoteService_UploadFile_Handler,
 		},
+		{
+			MethodName: "UnshareInstance",
+			Handler:    _GomoteService_UnshareInstance_Handler,
+		},
 		{
 			MethodName: "WriteFileFromURL",
 			Handler:    _GomoteService_WriteFileFromURL_Handler,
@@ -655,6 +829,11 @@ var GomoteService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GomoteService_ExecuteCommand_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReadSessionRecording",
+			Handler:       _GomoteService_ReadSessionRecording_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "gomote.proto",
 }
@@ -5,6 +5,9 @@
 // Package script implements a simple classification scripting language.
 // A script is a sequence of rules of the form “action <- pattern”,
 // meaning send results matching pattern to the named action.
+//
+// It is used by watchflakes to classify test failures, and, extended
+// with action arguments, by gopherbot for its issue rules.
 package script
 
 import (
@@ -23,10 +26,21 @@ type Script struct {
 
 // A Rule is a single Action <- Pattern rule.
 type Rule struct {
+	Line    int    // line number of the rule in the script
 	Action  string // "skip", "post", and so on
+	Arg     string // argument of the action, if any
 	Pattern Expr   // pattern expression
 }
 
+// String returns the syntax for the rule.
+func (r *Rule) String() string {
+	s := r.Action
+	if r.Arg != "" {
+		s += " " + strconv.Quote(r.Arg)
+	}
+	return s + " <- " + r.Pattern.String()
+}
+
 // Action returns the action specified by the script for the given record.
 func (s *Script) Action(record Record) string {
 	for _, r := range s.Rules {
@@ -200,11 +214,26 @@ func (e *SyntaxError) Error() string {
 	return fmt.Sprintf("%s:%d.%d: %s", e.File, e.Line, e.Offset, e.Err)
 }
 
+// A Language is a variant of the script language.
+type Language struct {
+	// Fields are the input fields that patterns can compare.
+	Fields []string
+
+	// Actions, if non-nil, maps the allowed actions to whether they
+	// take a quoted string argument, as in `label "name" <- pattern`.
+	// If nil, any action is allowed, without an argument.
+	Actions map[string]bool
+
+	// BareField is the field matched by a bare backquoted regexp.
+	BareField string
+}
+
 // A parser holds state for parsing a build expression.
 type parser struct {
 	file   string          // input file, for errors
 	s      string          // input string
 	i      int             // next read location in s
+	lang   *Language       // variant being parsed
 	fields map[string]bool // known input fields for comparisons
 
 	tok string // last token read; "`", "\"", "a" for backquoted regexp, literal string, identifier
@@ -212,20 +241,27 @@ type parser struct {
 	pos int    // position (start) of last token
 }
 
-// Parse parses text as a script,
-// returning the parsed form and any parse errors found.
+// Parse parses text as a script with patterns that may compare the
+// given fields, returning the parsed form and any parse errors found.
 // (The parser attempts to recover after parse errors by starting over
 // at the next newline, so multiple parse errors are possible.)
 // The file argument is used for reporting the file name in errors
 // and in the Script's File field;
 // Parse does not read from the file itself.
 func Parse(file, text string, fields []string) (*Script, []*SyntaxError) {
+	return (&Language{Fields: fields}).Parse(file, text)
+}
+
+// Parse is like the top-level Parse function, but parses text as a
+// script in the language l.
+func (l *Language) Parse(file, text string) (*Script, []*SyntaxError) {
 	p := &parser{
 		file: file,
 		s:    text,
+		lang: l,
 	}
 	p.fields = make(map[string]bool)
-	for _, f := range fields {
+	for _, f := range l.Fields {
 		p.fields[f] = true
 	}
 	var s Script
@@ -306,12 +342,30 @@ func (p *parser) rule() *Rule {
 	if p.tok != "a" {
 		p.unexpected()
 	}
-	action := p.lit
+	r := &Rule{Line: 1 + strings.Count(p.s[:p.pos], "\n"), Action: p.lit}
+	takesArg := false
+	if p.lang.Actions != nil {
+		var ok bool
+		if takesArg, ok = p.lang.Actions[r.Action]; !ok {
+			p.parseError("unknown action " + r.Action)
+		}
+	}
 	p.lex()
+	if takesArg {
+		if p.tok != "\"" {
+			p.parseError(r.Action + " requires quoted string")
+		}
+		if p.lit == "" {
+			p.parseError(r.Action + " requires non-empty string")
+		}
+		r.Arg = p.lit
+		p.lex()
+	}
 	if p.tok != "<-" {
 		p.unexpected()
 	}
-	return &Rule{Action: action, Pattern: p.or()}
+	r.Pattern = p.or()
+	return r
 }
 
 // or parses a sequence of || expressions.
@@ -416,7 +470,7 @@ func (p *parser) atom() Expr {
 			p.parseError("invalid regexp: " + err.Error())
 		}
 		p.lex()
-		return regx("", false, re)
+		return regx(p.lang.BareField, false, re)
 	}
 	panic("unreachable")
 }
@@ -462,10 +516,10 @@ Top:
 		}
 		p.tok = p.s[p.pos:p.i]
 		return
-	case '!', '>': // ! != > >=
+	case '!', '>': // ! != !~ > >=
 		p.pos = p.i
 		p.i++
-		if p.i < len(p.s) && p.s[p.i] == '=' {
+		if p.i < len(p.s) && (p.s[p.i] == '=' || p.s[p.pos] == '!' && p.s[p.i] == '~') {
 			p.i++
 		}
 		p.tok = p.s[p.pos:p.i]
@@ -0,0 +1,203 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// An AsOf is a point in the history of a corpus, to view the corpus as
+// it was then. The zero AsOf is the present.
+type AsOf struct {
+	// Time, if non-zero, is the time of the view. Mutations are
+	// replayed without the changes which happened after it, as told
+	// by the times in the mutations:
+	//   - GitHub issues and pull requests created after Time are
+	//     skipped, as are comments, events and reviews created after
+	//     it. Changes to the title, state, labels, milestone and other
+	//     fields of an issue synced after Time are skipped, unless it's
+	//     the first sync of the issue, in which case only its closing
+	//     after Time is.
+	//   - Gerrit CLs created after Time are skipped. The meta refs of
+	//     the other CLs, which hold their status, reviewers and
+	//     messages, and the branches of the projects are rewound to
+	//     their last commit at or before Time. Patch sets committed
+	//     after Time are skipped.
+	//   - Git commits are all loaded, as they're only reachable by
+	//     hash or from refs.
+	// Maintner syncs changes in batches, so a change made shortly
+	// before Time which was synced together with a change after it may
+	// be missing.
+	Time time.Time
+
+	// Mutations, if positive, is the number of mutations from the
+	// start of the log to replay. Unlike Time, it reproduces exactly
+	// what a corpus loaded then would have had.
+	Mutations int64
+}
+
+func (at AsOf) isZero() bool { return at.Time.IsZero() && at.Mutations <= 0 }
+
+// after reports whether the timestamp ts is after the time of the view.
+// A nil or invalid timestamp isn't.
+func (at AsOf) after(ts *google_protobuf.Timestamp) bool {
+	if at.Time.IsZero() || ts == nil {
+		return false
+	}
+	t, err := ptypes.Timestamp(ts)
+	return err == nil && t.After(at.Time)
+}
+
+// githubIssueMutationAsOf returns the part of the issue mutation m which
+// happened by the time of the view, or nil if none did. It records the
+// issues created after the time of the view, as only the first
+// mutation of an issue says when it was created.
+//
+// c.mu must be held.
+func (c *Corpus) githubIssueMutationAsOf(m *maintpb.GithubIssueMutation) *maintpb.GithubIssueMutation {
+	at := c.asOf
+	if at.Time.IsZero() {
+		return m
+	}
+	key := githubIssueKey{GitHubRepoID{m.Owner, m.Repo}, m.Number}
+	if c.asOfSkippedIssues[key] {
+		return nil
+	}
+	var exists bool
+	if gr := c.GitHub().Repo(m.Owner, m.Repo); gr != nil {
+		exists = gr.Issue(m.Number) != nil
+	}
+	if !exists && at.after(m.Created) {
+		if c.asOfSkippedIssues == nil {
+			c.asOfSkippedIssues = make(map[githubIssueKey]bool)
+		}
+		c.asOfSkippedIssues[key] = true
+		return nil
+	}
+
+	mc := *m
+	mc.Comment = nil
+	for _, cm := range m.Comment {
+		if !at.after(cm.Created) {
+			mc.Comment = append(mc.Comment, cm)
+		}
+	}
+	mc.Event = nil
+	for _, ev := range m.Event {
+		if !at.after(ev.Created) {
+			mc.Event = append(mc.Event, ev)
+		}
+	}
+	mc.Review = nil
+	for _, rv := range m.Review {
+		if !at.after(rv.Created) {
+			mc.Review = append(mc.Review, rv)
+		}
+	}
+	switch {
+	case exists && at.after(m.Updated):
+		// The issue's own fields are as of its update.
+		mc = maintpb.GithubIssueMutation{
+			Owner:         m.Owner,
+			Repo:          m.Repo,
+			Number:        m.Number,
+			Comment:       mc.Comment,
+			CommentStatus: m.CommentStatus,
+			Event:         mc.Event,
+			EventStatus:   m.EventStatus,
+			Review:        mc.Review,
+			ReviewStatus:  m.ReviewStatus,
+		}
+	case at.after(m.ClosedAt):
+		mc.Closed, mc.ClosedAt, mc.ClosedBy = nil, nil, nil
+	}
+	return &mc
+}
+
+// processGerritMutationAsOf processes the Gerrit mutation gm without
+// the CLs, patch sets and ref updates after the time of the view.
+//
+// c.mu must be held.
+func (c *Corpus) processGerritMutationAsOf(gm *maintpb.GerritMutation) {
+	// Process the commits first, so refs can be rewound through them.
+	commits := *gm
+	commits.Refs, commits.DeletedRefs = nil, nil
+	c.processGerritMutation(&commits)
+
+	gp := c.gerrit.projects[gm.Project]
+	refs := *gm
+	refs.Commits, refs.Refs = nil, nil
+	keptMeta := make(map[int32]bool) // CLs whose meta ref is kept
+	var patchSets []*maintpb.GitRef
+	for _, r := range gm.Refs {
+		gc, ok := c.gitCommit[c.gitHashFromHexStr(r.Sha1)]
+		if !ok {
+			// Let processGerritMutation report it.
+			refs.Refs = append(refs.Refs, r)
+			continue
+		}
+		clNum, version, isChange := parseChangeRef(r.Ref)
+		if isChange && version != 0 {
+			// Patch sets are kept below if their CL exists by then.
+			if !gc.CommitTime.After(c.asOf.Time) {
+				patchSets = append(patchSets, r)
+			}
+			continue
+		}
+		if gc = c.commitAsOf(gc); gc == nil {
+			continue
+		}
+		if isChange {
+			keptMeta[clNum] = true
+		}
+		refs.Refs = append(refs.Refs, &maintpb.GitRef{Ref: r.Ref, Sha1: gc.Hash.String()})
+	}
+	for _, r := range patchSets {
+		clNum, _, _ := parseChangeRef(r.Ref)
+		if cl := gp.cls[clNum]; keptMeta[clNum] || cl != nil && cl.Meta != nil {
+			refs.Refs = append(refs.Refs, r)
+		}
+	}
+	c.processGerritMutation(&refs)
+}
+
+// commitAsOf returns the last commit at or before the time of the view
+// in the first-parent history of gc, or nil if there's none.
+//
+// c.mu must be held.
+func (c *Corpus) commitAsOf(gc *GitCommit) *GitCommit {
+	for gc != nil && gc.Committer != placeholderCommitter && gc.CommitTime.After(c.asOf.Time) {
+		if len(gc.Parents) == 0 {
+			return nil
+		}
+		gc = gc.Parents[0]
+	}
+	if gc == nil || gc.Committer == placeholderCommitter {
+		return nil
+	}
+	return gc
+}
+
+// parseChangeRef parses a Gerrit change ref such as
+// "refs/changes/00/14700/1", returning its CL number and version, which
+// is 0 for the meta ref.
+func parseChangeRef(ref string) (clNum, version int32, ok bool) {
+	m := rxChangeRef.FindStringSubmatch(ref)
+	if m == nil {
+		return 0, 0, false
+	}
+	num, err := strconv.ParseInt(m[1], 10, 32)
+	version, ok = gerritVersionNumber(m[2])
+	if err != nil || !ok {
+		return 0, 0, false
+	}
+	return int32(num), version, true
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+func year(y int) time.Time { return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+// testCommit returns a git commit with the given parent, if any,
+// committed at t.
+func testCommit(parent *maintpb.GitCommit, t time.Time, msg string) *maintpb.GitCommit {
+	raw := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"
+	if parent != nil {
+		raw += "parent " + parent.Sha1 + "\n"
+	}
+	raw += fmt.Sprintf("author Gopher <gopher@golang.org> %d +0000\n", t.Unix())
+	raw += fmt.Sprintf("committer Gopher <gopher@golang.org> %d +0000\n\n%s", t.Unix(), msg)
+	return &maintpb.GitCommit{Sha1: fmt.Sprintf("%x", sha1.Sum([]byte(raw))), Raw: []byte(raw)}
+}
+
+// asOfTestLog returns a mutation log with GitHub issues and Gerrit CLs
+// which changed in 2023.
+func asOfTestLog(t *testing.T) *DiskMutationLogger {
+	meta1 := testCommit(nil, year(2020), "Create change\n\nPatch-set: 1\nStatus: new\n")
+	ps1 := testCommit(nil, year(2020), "cmd/go: fix\n")
+	meta2 := testCommit(meta1, year(2023), "Update patch set 1\n\nPatch-set: 1\nStatus: merged\n")
+	meta3 := testCommit(nil, year(2023), "Create change\n\nPatch-set: 1\nStatus: new\n")
+	ps3 := testCommit(nil, year(2021), "net/http: fix\n")
+	ref := func(ref string, gc *maintpb.GitCommit) *maintpb.GitRef {
+		return &maintpb.GitRef{Ref: ref, Sha1: gc.Sha1}
+	}
+
+	logger := NewDiskMutationLogger(t.TempDir())
+	for _, m := range []*maintpb.Mutation{
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Id: 101, Title: "old title",
+			Created: mustProtoFromTime(year(2020)), Updated: mustProtoFromTime(year(2020))}},
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Title: "new title",
+			Updated: mustProtoFromTime(year(2023)), Closed: &maintpb.BoolChange{Val: true}, ClosedAt: mustProtoFromTime(year(2023))}},
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Comment: []*maintpb.GithubIssueCommentMutation{
+			{Id: 1, Body: "old comment", Created: mustProtoFromTime(year(2021))},
+			{Id: 2, Body: "new comment", Created: mustProtoFromTime(year(2023))},
+		}}},
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 2, Id: 102, Title: "new issue",
+			Created: mustProtoFromTime(year(2023)), Updated: mustProtoFromTime(year(2023))}},
+		{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", Commits: []*maintpb.GitCommit{meta1, ps1},
+			Refs: []*maintpb.GitRef{ref("refs/changes/01/1001/meta", meta1), ref("refs/changes/01/1001/1", ps1)}}},
+		{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", Commits: []*maintpb.GitCommit{meta2, meta3, ps3},
+			Refs: []*maintpb.GitRef{ref("refs/changes/01/1001/meta", meta2), ref("refs/changes/02/1002/1", ps3), ref("refs/changes/02/1002/meta", meta3)}}},
+	} {
+		if err := logger.Log(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return logger
+}
+
+func TestInitializeAsOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       AsOf
+		title    string
+		closed   bool
+		comments []string
+		issue2   bool
+		cl1001   string // status
+		cl1002   bool
+	}{
+		{
+			name:     "present",
+			title:    "new title",
+			closed:   true,
+			comments: []string{"old comment", "new comment"},
+			issue2:   true,
+			cl1001:   "merged",
+			cl1002:   true,
+		},
+		{
+			name:     "2022",
+			at:       AsOf{Time: year(2022)},
+			title:    "old title",
+			comments: []string{"old comment"},
+			cl1001:   "new",
+		},
+		{
+			name:   "2 mutations",
+			at:     AsOf{Mutations: 2},
+			title:  "new title",
+			closed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := new(Corpus)
+			if err := c.InitializeAsOf(context.Background(), asOfTestLog(t), Filter{}, tt.at); err != nil {
+				t.Fatal(err)
+			}
+			repo := c.GitHub().Repo("golang", "go")
+			gi := repo.Issue(1)
+			if gi.Title != tt.title || gi.Closed != tt.closed {
+				t.Errorf("issue 1 has title %q, closed %v; want %q, %v", gi.Title, gi.Closed, tt.title, tt.closed)
+			}
+			var comments []string
+			gi.ForeachComment(func(co *GitHubComment) error {
+				comments = append(comments, co.Body)
+				return nil
+			})
+			if fmt.Sprint(comments) != fmt.Sprint(tt.comments) {
+				t.Errorf("issue 1 has comments %q; want %q", comments, tt.comments)
+			}
+			if got := repo.Issue(2) != nil; got != tt.issue2 {
+				t.Errorf("issue 2 exists = %v; want %v", got, tt.issue2)
+			}
+
+			gp := c.Gerrit().Project("go.googlesource.com", "go")
+			var status string
+			if gp != nil {
+				if cl := gp.CL(1001); cl != nil {
+					status = cl.Status
+				}
+			}
+			if status != tt.cl1001 {
+				t.Errorf("CL 1001 has status %q; want %q", status, tt.cl1001)
+			}
+			if got := gp != nil && gp.CL(1002) != nil; got != tt.cl1002 {
+				t.Errorf("CL 1002 exists = %v; want %v", got, tt.cl1002)
+			}
+		})
+	}
+}
+
+func TestUpdateAsOfPanics(t *testing.T) {
+	c := new(Corpus)
+	if err := c.InitializeAsOf(context.Background(), asOfTestLog(t), Filter{}, AsOf{Time: year(2022)}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Update of a view of the past didn't panic")
+		}
+	}()
+	c.Update(context.Background())
+}
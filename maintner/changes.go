@@ -0,0 +1,471 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// A ChangeKind is a kind of ChangeEvent.
+type ChangeKind int
+
+const (
+	// IssueCreated is the creation of a GitHub issue or pull request.
+	IssueCreated ChangeKind = iota + 1
+	// IssueClosed is the closing of a GitHub issue or pull request.
+	IssueClosed
+	// IssueReopened is the reopening of a GitHub issue or pull request.
+	IssueReopened
+	// IssueLabeled is the addition of Label to a GitHub issue or
+	// pull request.
+	IssueLabeled
+	// IssueUnlabeled is the removal of Label from a GitHub issue or
+	// pull request.
+	IssueUnlabeled
+	// IssueCommented is a new comment by Actor on a GitHub issue or
+	// pull request.
+	IssueCommented
+
+	// CLCreated is the creation of a Gerrit CL.
+	CLCreated
+	// CLPatchSet is the upload of patch set Version of a Gerrit CL.
+	CLPatchSet
+	// CLVote is a vote of Value by Actor on the Gerrit label Label of
+	// a CL. A removed vote has Value 0.
+	CLVote
+	// CLStatus is the change of the status of a Gerrit CL to Status,
+	// such as "merged" or "abandoned".
+	CLStatus
+)
+
+var changeKindNames = map[ChangeKind]string{
+	IssueCreated:   "IssueCreated",
+	IssueClosed:    "IssueClosed",
+	IssueReopened:  "IssueReopened",
+	IssueLabeled:   "IssueLabeled",
+	IssueUnlabeled: "IssueUnlabeled",
+	IssueCommented: "IssueCommented",
+	CLCreated:      "CLCreated",
+	CLPatchSet:     "CLPatchSet",
+	CLVote:         "CLVote",
+	CLStatus:       "CLStatus",
+}
+
+func (k ChangeKind) String() string {
+	if s, ok := changeKindNames[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// IsGitHub reports whether k is a kind of change to a GitHub issue.
+func (k ChangeKind) IsGitHub() bool { return k >= IssueCreated && k <= IssueCommented }
+
+// A ChangeEvent is a change to a GitHub issue or Gerrit CL made by a
+// mutation of the corpus, such as a CL being created or a label being
+// added to an issue.
+//
+// See Corpus.TrackChanges.
+type ChangeEvent struct {
+	// Mutation is the number of the mutation which made the change,
+	// counting the mutations processed by the corpus from 1.
+	// A mutation may make several changes.
+	Mutation int64
+
+	Kind ChangeKind
+
+	// Time is when the change was made, as told by GitHub or Gerrit.
+	Time time.Time
+
+	// GitHubRepo is the repo of the issue of a GitHub change.
+	GitHubRepo GitHubRepoID
+	// GerritProject is the project of the CL of a Gerrit change, such
+	// as "go.googlesource.com/go".
+	GerritProject string
+	// Number is the number of the issue or CL.
+	Number int32
+
+	// Actor is who made the change, if known. It's a GitHub login for
+	// GitHub changes. For Gerrit changes it's the email address Gerrit
+	// records the account by in the CL's meta commits, of the form
+	// "<account ID>@<server ID>" (like "5976@62eb7196-b449-3ce5-99f1-c037f21e1705"),
+	// except for CLPatchSet, where it's the email address of the author
+	// of the patch set's commit.
+	Actor string
+
+	Label   string // of IssueLabeled, IssueUnlabeled and CLVote
+	Value   int8   // of CLVote
+	Version int32  // of CLPatchSet
+	Status  string // of CLStatus
+}
+
+// ErrChangesExpired is returned by Corpus.WatchChanges and
+// Corpus.Changes when the change events requested are no longer kept.
+var ErrChangesExpired = errors.New("maintner: change events expired")
+
+// ErrChangesAhead is returned by Corpus.WatchChanges and Corpus.Changes
+// when the mutation number is past the last mutation processed by the
+// corpus, such as one from before a restart of a process which started
+// tracking changes afresh.
+var ErrChangesAhead = errors.New("maintner: mutation number is past the last mutation")
+
+// changeLog is the change events of the recent mutations of a corpus.
+type changeLog struct {
+	max    int           // most events to keep
+	events []ChangeEvent // oldest first
+	// expired is the number of the last mutation whose events may
+	// have been dropped, or which happened before tracking began.
+	expired int64
+	// more is closed, and replaced, when events are added.
+	more chan struct{}
+}
+
+// TrackChanges makes the corpus derive change events from the mutations
+// it processes from now on, for WatchChanges and Changes. It keeps at
+// least the last max events. It returns the number of mutations
+// processed so far, after which the events start.
+func (c *Corpus) TrackChanges(max int) int64 {
+	if max <= 0 {
+		panic("maintner: TrackChanges max must be positive")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.changes != nil {
+		panic("maintner: TrackChanges called twice")
+	}
+	c.changes = &changeLog{max: max, expired: c.mutations, more: make(chan struct{})}
+	return c.mutations
+}
+
+// WatchChanges calls fn, in order, with the change events of the
+// mutations after the mutation number after, waiting for new mutations
+// until ctx is done or fn returns an error, which it returns.
+// If after is zero, it starts with the next mutation.
+//
+// It returns ErrChangesExpired if some of the events after the
+// mutation number are no longer kept, ErrChangesAhead if the corpus
+// hasn't processed that many mutations, and an error if the corpus
+// isn't tracking changes.
+//
+// The corpus isn't locked while fn runs.
+func (c *Corpus) WatchChanges(ctx context.Context, after int64, fn func(*ChangeEvent) error) error {
+	c.mu.RLock()
+	cl := c.changes
+	if cl != nil && after == 0 {
+		after = c.mutations
+	}
+	ahead := after > c.mutations
+	c.mu.RUnlock()
+	if cl == nil {
+		return errors.New("maintner: corpus isn't tracking changes")
+	}
+	if ahead {
+		return ErrChangesAhead
+	}
+	for {
+		c.mu.RLock()
+		events, err := cl.after(after)
+		more := cl.more
+		c.mu.RUnlock()
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			if err := fn(&events[i]); err != nil {
+				return err
+			}
+			after = events[i].Mutation
+		}
+		if len(events) > 0 {
+			continue
+		}
+		select {
+		case <-more:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Changes returns the change events of the mutations after the
+// mutation number after, without waiting for more, and the number of
+// the last mutation processed, to pass as after to the next call.
+//
+// It returns ErrChangesExpired or ErrChangesAhead, along with the
+// number of the last mutation, if some of the events after the mutation
+// number are no longer kept or the corpus hasn't processed that many
+// mutations, and an error if the corpus isn't tracking changes.
+func (c *Corpus) Changes(after int64) (events []ChangeEvent, last int64, err error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.changes == nil {
+		return nil, 0, errors.New("maintner: corpus isn't tracking changes")
+	}
+	if after > c.mutations {
+		return nil, c.mutations, ErrChangesAhead
+	}
+	events, err = c.changes.after(after)
+	if err != nil {
+		return nil, c.mutations, err
+	}
+	return events, c.mutations, nil
+}
+
+// after returns a copy of the events of the mutations after the
+// mutation number after.
+//
+// The corpus must be locked.
+func (cl *changeLog) after(after int64) ([]ChangeEvent, error) {
+	if after < cl.expired {
+		return nil, ErrChangesExpired
+	}
+	var events []ChangeEvent
+	for i := len(cl.events) - 1; i >= 0 && cl.events[i].Mutation > after; i-- {
+		events = cl.events[i:]
+	}
+	return append([]ChangeEvent(nil), events...), nil
+}
+
+// addChanges adds the change events of the current mutation.
+//
+// c.mu must be held.
+func (c *Corpus) addChanges(events []ChangeEvent) {
+	cl := c.changes
+	if len(events) == 0 {
+		return
+	}
+	for i := range events {
+		events[i].Mutation = c.mutations
+	}
+	cl.events = append(cl.events, events...)
+	if len(cl.events) > 2*cl.max {
+		// Drop the oldest events, and the rest of the events of
+		// their mutation.
+		drop := len(cl.events) - cl.max
+		cl.expired = cl.events[drop-1].Mutation
+		for drop < len(cl.events) && cl.events[drop].Mutation == cl.expired {
+			drop++
+		}
+		cl.events = append(cl.events[:0:0], cl.events[drop:]...)
+	}
+	close(cl.more)
+	cl.more = make(chan struct{})
+}
+
+// githubIssueState is the state of a GitHub issue which change events
+// are derived from.
+type githubIssueState struct {
+	exists   bool
+	closed   bool
+	labels   map[int64]*GitHubLabel
+	comments map[int64]bool
+}
+
+// githubIssueStateOf returns the state of the issue the mutation m is
+// about.
+//
+// c.mu must be held.
+func (c *Corpus) githubIssueStateOf(m *maintpb.GithubIssueMutation) githubIssueState {
+	var s githubIssueState
+	gr := c.GitHub().Repo(m.Owner, m.Repo)
+	if gr == nil {
+		return s
+	}
+	gi := gr.Issue(m.Number)
+	if gi == nil || gi.NotExist {
+		return s
+	}
+	s.exists, s.closed = true, gi.Closed
+	s.labels = make(map[int64]*GitHubLabel)
+	for id, l := range gi.Labels {
+		s.labels[id] = l
+	}
+	s.comments = make(map[int64]bool)
+	for id := range gi.comments {
+		s.comments[id] = true
+	}
+	return s
+}
+
+// githubIssueChanges returns the change events of the processed issue
+// mutation m, whose issue had the state old before it.
+//
+// c.mu must be held.
+func (c *Corpus) githubIssueChanges(m *maintpb.GithubIssueMutation, old githubIssueState) []ChangeEvent {
+	gr := c.GitHub().Repo(m.Owner, m.Repo)
+	if gr == nil {
+		return nil
+	}
+	gi := gr.Issue(m.Number)
+	if gi == nil || gi.NotExist {
+		return nil
+	}
+	var events []ChangeEvent
+	add := func(kind ChangeKind, t time.Time, actor *GitHubUser) *ChangeEvent {
+		e := ChangeEvent{
+			Kind:       kind,
+			Time:       t,
+			GitHubRepo: gr.ID(),
+			Number:     gi.Number,
+		}
+		if actor != nil {
+			e.Actor = actor.Login
+		}
+		events = append(events, e)
+		return &events[len(events)-1]
+	}
+	if !old.exists {
+		add(IssueCreated, gi.Created, gi.User)
+	}
+	if gi.Closed && !old.closed {
+		add(IssueClosed, gi.ClosedAt, gi.ClosedBy)
+	} else if !gi.Closed && old.closed {
+		add(IssueReopened, gi.Updated, nil)
+	}
+	for _, id := range sortedLabelIDs(gi.Labels) {
+		if _, ok := old.labels[id]; !ok {
+			add(IssueLabeled, gi.Updated, nil).Label = gi.Labels[id].Name
+		}
+	}
+	for _, id := range sortedLabelIDs(old.labels) {
+		if _, ok := gi.Labels[id]; !ok {
+			add(IssueUnlabeled, gi.Updated, nil).Label = old.labels[id].Name
+		}
+	}
+	for _, cm := range m.Comment {
+		if old.comments[cm.Id] {
+			continue
+		}
+		if co := gi.comments[cm.Id]; co != nil {
+			add(IssueCommented, co.Created, co.User)
+		}
+	}
+	return events
+}
+
+// sortedLabelIDs returns the IDs of labels in order, so change events
+// are derived deterministically.
+func sortedLabelIDs(labels map[int64]*GitHubLabel) []int64 {
+	ids := make([]int64, 0, len(labels))
+	for id := range labels {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// gerritRefsOf returns the hashes the change refs of the Gerrit
+// mutation gm pointed to before it, keyed by ref name.
+//
+// c.mu must be held.
+func (c *Corpus) gerritRefsOf(gm *maintpb.GerritMutation) map[string]GitHash {
+	refs := make(map[string]GitHash)
+	var gp *GerritProject
+	if c.gerrit != nil {
+		gp = c.gerrit.projects[gm.Project]
+	}
+	for _, r := range gm.Refs {
+		clNum, version, ok := parseChangeRef(r.Ref)
+		if !ok || gp == nil {
+			continue
+		}
+		if h, ok := gp.remote[gerritCLVersion{clNum, version}]; ok {
+			refs[r.Ref] = h
+		}
+	}
+	return refs
+}
+
+// maxNewMetaCommits is the most meta commits of a CL a mutation is
+// looked at for change events.
+const maxNewMetaCommits = 1000
+
+// gerritChanges returns the change events of the processed Gerrit
+// mutation gm, whose change refs pointed to old before it.
+//
+// c.mu must be held.
+func (c *Corpus) gerritChanges(gm *maintpb.GerritMutation, old map[string]GitHash) []ChangeEvent {
+	var events []ChangeEvent
+	for _, r := range gm.Refs {
+		clNum, version, ok := parseChangeRef(r.Ref)
+		if !ok {
+			continue
+		}
+		h := c.gitHashFromHexStr(r.Sha1)
+		prev, seen := old[r.Ref]
+		if seen && prev == h {
+			continue
+		}
+		gc := c.gitCommit[h]
+		if gc == nil {
+			continue
+		}
+		if version != 0 {
+			if !seen {
+				events = append(events, ChangeEvent{
+					Kind:          CLPatchSet,
+					Time:          gc.CommitTime,
+					GerritProject: gm.Project,
+					Number:        clNum,
+					Actor:         gc.Author.Email(),
+					Version:       version,
+				})
+			}
+			continue
+		}
+
+		// Find the new meta commits, oldest first.
+		var metas []*GitCommit
+		for gc != nil && gc.Hash != prev && len(metas) < maxNewMetaCommits {
+			metas = append(metas, gc)
+			if len(gc.Parents) == 0 {
+				break
+			}
+			gc = c.gitCommit[gc.Parents[0].Hash]
+		}
+		for i := len(metas) - 1; i >= 0; i-- {
+			events = append(events, gerritMetaChanges(gm.Project, clNum, metas[i])...)
+		}
+	}
+	return events
+}
+
+// gerritMetaChanges returns the change events of the meta commit gc of
+// the CL clNum of the Gerrit project.
+func gerritMetaChanges(project string, clNum int32, gc *GitCommit) []ChangeEvent {
+	var events []ChangeEvent
+	add := func(kind ChangeKind, actor string) *ChangeEvent {
+		events = append(events, ChangeEvent{
+			Kind:          kind,
+			Time:          gc.CommitTime,
+			GerritProject: project,
+			Number:        clNum,
+			Actor:         actor,
+		})
+		return &events[len(events)-1]
+	}
+	author := gc.Author.Email()
+	root := len(gc.Parents) == 0
+	if root {
+		add(CLCreated, author)
+	}
+	meta := &GerritMeta{Commit: gc}
+	for _, v := range meta.LabelChanges() {
+		e := add(CLVote, v.Voter)
+		e.Label, e.Value = v.Label, v.Value
+	}
+	footer := meta.Footer()
+	if status := lineValue(footer, "Status: "); status != "" && !root {
+		add(CLStatus, author).Status = status
+	}
+	return events
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// changeString formats e for comparison in tests.
+func changeString(e *ChangeEvent) string {
+	s := fmt.Sprintf("%d %v", e.Mutation, e.Kind)
+	if e.Kind.IsGitHub() {
+		s += fmt.Sprintf(" %v#%d", e.GitHubRepo, e.Number)
+	} else {
+		s += fmt.Sprintf(" %s/%d", e.GerritProject, e.Number)
+	}
+	if e.Actor != "" {
+		s += " by " + e.Actor
+	}
+	switch e.Kind {
+	case IssueLabeled, IssueUnlabeled:
+		s += " " + e.Label
+	case CLVote:
+		s += fmt.Sprintf(" %s=%+d", e.Label, e.Value)
+	case CLPatchSet:
+		s += fmt.Sprintf(" v%d", e.Version)
+	case CLStatus:
+		s += " " + e.Status
+	}
+	return s
+}
+
+func TestWatchChanges(t *testing.T) {
+	c := new(Corpus)
+	c.processMutationLocked(&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Id: 101,
+		Created: mustProtoFromTime(year(2023)), Updated: mustProtoFromTime(year(2023)), User: &maintpb.GithubUser{Id: 1, Login: "gopher"},
+		AddLabel: []*maintpb.GithubLabel{{Id: 1, Name: "NeedsFix"}}}})
+	if n := c.TrackChanges(100); n != 1 {
+		t.Errorf("TrackChanges = %d; want 1", n)
+	}
+
+	meta1 := testCommit(nil, year(2023), "Create change\n\nPatch-set: 1\nStatus: new\n")
+	ps1 := testCommit(nil, year(2023), "cmd/go: fix\n")
+	meta2 := testCommit(meta1, year(2023), "Update patch set 1\n\nPatch-set: 1\nLabel: Code-Review=+2 Gopher <1@gerrit>\nLabel: -Run-TryBot\n")
+	meta3 := testCommit(meta2, year(2023), "Update patch set 1\n\nPatch-set: 1\nStatus: merged\n")
+	ref := func(ref string, gc *maintpb.GitCommit) *maintpb.GitRef {
+		return &maintpb.GitRef{Ref: ref, Sha1: gc.Sha1}
+	}
+	for _, m := range []*maintpb.Mutation{
+		{Github: &maintpb.GithubMutation{Owner: "golang", Repo: "go", Labels: []*maintpb.GithubLabel{{Id: 1, Name: "NeedsFix"}, {Id: 2, Name: "Documentation"}}}},
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Closed: &maintpb.BoolChange{Val: true},
+			RemoveLabel: []int64{1}, AddLabel: []*maintpb.GithubLabel{{Id: 2, Name: "Documentation"}}, Comment: []*maintpb.GithubIssueCommentMutation{
+				{Id: 1, User: &maintpb.GithubUser{Id: 2, Login: "gobot"}, Body: "closed", Created: mustProtoFromTime(year(2023))},
+			}}},
+		{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", Commits: []*maintpb.GitCommit{meta1, ps1},
+			Refs: []*maintpb.GitRef{ref("refs/changes/01/1001/meta", meta1), ref("refs/changes/01/1001/1", ps1)}}},
+		{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", Commits: []*maintpb.GitCommit{meta2, meta3},
+			Refs: []*maintpb.GitRef{ref("refs/changes/01/1001/meta", meta3), ref("refs/changes/01/1001/1", ps1)}}},
+	} {
+		c.processMutationLocked(m)
+	}
+
+	watch := func(after int64, n int) ([]string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		var got []string
+		err := c.WatchChanges(ctx, after, func(e *ChangeEvent) error {
+			got = append(got, changeString(e))
+			if len(got) == n {
+				return errStop
+			}
+			return nil
+		})
+		if err == errStop {
+			err = nil
+		}
+		return got, err
+	}
+	got, err := watch(1, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"3 IssueClosed golang/go#1",
+		"3 IssueLabeled golang/go#1 Documentation",
+		"3 IssueUnlabeled golang/go#1 NeedsFix",
+		"3 IssueCommented golang/go#1 by gobot",
+		"4 CLCreated go.googlesource.com/go/1001 by gopher@golang.org",
+		"4 CLPatchSet go.googlesource.com/go/1001 by gopher@golang.org v1",
+		"5 CLVote go.googlesource.com/go/1001 by 1@gerrit Code-Review=+2",
+		"5 CLVote go.googlesource.com/go/1001 by gopher@golang.org Run-TryBot=+0",
+		"5 CLStatus go.googlesource.com/go/1001 by gopher@golang.org merged",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := watch(0, 1); err != context.DeadlineExceeded {
+		t.Errorf("WatchChanges without new changes = %v; want it to wait", err)
+	}
+	if _, err := watch(-1, 1); !errors.Is(err, ErrChangesExpired) {
+		t.Errorf("WatchChanges before the tracking began = %v; want ErrChangesExpired", err)
+	}
+
+	events, last, err := c.Changes(4)
+	if err != nil || len(events) != 3 || last != 5 {
+		t.Errorf("Changes(4) = %d events, %d, %v; want 3 events, 5, nil", len(events), last, err)
+	}
+	events, last, err = c.Changes(last)
+	if err != nil || len(events) != 0 || last != 5 {
+		t.Errorf("Changes(5) = %d events, %d, %v; want 0 events, 5, nil", len(events), last, err)
+	}
+	if _, last, err := c.Changes(0); err != ErrChangesExpired || last != 5 {
+		t.Errorf("Changes(0) = %d, %v; want 5, ErrChangesExpired", last, err)
+	}
+	if _, err := watch(6, 1); err != ErrChangesAhead {
+		t.Errorf("WatchChanges past the last mutation = %v; want ErrChangesAhead", err)
+	}
+	if _, last, err := c.Changes(6); err != ErrChangesAhead || last != 5 {
+		t.Errorf("Changes(6) = %d, %v; want 5, ErrChangesAhead", last, err)
+	}
+}
+
+func TestWatchChangesExpired(t *testing.T) {
+	c := new(Corpus)
+	c.TrackChanges(2)
+	for i := int32(1); i <= 5; i++ {
+		c.processMutationLocked(&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: i, Id: int64(i),
+			Created: mustProtoFromTime(year(2023)), Updated: mustProtoFromTime(year(2023))}})
+	}
+	if err := c.WatchChanges(context.Background(), 2, func(*ChangeEvent) error { return errStop }); err != ErrChangesExpired {
+		t.Errorf("WatchChanges of dropped changes = %v; want ErrChangesExpired", err)
+	}
+	var first int32
+	c.WatchChanges(context.Background(), 3, func(e *ChangeEvent) error {
+		first = e.Number
+		return errStop
+	})
+	if first != 4 {
+		t.Errorf("first change after mutation 3 is of issue %d; want 4", first)
+	}
+}
+
+var errStop = errors.New("stop")
@@ -0,0 +1,307 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+//go:embed schema.sql
+var schema string
+
+// export updates the database db to reflect the mutation log src.
+// Only the entities changed by the mutations after the ones the
+// database already reflects are written.
+func export(ctx context.Context, db *sql.DB, src maintner.MutationSource) error {
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("creating schema: %v", err)
+	}
+	var after int64
+	err := db.QueryRowContext(ctx, "SELECT mutations FROM export").Scan(&after)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	ts := &touchSource{src: src, after: after}
+	c := new(maintner.Corpus)
+	if err := c.Initialize(ctx, ts); err != nil {
+		return err
+	}
+	if ts.n < after {
+		return fmt.Errorf("the log has %d mutations, but the database reflects %d; is it from another log?", ts.n, after)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	w := &writer{ctx: ctx, tx: tx, stmts: make(map[string]*sql.Stmt)}
+	w.githubLabels(c)
+	for k := range ts.issues {
+		w.githubIssue(c, k)
+	}
+	for k := range ts.cls {
+		w.gerritCL(c, k)
+	}
+	for _, k := range ts.commits {
+		w.gitCommit(c, k)
+	}
+	w.exec("DELETE FROM export")
+	w.exec("INSERT INTO export (mutations, updated) VALUES (?, ?)", ts.n, sqlTime(time.Now()))
+	if w.err != nil {
+		return w.err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("Exported %d issues, %d CLs and %d commits changed by mutations %d to %d.",
+		len(ts.issues), len(ts.cls), len(ts.commits), after+1, ts.n)
+	return nil
+}
+
+type issueKey struct {
+	repo   maintner.GitHubRepoID
+	number int32
+}
+
+type clKey struct {
+	project string // "go.googlesource.com/go"
+	number  int32
+}
+
+type commitKey struct {
+	repo string // "go", "net", etc.
+	hash string
+}
+
+// A touchSource is a mutation source which records the entities
+// changed by the mutations of src after the first after of them.
+type touchSource struct {
+	src   maintner.MutationSource
+	after int64
+
+	n       int64 // mutations seen
+	issues  map[issueKey]bool
+	cls     map[clKey]bool
+	commits []commitKey
+}
+
+func (s *touchSource) GetMutations(ctx context.Context) <-chan maintner.MutationStreamEvent {
+	in := s.src.GetMutations(ctx)
+	out := make(chan maintner.MutationStreamEvent)
+	go func() {
+		for e := range in {
+			if e.Mutation != nil {
+				s.n++
+				if s.n > s.after {
+					s.touch(e.Mutation)
+				}
+			}
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+			if e.Err != nil || e.End {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// touch records the entities changed by the mutation m.
+func (s *touchSource) touch(m *maintpb.Mutation) {
+	if im := m.GithubIssue; im != nil {
+		if s.issues == nil {
+			s.issues = make(map[issueKey]bool)
+		}
+		s.issues[issueKey{maintner.GitHubRepoID{Owner: im.Owner, Repo: im.Repo}, im.Number}] = true
+	}
+	if gm := m.Gerrit; gm != nil {
+		for _, r := range gm.Refs {
+			num, ok := changeRefCL(r.Ref)
+			if !ok {
+				continue
+			}
+			if s.cls == nil {
+				s.cls = make(map[clKey]bool)
+			}
+			s.cls[clKey{gm.Project, num}] = true
+		}
+	}
+	if gm := m.Git; gm != nil && gm.Commit != nil && gm.Repo.GetGoRepo() != "" {
+		s.commits = append(s.commits, commitKey{gm.Repo.GoRepo, gm.Commit.Sha1})
+	}
+}
+
+// changeRefCL returns the CL number of a Gerrit change ref such as
+// "refs/changes/00/14700/1" or "refs/changes/00/14700/meta".
+func changeRefCL(ref string) (int32, bool) {
+	f := strings.Split(ref, "/")
+	if len(f) != 5 || f[0] != "refs" || f[1] != "changes" {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(f[3], 10, 32)
+	return int32(n), err == nil
+}
+
+// A writer writes the entities of a corpus to the database. It records
+// the first error.
+type writer struct {
+	ctx   context.Context
+	tx    *sql.Tx
+	stmts map[string]*sql.Stmt // by query
+	err   error
+}
+
+// exec executes the query with args, preparing it the first time.
+func (w *writer) exec(query string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	st, ok := w.stmts[query]
+	if !ok {
+		st, w.err = w.tx.PrepareContext(w.ctx, query)
+		if w.err != nil {
+			return
+		}
+		w.stmts[query] = st
+	}
+	if _, err := st.ExecContext(w.ctx, args...); err != nil {
+		w.err = fmt.Errorf("%s: %v", strings.Fields(query)[0:3], err)
+	}
+}
+
+// insert inserts or replaces a row of table.
+func (w *writer) insert(table string, values ...interface{}) {
+	w.exec("INSERT OR REPLACE INTO "+table+" VALUES (?"+strings.Repeat(", ?", len(values)-1)+")", values...)
+}
+
+// githubLabels replaces the labels of all GitHub repos.
+func (w *writer) githubLabels(c *maintner.Corpus) {
+	w.exec("DELETE FROM github_labels")
+	c.GitHub().ForeachRepo(func(gr *maintner.GitHubRepo) error {
+		return gr.ForeachLabel(func(l *maintner.GitHubLabel) error {
+			w.insert("github_labels", gr.ID().String(), l.ID, l.Name)
+			return nil
+		})
+	})
+}
+
+// githubIssue replaces the rows of the GitHub issue k.
+func (w *writer) githubIssue(c *maintner.Corpus, k issueKey) {
+	repo := k.repo.String()
+	for _, table := range []string{"github_issues", "github_issue_labels", "github_comments", "github_events"} {
+		w.exec("DELETE FROM "+table+" WHERE repo = ? AND number = ?", repo, k.number)
+	}
+	gr := c.GitHub().Repo(k.repo.Owner, k.repo.Repo)
+	if gr == nil {
+		return
+	}
+	gi := gr.Issue(k.number)
+	if gi == nil || gi.NotExist {
+		return
+	}
+	var milestone string
+	if gi.Milestone != nil {
+		milestone = gi.Milestone.Title
+	}
+	w.insert("github_issues", repo, gi.Number, gi.ID, gi.PullRequest, login(gi.User), gi.Title, gi.Body,
+		gi.Closed, gi.Locked, milestone, sqlTime(gi.Created), sqlTime(gi.Updated), sqlTime(gi.ClosedAt))
+	for _, l := range gi.Labels {
+		w.insert("github_issue_labels", repo, gi.Number, l.Name)
+	}
+	gi.ForeachComment(func(co *maintner.GitHubComment) error {
+		w.insert("github_comments", repo, gi.Number, co.ID, login(co.User), sqlTime(co.Created), sqlTime(co.Updated), co.Body)
+		return nil
+	})
+	gi.ForeachEvent(func(e *maintner.GitHubIssueEvent) error {
+		w.insert("github_events", repo, gi.Number, e.ID, e.Type, login(e.Actor), sqlTime(e.Created),
+			e.Label, login(e.Assignee), e.Milestone, e.From, e.To, e.CommitID)
+		return nil
+	})
+}
+
+// gerritCL replaces the rows of the Gerrit CL k.
+func (w *writer) gerritCL(c *maintner.Corpus, k clKey) {
+	for _, table := range []string{"gerrit_cls", "gerrit_messages", "gerrit_votes", "gerrit_hashtags"} {
+		w.exec("DELETE FROM "+table+" WHERE project = ? AND number = ?", k.project, k.number)
+	}
+	i := strings.Index(k.project, "/")
+	if i < 0 {
+		return
+	}
+	gp := c.Gerrit().Project(k.project[:i], k.project[i+1:])
+	if gp == nil {
+		return
+	}
+	cl := gp.CL(k.number)
+	if cl == nil || cl.Meta == nil || len(cl.Metas) == 0 || cl.Commit == nil || cl.Private {
+		return
+	}
+	var owner string
+	if p := cl.Owner(); p != nil {
+		owner = p.Email()
+	}
+	w.insert("gerrit_cls", k.project, cl.Number, cl.ChangeID(), cl.Branch(), cl.Status, owner, cl.Subject(),
+		cl.Version, cl.Commit.Hash.String(), cl.WorkInProgress(), sqlTime(cl.Created), sqlTime(cl.Meta.Commit.CommitTime))
+	for i, m := range cl.Messages {
+		w.insert("gerrit_messages", k.project, cl.Number, i+1, m.Version, m.Author.Email(), sqlTime(m.Date), m.Message)
+	}
+	seq := 0
+	for _, m := range cl.Metas {
+		for _, v := range m.LabelChanges() {
+			seq++
+			w.insert("gerrit_votes", k.project, cl.Number, seq, m.Commit.Hash.String(), sqlTime(m.Commit.CommitTime), v.Label, v.Value, v.Voter)
+		}
+	}
+	cl.Meta.Hashtags().Foreach(func(tag string) {
+		if tag != "" {
+			w.insert("gerrit_hashtags", k.project, cl.Number, tag)
+		}
+	})
+}
+
+// gitCommit replaces the rows of the git commit k.
+func (w *writer) gitCommit(c *maintner.Corpus, k commitKey) {
+	gc := c.GitCommit(k.hash)
+	if gc == nil {
+		return
+	}
+	w.insert("git_commits", k.repo, k.hash, gc.Author.Name(), gc.Author.Email(), sqlTime(gc.AuthorTime),
+		gc.Committer.Name(), gc.Committer.Email(), sqlTime(gc.CommitTime), gc.Summary(), gc.Msg)
+	w.exec("DELETE FROM git_commit_parents WHERE hash = ?", k.hash)
+	for i, p := range gc.Parents {
+		w.insert("git_commit_parents", k.hash, i, p.Hash.String())
+	}
+}
+
+// sqlTime returns the database value of t.
+func sqlTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// login returns the login of the GitHub user u, or "" if u is nil.
+func login(u *maintner.GitHubUser) string {
+	if u == nil {
+		return ""
+	}
+	return u.Login
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	tspb "github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+func year(y int) time.Time { return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+func timestamp(t *testing.T, tm time.Time) *tspb.Timestamp {
+	ts, err := ptypes.TimestampProto(tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+// testCommit returns a git commit with the given parent, if any,
+// committed at tm.
+func testCommit(parent *maintpb.GitCommit, tm time.Time, msg string) *maintpb.GitCommit {
+	raw := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"
+	if parent != nil {
+		raw += "parent " + parent.Sha1 + "\n"
+	}
+	raw += fmt.Sprintf("author Gopher <gopher@golang.org> %d +0000\n", tm.Unix())
+	raw += fmt.Sprintf("committer Gopher <gopher@golang.org> %d +0000\n\n%s", tm.Unix(), msg)
+	return &maintpb.GitCommit{Sha1: fmt.Sprintf("%x", sha1.Sum([]byte(raw))), Raw: []byte(raw)}
+}
+
+// query returns the rows of the query as strings of their columns
+// separated by "|".
+func query(t *testing.T, db *sql.DB, q string) []string {
+	t.Helper()
+	rows, err := db.Query(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []string
+	for rows.Next() {
+		vals := make([]sql.NullString, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			t.Fatal(err)
+		}
+		var f []string
+		for _, v := range vals {
+			f = append(f, v.String)
+		}
+		out = append(out, strings.Join(f, "|"))
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestExport(t *testing.T) {
+	logDir := t.TempDir()
+	logger := maintner.NewDiskMutationLogger(logDir)
+	log := func(ms ...*maintpb.Mutation) {
+		for _, m := range ms {
+			if err := logger.Log(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	meta1 := testCommit(nil, year(2023), "Create change\n\nPatch-set: 1\nBranch: refs/heads/master\nChange-id: I0123456789abcdef0123456789abcdef01234567\nSubject: cmd/go: fix\nStatus: new\nHashtags: wait-release\n")
+	ps1 := testCommit(nil, year(2023), "cmd/go: fix\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n")
+	meta2 := testCommit(meta1, year(2023).Add(time.Hour), "Update patch set 1\n\nPatch-set: 1\nLabel: Run-TryBot=+1\n")
+	ref := func(ref string, gc *maintpb.GitCommit) *maintpb.GitRef {
+		return &maintpb.GitRef{Ref: ref, Sha1: gc.Sha1}
+	}
+	root := testCommit(nil, year(2022), "all: start\n")
+	head := testCommit(root, year(2023), "all: continue\n\nMore text.\n")
+	log(
+		&maintpb.Mutation{Github: &maintpb.GithubMutation{Owner: "golang", Repo: "go",
+			Labels: []*maintpb.GithubLabel{{Id: 1, Name: "NeedsFix"}}}},
+		&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Id: 101,
+			Title: "cmd/go: broken", Body: "It's broken.", User: &maintpb.GithubUser{Id: 1, Login: "gopher"},
+			Created: timestamp(t, year(2023)), Updated: timestamp(t, year(2023)),
+			AddLabel: []*maintpb.GithubLabel{{Id: 1, Name: "NeedsFix"}},
+			Comment: []*maintpb.GithubIssueCommentMutation{
+				{Id: 1, User: &maintpb.GithubUser{Id: 2, Login: "gobot"}, Body: "CL 1001 fixes it.", Created: timestamp(t, year(2023))},
+			}}},
+		&maintpb.Mutation{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", Commits: []*maintpb.GitCommit{meta1, meta2, ps1},
+			Refs: []*maintpb.GitRef{ref("refs/changes/01/1001/meta", meta2), ref("refs/changes/01/1001/1", ps1)}}},
+		&maintpb.Mutation{Git: &maintpb.GitMutation{Repo: &maintpb.GitRepo{GoRepo: "go"}, Commit: root}},
+		&maintpb.Mutation{Git: &maintpb.GitMutation{Repo: &maintpb.GitRepo{GoRepo: "go"}, Commit: head}},
+	)
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "maintner.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	if err := export(ctx, db, maintner.NewDiskMutationLogger(logDir)); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	check := func(q string, want ...string) {
+		t.Helper()
+		if got := query(t, db, q); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", q, got, want)
+		}
+	}
+	check("SELECT mutations FROM export", "5")
+	check("SELECT * FROM github_labels", "golang/go|1|NeedsFix")
+	check("SELECT repo, number, author, title, closed, created, closed_at FROM github_issues",
+		"golang/go|1|gopher|cmd/go: broken|0|2023-01-01T00:00:00Z|")
+	check("SELECT label FROM github_issue_labels", "NeedsFix")
+	check("SELECT author, body FROM github_comments", "gobot|CL 1001 fixes it.")
+	check("SELECT project, number, change_id, branch, status, owner, subject, version, commit_hash, updated FROM gerrit_cls",
+		"go.googlesource.com/go|1001|I0123456789abcdef0123456789abcdef01234567|master|new|gopher@golang.org|cmd/go: fix|1|"+ps1.Sha1+"|2023-01-01T01:00:00Z")
+	check("SELECT seq, meta, label, value, voter FROM gerrit_votes",
+		"1|"+meta2.Sha1+"|Run-TryBot|1|gopher@golang.org")
+	check("SELECT hashtag FROM gerrit_hashtags", "wait-release")
+	check("SELECT hash, author_email, subject FROM git_commits ORDER BY commit_time",
+		root.Sha1+"|gopher@golang.org|all: start", head.Sha1+"|gopher@golang.org|all: continue")
+	check("SELECT * FROM git_commit_parents", head.Sha1+"|0|"+root.Sha1)
+
+	// Export again after more mutations.
+	meta3 := testCommit(meta2, year(2023).Add(2*time.Hour), "Update patch set 1\n\nPatch-set: 1\nLabel: Code-Review=+2 Gopher <1@gerrit>\nLabel: -Run-TryBot\n")
+	log(
+		&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1,
+			Updated: timestamp(t, year(2024)), Closed: &maintpb.BoolChange{Val: true}, ClosedAt: timestamp(t, year(2024)),
+			RemoveLabel: []int64{1}}},
+		&maintpb.Mutation{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", Commits: []*maintpb.GitCommit{meta3},
+			Refs: []*maintpb.GitRef{ref("refs/changes/01/1001/meta", meta3), ref("refs/changes/01/1001/1", ps1)}}},
+	)
+	if err := export(ctx, db, maintner.NewDiskMutationLogger(logDir)); err != nil {
+		t.Fatalf("second export: %v", err)
+	}
+	check("SELECT mutations FROM export", "7")
+	check("SELECT repo, number, closed, closed_at FROM github_issues", "golang/go|1|1|2024-01-01T00:00:00Z")
+	check("SELECT label FROM github_issue_labels")
+	check("SELECT author FROM github_comments", "gobot")
+	check("SELECT seq, label, value, voter FROM gerrit_votes",
+		"1|Run-TryBot|1|gopher@golang.org", "2|Code-Review|2|1@gerrit", "3|Run-TryBot|0|gopher@golang.org")
+	check("SELECT COUNT(*) FROM git_commits", "2")
+}
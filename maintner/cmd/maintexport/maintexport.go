@@ -0,0 +1,58 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The maintexport command exports the maintner corpus of the Go project
+// to a SQLite database, for analysis with SQL.
+//
+// It writes normalized tables of GitHub issues, their comments, events
+// and labels, of Gerrit CLs, their messages, votes and hashtags, and of
+// the commits of the git repos. The schema is documented in schema.sql.
+//
+// The database records the number of mutations of the maintner log it
+// reflects. Running maintexport again with the same database updates
+// only the issues, CLs and commits changed by the mutations since.
+//
+// Usage:
+//
+//	maintexport [-db maintner.db]
+//	sqlite3 maintner.db 'SELECT label, COUNT(*) FROM github_issue_labels GROUP BY label'
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/godata"
+)
+
+var (
+	dbFile   = flag.String("db", "maintner.db", "SQLite database file to export to; it's created if it doesn't exist")
+	server   = flag.String("server", godata.Server, "URL of the maintner log server")
+	cacheDir = flag.String("cache-dir", "", "directory of the local copy of the maintner log (default is godata's)")
+)
+
+func main() {
+	flag.Parse()
+	dir := *cacheDir
+	if dir == "" {
+		dir = godata.Dir()
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		log.Fatal(err)
+	}
+	db, err := sql.Open("sqlite3", *dbFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+	src := maintner.NewNetworkMutationSource(*server, dir)
+	if err := export(context.Background(), db, src); err != nil {
+		log.Fatalf("Exporting to %s: %v", *dbFile, err)
+	}
+}
@@ -0,0 +1,167 @@
+-- Copyright 2023 The Go Authors. All rights reserved.
+-- Use of this source code is governed by a BSD-style
+-- license that can be found in the LICENSE file.
+
+-- This is the schema of the SQLite databases written by maintexport.
+--
+-- Times are stored as text in RFC 3339 form in UTC, such as
+-- "2023-01-10T17:04:05Z", which SQLite's date and time functions
+-- accept, or NULL if unknown. Booleans are stored as 0 or 1.
+--
+-- GitHub repos are named "owner/repo", such as "golang/go", and Gerrit
+-- projects are named "server/project", such as "go.googlesource.com/go".
+
+-- export is the state of the export. It has a single row.
+CREATE TABLE IF NOT EXISTS export (
+	-- mutations is the number of mutations of the maintner log the
+	-- database reflects. The next export only updates the entities
+	-- changed by the mutations after it.
+	mutations INTEGER NOT NULL,
+	-- updated is when the database was last exported to.
+	updated TEXT NOT NULL
+);
+
+-- github_labels are the labels of GitHub repos.
+CREATE TABLE IF NOT EXISTS github_labels (
+	repo TEXT NOT NULL,
+	id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	PRIMARY KEY (repo, id)
+);
+
+-- github_issues are GitHub issues and pull requests.
+CREATE TABLE IF NOT EXISTS github_issues (
+	repo TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	id INTEGER NOT NULL,
+	pull_request INTEGER NOT NULL,
+	author TEXT NOT NULL,     -- login
+	title TEXT NOT NULL,
+	body TEXT NOT NULL,
+	closed INTEGER NOT NULL,
+	locked INTEGER NOT NULL,
+	milestone TEXT NOT NULL,  -- title, or "" for none
+	created TEXT,
+	updated TEXT,
+	closed_at TEXT,
+	PRIMARY KEY (repo, number)
+);
+
+-- github_issue_labels are the current labels of GitHub issues and pull
+-- requests. The history of labels is in github_events.
+CREATE TABLE IF NOT EXISTS github_issue_labels (
+	repo TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	label TEXT NOT NULL,
+	PRIMARY KEY (repo, number, label)
+);
+
+-- github_comments are the comments on GitHub issues and pull requests.
+CREATE TABLE IF NOT EXISTS github_comments (
+	repo TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	id INTEGER NOT NULL,
+	author TEXT NOT NULL,  -- login
+	created TEXT,
+	updated TEXT,
+	body TEXT NOT NULL,
+	PRIMARY KEY (repo, number, id)
+);
+
+-- github_events are the events of GitHub issues and pull requests, such
+-- as "labeled", "closed" or "milestoned". See
+-- https://docs.github.com/en/rest/issues/events.
+CREATE TABLE IF NOT EXISTS github_events (
+	repo TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	id INTEGER NOT NULL,
+	type TEXT NOT NULL,
+	actor TEXT NOT NULL,      -- login
+	created TEXT,
+	label TEXT NOT NULL,      -- for "labeled" and "unlabeled"
+	assignee TEXT NOT NULL,   -- login, for "assigned" and "unassigned"
+	milestone TEXT NOT NULL,  -- for "milestoned" and "demilestoned"
+	from_title TEXT NOT NULL, -- for "renamed"
+	to_title TEXT NOT NULL,   -- for "renamed"
+	commit_id TEXT NOT NULL,  -- for "closed" and "referenced"
+	PRIMARY KEY (repo, number, id)
+);
+
+CREATE INDEX IF NOT EXISTS github_events_type ON github_events (type);
+
+-- gerrit_cls are Gerrit CLs.
+CREATE TABLE IF NOT EXISTS gerrit_cls (
+	project TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	change_id TEXT NOT NULL,
+	branch TEXT NOT NULL,
+	status TEXT NOT NULL,  -- "new", "merged", "abandoned" or "draft"
+	owner TEXT NOT NULL,   -- email address of the author of patch set 1
+	subject TEXT NOT NULL,
+	version INTEGER NOT NULL,  -- latest patch set
+	commit_hash TEXT NOT NULL, -- of the latest patch set
+	work_in_progress INTEGER NOT NULL,
+	created TEXT,
+	updated TEXT,  -- time of the latest meta commit
+	PRIMARY KEY (project, number)
+);
+
+-- gerrit_messages are the messages of Gerrit CLs, such as review
+-- comments.
+CREATE TABLE IF NOT EXISTS gerrit_messages (
+	project TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	seq INTEGER NOT NULL,      -- order in the CL, from 1
+	version INTEGER NOT NULL,  -- patch set
+	author TEXT NOT NULL,      -- email address of the Gerrit account
+	date TEXT,
+	message TEXT NOT NULL,
+	PRIMARY KEY (project, number, seq)
+);
+
+-- gerrit_votes are the votes on the labels of Gerrit CLs, in the order
+-- they were cast. The current vote of a voter is their last one.
+CREATE TABLE IF NOT EXISTS gerrit_votes (
+	project TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	seq INTEGER NOT NULL,   -- order in the CL, from 1
+	meta TEXT NOT NULL,     -- hash of the meta commit of the vote
+	date TEXT,
+	label TEXT NOT NULL,    -- "Code-Review", "Run-TryBot", etc.
+	value INTEGER NOT NULL, -- 0 for a removed vote
+	voter TEXT NOT NULL,    -- email address of the Gerrit account
+	PRIMARY KEY (project, number, seq)
+);
+
+CREATE INDEX IF NOT EXISTS gerrit_votes_label ON gerrit_votes (label);
+
+-- gerrit_hashtags are the current hashtags of Gerrit CLs.
+CREATE TABLE IF NOT EXISTS gerrit_hashtags (
+	project TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	hashtag TEXT NOT NULL,
+	PRIMARY KEY (project, number, hashtag)
+);
+
+-- git_commits are the commits of the git repos on go.googlesource.com.
+CREATE TABLE IF NOT EXISTS git_commits (
+	repo TEXT NOT NULL,  -- "go", "net", etc.
+	hash TEXT NOT NULL,
+	author_name TEXT NOT NULL,
+	author_email TEXT NOT NULL,
+	author_time TEXT,
+	committer_name TEXT NOT NULL,
+	committer_email TEXT NOT NULL,
+	commit_time TEXT,
+	subject TEXT NOT NULL,
+	message TEXT NOT NULL,
+	PRIMARY KEY (repo, hash)
+);
+
+-- git_commit_parents are the parents of git commits.
+CREATE TABLE IF NOT EXISTS git_commit_parents (
+	hash TEXT NOT NULL,
+	idx INTEGER NOT NULL,  -- 0 for the first parent
+	parent TEXT NOT NULL,
+	PRIMARY KEY (hash, idx)
+);
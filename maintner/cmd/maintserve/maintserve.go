@@ -12,6 +12,15 @@
 //
 // maintserve displays partial Gerrit CL data that is available within the
 // maintner corpus. Code diffs and inline review comments are not included.
+//
+// Its search page, at /search, finds issues, pull requests and CLs across
+// all repositories by label, milestone, author, reviewer, hashtag, status,
+// touched directory and age, such as
+//
+//	is:open is:cl reviewer:gopher dir:net/http age:>30d sort:created-asc
+//
+// Searches are shareable by URL, and /search.json serves the results as
+// JSON. The -queries flag names a file of saved searches to list.
 package main
 
 import (
@@ -38,7 +47,10 @@ import (
 	"golang.org/x/build/maintner/godata"
 )
 
-var httpFlag = flag.String("http", ":8080", "Listen for HTTP connections on this address.")
+var (
+	httpFlag    = flag.String("http", ":8080", "Listen for HTTP connections on this address.")
+	queriesFlag = flag.String("queries", "", "File of saved searches to list, one per line in the form \"name: query\".")
+)
 
 func main() {
 	flag.Parse()
@@ -54,6 +66,15 @@ func run() error {
 		return err
 	}
 
+	var saved []savedQuery
+	if *queriesFlag != "" {
+		var err error
+		saved, err = readSavedQueries(*queriesFlag)
+		if err != nil {
+			return err
+		}
+	}
+
 	corpus, err := godata.Get(context.Background())
 	if err != nil {
 		return err
@@ -97,6 +118,7 @@ func run() error {
 	printServingAt(*httpFlag)
 	err = http.ListenAndServe(*httpFlag, &handler{
 		c:              corpus,
+		saved:          saved,
 		fontsHandler:   httpgzip.FileServer(gofontwoff.Assets, httpgzip.FileServerOptions{}),
 		issuesHandler:  issuesApp,
 		changesHandler: changesApp,
@@ -116,6 +138,7 @@ func printServingAt(addr string) {
 // choosing from various endpoints and parsing the repository ID from URL.
 type handler struct {
 	c              *maintner.Corpus
+	saved          []savedQuery
 	fontsHandler   http.Handler
 	issuesHandler  http.Handler
 	changesHandler http.Handler
@@ -128,6 +151,12 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// Handle "/search" and "/search.json".
+	if req.URL.Path == "/search" || req.URL.Path == "/search.json" {
+		h.serveSearch(w, req)
+		return
+	}
+
 	// Handle "/assets/fonts/...".
 	if strings.HasPrefix(req.URL.Path, "/assets/fonts") {
 		req = stripPrefix(req, len("/assets/fonts"))
@@ -189,6 +218,17 @@ var indexHTML = template.Must(template.New("").Parse(`<html>
 	<body>
 		<div style="max-width: 800px; margin: 0 auto 100px auto;">
 			<h2>maintserve</h2>
+			<form action="/search">
+				<input name="q" size="60" placeholder="is:open label:NeedsFix dir:net/http">
+				<input type="submit" class="btn" value="Search">
+			</form>
+			{{- with .Saved}}
+			<h3>Saved Searches</h3>
+			<ul>{{range .}}
+				<li><a href="/search?q={{.Query}}">{{.Name}}</a> <code>{{.Query}}</code></li>
+				{{- end}}
+			</ul>
+			{{- end}}
 			<div style="display: inline-block; width: 50%;">
 				<h3>GitHub Repos</h3>
 				<ul>{{range .Repos}}
@@ -263,6 +303,7 @@ func (h *handler) serveIndex(w http.ResponseWriter, req *http.Request) {
 	err = indexHTML.Execute(w, map[string]interface{}{
 		"Repos":    repos,
 		"Projects": projects,
+		"Saved":    h.saved,
 	})
 	if err != nil {
 		log.Println(err)
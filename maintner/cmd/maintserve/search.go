@@ -0,0 +1,635 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/build/maintner"
+)
+
+// A query is a parsed search query, such as
+//
+//	is:open label:NeedsFix dir:net/http age:>30d sort:created
+//
+// Its terms are:
+//
+//	is:issue, is:pr, is:cl    kind of result
+//	is:open, is:closed, is:merged, is:abandoned, or status:...
+//	repo:golang/go            GitHub repo or Gerrit project, such as go.googlesource.com/go
+//	label:NeedsFix            GitHub label; -label:... excludes it
+//	milestone:Go1.21          GitHub milestone title, or "none"
+//	author:gopher             GitHub login, or name or email of a CL's owner
+//	reviewer:gopher           name, email or account ID of a CL reviewer
+//	hashtag:wait-release      Gerrit hashtag; -hashtag:... excludes it
+//	dir:net/http              directory touched by a CL, or named by an issue title
+//	age:>30d, updated:<1w     time since creation or last update, in h, d, w or y
+//	sort:updated              order by created, updated or number; add -asc for ascending
+//
+// Other words must appear in the title. Values with spaces may be
+// quoted, as in label:"help wanted". Repeated label and hashtag terms
+// must all match; other repeated terms match any of their values.
+type query struct {
+	kinds                 map[string]bool // "issue", "pr" and "cl"; nil for all
+	status                string          // "open", "closed", "merged" or "abandoned"; "" for any
+	repos                 []string
+	labels, notLabels     []string
+	milestones            []string
+	authors, reviewers    []string
+	hashtags, notHashtags []string
+	dirs                  []string
+	createdBefore         time.Time
+	createdAfter          time.Time
+	updatedBefore         time.Time
+	updatedAfter          time.Time
+	words                 []string // lower case
+	sort                  string   // "created", "updated" or "number"
+	asc                   bool
+}
+
+// parseQuery parses the search query s, interpreting ages relative to now.
+func parseQuery(s string, now time.Time) (*query, error) {
+	q := &query{sort: "updated"}
+	for _, term := range splitQuery(s) {
+		key, val := "", term
+		if i := strings.Index(term, ":"); i > 0 {
+			key, val = term[:i], strings.Trim(term[i+1:], `"`)
+		}
+		if key != "" && val == "" {
+			return nil, fmt.Errorf("missing value in %q", term)
+		}
+		switch key {
+		case "":
+			q.words = append(q.words, strings.ToLower(strings.Trim(val, `"`)))
+		case "is", "status":
+			switch val {
+			case "issue", "pr", "cl":
+				if key == "status" {
+					return nil, fmt.Errorf("unknown status %q", val)
+				}
+				if q.kinds == nil {
+					q.kinds = make(map[string]bool)
+				}
+				q.kinds[val] = true
+			case "open", "closed", "merged", "abandoned":
+				q.status = val
+			default:
+				return nil, fmt.Errorf("unknown %s %q", key, val)
+			}
+		case "repo":
+			q.repos = append(q.repos, val)
+		case "label":
+			q.labels = append(q.labels, val)
+		case "-label":
+			q.notLabels = append(q.notLabels, val)
+		case "milestone":
+			q.milestones = append(q.milestones, val)
+		case "author":
+			q.authors = append(q.authors, val)
+		case "reviewer":
+			q.reviewers = append(q.reviewers, val)
+		case "hashtag":
+			q.hashtags = append(q.hashtags, val)
+		case "-hashtag":
+			q.notHashtags = append(q.notHashtags, val)
+		case "dir":
+			q.dirs = append(q.dirs, strings.Trim(val, "/"))
+		case "age", "updated":
+			before, after, err := parseAge(val, now)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", key, err)
+			}
+			if key == "age" {
+				q.createdBefore, q.createdAfter = before, after
+			} else {
+				q.updatedBefore, q.updatedAfter = before, after
+			}
+		case "sort":
+			by := strings.TrimSuffix(strings.TrimSuffix(val, "-desc"), "-asc")
+			switch by {
+			case "created", "updated", "number":
+			default:
+				return nil, fmt.Errorf("unknown sort order %q", val)
+			}
+			q.sort, q.asc = by, strings.HasSuffix(val, "-asc")
+		default:
+			return nil, fmt.Errorf("unknown search term %q", key+":")
+		}
+	}
+	return q, nil
+}
+
+// splitQuery splits s into space-separated terms, keeping spaces
+// within double quotes.
+func splitQuery(s string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			term.WriteRune(r)
+		case r == ' ' && !quoted:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+// parseAge parses an age such as ">30d" (more than 30 days ago), "<1w"
+// (less than a week ago) or "2y" (same as ">2y"). It returns the
+// bounds of the matching times; one of them is zero.
+func parseAge(s string, now time.Time) (before, after time.Time, err error) {
+	newer := strings.HasPrefix(s, "<")
+	s = strings.TrimLeft(s, "<>")
+	if len(s) < 2 {
+		return before, after, fmt.Errorf("bad age %q", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return before, after, fmt.Errorf("bad age %q", s)
+	}
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	case 'y':
+		unit = 365 * 24 * time.Hour
+	default:
+		return before, after, fmt.Errorf("bad unit in age %q; want h, d, w or y", s)
+	}
+	t := now.Add(-time.Duration(n) * unit)
+	if newer {
+		return before, t, nil
+	}
+	return t, after, nil
+}
+
+// A searchResult is an issue, pull request or CL matching a query.
+type searchResult struct {
+	Kind      string    `json:"kind"` // "issue", "pr" or "cl"
+	Repo      string    `json:"repo"` // "golang/go" or "go.googlesource.com/go"
+	Number    int32     `json:"number"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author"` // GitHub login or email address
+	Status    string    `json:"status"` // "open", "closed", "merged" or "abandoned"
+	Labels    []string  `json:"labels,omitempty"`
+	Milestone string    `json:"milestone,omitempty"`
+	Hashtags  []string  `json:"hashtags,omitempty"`
+	Created   time.Time `json:"created"`
+	Updated   time.Time `json:"updated"`
+	URL       string    `json:"url"` // path of its page in maintserve
+}
+
+// search returns the issues, pull requests and CLs of c matching q, in
+// the order of q.
+func search(c *maintner.Corpus, q *query) []*searchResult {
+	var results []*searchResult
+	c.GitHub().ForeachRepo(func(r *maintner.GitHubRepo) error {
+		repo := r.ID().String()
+		if !matchAny(q.repos, repo) {
+			return nil
+		}
+		return r.ForeachIssue(func(gi *maintner.GitHubIssue) error {
+			if res := matchIssue(q, repo, gi); res != nil {
+				results = append(results, res)
+			}
+			return nil
+		})
+	})
+	c.Gerrit().ForeachProjectUnsorted(func(p *maintner.GerritProject) error {
+		project := p.ServerSlashProject()
+		if !matchAny(q.repos, project) {
+			return nil
+		}
+		return p.ForeachCLUnsorted(func(cl *maintner.GerritCL) error {
+			if res := matchCL(q, project, cl); res != nil {
+				results = append(results, res)
+			}
+			return nil
+		})
+	})
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if q.asc {
+			a, b = b, a
+		}
+		switch q.sort {
+		case "created":
+			if !a.Created.Equal(b.Created) {
+				return a.Created.After(b.Created)
+			}
+		case "updated":
+			if !a.Updated.Equal(b.Updated) {
+				return a.Updated.After(b.Updated)
+			}
+		}
+		if a.Number != b.Number {
+			return a.Number > b.Number
+		}
+		return a.Repo > b.Repo
+	})
+	return results
+}
+
+// matchIssue returns the result for the GitHub issue or pull request
+// gi of repo if it matches q, or nil.
+func matchIssue(q *query, repo string, gi *maintner.GitHubIssue) *searchResult {
+	if gi.NotExist {
+		return nil
+	}
+	res := &searchResult{
+		Kind:    "issue",
+		Repo:    repo,
+		Number:  gi.Number,
+		Title:   gi.Title,
+		Status:  "open",
+		Created: gi.Created,
+		Updated: gi.Updated,
+		URL:     fmt.Sprintf("/%s/%d", repo, gi.Number),
+	}
+	if gi.PullRequest {
+		res.Kind = "pr"
+	}
+	if gi.Closed {
+		res.Status = "closed"
+	}
+	if gi.User != nil {
+		res.Author = gi.User.Login
+	}
+	if ms := gi.Milestone; ms != nil && !ms.IsNone() {
+		res.Milestone = ms.Title
+	}
+	for _, l := range gi.Labels {
+		res.Labels = append(res.Labels, l.Name)
+	}
+	sort.Strings(res.Labels)
+
+	if !q.matchCommon(res) || len(q.reviewers) > 0 || len(q.hashtags) > 0 {
+		return nil
+	}
+	for _, l := range q.labels {
+		if !containsFold(res.Labels, l) {
+			return nil
+		}
+	}
+	for _, l := range q.notLabels {
+		if containsFold(res.Labels, l) {
+			return nil
+		}
+	}
+	if len(q.milestones) > 0 && !matchAny(q.milestones, res.Milestone) &&
+		!(res.Milestone == "" && matchAny(q.milestones, "none")) {
+		return nil
+	}
+	if len(q.authors) > 0 && !matchAny(q.authors, res.Author) {
+		return nil
+	}
+	if len(q.dirs) > 0 && !matchDirs(q.dirs, titleDirs(gi.Title)) {
+		return nil
+	}
+	return res
+}
+
+// matchCL returns the result for the Gerrit CL cl of project if it
+// matches q, or nil.
+func matchCL(q *query, project string, cl *maintner.GerritCL) *searchResult {
+	if cl.Private || cl.Meta == nil || cl.Commit == nil {
+		return nil
+	}
+	res := &searchResult{
+		Kind:    "cl",
+		Repo:    project,
+		Number:  cl.Number,
+		Title:   cl.Subject(),
+		Status:  cl.Status,
+		Created: cl.Created,
+		Updated: cl.Meta.Commit.CommitTime,
+		URL:     fmt.Sprintf("/%s/%d", project, cl.Number),
+	}
+	if res.Status == "new" || res.Status == "draft" {
+		res.Status = "open"
+	}
+	owner := cl.Owner()
+	if owner != nil {
+		res.Author = owner.Email()
+	}
+	hashtags := cl.Meta.Hashtags()
+	hashtags.Foreach(func(tag string) {
+		res.Hashtags = append(res.Hashtags, tag)
+	})
+
+	if !q.matchCommon(res) || len(q.labels) > 0 || len(q.milestones) > 0 {
+		return nil
+	}
+	for _, tag := range q.hashtags {
+		if !hashtags.Contains(tag) {
+			return nil
+		}
+	}
+	for _, tag := range q.notHashtags {
+		if hashtags.Contains(tag) {
+			return nil
+		}
+	}
+	if len(q.authors) > 0 && (owner == nil || !matchPerson(q.authors, owner)) {
+		return nil
+	}
+	if len(q.reviewers) > 0 && !matchReviewers(q.reviewers, cl) {
+		return nil
+	}
+	if len(q.dirs) > 0 {
+		var files []string
+		for _, f := range cl.Commit.Files {
+			files = append(files, f.File)
+		}
+		if !matchDirs(q.dirs, files) {
+			return nil
+		}
+	}
+	return res
+}
+
+// matchCommon reports whether res matches the terms of q that apply
+// to all kinds of results.
+func (q *query) matchCommon(res *searchResult) bool {
+	if q.kinds != nil && !q.kinds[res.Kind] {
+		return false
+	}
+	switch q.status {
+	case "":
+	case "closed":
+		if res.Status == "open" {
+			return false
+		}
+	default:
+		if res.Status != q.status {
+			return false
+		}
+	}
+	if !q.createdBefore.IsZero() && !res.Created.Before(q.createdBefore) ||
+		!q.createdAfter.IsZero() && !res.Created.After(q.createdAfter) ||
+		!q.updatedBefore.IsZero() && !res.Updated.Before(q.updatedBefore) ||
+		!q.updatedAfter.IsZero() && !res.Updated.After(q.updatedAfter) {
+		return false
+	}
+	title := strings.ToLower(res.Title)
+	for _, w := range q.words {
+		if !strings.Contains(title, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAny reports whether list is empty or has s in it, ignoring case.
+func matchAny(list []string, s string) bool {
+	return len(list) == 0 || containsFold(list, s)
+}
+
+// containsFold reports whether list has s in it, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPerson reports whether one of names is the name, email address
+// or the local part of the email address of p, ignoring case.
+func matchPerson(names []string, p *maintner.GitPerson) bool {
+	email := p.Email()
+	local := email
+	if i := strings.Index(email, "@"); i >= 0 {
+		local = email[:i]
+	}
+	return matchAny(names, p.Name()) || matchAny(names, email) || matchAny(names, local)
+}
+
+// matchReviewers reports whether one of names matches a reviewer of cl,
+// that is someone added as a reviewer or who has a vote on a label.
+func matchReviewers(names []string, cl *maintner.GerritCL) bool {
+	for _, m := range cl.Metas {
+		remain := m.Footer()
+		for {
+			i := strings.Index(remain, "\nReviewer: ")
+			if i < 0 {
+				break
+			}
+			remain = remain[i+len("\nReviewer: "):]
+			line := remain
+			if j := strings.Index(line, "\n"); j >= 0 {
+				line = line[:j]
+			}
+			if matchPerson(names, &maintner.GitPerson{Str: line}) {
+				return true
+			}
+		}
+	}
+	if len(cl.Metas) == 0 {
+		return false
+	}
+	votes, err := cl.Metas[len(cl.Metas)-1].LabelVotes()
+	if err != nil {
+		return false
+	}
+	for _, voters := range votes {
+		for voter, v := range voters {
+			if v != 0 && matchPerson(names, &maintner.GitPerson{Str: "<" + voter + ">"}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchDirs reports whether one of paths is one of dirs or in it.
+func matchDirs(dirs, paths []string) bool {
+	for _, dir := range dirs {
+		for _, p := range paths {
+			if p == dir || strings.HasPrefix(p, dir+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// titleDirs returns the directories named by the prefix of an issue
+// title such as "net/http, net/url: fix parsing".
+func titleDirs(title string) []string {
+	i := strings.Index(title, ": ")
+	if i < 0 {
+		return nil
+	}
+	var dirs []string
+	for _, d := range strings.Split(title[:i], ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			dirs = append(dirs, d)
+		}
+	}
+	return dirs
+}
+
+// A savedQuery is a named search query listed by maintserve.
+type savedQuery struct {
+	Name  string
+	Query string
+}
+
+// readSavedQueries reads the saved queries in file, one per line in
+// the form "name: query". Blank lines and lines starting with # are
+// ignored.
+func readSavedQueries(file string) ([]savedQuery, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var saved []savedQuery
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		i := strings.Index(text, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("%s:%d: want \"name: query\"", file, line)
+		}
+		name, q := strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:])
+		if _, err := parseQuery(q, time.Now()); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", file, line, err)
+		}
+		saved = append(saved, savedQuery{Name: name, Query: q})
+	}
+	return saved, s.Err()
+}
+
+// defaultSearchLimit is the number of results returned by default.
+const defaultSearchLimit = 100
+
+var searchHTML = template.Must(template.New("").Parse(`<html>
+	<head>
+		<title>{{with .Query}}{{.}} - {{end}}maintserve search</title>
+		<meta name="viewport" content="width=device-width">
+		<link href="/assets/fonts/fonts.css" rel="stylesheet" type="text/css">
+		<link href="/assets/style.css" rel="stylesheet" type="text/css">
+	</head>
+	<body>
+		<div style="max-width: 800px; margin: 0 auto 100px auto;">
+			<h2><a href="/">maintserve</a> search</h2>
+			<form action="/search">
+				<input name="q" value="{{.Query}}" size="60" placeholder="is:open label:NeedsFix dir:net/http">
+				<input type="submit" class="btn" value="Search">
+				<a href="/search.json?q={{.Query}}">JSON</a>
+			</form>
+			{{- with .Saved}}
+			<p>Saved searches:{{range .}} <a href="/search?q={{.Query}}">{{.Name}}</a>{{end}}</p>
+			{{- end}}
+			{{- if .Err}}
+			<p style="color: #d9534f;">{{.Err}}</p>
+			{{- else if .Query}}
+			<p>{{.Total}} results{{if lt (len .Results) .Total}}, showing the first {{len .Results}}{{end}}.</p>
+			<table>{{range .Results}}
+				<tr>
+					<td>{{.Kind}}</td>
+					<td><a href="{{.URL}}">{{.Repo}}#{{.Number}}</a></td>
+					<td>{{.Title}}{{range .Labels}} <span class="btn">{{.}}</span>{{end}}{{range .Hashtags}} <span class="btn">#{{.}}</span>{{end}}</td>
+					<td>{{.Author}}</td>
+					<td>{{.Status}}</td>
+					<td>{{.Updated.Format "2006-01-02"}}</td>
+				</tr>
+				{{- end}}
+			</table>
+			{{- end}}
+		</div>
+	</body>
+</html>`))
+
+// serveSearch serves the search page at "/search" and its JSON form at
+// "/search.json". The query is in the q parameter, so that searches
+// can be shared by URL, and the limit parameter sets the number of
+// results.
+func (h *handler) serveSearch(w http.ResponseWriter, req *http.Request) {
+	qs := strings.TrimSpace(req.FormValue("q"))
+	limit := defaultSearchLimit
+	if s := req.FormValue("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			http.Error(w, fmt.Sprintf("400 Bad Request\n\nbad limit %q", s), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	var results []*searchResult
+	q, err := parseQuery(qs, time.Now())
+	if err == nil && qs != "" {
+		results = search(h.c, q)
+	}
+	total := len(results)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	if req.URL.Path == "/search.json" {
+		if err != nil {
+			http.Error(w, fmt.Sprintf("400 Bad Request\n\n%v", err), http.StatusBadRequest)
+			return
+		}
+		if results == nil {
+			results = []*searchResult{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		e := json.NewEncoder(w)
+		e.SetIndent("", "\t")
+		err := e.Encode(map[string]interface{}{
+			"query":   qs,
+			"total":   total,
+			"results": results,
+		})
+		if err != nil {
+			log.Println(err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = searchHTML.Execute(w, map[string]interface{}{
+		"Query":   qs,
+		"Saved":   h.saved,
+		"Err":     err,
+		"Total":   total,
+		"Results": results,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
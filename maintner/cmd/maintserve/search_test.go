@@ -0,0 +1,326 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+var testNow = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+func TestParseQuery(t *testing.T) {
+	q, err := parseQuery(`is:open is:cl repo:golang/go label:"help wanted" -label:WaitingForInfo author:gopher dir:/net/http/ age:>30d sort:created-asc HTTP timeout`, testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &query{
+		kinds:         map[string]bool{"cl": true},
+		status:        "open",
+		repos:         []string{"golang/go"},
+		labels:        []string{"help wanted"},
+		notLabels:     []string{"WaitingForInfo"},
+		authors:       []string{"gopher"},
+		dirs:          []string{"net/http"},
+		createdBefore: testNow.Add(-30 * 24 * time.Hour),
+		words:         []string{"http", "timeout"},
+		sort:          "created",
+		asc:           true,
+	}
+	if !reflect.DeepEqual(q, want) {
+		t.Errorf("parseQuery =\n%+v\nwant\n%+v", q, want)
+	}
+
+	for _, s := range []string{
+		"is:bogus",
+		"status:cl",
+		"label:",
+		"bogus:term",
+		"age:30x",
+		"sort:title",
+	} {
+		if _, err := parseQuery(s, testNow); err == nil {
+			t.Errorf("parseQuery(%q) succeeded; want error", s)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		in            string
+		before, after time.Time
+		wantErr       bool
+	}{
+		{in: ">30d", before: testNow.Add(-30 * day)},
+		{in: "2y", before: testNow.Add(-2 * 365 * day)},
+		{in: "<1w", after: testNow.Add(-7 * day)},
+		{in: "<12h", after: testNow.Add(-12 * time.Hour)},
+		{in: "", wantErr: true},
+		{in: ">d", wantErr: true},
+		{in: "-1d", wantErr: true},
+		{in: "3m", wantErr: true},
+	}
+	for _, tt := range tests {
+		before, after, err := parseAge(tt.in, testNow)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAge(%q) error = %v; want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !before.Equal(tt.before) || !after.Equal(tt.after) {
+			t.Errorf("parseAge(%q) = %v, %v; want %v, %v", tt.in, before, after, tt.before, tt.after)
+		}
+	}
+}
+
+func TestMatchIssue(t *testing.T) {
+	issue := &maintner.GitHubIssue{
+		Number:    1,
+		Title:     "net/http: Server timeout",
+		User:      &maintner.GitHubUser{Login: "gopher"},
+		Labels:    map[int64]*maintner.GitHubLabel{1: {ID: 1, Name: "NeedsFix"}, 2: {ID: 2, Name: "help wanted"}},
+		Milestone: &maintner.GitHubMilestone{Title: "Go1.21"},
+		Created:   testNow.Add(-60 * 24 * time.Hour),
+		Updated:   testNow.Add(-2 * 24 * time.Hour),
+	}
+	pr := &maintner.GitHubIssue{
+		Number:      2,
+		Title:       "cmd/go: fix build",
+		User:        &maintner.GitHubUser{Login: "contributor"},
+		PullRequest: true,
+		Closed:      true,
+		Created:     testNow.Add(-3 * 24 * time.Hour),
+		Updated:     testNow.Add(-3 * 24 * time.Hour),
+	}
+	tests := []struct {
+		query string
+		want  []int32
+	}{
+		{"", []int32{1, 2}},
+		{"is:issue", []int32{1}},
+		{"is:pr", []int32{2}},
+		{"is:cl", nil},
+		{"is:open", []int32{1}},
+		{"is:closed", []int32{2}},
+		{"label:needsfix", []int32{1}},
+		{`label:NeedsFix label:"help wanted"`, []int32{1}},
+		{"label:NeedsFix label:Documentation", nil},
+		{"-label:NeedsFix", []int32{2}},
+		{"milestone:Go1.21", []int32{1}},
+		{"milestone:none", []int32{2}},
+		{"author:gopher author:contributor", []int32{1, 2}},
+		{"author:gopher", []int32{1}},
+		{"dir:net/http", []int32{1}},
+		{"dir:net", []int32{1}},
+		{"dir:cmd/go", []int32{2}},
+		{"dir:cmd/compile", nil},
+		{"age:>30d", []int32{1}},
+		{"updated:<1w", []int32{1, 2}},
+		{"timeout", []int32{1}},
+		{"reviewer:gopher", nil},
+		{"hashtag:wait-release", nil},
+	}
+	for _, tt := range tests {
+		q, err := parseQuery(tt.query, testNow)
+		if err != nil {
+			t.Errorf("parseQuery(%q): %v", tt.query, err)
+			continue
+		}
+		var got []int32
+		for _, gi := range []*maintner.GitHubIssue{issue, pr} {
+			if res := matchIssue(q, "golang/go", gi); res != nil {
+				got = append(got, res.Number)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("query %q matches issues %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	res := matchIssue(&query{}, "golang/go", issue)
+	want := &searchResult{
+		Kind:      "issue",
+		Repo:      "golang/go",
+		Number:    1,
+		Title:     "net/http: Server timeout",
+		Author:    "gopher",
+		Status:    "open",
+		Labels:    []string{"NeedsFix", "help wanted"},
+		Milestone: "Go1.21",
+		Created:   issue.Created,
+		Updated:   issue.Updated,
+		URL:       "/golang/go/1",
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("matchIssue =\n%+v\nwant\n%+v", res, want)
+	}
+}
+
+// testCommit returns a git commit by author at time t with the message
+// msg, changing files.
+func testCommit(parent *maintpb.GitCommit, author string, t time.Time, msg string, files ...string) *maintpb.GitCommit {
+	raw := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"
+	if parent != nil {
+		raw += "parent " + parent.Sha1 + "\n"
+	}
+	raw += fmt.Sprintf("author %s %d +0000\n", author, t.Unix())
+	raw += fmt.Sprintf("committer %s %d +0000\n\n%s", author, t.Unix(), msg)
+	gc := &maintpb.GitCommit{Sha1: fmt.Sprintf("%x", sha1.Sum([]byte(raw))), Raw: []byte(raw)}
+	if len(files) > 0 {
+		gc.DiffTree = new(maintpb.GitDiffTree)
+		for _, f := range files {
+			gc.DiffTree.File = append(gc.DiffTree.File, &maintpb.GitDiffTreeFile{File: f, Added: 1})
+		}
+	}
+	return gc
+}
+
+// mutationSource is a maintner.MutationSource of a fixed list of
+// mutations.
+type mutationSource []*maintpb.Mutation
+
+func (s mutationSource) GetMutations(ctx context.Context) <-chan maintner.MutationStreamEvent {
+	ch := make(chan maintner.MutationStreamEvent, len(s)+1)
+	for _, m := range s {
+		ch <- maintner.MutationStreamEvent{Mutation: m}
+	}
+	ch <- maintner.MutationStreamEvent{End: true}
+	return ch
+}
+
+func TestMatchCL(t *testing.T) {
+	const (
+		owner    = "Gopher <gopher@golang.org>"
+		ownerID  = "Gopher <1000@62eb7196-b449-3ce5-99f1-c037f21e1705>"
+		reviewer = "Reviewer Person <5555@62eb7196-b449-3ce5-99f1-c037f21e1705>"
+		voter    = "Voter <7777@62eb7196-b449-3ce5-99f1-c037f21e1705>"
+	)
+	created := testNow.Add(-40 * 24 * time.Hour)
+	meta1 := testCommit(nil, ownerID, created, "Create change\n\nPatch-set: 1\nStatus: new\nHashtags: wait-release\n")
+	ps1 := testCommit(nil, owner, created, "net/http: fix Server timeout\n\nFixes golang/go#1\n", "src/net/http/server.go")
+	meta2 := testCommit(meta1, reviewer, testNow.Add(-24*time.Hour),
+		"Update patch set 1\n\nPatch-set: 1\nReviewer: "+reviewer+"\nLabel: Code-Review=+2 "+reviewer+"\nLabel: Run-TryBot=+1 "+voter+"\n")
+	meta3 := testCommit(nil, ownerID, created, "Create change\n\nPatch-set: 1\nStatus: abandoned\n")
+	ps3 := testCommit(nil, owner, created, "cmd/go: add flag\n", "src/cmd/go/main.go")
+	ref := func(ref string, gc *maintpb.GitCommit) *maintpb.GitRef {
+		return &maintpb.GitRef{Ref: ref, Sha1: gc.Sha1}
+	}
+	c := new(maintner.Corpus)
+	err := c.Initialize(context.Background(), mutationSource{
+		{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", Commits: []*maintpb.GitCommit{meta1, ps1, meta2, meta3, ps3},
+			Refs: []*maintpb.GitRef{
+				ref("refs/changes/01/1001/meta", meta2), ref("refs/changes/01/1001/1", ps1),
+				ref("refs/changes/02/1002/meta", meta3), ref("refs/changes/02/1002/1", ps3),
+			}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gp := c.Gerrit().Project("go.googlesource.com", "go")
+	cl1, cl2 := gp.CL(1001), gp.CL(1002)
+	if cl1 == nil || cl2 == nil {
+		t.Fatalf("CLs 1001 and 1002 = %v, %v; want both", cl1, cl2)
+	}
+
+	tests := []struct {
+		query string
+		want  []int32
+	}{
+		{"", []int32{1001, 1002}},
+		{"is:cl", []int32{1001, 1002}},
+		{"is:issue", nil},
+		{"is:open", []int32{1001}},
+		{"is:abandoned", []int32{1002}},
+		{"is:closed", []int32{1002}},
+		{"author:gopher", []int32{1001, 1002}},
+		{"author:gopher@golang.org", []int32{1001, 1002}},
+		{"author:someone", nil},
+		{"reviewer:5555", []int32{1001}},
+		{`reviewer:"Reviewer Person"`, []int32{1001}},
+		{"reviewer:7777", []int32{1001}},
+		{"reviewer:gopher", nil},
+		{"hashtag:wait-release", []int32{1001}},
+		{"-hashtag:wait-release", []int32{1002}},
+		{"dir:src/net/http", []int32{1001}},
+		{"dir:src/cmd", []int32{1002}},
+		{"updated:<1w", []int32{1001}},
+		{"age:>30d", []int32{1001, 1002}},
+		{"timeout", []int32{1001}},
+		{"label:NeedsFix", nil},
+		{"milestone:none", nil},
+	}
+	for _, tt := range tests {
+		q, err := parseQuery(tt.query, testNow)
+		if err != nil {
+			t.Errorf("parseQuery(%q): %v", tt.query, err)
+			continue
+		}
+		var got []int32
+		for _, cl := range []*maintner.GerritCL{cl1, cl2} {
+			if res := matchCL(q, "go.googlesource.com/go", cl); res != nil {
+				got = append(got, res.Number)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("query %q matches CLs %v; want %v", tt.query, got, tt.want)
+		}
+	}
+
+	res := matchCL(&query{}, "go.googlesource.com/go", cl1)
+	if res == nil || res.Kind != "cl" || res.Title != "net/http: fix Server timeout" ||
+		res.Author != "gopher@golang.org" || res.Status != "open" ||
+		!reflect.DeepEqual(res.Hashtags, []string{"wait-release"}) || res.URL != "/go.googlesource.com/go/1001" {
+		t.Errorf("matchCL = %+v", res)
+	}
+}
+
+func TestReadSavedQueries(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "queries")
+	err := os.WriteFile(file, []byte(`# Queries for triage.
+
+Needs fix: is:open label:NeedsFix
+Stale CLs:   is:cl is:open updated:>30d
+`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readSavedQueries(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []savedQuery{
+		{Name: "Needs fix", Query: "is:open label:NeedsFix"},
+		{Name: "Stale CLs", Query: "is:cl is:open updated:>30d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readSavedQueries = %+v; want %+v", got, want)
+	}
+
+	for _, text := range []string{
+		"no query here\n",
+		": is:open\n",
+		"Bad: is:bogus\n",
+	} {
+		if err := os.WriteFile(file, []byte(text), 0666); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readSavedQueries(file); err == nil {
+			t.Errorf("readSavedQueries of %q succeeded; want error", text)
+		}
+	}
+	if _, err := readSavedQueries(filepath.Join(dir, "missing")); err == nil {
+		t.Error("readSavedQueries of a missing file succeeded; want error")
+	}
+}
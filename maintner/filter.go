@@ -0,0 +1,220 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// A Filter selects the parts of a corpus to load, so tools which only
+// need a few projects don't spend the time and memory to load them all.
+// The zero Filter loads everything.
+//
+// For each kind of entity, a nil list loads all of them, and an empty,
+// non-nil list loads none.
+type Filter struct {
+	// GitHubRepos are the GitHub repos whose issues, pull requests,
+	// labels and milestones to load.
+	GitHubRepos []GitHubRepoID
+	// GerritProjects are the Gerrit projects whose CLs to load, such
+	// as "go.googlesource.com/go".
+	GerritProjects []string
+	// GitRepos are the go.googlesource.com repos whose git commits to
+	// load, such as "go" or "net".
+	GitRepos []string
+
+	// GitHubIssuesSince, if non-zero, is the time horizon of GitHub
+	// issues and pull requests: those created before it aren't loaded.
+	GitHubIssuesSince time.Time
+}
+
+// A mutationFilter is a Filter prepared for matching mutations.
+// A nil *mutationFilter matches all mutations.
+type mutationFilter struct {
+	githubRepos    map[GitHubRepoID]bool // nil means all
+	gerritProjects map[string]bool       // nil means all
+	gitRepos       map[string]bool       // nil means all
+	issuesSince    time.Time
+
+	// skippedIssues are the GitHub issues which are older than
+	// issuesSince, so their later mutations are skipped as well.
+	skippedIssues map[githubIssueKey]bool
+}
+
+type githubIssueKey struct {
+	repo   GitHubRepoID
+	number int32
+}
+
+// newMutationFilter returns the mutationFilter of f, or nil if f loads
+// everything.
+func newMutationFilter(f Filter) *mutationFilter {
+	if f.GitHubRepos == nil && f.GerritProjects == nil && f.GitRepos == nil && f.GitHubIssuesSince.IsZero() {
+		return nil
+	}
+	mf := &mutationFilter{issuesSince: f.GitHubIssuesSince}
+	if f.GitHubRepos != nil {
+		mf.githubRepos = make(map[GitHubRepoID]bool)
+		for _, id := range f.GitHubRepos {
+			mf.githubRepos[id] = true
+		}
+	}
+	mf.gerritProjects = stringSet(f.GerritProjects)
+	mf.gitRepos = stringSet(f.GitRepos)
+	return mf
+}
+
+// stringSet returns the set of the strings in list, or nil if list is nil.
+func stringSet(list []string) map[string]bool {
+	if list == nil {
+		return nil
+	}
+	set := make(map[string]bool)
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
+
+func (mf *mutationFilter) wantGitHubRepo(owner, repo string) bool {
+	return mf == nil || mf.githubRepos == nil || mf.githubRepos[GitHubRepoID{owner, repo}]
+}
+
+func (mf *mutationFilter) wantGerritProject(project string) bool {
+	return mf == nil || mf.gerritProjects == nil || mf.gerritProjects[project]
+}
+
+// wantGitRepo reports whether to load the commits of the git repo.
+// Commits of an unnamed repo are only loaded if all repos are.
+func (mf *mutationFilter) wantGitRepo(repo *maintpb.GitRepo) bool {
+	return mf == nil || mf.gitRepos == nil || mf.gitRepos[repo.GetGoRepo()]
+}
+
+// wantGitHubIssue reports whether to apply the issue mutation m to the
+// corpus c. It records the issues skipped for being older than the
+// time horizon, as only their first mutation says when they were
+// created.
+//
+// c.mu must be held.
+func (mf *mutationFilter) wantGitHubIssue(c *Corpus, m *maintpb.GithubIssueMutation) bool {
+	if mf == nil {
+		return true
+	}
+	if !mf.wantGitHubRepo(m.Owner, m.Repo) {
+		return false
+	}
+	if mf.issuesSince.IsZero() {
+		return true
+	}
+	key := githubIssueKey{GitHubRepoID{m.Owner, m.Repo}, m.Number}
+	if mf.skippedIssues[key] {
+		return false
+	}
+	if gr := c.GitHub().Repo(m.Owner, m.Repo); gr != nil && gr.Issue(m.Number) != nil {
+		// Already loaded.
+		return true
+	}
+	if created, err := ptypes.Timestamp(m.Created); err == nil && created.Before(mf.issuesSince) {
+		if mf.skippedIssues == nil {
+			mf.skippedIssues = make(map[githubIssueKey]bool)
+		}
+		mf.skippedIssues[key] = true
+		return false
+	}
+	return true
+}
+
+// wantRecord reports whether the encoded maintpb.Mutation rec may have
+// parts wanted by the filter, so it's worth decoding. It only looks at
+// the fields which identify the repos and projects of the mutation;
+// the time horizon is applied to decoded mutations.
+// Records it can't parse are wanted, so decoding them reports the
+// error.
+func (mf *mutationFilter) wantRecord(rec []byte) bool {
+	if mf == nil {
+		return true
+	}
+	for len(rec) > 0 {
+		num, typ, n := protowire.ConsumeTag(rec)
+		if n < 0 {
+			return true
+		}
+		rec = rec[n:]
+		if typ != protowire.BytesType {
+			if n = protowire.ConsumeFieldValue(num, typ, rec); n < 0 {
+				return true
+			}
+			rec = rec[n:]
+			continue
+		}
+		msg, n := protowire.ConsumeBytes(rec)
+		if n < 0 {
+			return true
+		}
+		rec = rec[n:]
+		var want bool
+		switch num {
+		case 1, 3: // github_issue, github
+			owner, repo := stringField(msg, 1), stringField(msg, 2)
+			want = mf.wantGitHubRepo(owner, repo)
+		case 2: // git
+			want = mf.wantGitRepo(&maintpb.GitRepo{GoRepo: stringField(bytesField(msg, 1), 1)})
+		case 4: // gerrit
+			want = mf.wantGerritProject(stringField(msg, 1))
+		default:
+			want = true
+		}
+		if want {
+			return true
+		}
+	}
+	return false
+}
+
+// bytesField returns the last value of the bytes field num of the
+// encoded message msg, or nil if it has none.
+func bytesField(msg []byte, num protowire.Number) []byte {
+	var v []byte
+	for len(msg) > 0 {
+		n2, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return v
+		}
+		msg = msg[n:]
+		if n2 == num && typ == protowire.BytesType {
+			b, n := protowire.ConsumeBytes(msg)
+			if n < 0 {
+				return v
+			}
+			v = b
+			msg = msg[n:]
+			continue
+		}
+		if n = protowire.ConsumeFieldValue(n2, typ, msg); n < 0 {
+			return v
+		}
+		msg = msg[n:]
+	}
+	return v
+}
+
+// stringField returns the last value of the string field num of the
+// encoded message msg, or "" if it has none.
+func stringField(msg []byte, num protowire.Number) string {
+	return string(bytesField(msg, num))
+}
+
+// A filteringMutationSource is a MutationSource which can skip the
+// records of unwanted mutations before decoding them, such as the disk
+// and network sources.
+type filteringMutationSource interface {
+	MutationSource
+	setRecordFilter(want func(rec []byte) bool)
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+
+	"golang.org/x/build/maintner/maintpb"
+)
+
+func TestFilterWantRecord(t *testing.T) {
+	mf := newMutationFilter(Filter{
+		GitHubRepos:    []GitHubRepoID{{"golang", "go"}},
+		GerritProjects: []string{"go.googlesource.com/go"},
+		GitRepos:       []string{},
+	})
+	tests := []struct {
+		name string
+		m    *maintpb.Mutation
+		want bool
+	}{
+		{"wanted issue", &maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Title: "x"}}, true},
+		{"unwanted issue", &maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "tools", Number: 1, Title: "x"}}, false},
+		{"unwanted labels", &maintpb.Mutation{Github: &maintpb.GithubMutation{Owner: "golang", Repo: "tools", Labels: []*maintpb.GithubLabel{{Id: 1}}}}, false},
+		{"wanted CL", &maintpb.Mutation{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go", DeletedRefs: []string{"x"}}}, true},
+		{"unwanted CL", &maintpb.Mutation{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/net"}}, false},
+		{"unwanted commit", &maintpb.Mutation{Git: &maintpb.GitMutation{Repo: &maintpb.GitRepo{GoRepo: "go"}, Commit: &maintpb.GitCommit{Sha1: "abc"}}}, false},
+		{"mixed", &maintpb.Mutation{
+			Git:    &maintpb.GitMutation{Repo: &maintpb.GitRepo{GoRepo: "go"}},
+			Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/go"},
+		}, true},
+	}
+	for _, tt := range tests {
+		rec, err := proto.Marshal(tt.m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := mf.wantRecord(rec); got != tt.want {
+			t.Errorf("%s: wantRecord = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+	if !mf.wantRecord([]byte{0xff}) {
+		t.Errorf("wantRecord of a corrupt record = false; want true")
+	}
+	if rec, _ := proto.Marshal(tests[1].m); !(*mutationFilter)(nil).wantRecord(rec) {
+		t.Errorf("wantRecord of the nil filter = false; want true")
+	}
+}
+
+func TestInitializeWithFilter(t *testing.T) {
+	ts := func(year int) *google_protobuf.Timestamp {
+		return mustProtoFromTime(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	logger := NewDiskMutationLogger(t.TempDir())
+	for _, m := range []*maintpb.Mutation{
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Id: 101, Title: "old", Created: ts(2020)}},
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 2, Id: 102, Title: "new", Created: ts(2023)}},
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1, Title: "old, retitled"}},
+		{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "tools", Number: 3, Id: 103, Title: "tools", Created: ts(2023)}},
+		{Github: &maintpb.GithubMutation{Owner: "golang", Repo: "tools", Labels: []*maintpb.GithubLabel{{Id: 1, Name: "gopls"}}}},
+		{Gerrit: &maintpb.GerritMutation{Project: "go.googlesource.com/net", DeletedRefs: []string{"refs/heads/x"}}},
+	} {
+		if err := logger.Log(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var decoded int
+	c := new(Corpus)
+	err := c.InitializeWithFilter(context.Background(), countingSource{logger, &decoded}, Filter{
+		GitHubRepos:       []GitHubRepoID{{"golang", "go"}},
+		GerritProjects:    []string{},
+		GitHubIssuesSince: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != 3 {
+		t.Errorf("decoded %d mutations; want the 3 of golang/go", decoded)
+	}
+	goRepo := c.GitHub().Repo("golang", "go")
+	if goRepo == nil {
+		t.Fatal("golang/go wasn't loaded")
+	}
+	if gi := goRepo.Issue(1); gi != nil {
+		t.Errorf("issue 1, created before the horizon, was loaded: %+v", gi)
+	}
+	if gi := goRepo.Issue(2); gi == nil || gi.Title != "new" {
+		t.Errorf("issue 2 = %+v; want it loaded", gi)
+	}
+	if c.GitHub().Repo("golang", "tools") != nil {
+		t.Errorf("golang/tools was loaded")
+	}
+	if c.Gerrit().Project("go.googlesource.com", "net") != nil {
+		t.Errorf("go.googlesource.com/net was loaded")
+	}
+}
+
+// countingSource is a filtering mutation source which counts the
+// mutations it decodes.
+type countingSource struct {
+	*DiskMutationLogger
+	decoded *int
+}
+
+func (s countingSource) GetMutations(ctx context.Context) <-chan MutationStreamEvent {
+	in := s.DiskMutationLogger.GetMutations(ctx)
+	out := make(chan MutationStreamEvent)
+	go func() {
+		for e := range in {
+			if e.Mutation != nil {
+				*s.decoded++
+			}
+			out <- e
+			if e.Err != nil || e.End {
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -1480,6 +1480,38 @@ func (m *GerritMeta) HashtagsRemoved() GerritHashtags {
 	return removed
 }
 
+// A GerritVote is a vote on a label of a Gerrit CL.
+type GerritVote struct {
+	Label string // "Code-Review", "Run-TryBot", etc.
+	Value int8   // 0 for a removed vote
+	Voter string // email address of the Gerrit account
+}
+
+// LabelChanges returns the votes set or removed by the meta commit m
+// itself, in the order of its footer. Unlike LabelVotes, it doesn't
+// consider the meta commits before m.
+func (m *GerritMeta) LabelChanges() []GerritVote {
+	var votes []GerritVote
+	email := m.Commit.Author.Email()
+	remain := m.Footer()
+	for len(remain) > 0 {
+		var labelEqVal string
+		labelEqVal, remain = lineValueRest(remain, "Label: ")
+		if labelEqVal == "" {
+			continue
+		}
+		label, value, whose := parseGerritLabelValue(labelEqVal)
+		if label == "" {
+			continue
+		}
+		if whose == "" {
+			whose = email
+		}
+		votes = append(votes, GerritVote{Label: strings.TrimPrefix(label, "-"), Value: value, Voter: whose})
+	}
+	return votes
+}
+
 // LabelVotes returns a map from label name to voter email to their vote.
 //
 // This is relatively expensive to call compared to other methods in maintner.
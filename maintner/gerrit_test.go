@@ -6,6 +6,7 @@ package maintner
 
 import (
 	"bytes"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -353,6 +354,30 @@ func TestParseGerritLabelValue(t *testing.T) {
 	}
 }
 
+func TestGerritMetaLabelChanges(t *testing.T) {
+	m := &GerritMeta{Commit: &GitCommit{
+		Author: &GitPerson{Str: "Gopher <1@62eb7196-b449-3ce5-99f1-c037f21e1705>"},
+		Msg: `Update patch set 2
+
+Patch Set 2: Code-Review+2
+
+Patch-set: 2
+Label: Code-Review=+2
+Label: -Run-TryBot
+Label: TryBot-Result=-1 Gobot Gobot <5976@62eb7196-b449-3ce5-99f1-c037f21e1705>
+`,
+	}}
+	got := m.LabelChanges()
+	want := []GerritVote{
+		{"Code-Review", 2, "1@62eb7196-b449-3ce5-99f1-c037f21e1705"},
+		{"Run-TryBot", 0, "1@62eb7196-b449-3ce5-99f1-c037f21e1705"},
+		{"TryBot-Result", -1, "5976@62eb7196-b449-3ce5-99f1-c037f21e1705"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LabelChanges = %+v; want %+v", got, want)
+	}
+}
+
 var hashtagTests = []struct {
 	commit     string
 	wantAdd    string
@@ -284,6 +284,7 @@ type GitHubIssue struct {
 	reviewsSyncedAsOf  time.Time                   // as of server's Date header
 	events             map[int64]*GitHubIssueEvent // by event.ID
 	reviews            map[int64]*GitHubReview     // by event.ID
+	pr                 *GitHubPullRequest          // nil until synced
 }
 
 // LastModified reports the most recent time that any known metadata was updated.
@@ -1444,6 +1445,10 @@ func (c *Corpus) processGithubIssueMutation(m *maintpb.GithubIssueMutation) {
 			gi.reviewsSyncedAsOf = serverDate.UTC()
 		}
 	}
+
+	if m.PullRequestChange != nil {
+		c.github.processPullRequestMutation(gi, m.PullRequestChange)
+	}
 }
 
 // githubCache is an httpcache.Cache wrapper that only
@@ -1572,6 +1577,8 @@ type githubRepoPoller struct {
 	githubCaching *github.Client
 	githubDirect  *github.Client // not caching
 	client        httpClient     // the client used to poll github
+
+	unknownPRs map[int32]*unknownPullRequest // by issue number; modified by syncPullRequests
 }
 
 func (p *githubRepoPoller) Owner() string { return p.gr.id.Owner }
@@ -1595,6 +1602,9 @@ func (p *githubRepoPoller) sync(ctx context.Context, expectChanges bool) error {
 	if err := p.syncReviews(ctx); err != nil {
 		return err
 	}
+	if err := p.syncPullRequests(ctx); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,465 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-github/github"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// GitHubPullRequest is the pull request specific state of a GitHubIssue.
+// See https://docs.github.com/en/rest/pulls/pulls#get-a-pull-request.
+type GitHubPullRequest struct {
+	HeadRepo GitHubRepoID // repo of the head branch, usually a fork
+	HeadRef  string       // head branch, such as "patch-1"
+	HeadSHA  string
+	BaseRef  string // base branch, such as "master"
+	BaseSHA  string
+
+	// MergeableState is GitHub's mergeable_state, such as "clean",
+	// "dirty" (conflicting) or "unknown" while GitHub computes it.
+	// Mergeable is whether GitHub could merge the pull request. It's
+	// only meaningful if MergeableState is neither "unknown" nor empty.
+	Mergeable      bool
+	MergeableState string
+
+	Merged   bool
+	MergedAt time.Time
+
+	commits     []*GitHubPullRequestCommit // oldest first
+	files       []*GitHubPullRequestFile   // by filename
+	commitsHead string                     // HeadSHA when commits were synced
+	filesHead   string                     // HeadSHA when files were synced
+	syncedAsOf  time.Time                  // as of server's Date header
+}
+
+// GitHubPullRequestCommit is a commit of a pull request.
+type GitHubPullRequestCommit struct {
+	SHA            string
+	Author         *GitHubUser // GitHub account of the author, or nil if unknown
+	AuthorName     string
+	AuthorEmail    string
+	AuthorTime     time.Time
+	CommitterName  string
+	CommitterEmail string
+	CommitTime     time.Time
+	Message        string
+	ParentSHAs     []string
+}
+
+// GitHubPullRequestFile is a file changed by a pull request, with its
+// diff stats.
+type GitHubPullRequestFile struct {
+	Filename  string
+	Status    string // "added", "removed", "modified", "renamed", etc.
+	Additions int    // lines added
+	Deletions int    // lines deleted
+}
+
+// PullRequestInfo returns the pull request state of gi, or nil if gi
+// isn't a pull request or its state hasn't been synced yet.
+func (gi *GitHubIssue) PullRequestInfo() *GitHubPullRequest {
+	if !gi.PullRequest {
+		return nil
+	}
+	return gi.pr
+}
+
+// ForeachCommit calls fn for each commit of the pull request, oldest
+// first, as of the last sync of its commits.
+//
+// If fn returns an error, iteration ends and ForeachCommit returns
+// with that error.
+func (pr *GitHubPullRequest) ForeachCommit(fn func(*GitHubPullRequestCommit) error) error {
+	for _, c := range pr.commits {
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForeachFile calls fn for each file changed by the pull request, in
+// order of filename, as of the last sync of its files.
+//
+// If fn returns an error, iteration ends and ForeachFile returns
+// with that error.
+func (pr *GitHubPullRequest) ForeachFile(fn func(*GitHubPullRequestFile) error) error {
+	for _, f := range pr.files {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilesSynced reports whether the commits and files of the pull
+// request are those of its current head.
+func (pr *GitHubPullRequest) FilesSynced() bool {
+	return pr.HeadSHA != "" && pr.commitsHead == pr.HeadSHA && pr.filesHead == pr.HeadSHA
+}
+
+// pullRequestSynced reports whether the pull request state of gi is up
+// to date. GitHub computes the mergeability of open pull requests in
+// the background, so they're out of date while it's unknown.
+func (gi *GitHubIssue) pullRequestSynced() bool {
+	pr := gi.pr
+	if pr == nil || !pr.syncedAsOf.After(gi.Updated) {
+		return false
+	}
+	if pr.HeadSHA == "" {
+		// GitHub didn't find the pull request.
+		return true
+	}
+	if !gi.Closed && pr.MergeableState == "unknown" {
+		return false
+	}
+	return pr.FilesSynced()
+}
+
+// processPullRequestMutation updates the pull request state of gi.
+// g.c.mu is held for writing.
+func (g *GitHub) processPullRequestMutation(gi *GitHubIssue, m *maintpb.GithubPullRequestMutation) {
+	pr := gi.pr
+	if pr == nil {
+		pr = new(GitHubPullRequest)
+		gi.pr = pr
+	}
+	if m.HeadRepo != "" {
+		if i := strings.Index(m.HeadRepo, "/"); i > 0 {
+			pr.HeadRepo = GitHubRepoID{Owner: m.HeadRepo[:i], Repo: m.HeadRepo[i+1:]}
+		}
+	}
+	if m.HeadRef != "" {
+		pr.HeadRef = m.HeadRef
+	}
+	if m.HeadSha != "" {
+		pr.HeadSHA = m.HeadSha
+	}
+	if m.BaseRef != "" {
+		pr.BaseRef = m.BaseRef
+	}
+	if m.BaseSha != "" {
+		pr.BaseSHA = m.BaseSha
+	}
+	if m.Mergeable != nil {
+		pr.Mergeable = m.Mergeable.Val
+	}
+	if m.MergeableState != "" {
+		pr.MergeableState = m.MergeableState
+	}
+	if m.Merged != nil {
+		pr.Merged = m.Merged.Val
+	}
+	if m.MergedAt != nil {
+		if t, err := ptypes.Timestamp(m.MergedAt); err == nil {
+			pr.MergedAt = t.UTC()
+		}
+	}
+	if m.CommitStatus != nil {
+		pr.commits = make([]*GitHubPullRequestCommit, 0, len(m.Commit))
+		for _, pc := range m.Commit {
+			pr.commits = append(pr.commits, g.newPullRequestCommit(pc))
+		}
+		pr.commitsHead = pr.HeadSHA
+	}
+	if m.FileStatus != nil {
+		pr.files = make([]*GitHubPullRequestFile, 0, len(m.File))
+		for _, pf := range m.File {
+			pr.files = append(pr.files, &GitHubPullRequestFile{
+				Filename:  pf.Filename,
+				Status:    pf.Status,
+				Additions: int(pf.Additions),
+				Deletions: int(pf.Deletions),
+			})
+		}
+		sort.Slice(pr.files, func(i, j int) bool { return pr.files[i].Filename < pr.files[j].Filename })
+		pr.filesHead = pr.HeadSHA
+	}
+	if m.SyncStatus != nil && m.SyncStatus.ServerDate != nil {
+		if serverDate, err := ptypes.Timestamp(m.SyncStatus.ServerDate); err == nil {
+			pr.syncedAsOf = serverDate.UTC()
+		}
+	}
+}
+
+func (g *GitHub) newPullRequestCommit(pc *maintpb.GithubPullRequestCommit) *GitHubPullRequestCommit {
+	c := &GitHubPullRequestCommit{
+		SHA:            pc.Sha,
+		Author:         g.getUser(pc.Author),
+		AuthorName:     pc.AuthorName,
+		AuthorEmail:    pc.AuthorEmail,
+		CommitterName:  pc.CommitterName,
+		CommitterEmail: pc.CommitterEmail,
+		Message:        pc.Message,
+		ParentSHAs:     pc.ParentSha,
+	}
+	if pc.AuthorTime != nil {
+		c.AuthorTime, _ = ptypes.Timestamp(pc.AuthorTime)
+		c.AuthorTime = c.AuthorTime.UTC()
+	}
+	if pc.CommitTime != nil {
+		c.CommitTime, _ = ptypes.Timestamp(pc.CommitTime)
+		c.CommitTime = c.CommitTime.UTC()
+	}
+	return c
+}
+
+// newPullRequestMutation returns the mutation updating the pull request
+// state old, which may be nil, to pr. It doesn't include commits, files
+// or the sync status.
+func newPullRequestMutation(old *GitHubPullRequest, pr *github.PullRequest) *maintpb.GithubPullRequestMutation {
+	if old == nil {
+		old = new(GitHubPullRequest)
+	}
+	m := new(maintpb.GithubPullRequestMutation)
+	if v := pr.GetHead().GetRepo().GetFullName(); v != "" && v != old.HeadRepo.String() {
+		m.HeadRepo = v
+	}
+	if v := pr.GetHead().GetRef(); v != old.HeadRef {
+		m.HeadRef = v
+	}
+	if v := pr.GetHead().GetSHA(); v != old.HeadSHA {
+		m.HeadSha = v
+	}
+	if v := pr.GetBase().GetRef(); v != old.BaseRef {
+		m.BaseRef = v
+	}
+	if v := pr.GetBase().GetSHA(); v != old.BaseSHA {
+		m.BaseSha = v
+	}
+	if pr.Mergeable != nil && *pr.Mergeable != old.Mergeable {
+		m.Mergeable = &maintpb.BoolChange{Val: *pr.Mergeable}
+	}
+	if v := pr.GetMergeableState(); v != old.MergeableState {
+		m.MergeableState = v
+	}
+	if pr.Merged != nil && *pr.Merged != old.Merged {
+		m.Merged = &maintpb.BoolChange{Val: *pr.Merged}
+	}
+	if t := pr.GetMergedAt(); !t.IsZero() && !t.Equal(old.MergedAt) {
+		m.MergedAt, _ = ptypes.TimestampProto(t)
+	}
+	return m
+}
+
+// Pull requests whose mergeability GitHub still hasn't computed when
+// they're fetched are fetched again after a delay, which doubles from
+// minUnknownPullRequestDelay up to maxUnknownPullRequestDelay while it
+// stays unknown.
+const (
+	minUnknownPullRequestDelay = time.Minute
+	maxUnknownPullRequestDelay = 6 * time.Hour
+)
+
+// unknownPullRequest is the retry state of a pull request whose
+// mergeability was unknown when last fetched.
+type unknownPullRequest struct {
+	next  time.Time     // when to fetch it again
+	delay time.Duration // between the last two fetches
+}
+
+// backOffUnknownPullRequest delays fetching the pull request of issue
+// num again, after its mergeability was unknown.
+func (p *githubRepoPoller) backOffUnknownPullRequest(num int32) {
+	u := p.unknownPRs[num]
+	if u == nil {
+		if p.unknownPRs == nil {
+			p.unknownPRs = make(map[int32]*unknownPullRequest)
+		}
+		u = &unknownPullRequest{delay: minUnknownPullRequestDelay / 2}
+		p.unknownPRs[num] = u
+	}
+	u.delay *= 2
+	if u.delay > maxUnknownPullRequestDelay {
+		u.delay = maxUnknownPullRequestDelay
+	}
+	u.next = time.Now().Add(u.delay)
+}
+
+func (p *githubRepoPoller) issueNumbersWithStalePullRequestSync() (issueNums []int32) {
+	p.c.mu.RLock()
+	defer p.c.mu.RUnlock()
+
+	now := time.Now()
+	for n, gi := range p.gr.issues {
+		if !gi.PullRequest || gi.NotExist || gi.pullRequestSynced() {
+			continue
+		}
+		// Unless the issue changed since, wait to fetch a pull
+		// request again whose mergeability was unknown.
+		if u := p.unknownPRs[n]; u != nil && now.Before(u.next) && gi.pr.syncedAsOf.After(gi.Updated) {
+			continue
+		}
+		issueNums = append(issueNums, n)
+	}
+	sort.Slice(issueNums, func(i, j int) bool {
+		return issueNums[i] < issueNums[j]
+	})
+	return issueNums
+}
+
+// syncPullRequests syncs the state, commits and files of the pull
+// requests changed since their last sync. Unlike the other syncs, it
+// makes one pass: pull requests whose mergeability GitHub is still
+// computing are retried by a later sync, backing off while it stays
+// unknown, and those which fail to sync are retried by the next one.
+func (p *githubRepoPoller) syncPullRequests(ctx context.Context) error {
+	nums := p.issueNumbersWithStalePullRequestSync()
+	remain := len(nums)
+	for _, num := range nums {
+		p.logf("pull request sync: %d pull requests remaining; syncing %v", remain, num)
+		if err := p.syncPullRequest(ctx, num); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			p.logf("pull request sync on issue %d: %v", num, err)
+		}
+		remain--
+	}
+	return nil
+}
+
+func (p *githubRepoPoller) syncPullRequest(ctx context.Context, issueNum int32) error {
+	p.c.mu.RLock()
+	gi := p.gr.issues[issueNum]
+	if gi == nil {
+		p.c.mu.RUnlock()
+		panic(fmt.Sprintf("bogus issue %v", issueNum))
+	}
+	var old GitHubPullRequest
+	if gi.pr != nil {
+		old = *gi.pr
+	}
+	updated, closed := gi.Updated, gi.Closed
+	p.c.mu.RUnlock()
+
+	var pr *github.PullRequest
+	var res *github.Response
+	for {
+		var err error
+		pr, res, err = p.githubDirect.PullRequests.Get(ctx, p.Owner(), p.Repo(), int(issueNum))
+		if err == nil {
+			break
+		}
+		if canRetry(ctx, err) {
+			continue
+		}
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			// Don't retry it until the issue is updated.
+			pr = new(github.PullRequest)
+			break
+		}
+		return err
+	}
+	serverDate, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return fmt.Errorf("invalid server Date response: %v", err)
+	}
+	sdp, _ := ptypes.TimestampProto(serverDate.UTC())
+
+	pm := newPullRequestMutation(&old, pr)
+	if head := pr.GetHead().GetSHA(); head != "" {
+		if head != old.commitsHead {
+			err := p.foreachItem(ctx, 1, func(ctx context.Context, page int) ([]interface{}, *github.Response, error) {
+				commits, res, err := p.githubDirect.PullRequests.ListCommits(ctx, p.Owner(), p.Repo(), int(issueNum),
+					&github.ListOptions{Page: page, PerPage: 100})
+				is := make([]interface{}, len(commits))
+				for i, c := range commits {
+					is[i] = c
+				}
+				return is, res, err
+			}, func(v interface{}) error {
+				pm.Commit = append(pm.Commit, pullRequestCommitProto(v.(*github.RepositoryCommit)))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			pm.CommitStatus = &maintpb.GithubIssueSyncStatus{ServerDate: sdp}
+		}
+		if head != old.filesHead {
+			err := p.foreachItem(ctx, 1, func(ctx context.Context, page int) ([]interface{}, *github.Response, error) {
+				files, res, err := p.githubDirect.PullRequests.ListFiles(ctx, p.Owner(), p.Repo(), int(issueNum),
+					&github.ListOptions{Page: page, PerPage: 100})
+				is := make([]interface{}, len(files))
+				for i, f := range files {
+					is[i] = f
+				}
+				return is, res, err
+			}, func(v interface{}) error {
+				f := v.(*github.CommitFile)
+				pm.File = append(pm.File, &maintpb.GithubPullRequestFile{
+					Filename:  f.GetFilename(),
+					Status:    f.GetStatus(),
+					Additions: int32(f.GetAdditions()),
+					Deletions: int32(f.GetDeletions()),
+				})
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			pm.FileStatus = &maintpb.GithubIssueSyncStatus{ServerDate: sdp}
+		}
+	}
+
+	if !closed && pr.GetMergeableState() == "unknown" {
+		p.backOffUnknownPullRequest(issueNum)
+	} else {
+		delete(p.unknownPRs, issueNum)
+	}
+	if proto.Equal(pm, new(maintpb.GithubPullRequestMutation)) && old.syncedAsOf.After(updated) {
+		// Nothing changed, and the last sync is still recent
+		// enough, as when GitHub is computing the mergeability.
+		return nil
+	}
+	pm.SyncStatus = &maintpb.GithubIssueSyncStatus{ServerDate: sdp}
+	p.c.addMutation(&maintpb.Mutation{
+		GithubIssue: &maintpb.GithubIssueMutation{
+			Owner:             p.Owner(),
+			Repo:              p.Repo(),
+			Number:            issueNum,
+			PullRequestChange: pm,
+		},
+	})
+	return nil
+}
+
+// pullRequestCommitProto converts a pull request commit from the GitHub
+// API to its protobuf form.
+func pullRequestCommitProto(rc *github.RepositoryCommit) *maintpb.GithubPullRequestCommit {
+	c := rc.GetCommit()
+	pc := &maintpb.GithubPullRequestCommit{
+		Sha:            rc.GetSHA(),
+		AuthorName:     c.GetAuthor().GetName(),
+		AuthorEmail:    c.GetAuthor().GetEmail(),
+		CommitterName:  c.GetCommitter().GetName(),
+		CommitterEmail: c.GetCommitter().GetEmail(),
+		Message:        c.GetMessage(),
+	}
+	if u := rc.GetAuthor(); u.GetID() != 0 {
+		pc.Author = &maintpb.GithubUser{Id: u.GetID(), Login: u.GetLogin()}
+	}
+	if t := c.GetAuthor().GetDate(); !t.IsZero() {
+		pc.AuthorTime, _ = ptypes.TimestampProto(t)
+	}
+	if t := c.GetCommitter().GetDate(); !t.IsZero() {
+		pc.CommitTime, _ = ptypes.TimestampProto(t)
+	}
+	for _, parent := range rc.Parents {
+		pc.ParentSha = append(pc.ParentSha, parent.GetSHA())
+	}
+	return pc
+}
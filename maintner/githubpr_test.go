@@ -0,0 +1,185 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// testPullRequestServer is a fake GitHub API server for pull request 5
+// of golang/go, failing to serve pull request 4.
+type testPullRequestServer struct {
+	date      time.Time // of responses
+	head      string
+	mergeable string // "true", "false" or "null"
+	requests  map[string]int
+}
+
+func (s *testPullRequestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests[r.URL.Path]++
+	w.Header().Set("Date", s.date.Format(http.TimeFormat))
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/repos/golang/go/pulls/4":
+		http.Error(w, "server error", http.StatusInternalServerError)
+	case "/repos/golang/go/pulls/5":
+		state := "clean"
+		if s.mergeable == "null" {
+			state = "unknown"
+		}
+		fmt.Fprintf(w, `{"number": 5, "mergeable": %s, "mergeable_state": %q, "merged": false,
+			"head": {"ref": "patch-1", "sha": %q, "repo": {"full_name": "gopher/go"}},
+			"base": {"ref": "master", "sha": "base1"}}`, s.mergeable, state, s.head)
+	case "/repos/golang/go/pulls/5/commits":
+		fmt.Fprintf(w, `[{"sha": "c1", "parents": [{"sha": "base1"}], "author": {"id": 1, "login": "gopher"},
+			"commit": {"message": "net/http: fix\n", "author": {"name": "Gopher", "email": "gopher@golang.org", "date": "2023-05-01T00:00:00Z"},
+				"committer": {"name": "Gopher", "email": "gopher@golang.org", "date": "2023-05-02T00:00:00Z"}}},
+			{"sha": %q, "parents": [{"sha": "c1"}], "commit": {"message": "net/http: more\n", "author": {"name": "Anonymous"}}}]`, s.head)
+	case "/repos/golang/go/pulls/5/files":
+		fmt.Fprint(w, `[{"filename": "src/net/http/server.go", "status": "modified", "additions": 10, "deletions": 2},
+			{"filename": "src/net/http/client.go", "status": "added", "additions": 30}]`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSyncPullRequest(t *testing.T) {
+	var c Corpus
+	c.initGithub()
+	gr := c.github.getOrCreateRepo("golang", "go")
+	gi := &GitHubIssue{
+		Number:      5,
+		PullRequest: true,
+		Updated:     time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	broken := &GitHubIssue{
+		Number:      4,
+		PullRequest: true,
+		Updated:     time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	gr.issues = map[int32]*GitHubIssue{4: broken, 5: gi}
+
+	s := &testPullRequestServer{
+		date:      time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		head:      "c2",
+		mergeable: "null",
+		requests:  make(map[string]int),
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	ghc := github.NewClient(server.Client())
+	ghc.BaseURL, _ = url.Parse(server.URL + "/")
+	p := &githubRepoPoller{c: &c, gr: gr, githubDirect: ghc}
+	ctx := context.Background()
+
+	if got := p.issueNumbersWithStalePullRequestSync(); !reflect.DeepEqual(got, []int32{4, 5}) {
+		t.Fatalf("stale pull requests = %v; want [4 5]", got)
+	}
+	// Failing to get pull request 4 doesn't stop the sync of 5.
+	if err := p.syncPullRequests(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if broken.PullRequestInfo() != nil {
+		t.Errorf("PullRequestInfo of the failed pull request = %+v; want nil", broken.PullRequestInfo())
+	}
+	delete(gr.issues, 4)
+	pr := gi.PullRequestInfo()
+	if pr == nil {
+		t.Fatal("PullRequestInfo = nil after sync")
+	}
+	if pr.HeadRepo != (GitHubRepoID{"gopher", "go"}) || pr.HeadRef != "patch-1" || pr.HeadSHA != "c2" ||
+		pr.BaseRef != "master" || pr.BaseSHA != "base1" || pr.MergeableState != "unknown" {
+		t.Errorf("pull request = %+v", pr)
+	}
+	var commits []string
+	pr.ForeachCommit(func(c *GitHubPullRequestCommit) error {
+		author := c.AuthorName
+		if c.Author != nil {
+			author = c.Author.Login
+		}
+		commits = append(commits, fmt.Sprintf("%s %v %s %s", c.SHA, c.ParentSHAs, author, c.AuthorTime.Format("2006-01-02")))
+		return nil
+	})
+	wantCommits := []string{"c1 [base1] gopher 2023-05-01", "c2 [c1] Anonymous 0001-01-01"}
+	if !reflect.DeepEqual(commits, wantCommits) {
+		t.Errorf("commits = %q; want %q", commits, wantCommits)
+	}
+	var files []string
+	pr.ForeachFile(func(f *GitHubPullRequestFile) error {
+		files = append(files, fmt.Sprintf("%s %s +%d -%d", f.Filename, f.Status, f.Additions, f.Deletions))
+		return nil
+	})
+	wantFiles := []string{"src/net/http/client.go added +30 -0", "src/net/http/server.go modified +10 -2"}
+	if !reflect.DeepEqual(files, wantFiles) {
+		t.Errorf("files = %q; want %q", files, wantFiles)
+	}
+
+	// The mergeability is still unknown, so the pull request is
+	// fetched again after a delay, without a mutation if it's still
+	// unknown.
+	if got := p.issueNumbersWithStalePullRequestSync(); len(got) != 0 {
+		t.Errorf("stale pull requests right after an unknown mergeability = %v; want none", got)
+	}
+	mutations := c.mutations
+	p.unknownPRs[5].next = time.Time{}
+	if err := p.syncPullRequests(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if c.mutations != mutations {
+		t.Errorf("fetching an unchanged pull request added %d mutations; want none", c.mutations-mutations)
+	}
+	if u := p.unknownPRs[5]; u == nil || u.delay != 2*minUnknownPullRequestDelay {
+		t.Errorf("retry of a pull request unknown twice = %+v; want delay %v", u, 2*minUnknownPullRequestDelay)
+	}
+
+	// The next sync after the delay gets the mergeability, but not the
+	// unchanged commits and files.
+	s.mergeable = "true"
+	p.unknownPRs[5].next = time.Time{}
+	if err := p.syncPullRequests(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if p.unknownPRs[5] != nil {
+		t.Errorf("retry state after a known mergeability = %+v; want none", p.unknownPRs[5])
+	}
+	if !pr.Mergeable || pr.MergeableState != "clean" {
+		t.Errorf("after second sync, Mergeable, MergeableState = %v, %q; want true, clean", pr.Mergeable, pr.MergeableState)
+	}
+	wantRequests := map[string]int{
+		"/repos/golang/go/pulls/4":         1,
+		"/repos/golang/go/pulls/5":         3,
+		"/repos/golang/go/pulls/5/commits": 1,
+		"/repos/golang/go/pulls/5/files":   1,
+	}
+	if !reflect.DeepEqual(s.requests, wantRequests) {
+		t.Errorf("requests = %v; want %v", s.requests, wantRequests)
+	}
+	if got := p.issueNumbersWithStalePullRequestSync(); len(got) != 0 {
+		t.Errorf("stale pull requests after sync = %v; want none", got)
+	}
+
+	// A new head gets new commits and files.
+	s.head = "c3"
+	gi.Updated = time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+	s.date = time.Date(2023, 6, 3, 0, 0, 0, 0, time.UTC)
+	if err := p.syncPullRequests(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if pr.HeadSHA != "c3" || !pr.FilesSynced() || pr.commits[1].SHA != "c3" {
+		t.Errorf("after head change, HeadSHA = %q, FilesSynced = %v, commits[1] = %q", pr.HeadSHA, pr.FilesSynced(), pr.commits[1].SHA)
+	}
+	if n := s.requests["/repos/golang/go/pulls/5/files"]; n != 2 {
+		t.Errorf("files requested %d times; want 2", n)
+	}
+}
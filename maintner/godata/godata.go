@@ -46,13 +46,45 @@ const Server = "https://maintner.golang.org/logs"
 // See https://pkg.go.dev/golang.org/x/build/maintner#Corpus for how
 // to walk the data structure.
 func Get(ctx context.Context) (*maintner.Corpus, error) {
+	return GetFiltered(ctx, maintner.Filter{})
+}
+
+// GetFiltered is like Get, but only loads the parts of the corpus
+// selected by f. The whole mutation log is still downloaded and cached,
+// but tools which only need a few projects start faster and use a
+// fraction of the memory.
+//
+// For example, to load only the CLs of the go repo, use:
+//
+//	godata.GetFiltered(ctx, maintner.Filter{
+//		GitHubRepos:    []maintner.GitHubRepoID{},
+//		GerritProjects: []string{"go.googlesource.com/go"},
+//		GitRepos:       []string{},
+//	})
+func GetFiltered(ctx context.Context, f maintner.Filter) (*maintner.Corpus, error) {
+	targetDir := Dir()
+	if err := os.MkdirAll(targetDir, 0700); err != nil {
+		return nil, err
+	}
+	mutSrc := maintner.NewNetworkMutationSource(Server, targetDir)
+	corpus := new(maintner.Corpus)
+	if err := corpus.InitializeWithFilter(ctx, mutSrc, f); err != nil {
+		return nil, err
+	}
+	return corpus, nil
+}
+
+// GetAsOf is like GetFiltered, but returns a read-only view of the
+// corpus as it was at the point at, such as a time in the past.
+// It can't be updated.
+func GetAsOf(ctx context.Context, f maintner.Filter, at maintner.AsOf) (*maintner.Corpus, error) {
 	targetDir := Dir()
 	if err := os.MkdirAll(targetDir, 0700); err != nil {
 		return nil, err
 	}
 	mutSrc := maintner.NewNetworkMutationSource(Server, targetDir)
 	corpus := new(maintner.Corpus)
-	if err := corpus.Initialize(ctx, mutSrc); err != nil {
+	if err := corpus.InitializeAsOf(ctx, mutSrc, f, at); err != nil {
 		return nil, err
 	}
 	return corpus, nil
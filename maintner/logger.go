@@ -6,9 +6,13 @@ package maintner
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -24,11 +28,17 @@ type MutationLogger interface {
 }
 
 // DiskMutationLogger logs mutations to disk.
+//
+// It is also a LogStore whose segments are its log files, oldest
+// first.
 type DiskMutationLogger struct {
 	directory string
 
-	mu   sync.Mutex
-	done bool // true after first GetMutations
+	mu     sync.Mutex
+	done   bool                  // true after first GetMutations
+	want   func(rec []byte) bool // if non-nil, reports whether to decode a record
+	listed bool                  // whether last has been initialized from the directory
+	last   string                // full path of the lexically last log file
 }
 
 // NewDiskMutationLogger creates a new DiskMutationLogger, which will create
@@ -56,7 +66,150 @@ func (d *DiskMutationLogger) Log(m *maintpb.Mutation) error {
 	}
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	return reclog.AppendRecordToFile(d.filename(), data)
+	return reclog.AppendRecordToFile(d.logFilenameLocked(), data)
+}
+
+// logFilenameLocked returns the file that Log appends to: the file
+// for the current day, unless a later file exists, such as one
+// written by WriteSegment, in which case that file is used so that
+// the log stays in lexical order.
+func (d *DiskMutationLogger) logFilenameLocked() string {
+	if !d.listed {
+		if fis, err := d.filesLocked(); err == nil && len(fis) > 0 {
+			d.last = filepath.Join(d.directory, fis[len(fis)-1].Name())
+		}
+		d.listed = true
+	}
+	name := d.filename()
+	if d.last > name {
+		name = d.last
+	}
+	d.last = name
+	return name
+}
+
+// filesLocked returns d's log files in lexical order.
+// A missing directory has no files.
+func (d *DiskMutationLogger) filesLocked() ([]os.FileInfo, error) {
+	fis, err := ioutil.ReadDir(d.directory)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []os.FileInfo
+	for _, fi := range fis {
+		if fi.Mode().IsRegular() && strings.HasPrefix(fi.Name(), "maintner-") && strings.HasSuffix(fi.Name(), ".mutlog") {
+			files = append(files, fi)
+		}
+	}
+	return files, nil
+}
+
+// Segments implements LogStore. It reads every log file to compute
+// its SHA-224.
+func (d *DiskMutationLogger) Segments(ctx context.Context) ([]LogSegmentJSON, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fis, err := d.filesLocked()
+	if err != nil {
+		return nil, err
+	}
+	var segs []LogSegmentJSON
+	for i, fi := range fis {
+		f, err := os.Open(filepath.Join(d.directory, fi.Name()))
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.New224()
+		n, err := io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, LogSegmentJSON{
+			Number: i,
+			Size:   n,
+			SHA224: fmt.Sprintf("%x", h.Sum(nil)),
+		})
+	}
+	return segs, nil
+}
+
+// ReadSegment implements LogStore.
+func (d *DiskMutationLogger) ReadSegment(ctx context.Context, n int) (io.ReadCloser, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fis, err := d.filesLocked()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n >= len(fis) {
+		return nil, fmt.Errorf("segment %d not found; have %d segments", n, len(fis))
+	}
+	return os.Open(filepath.Join(d.directory, fis[n].Name()))
+}
+
+// WriteSegment implements LogStore. A new segment is written to the
+// file for the current day, or, if that wouldn't sort after the
+// existing files, to a file named after the last one with a numeric
+// suffix.
+func (d *DiskMutationLogger) WriteSegment(ctx context.Context, n int, data []byte) (LogSegmentJSON, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fis, err := d.filesLocked()
+	if err != nil {
+		return LogSegmentJSON{}, err
+	}
+	var name string
+	switch {
+	case n >= 0 && n < len(fis):
+		name = filepath.Join(d.directory, fis[n].Name())
+	case n == len(fis):
+		name = d.filename()
+		if len(fis) > 0 {
+			if last := filepath.Join(d.directory, fis[len(fis)-1].Name()); name <= last {
+				name = nextLogFilename(last)
+			}
+		}
+	default:
+		return LogSegmentJSON{}, fmt.Errorf("can't write segment %d; have %d segments", n, len(fis))
+	}
+	if err := os.MkdirAll(d.directory, 0755); err != nil {
+		return LogSegmentJSON{}, err
+	}
+	tmp := name + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return LogSegmentJSON{}, err
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		return LogSegmentJSON{}, err
+	}
+	d.last = name
+	if len(fis) > 0 {
+		if last := filepath.Join(d.directory, fis[len(fis)-1].Name()); last > name {
+			d.last = last
+		}
+	}
+	d.listed = true
+	return LogSegmentJSON{
+		Number: n,
+		Size:   int64(len(data)),
+		SHA224: fmt.Sprintf("%x", sha256.Sum224(data)),
+	}, nil
+}
+
+// nextLogFilename returns a log file name that sorts just after
+// name, by adding or incrementing a "_NNNN" suffix.
+func nextLogFilename(name string) string {
+	base := strings.TrimSuffix(name, ".mutlog")
+	if i := strings.LastIndex(base, "_"); i >= 0 && i > strings.LastIndex(base, string(filepath.Separator)) {
+		if n, err := strconv.Atoi(base[i+1:]); err == nil {
+			return fmt.Sprintf("%s_%04d.mutlog", base[:i], n+1)
+		}
+	}
+	return base + "_0001.mutlog"
 }
 
 func (d *DiskMutationLogger) ForeachFile(fn func(fullPath string, fi os.FileInfo) error) error {
@@ -83,6 +236,8 @@ func (d *DiskMutationLogger) ForeachFile(fn func(fullPath string, fi os.FileInfo
 	})
 }
 
+func (d *DiskMutationLogger) setRecordFilter(want func(rec []byte) bool) { d.want = want }
+
 func (d *DiskMutationLogger) GetMutations(ctx context.Context) <-chan MutationStreamEvent {
 	d.mu.Lock()
 	wasDone := d.done
@@ -102,6 +257,9 @@ func (d *DiskMutationLogger) GetMutations(ctx context.Context) <-chan MutationSt
 	go func() {
 		err := d.ForeachFile(func(fullPath string, fi os.FileInfo) error {
 			return reclog.ForeachFileRecord(fullPath, func(off int64, hdr, rec []byte) error {
+				if d.want != nil && !d.want(rec) {
+					return nil
+				}
 				m := new(maintpb.Mutation)
 				if err := proto.Unmarshal(rec, m); err != nil {
 					return err
@@ -0,0 +1,151 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+
+	"golang.org/x/build/maintner/reclog"
+)
+
+// A LogStore stores a mutation log as a sequence of segments, each
+// holding a run of reclog records.
+//
+// Implementations include DiskMutationLogger and the stores in the
+// maintnerd/gcslog and maintnerd/s3log packages.
+type LogStore interface {
+	// Segments returns the stored segments in order. Segment
+	// numbers start at 0 and are contiguous. A segment's URL, if
+	// non-empty, is where clients may fetch it directly; otherwise
+	// it must be served from ReadSegment.
+	Segments(ctx context.Context) ([]LogSegmentJSON, error)
+
+	// ReadSegment returns the contents of segment n.
+	ReadSegment(ctx context.Context, n int) (io.ReadCloser, error)
+
+	// WriteSegment stores data as segment n, replacing its previous
+	// contents, if any, and returns the segment's description. n must
+	// be at most the number of segments, in which case it appends a
+	// new segment.
+	WriteSegment(ctx context.Context, n int, data []byte) (LogSegmentJSON, error)
+}
+
+// CopyLog copies the mutation log of src to dst, re-chunking its
+// records into segments of at most maxSize bytes, unless a single
+// record is larger, as GCSLog does when logging. It verifies the
+// SHA-224 sum of each segment read from src, and at the end that dst
+// lists the segments written. Segments of dst that already match the
+// copy are left alone, so an interrupted copy can be resumed, and
+// copying again after src has grown only rewrites the last segment of
+// dst and appends new ones.
+func CopyLog(ctx context.Context, dst, src LogStore, maxSize int) error {
+	srcSegs, err := src.Segments(ctx)
+	if err != nil {
+		return fmt.Errorf("listing source segments: %v", err)
+	}
+	dstSegs, err := dst.Segments(ctx)
+	if err != nil {
+		return fmt.Errorf("listing destination segments: %v", err)
+	}
+
+	var (
+		want  []LogSegmentJSON // segments of the copy so far
+		chunk bytes.Buffer     // records of the next segment
+		rec   bytes.Buffer     // the next record
+	)
+	flush := func() error {
+		n := len(want)
+		data := chunk.Bytes()
+		seg := LogSegmentJSON{
+			Number: n,
+			Size:   int64(len(data)),
+			SHA224: fmt.Sprintf("%x", sha256.Sum224(data)),
+		}
+		want = append(want, seg)
+		defer chunk.Reset()
+		if n < len(dstSegs) {
+			if sameSegment(dstSegs[n], seg) {
+				return nil
+			}
+			// Only the last segment may still be growing.
+			if n != len(dstSegs)-1 {
+				return fmt.Errorf("destination segment %d (sha224 %s) differs from the copy (sha224 %s)", n, dstSegs[n].SHA224, seg.SHA224)
+			}
+		}
+		log.Printf("writing segment %d (%d bytes)", n, len(data))
+		if _, err := dst.WriteSegment(ctx, n, data); err != nil {
+			return fmt.Errorf("writing segment %d: %v", n, err)
+		}
+		return nil
+	}
+	for i, seg := range srcSegs {
+		data, err := readSegment(ctx, src, i)
+		if err != nil {
+			return err
+		}
+		if got := fmt.Sprintf("%x", sha256.Sum224(data)); int64(len(data)) != seg.Size || got != seg.SHA224 {
+			return fmt.Errorf("source segment %d has size %d, sha224 %s; listed as size %d, sha224 %s", i, len(data), got, seg.Size, seg.SHA224)
+		}
+		err = reclog.ForeachRecord(bytes.NewReader(data), 0, func(_ int64, _, data []byte) error {
+			rec.Reset()
+			reclog.WriteRecord(&rec, int64(chunk.Len()), data)
+			if chunk.Len() > 0 && chunk.Len()+rec.Len() > maxSize {
+				if err := flush(); err != nil {
+					return err
+				}
+				rec.Reset()
+				reclog.WriteRecord(&rec, 0, data)
+			}
+			chunk.Write(rec.Bytes())
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("source segment %d: %v", i, err)
+		}
+	}
+	if chunk.Len() > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	dstSegs, err = dst.Segments(ctx)
+	if err != nil {
+		return fmt.Errorf("listing destination segments: %v", err)
+	}
+	if len(dstSegs) != len(want) {
+		return fmt.Errorf("destination has %d segments after copy; want %d", len(dstSegs), len(want))
+	}
+	for i, seg := range want {
+		if !sameSegment(dstSegs[i], seg) {
+			return fmt.Errorf("destination segment %d has size %d, sha224 %s after copy; want size %d, sha224 %s",
+				i, dstSegs[i].Size, dstSegs[i].SHA224, seg.Size, seg.SHA224)
+		}
+	}
+	return nil
+}
+
+func sameSegment(a, b LogSegmentJSON) bool {
+	return a.Number == b.Number && a.Size == b.Size && a.SHA224 == b.SHA224
+}
+
+func readSegment(ctx context.Context, s LogStore, n int) ([]byte, error) {
+	rc, err := s.ReadSegment(ctx, n)
+	if err != nil {
+		return nil, fmt.Errorf("opening segment %d: %v", n, err)
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading segment %d: %v", n, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/build/maintner/maintpb"
+	"golang.org/x/build/maintner/reclog"
+)
+
+func TestNextLogFilename(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"/d/maintner-2023-06-01.mutlog", "/d/maintner-2023-06-01_0001.mutlog"},
+		{"/d/maintner-2023-06-01_0001.mutlog", "/d/maintner-2023-06-01_0002.mutlog"},
+		{"/d_1/maintner-2023-06-01.mutlog", "/d_1/maintner-2023-06-01_0001.mutlog"},
+	} {
+		got := nextLogFilename(filepath.FromSlash(tt.in))
+		if want := filepath.FromSlash(tt.want); got != want {
+			t.Errorf("nextLogFilename(%q) = %q; want %q", tt.in, got, want)
+		}
+		if got <= tt.in {
+			t.Errorf("nextLogFilename(%q) = %q doesn't sort after it", tt.in, got)
+		}
+	}
+}
+
+// logFiles returns the base names of the log files in d.
+func logFiles(t *testing.T, d *DiskMutationLogger) []string {
+	t.Helper()
+	var names []string
+	if err := d.ForeachFile(func(path string, _ os.FileInfo) error {
+		names = append(names, filepath.Base(path))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+// testSegment returns a segment holding a record of each of recs.
+func testSegment(recs ...string) []byte {
+	var buf bytes.Buffer
+	for _, rec := range recs {
+		reclog.WriteRecord(&buf, int64(buf.Len()), []byte(rec))
+	}
+	return buf.Bytes()
+}
+
+// segmentRecords returns the records of each segment of s.
+func segmentRecords(t *testing.T, s LogStore) [][]string {
+	t.Helper()
+	ctx := context.Background()
+	segs, err := s.Segments(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs [][]string
+	for _, seg := range segs {
+		data, err := readSegment(ctx, s, seg.Number)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var segRecs []string
+		err = reclog.ForeachRecord(bytes.NewReader(data), 0, func(_ int64, _, rec []byte) error {
+			segRecs = append(segRecs, string(rec))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("segment %d: %v", seg.Number, err)
+		}
+		recs = append(recs, segRecs)
+	}
+	return recs
+}
+
+// countingStore is a LogStore counting the segments written.
+type countingStore struct {
+	LogStore
+	writes int
+}
+
+func (s *countingStore) WriteSegment(ctx context.Context, n int, data []byte) (LogSegmentJSON, error) {
+	s.writes++
+	return s.LogStore.WriteSegment(ctx, n, data)
+}
+
+func TestCopyLog(t *testing.T) {
+	ctx := context.Background()
+	a, b, c := strings.Repeat("a", 100), strings.Repeat("b", 100), strings.Repeat("c", 100)
+	d := strings.Repeat("d", 300)
+	src := NewDiskMutationLogger(t.TempDir())
+	for i, data := range [][]byte{testSegment(a, b), testSegment(c), testSegment(d)} {
+		if _, err := src.WriteSegment(ctx, i, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The records are re-chunked into segments of up to 400 bytes.
+	const maxSize = 400
+	disk := NewDiskMutationLogger(filepath.Join(t.TempDir(), "new"))
+	dst := &countingStore{LogStore: disk}
+	if err := CopyLog(ctx, dst, src, maxSize); err != nil {
+		t.Fatalf("CopyLog: %v", err)
+	}
+	want := [][]string{{a, b, c}, {d}}
+	if got := segmentRecords(t, dst); !reflect.DeepEqual(got, want) {
+		t.Errorf("destination records = %.10q; want %.10q", got, want)
+	}
+	segs, err := dst.Segments(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, seg := range segs {
+		if seg.Size > maxSize {
+			t.Errorf("segment %d has %d bytes; want at most %d", seg.Number, seg.Size, maxSize)
+		}
+	}
+
+	// Copying again writes nothing.
+	dst.writes = 0
+	if err := CopyLog(ctx, dst, src, maxSize); err != nil {
+		t.Fatalf("second CopyLog: %v", err)
+	}
+	if dst.writes != 0 {
+		t.Errorf("second CopyLog wrote %d segments; want 0", dst.writes)
+	}
+
+	// After the source grows, only the last segment is rewritten.
+	m := &maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 1}}
+	mdata, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Log(m); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyLog(ctx, dst, src, maxSize); err != nil {
+		t.Fatalf("CopyLog of grown source: %v", err)
+	}
+	if dst.writes != 1 {
+		t.Errorf("CopyLog of grown source wrote %d segments; want 1", dst.writes)
+	}
+	want = [][]string{{a, b, c}, {d, string(mdata)}}
+	if got := segmentRecords(t, dst); !reflect.DeepEqual(got, want) {
+		t.Errorf("destination records after growth = %.10q; want %.10q", got, want)
+	}
+
+	// New mutations go to the last segment, keeping the log in order,
+	// even though they're logged on the same day that the segments
+	// were written.
+	if err := disk.Log(m); err != nil {
+		t.Fatal(err)
+	}
+	if files := logFiles(t, disk); len(files) != 2 {
+		t.Fatalf("log files = %q; want 2", files)
+	}
+	want = [][]string{{a, b, c}, {d, string(mdata), string(mdata)}}
+	if got := segmentRecords(t, disk); !reflect.DeepEqual(got, want) {
+		t.Errorf("records after Log = %.10q; want %.10q", got, want)
+	}
+}
+
+// corruptStore is a LogStore whose segments don't match their listing.
+type corruptStore struct{ *DiskMutationLogger }
+
+func (s corruptStore) ReadSegment(ctx context.Context, n int) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("corrupt")), nil
+}
+
+func TestCopyLogCorrupt(t *testing.T) {
+	ctx := context.Background()
+	src := NewDiskMutationLogger(t.TempDir())
+	if _, err := src.WriteSegment(ctx, 0, testSegment("zero")); err != nil {
+		t.Fatal(err)
+	}
+	dst := NewDiskMutationLogger(t.TempDir())
+	err := CopyLog(ctx, dst, corruptStore{src}, 100)
+	if err == nil || !strings.Contains(err.Error(), "sha224") {
+		t.Errorf("CopyLog of corrupt segment = %v; want sha224 mismatch", err)
+	}
+	if segs, _ := dst.Segments(ctx); len(segs) != 0 {
+		t.Errorf("destination has %d segments after failed copy; want 0", len(segs))
+	}
+
+	// A source segment which isn't a run of records isn't copied.
+	bad := NewDiskMutationLogger(t.TempDir())
+	if _, err := bad.WriteSegment(ctx, 0, []byte("zero")); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyLog(ctx, dst, bad, 100); err == nil {
+		t.Errorf("CopyLog of malformed segment succeeded; want error")
+	}
+
+	// A destination whose earlier segments differ isn't overwritten.
+	for i, data := range [][]byte{testSegment("other"), testSegment("one")} {
+		if _, err := dst.WriteSegment(ctx, i, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := src.WriteSegment(ctx, 1, testSegment("one")); err != nil {
+		t.Fatal(err)
+	}
+	// Each record is a segment of its own.
+	if err := CopyLog(ctx, dst, src, 10); err == nil || !strings.Contains(err.Error(), "differs") {
+		t.Errorf("CopyLog to diverged destination = %v; want error", err)
+	}
+	if got, want := segmentRecords(t, dst), [][]string{{"other"}, {"one"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("diverged destination records = %q; want %q", got, want)
+	}
+}
@@ -34,13 +34,24 @@ type Corpus struct {
 	verbose        bool
 	dataDir        string
 	sawErrSplit    bool
+	filter         *mutationFilter // from InitializeWithFilter; nil loads everything
+	asOf           AsOf            // from InitializeAsOf; zero for the present
 
 	mu sync.RWMutex // guards all following fields
 	// corpus state:
-	didInit   bool // true after Initialize completes successfully
+	didInit   bool  // true after Initialize completes successfully
+	mutations int64 // number of mutations processed, for AsOf.Mutations
 	debug     bool
 	strIntern map[string]string // interned strings, including binary githashes
 
+	// asOfSkippedIssues are the GitHub issues created after the time
+	// of the view, whose later mutations are skipped as well.
+	asOfSkippedIssues map[githubIssueKey]bool
+
+	// changes are the change events of the recent mutations, if
+	// TrackChanges was called.
+	changes *changeLog
+
 	// pubsub:
 	activityChans map[string]chan struct{} // keyed by topic
 
@@ -226,11 +237,47 @@ type MutationStreamEvent struct {
 // MutationSource. It returns once it's up-to-date. To incrementally
 // update it later, use the Update method.
 func (c *Corpus) Initialize(ctx context.Context, src MutationSource) error {
+	return c.InitializeWithFilter(ctx, src, Filter{})
+}
+
+// InitializeWithFilter behaves just like Initialize, but only loads the
+// parts of the corpus selected by f, then and in later updates.
+// Mutations of other parts are skipped, before they're decoded if src
+// supports it, as the mutation sources returned by
+// NewNetworkMutationSource and NewDiskMutationLogger do.
+//
+// A leader's corpus can't be filtered.
+func (c *Corpus) InitializeWithFilter(ctx context.Context, src MutationSource, f Filter) error {
+	return c.InitializeAsOf(ctx, src, f, AsOf{})
+}
+
+// InitializeAsOf behaves just like InitializeWithFilter, but only
+// replays the mutations up to the point at, making a read-only view of
+// the corpus as it was then. Unless at is zero, the corpus can't be
+// updated.
+func (c *Corpus) InitializeAsOf(ctx context.Context, src MutationSource, f Filter, at AsOf) error {
 	if c.mutationSource != nil {
 		panic("duplicate call to Initialize")
 	}
+	c.filter = newMutationFilter(f)
+	c.asOf = at
+	if c.mutationLogger != nil && (c.filter != nil || !at.isZero()) {
+		panic("can't filter the corpus or view its past in leader mode")
+	}
+	// Skipping records would change the count of mutations.
+	if fs, ok := src.(filteringMutationSource); ok && c.filter != nil && at.Mutations <= 0 {
+		fs.setRecordFilter(c.filter.wantRecord)
+	}
 	c.mutationSource = src
-	log.Printf("Loading data from log %T ...", src)
+	if at.isZero() {
+		log.Printf("Loading data from log %T ...", src)
+	} else {
+		log.Printf("Loading data from log %T as of %+v ...", src, at)
+		// Stop the source when the view is complete.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+	}
 	return c.update(ctx, nil)
 }
 
@@ -253,6 +300,9 @@ func (c *Corpus) Update(ctx context.Context) error {
 	if c.mutationSource == nil {
 		panic("Update called without call to Initialize")
 	}
+	if !c.asOf.isZero() {
+		panic("Update called on a view of the corpus as of the past")
+	}
 	if c.sawErrSplit {
 		panic("Update called after previous call returned ErrSplit")
 	}
@@ -270,6 +320,9 @@ func (c *Corpus) UpdateWithLocker(ctx context.Context, lk sync.Locker) error {
 	if c.mutationSource == nil {
 		panic("UpdateWithLocker called without call to Initialize")
 	}
+	if !c.asOf.isZero() {
+		panic("UpdateWithLocker called on a view of the corpus as of the past")
+	}
 	if c.sawErrSplit {
 		panic("UpdateWithLocker called after previous call returned ErrSplit")
 	}
@@ -318,6 +371,14 @@ func (c *Corpus) update(ctx context.Context, lk sync.Locker) error {
 			lk.Lock()
 			c.processMutationLocked(e.Mutation)
 			lk.Unlock()
+			if c.asOf.Mutations > 0 && c.mutations >= c.asOf.Mutations {
+				c.didInit = true
+				lk.Lock()
+				c.finishProcessing()
+				lk.Unlock()
+				log.Printf("Loaded %d mutations from log %T.", c.mutations, src)
+				return nil
+			}
 		}
 	}
 }
@@ -344,17 +405,39 @@ func (c *Corpus) addMutation(m *maintpb.Mutation) {
 
 // c.mu must be held.
 func (c *Corpus) processMutationLocked(m *maintpb.Mutation) {
-	if im := m.GithubIssue; im != nil {
-		c.processGithubIssueMutation(im)
+	c.mutations++
+	var changes []ChangeEvent
+	if im := m.GithubIssue; im != nil && c.filter.wantGitHubIssue(c, im) {
+		if im = c.githubIssueMutationAsOf(im); im != nil {
+			if c.changes == nil {
+				c.processGithubIssueMutation(im)
+			} else {
+				old := c.githubIssueStateOf(im)
+				c.processGithubIssueMutation(im)
+				changes = append(changes, c.githubIssueChanges(im, old)...)
+			}
+		}
 	}
-	if gm := m.Github; gm != nil {
+	if gm := m.Github; gm != nil && c.filter.wantGitHubRepo(gm.Owner, gm.Repo) {
 		c.processGithubMutation(gm)
 	}
-	if gm := m.Git; gm != nil {
+	if gm := m.Git; gm != nil && c.filter.wantGitRepo(gm.Repo) {
 		c.processGitMutation(gm)
 	}
-	if gm := m.Gerrit; gm != nil {
-		c.processGerritMutation(gm)
+	if gm := m.Gerrit; gm != nil && c.filter.wantGerritProject(gm.Project) {
+		switch {
+		case !c.asOf.Time.IsZero():
+			c.processGerritMutationAsOf(gm)
+		case c.changes != nil:
+			old := c.gerritRefsOf(gm)
+			c.processGerritMutation(gm)
+			changes = append(changes, c.gerritChanges(gm, old)...)
+		default:
+			c.processGerritMutation(gm)
+		}
+	}
+	if c.changes != nil {
+		c.addChanges(changes)
 	}
 }
 
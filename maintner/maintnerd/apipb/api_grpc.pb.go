@@ -21,6 +21,9 @@ type MaintnerServiceClient interface {
 	// HasAncestor reports whether one commit contains another commit
 	// in its git history.
 	HasAncestor(ctx context.Context, in *HasAncestorRequest, opts ...grpc.CallOption) (*HasAncestorResponse, error)
+	// ListCommitRange lists the commits between two commits, such as
+	// for bisecting a failure.
+	ListCommitRange(ctx context.Context, in *ListCommitRangeRequest, opts ...grpc.CallOption) (*ListCommitRangeResponse, error)
 	// GetRef returns information about a git ref.
 	GetRef(ctx context.Context, in *GetRefRequest, opts ...grpc.CallOption) (*GetRefResponse, error)
 	// GoFindTryWork finds trybot work for the coordinator to build & test.
@@ -41,6 +44,21 @@ type MaintnerServiceClient interface {
 	// contain any pass/fail information; it only contains information on the branches
 	// and commits themselves.
 	GetDashboard(ctx context.Context, in *DashboardRequest, opts ...grpc.CallOption) (*DashboardResponse, error)
+	// WatchMutations streams the changes to GitHub issues and Gerrit CLs,
+	// such as a CL being created or an issue being labeled, as the
+	// corpus processes new mutations, so clients can react to them without
+	// replaying and diffing the corpus.
+	//
+	// The server keeps the events of its recent mutations, so clients
+	// can resume watching from the offset of the last event they saw.
+	// Offsets count the mutations processed by the server since it
+	// started, so they don't survive a restart of maintnerd. If the
+	// events after the start offset are no longer kept, it fails with
+	// code OutOfRange, and with InvalidArgument if the offset is past the
+	// server's last mutation, as after a restart. Either way the client
+	// has missed events and must resync: watch again from offset zero,
+	// then reread the state it cares about, such as with a full scan.
+	WatchMutations(ctx context.Context, in *WatchMutationsRequest, opts ...grpc.CallOption) (MaintnerService_WatchMutationsClient, error)
 }
 
 type maintnerServiceClient struct {
@@ -60,6 +78,15 @@ func (c *maintnerServiceClient) HasAncestor(ctx context.Context, in *HasAncestor
 	return out, nil
 }
 
+func (c *maintnerServiceClient) ListCommitRange(ctx context.Context, in *ListCommitRangeRequest, opts ...grpc.CallOption) (*ListCommitRangeResponse, error) {
+	out := new(ListCommitRangeResponse)
+	err := c.cc.Invoke(ctx, "/apipb.MaintnerService/ListCommitRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *maintnerServiceClient) GetRef(ctx context.Context, in *GetRefRequest, opts ...grpc.CallOption) (*GetRefResponse, error) {
 	out := new(GetRefResponse)
 	err := c.cc.Invoke(ctx, "/apipb.MaintnerService/GetRef", in, out, opts...)
@@ -96,6 +123,38 @@ func (c *maintnerServiceClient) GetDashboard(ctx context.Context, in *DashboardR
 	return out, nil
 }
 
+func (c *maintnerServiceClient) WatchMutations(ctx context.Context, in *WatchMutationsRequest, opts ...grpc.CallOption) (MaintnerService_WatchMutationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MaintnerService_ServiceDesc.Streams[0], "/apipb.MaintnerService/WatchMutations", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &maintnerServiceWatchMutationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MaintnerService_WatchMutationsClient interface {
+	Recv() (*ChangeEvent, error)
+	grpc.ClientStream
+}
+
+type maintnerServiceWatchMutationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *maintnerServiceWatchMutationsClient) Recv() (*ChangeEvent, error) {
+	m := new(ChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // MaintnerServiceServer is the server API for MaintnerService service.
 // All implementations must embed UnimplementedMaintnerServiceServer
 // for forward compatibility
@@ -103,6 +162,9 @@ type MaintnerServiceServer interface {
 	// HasAncestor reports whether one commit contains another commit
 	// in its git history.
 	HasAncestor(context.Context, *HasAncestorRequest) (*HasAncestorResponse, error)
+	// ListCommitRange lists the commits between two commits, such as
+	// for bisecting a failure.
+	ListCommitRange(context.Context, *ListCommitRangeRequest) (*ListCommitRangeResponse, error)
 	// GetRef returns information about a git ref.
 	GetRef(context.Context, *GetRefRequest) (*GetRefResponse, error)
 	// GoFindTryWork finds trybot work for the coordinator to build & test.
@@ -123,6 +185,21 @@ type MaintnerServiceServer interface {
 	// contain any pass/fail information; it only contains information on the branches
 	// and commits themselves.
 	GetDashboard(context.Context, *DashboardRequest) (*DashboardResponse, error)
+	// WatchMutations streams the changes to GitHub issues and Gerrit CLs,
+	// such as a CL being created or an issue being labeled, as the
+	// corpus processes new mutations, so clients can react to them without
+	// replaying and diffing the corpus.
+	//
+	// The server keeps the events of its recent mutations, so clients
+	// can resume watching from the offset of the last event they saw.
+	// Offsets count the mutations processed by the server since it
+	// started, so they don't survive a restart of maintnerd. If the
+	// events after the start offset are no longer kept, it fails with
+	// code OutOfRange, and with InvalidArgument if the offset is past the
+	// server's last mutation, as after a restart. Either way the client
+	// has missed events and must resync: watch again from offset zero,
+	// then reread the state it cares about, such as with a full scan.
+	WatchMutations(*WatchMutationsRequest, MaintnerService_WatchMutationsServer) error
 	mustEmbedUnimplementedMaintnerServiceServer()
 }
 
@@ -133,6 +210,9 @@ type UnimplementedMaintnerServiceServer struct {
 func (UnimplementedMaintnerServiceServer) HasAncestor(context.Context, *HasAncestorRequest) (*HasAncestorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HasAncestor not implemented")
 }
+func (UnimplementedMaintnerServiceServer) ListCommitRange(context.Context, *ListCommitRangeRequest) (*ListCommitRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommitRange not implemented")
+}
 func (UnimplementedMaintnerServiceServer) GetRef(context.Context, *GetRefRequest) (*GetRefResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRef not implemented")
 }
@@ -145,6 +225,9 @@ func (UnimplementedMaintnerServiceServer) ListGoReleases(context.Context, *ListG
 func (UnimplementedMaintnerServiceServer) GetDashboard(context.Context, *DashboardRequest) (*DashboardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDashboard not implemented")
 }
+func (UnimplementedMaintnerServiceServer) WatchMutations(*WatchMutationsRequest, MaintnerService_WatchMutationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMutations not implemented")
+}
 func (UnimplementedMaintnerServiceServer) mustEmbedUnimplementedMaintnerServiceServer() {}
 
 // UnsafeMaintnerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -176,6 +259,24 @@ func _MaintnerService_HasAncestor_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MaintnerService_ListCommitRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommitRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintnerServiceServer).ListCommitRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.MaintnerService/ListCommitRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintnerServiceServer).ListCommitRange(ctx, req.(*ListCommitRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MaintnerService_GetRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRefRequest)
 	if err := dec(in); err != nil {
@@ -248,6 +349,27 @@ func _MaintnerService_GetDashboard_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MaintnerService_WatchMutations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMutationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MaintnerServiceServer).WatchMutations(m, &maintnerServiceWatchMutationsServer{stream})
+}
+
+type MaintnerService_WatchMutationsServer interface {
+	Send(*ChangeEvent) error
+	grpc.ServerStream
+}
+
+type maintnerServiceWatchMutationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *maintnerServiceWatchMutationsServer) Send(m *ChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // MaintnerService_ServiceDesc is the grpc.ServiceDesc for MaintnerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +381,10 @@ var MaintnerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HasAncestor",
 			Handler:    _MaintnerService_HasAncestor_Handler,
 		},
+		{
+			MethodName: "ListCommitRange",
+			Handler:    _MaintnerService_ListCommitRange_Handler,
+		},
 		{
 			MethodName: "GetRef",
 			Handler:    _MaintnerService_GetRef_Handler,
@@ -276,6 +402,12 @@ var MaintnerService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _MaintnerService_GetDashboard_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMutations",
+			Handler:       _MaintnerService_WatchMutations_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api.proto",
 }
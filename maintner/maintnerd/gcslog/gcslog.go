@@ -3,6 +3,8 @@
 // license that can be found in the LICENSE file.
 
 // Package gcslog is an implementation of maintner.MutationSource and Logger for Google Cloud Storage.
+//
+// The log can also be kept in any other maintner.LogStore; see NewLog.
 package gcslog
 
 import (
@@ -15,27 +17,23 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"path"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"cloud.google.com/go/storage"
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/build/maintner"
 	"golang.org/x/build/maintner/maintpb"
 	"golang.org/x/build/maintner/reclog"
-	"google.golang.org/api/iterator"
 )
 
-// targetObjectSize is the goal maximum size for each log segment on
+// TargetObjectSize is the goal maximum size for each log segment on
 // GCS. In the unlikely case that a single record is larger than this
 // then a segment would be bigger than this (records are never split
 // between log segments).  But otherwise this is the max size.
-const targetObjectSize = 16 << 20
+const TargetObjectSize = 16 << 20
 
 const flushInterval = 10 * time.Minute
 
@@ -43,13 +41,10 @@ const flushInterval = 10 * time.Minute
 var _ maintner.MutationLogger = &GCSLog{}
 var _ maintner.MutationSource = &GCSLog{}
 
-// GCSLog logs mutations to GCS.
+// GCSLog logs mutations to GCS, or to another LogStore.
 type GCSLog struct {
-	sc            *storage.Client
-	bucketName    string
-	bucket        *storage.BucketHandle
-	segmentPrefix string
-	debug         bool
+	store maintner.LogStore
+	debug bool
 
 	mu         sync.Mutex // guards the following
 	cond       *sync.Cond
@@ -61,18 +56,14 @@ type GCSLog struct {
 }
 
 type gcsLogSegment struct {
-	num     int // starting with 0
-	size    int64
-	sha224  string // in lowercase hex
-	created time.Time
-}
-
-func (s gcsLogSegment) ObjectName() string {
-	return fmt.Sprintf("%04d.%s.mutlog", s.num, s.sha224)
+	num    int // starting with 0
+	size   int64
+	sha224 string // in lowercase hex
+	url    string // if non-empty, where clients can fetch the segment directly
 }
 
 func (s gcsLogSegment) String() string {
-	return fmt.Sprintf("{gcsLogSegment num=%v, size=%v, sha=%v, created=%v}", s.num, s.size, s.sha224, s.created.Format(time.RFC3339))
+	return fmt.Sprintf("{gcsLogSegment num=%v, size=%v, sha=%v}", s.num, s.size, s.sha224)
 }
 
 // newGCSLogBase returns a new gcsLog instance without any association
@@ -89,67 +80,46 @@ func newGCSLogBase() *GCSLog {
 // If the bucket name contains a "/", the part after the slash will be a
 // prefix for the segments.
 func NewGCSLog(ctx context.Context, bucketName string) (*GCSLog, error) {
-	sc, err := storage.NewClient(ctx)
+	store, err := NewGCSStore(ctx, bucketName)
 	if err != nil {
-		return nil, fmt.Errorf("storage.NewClient: %v", err)
+		return nil, err
 	}
-	gl := newGCSLogBase()
-	gl.sc = sc
-
-	prefix := ""
-	if f := strings.SplitN(bucketName, "/", 2); len(f) > 1 {
-		bucketName, prefix = f[0], f[1]
+	if err := store.DeleteStaleSegments(ctx); err != nil {
+		return nil, err
 	}
+	return NewLog(ctx, store)
+}
 
-	gl.bucketName = bucketName
-	gl.segmentPrefix = prefix
-	gl.bucket = sc.Bucket(gl.bucketName)
+// NewLog creates a GCSLog that logs mutations to store, which holds
+// segments of up to 16 MB. Mutations are buffered in memory and
+// written to the last segment periodically, or when it fills up.
+func NewLog(ctx context.Context, store maintner.LogStore) (*GCSLog, error) {
+	gl := newGCSLogBase()
+	gl.store = store
 	if err := gl.initLoad(ctx); err != nil {
 		return nil, err
 	}
 	return gl, nil
 }
 
-// objNameRx is used to identify a mutation log file by suffix.
-var objnameRx = regexp.MustCompile(`(\d{4})\.([0-9a-f]{56})\.mutlog$`)
-
 func (gl *GCSLog) initLoad(ctx context.Context) error {
-	it := gl.bucket.Objects(ctx, nil)
+	segs, err := gl.store.Segments(ctx)
+	if err != nil {
+		return err
+	}
 	maxNum := 0
-	for {
-		objAttrs, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("iterating over %s bucket: %v", gl.bucketName, err)
-		}
-		if !strings.HasPrefix(objAttrs.Name, gl.segmentPrefix) {
-			log.Printf("Ignoring GCS object with invalid prefix %q", objAttrs.Name)
-			continue
-		}
-		m := objnameRx.FindStringSubmatch(objAttrs.Name)
-		if m == nil {
-			log.Printf("Ignoring unrecognized GCS object %q", objAttrs.Name)
-			continue
-		}
-		n, _ := strconv.ParseInt(m[1], 10, 32)
+	for _, s := range segs {
 		seg := gcsLogSegment{
-			num:     int(n),
-			sha224:  m[2],
-			size:    objAttrs.Size,
-			created: objAttrs.Created,
+			num:    s.Number,
+			size:   s.Size,
+			sha224: s.SHA224,
+			url:    s.URL,
 		}
+		gl.seg[seg.num] = seg
+		log.Printf("seg[%v] = %s", seg.num, seg)
 		if seg.num > maxNum {
 			maxNum = seg.num
 		}
-		if prevSeg, ok := gl.seg[int(n)]; !ok || prevSeg.created.Before(seg.created) {
-			gl.seg[int(n)] = seg
-			log.Printf("seg[%v] = %s", n, seg)
-			if ok {
-				gl.deleteOldSegment(ctx, gl.objectPath(prevSeg))
-			}
-		}
 	}
 	gl.curNum = maxNum
 
@@ -167,12 +137,12 @@ func (gl *GCSLog) initLoad(ctx context.Context) error {
 	// Should we resume writing to the latest entry?
 	// If the latest one is big enough, leave it be.
 	// Otherwise slurp it in and we'll append to it.
-	if gl.seg[maxNum].size >= targetObjectSize-(4<<10) {
+	if gl.seg[maxNum].size >= TargetObjectSize-(4<<10) {
 		gl.curNum++
 		return nil
 	}
 
-	r, err := gl.bucket.Object(gl.objectPath(gl.seg[maxNum])).NewReader(ctx)
+	r, err := gl.store.ReadSegment(ctx, maxNum)
 	if err != nil {
 		return err
 	}
@@ -184,10 +154,6 @@ func (gl *GCSLog) initLoad(ctx context.Context) error {
 	return nil
 }
 
-func (gl *GCSLog) objectPath(seg gcsLogSegment) string {
-	return path.Join(gl.segmentPrefix, seg.ObjectName())
-}
-
 func (gl *GCSLog) serveLogFile(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" && r.Method != "HEAD" {
 		http.Error(w, "bad method", http.StatusBadRequest)
@@ -207,9 +173,13 @@ func (gl *GCSLog) serveLogFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if num != gl.curNum {
-		obj := gl.objectPath(gl.seg[num])
+		seg := gl.seg[num]
 		gl.mu.Unlock()
-		http.Redirect(w, r, "https://storage.googleapis.com/"+gl.bucketName+"/"+obj, http.StatusFound)
+		if seg.url != "" {
+			http.Redirect(w, r, seg.url, http.StatusFound)
+			return
+		}
+		gl.serveSegment(w, r, seg)
 		return
 	}
 	content := gl.logBuf.String()
@@ -219,6 +189,32 @@ func (gl *GCSLog) serveLogFile(w http.ResponseWriter, r *http.Request) {
 	http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
 }
 
+// serveSegment serves a completed segment that has no URL of its
+// own from the store.
+func (gl *GCSLog) serveSegment(w http.ResponseWriter, r *http.Request, seg gcsLogSegment) {
+	rc, err := gl.store.ReadSegment(r.Context(), seg.num)
+	if err != nil {
+		log.Printf("reading segment %d: %v", seg.num, err)
+		http.Error(w, "error reading segment", http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if r.Method == "HEAD" {
+		w.Header().Set("Content-Length", strconv.FormatInt(seg.size, 10))
+		return
+	}
+	// Read the segment, at most TargetObjectSize bytes, to serve the
+	// byte ranges clients ask for.
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		log.Printf("reading segment %d: %v", seg.num, err)
+		http.Error(w, "error reading segment", http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+}
+
 func (gl *GCSLog) serveJSONLogsIndex(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" && r.Method != "HEAD" {
 		http.Error(w, "bad method", http.StatusBadRequest)
@@ -327,11 +323,15 @@ func (gl *GCSLog) getJSONLogs(startSeg int) (segs []maintner.LogSegmentJSON) {
 	segs = make([]maintner.LogSegmentJSON, 0, gl.curNum-startSeg)
 	for i := startSeg; i < gl.curNum; i++ {
 		seg := gl.seg[i]
+		url := seg.url
+		if url == "" {
+			url = fmt.Sprintf("/logs/%d", i)
+		}
 		segs = append(segs, maintner.LogSegmentJSON{
 			Number: i,
 			Size:   seg.size,
 			SHA224: seg.sha224,
-			URL:    fmt.Sprintf("https://storage.googleapis.com/%s/%s", gl.bucketName, gl.objectPath(seg)),
+			URL:    url,
 		})
 	}
 	if gl.logBuf.Len() > 0 {
@@ -381,7 +381,7 @@ func (gl *GCSLog) Log(m *maintpb.Mutation) error {
 	defer gl.mu.Unlock()
 
 	// If we have some data and this item would push us over, flush.
-	if gl.logBuf.Len()+len(data) > targetObjectSize {
+	if gl.logBuf.Len()+len(data) > TargetObjectSize {
 		log.Printf("Log: record requires buffer flush.")
 		if err := gl.flushLocked(context.TODO()); err != nil {
 			return err
@@ -441,26 +441,15 @@ func (gl *GCSLog) flushLocked(ctx context.Context) error {
 		sha224: fmt.Sprintf("%x", sha256.Sum224(buf)),
 		size:   int64(len(buf)),
 	}
-	objName := gl.objectPath(seg)
-	log.Printf("flushing %s (%d bytes)", objName, len(buf))
+	log.Printf("flushing segment %d (%d bytes)", seg.num, len(buf))
 	err := try(4, time.Second, func() error {
-		w := gl.bucket.Object(objName).NewWriter(ctx)
-		w.ContentType = "application/octet-stream"
-		if _, err := w.Write(buf); err != nil {
-			return err
-		}
-		if err := w.Close(); err != nil {
-			return err
-		}
-		attrs := w.Attrs()
-		seg.created = attrs.Created
-		return nil
+		written, err := gl.store.WriteSegment(ctx, seg.num, buf)
+		seg.url = written.URL
+		return err
 	})
 	if err != nil {
 		return err
 	}
-
-	old := gl.seg[seg.num]
 	gl.seg[seg.num] = seg
 
 	// Atomically update the manifest file. If we lose the CAS
@@ -468,47 +457,32 @@ func (gl *GCSLog) flushLocked(ctx context.Context) error {
 	// running at the same time (e.g. accidental replica count > 1
 	// in k8s config) and we should fail hard.
 	// TODO: that^
-
-	// Delete any old segment from the same position.
-	if old.sha224 != "" && old.sha224 != seg.sha224 {
-		gl.deleteOldSegment(ctx, gl.objectPath(old))
-	}
 	return nil
 }
 
-func (gl *GCSLog) deleteOldSegment(ctx context.Context, objName string) {
-	err := gl.bucket.Object(objName).Delete(ctx)
-	if err != nil {
-		// Can ignore, though. Probably emphemeral, and not critical.
-		// It'll be deleted by new versions or next start-up anyway.
-		log.Printf("Warning: error deleting old segment version %v: %v", objName, err)
-	} else {
-		log.Printf("deleted old segment version %v", objName)
-	}
-}
-
-func (gl *GCSLog) objectNames() (names []string) {
+func (gl *GCSLog) segmentNums() []int {
 	gl.mu.Lock()
 	defer gl.mu.Unlock()
-	for _, seg := range gl.seg {
-		names = append(names, gl.objectPath(seg))
+	nums := make([]int, 0, len(gl.seg))
+	for n := range gl.seg {
+		nums = append(nums, n)
 	}
-	sort.Strings(names)
-	return
+	sort.Ints(nums)
+	return nums
 }
 
 func (gl *GCSLog) foreachSegmentReader(ctx context.Context, fn func(r io.Reader) error) error {
-	objs := gl.objectNames()
-	for i, obj := range objs {
-		log.Printf("Reading %d/%d: %s ...", i+1, len(objs), obj)
-		rd, err := gl.bucket.Object(obj).NewReader(ctx)
+	nums := gl.segmentNums()
+	for i, n := range nums {
+		log.Printf("Reading %d/%d: segment %d ...", i+1, len(nums), n)
+		rd, err := gl.store.ReadSegment(ctx, n)
 		if err != nil {
-			return fmt.Errorf("failed to open %v: %v", obj, err)
+			return fmt.Errorf("failed to open segment %d: %v", n, err)
 		}
 		err = fn(rd)
 		rd.Close()
 		if err != nil {
-			return fmt.Errorf("error processing %v: %v", obj, err)
+			return fmt.Errorf("error processing segment %d: %v", n, err)
 		}
 	}
 	return nil
@@ -560,28 +534,6 @@ func try(tries int, firstDelay time.Duration, fn func() error) error {
 	return err
 }
 
-// CopyFrom is only used for the one-time migrate from disk-to-GCS code path.
-func (gl *GCSLog) CopyFrom(src maintner.MutationSource) error {
-	gl.curNum = 0
-	ctx := context.Background()
-	for e := range src.GetMutations(ctx) {
-		if e.Err != nil {
-			log.Printf("Corpus.Initialize: %v", e.Err)
-			return e.Err
-		}
-		if e.End {
-			log.Printf("reached end. flushing.")
-			err := gl.flush(ctx)
-			log.Printf("final flush = %v", err)
-			return nil
-		}
-		if err := gl.Log(e.Mutation); err != nil {
-			return err
-		}
-	}
-	panic("unexpected channel close")
-}
-
 // RegisterHandlers adds handlers for the default paths (/logs and /logs/).
 func (gl *GCSLog) RegisterHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/logs", gl.serveJSONLogsIndex)
@@ -6,9 +6,14 @@ package gcslog
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
+	"golang.org/x/build/maintner"
 	"golang.org/x/build/maintner/maintpb"
 )
 
@@ -42,3 +47,56 @@ func testGCSLogWakeup(t *testing.T, activity bool) {
 		t.Errorf("timeout")
 	}
 }
+
+func TestServeStoreSegments(t *testing.T) {
+	ctx := context.Background()
+	store := maintner.NewDiskMutationLogger(t.TempDir())
+	for i, data := range []string{"segment zero", "segment one"} {
+		if _, err := store.WriteSegment(ctx, i, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gl, err := NewLog(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	gl.RegisterHandlers(mux)
+	get := func(path string) string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: %v", path, rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	var segs []maintner.LogSegmentJSON
+	if err := json.Unmarshal([]byte(get("/logs")), &segs); err != nil {
+		t.Fatal(err)
+	}
+	var urls []string
+	for _, seg := range segs {
+		urls = append(urls, seg.URL)
+	}
+	if want := []string{"/logs/0", "/logs/1"}; !reflect.DeepEqual(urls, want) {
+		t.Errorf("segment URLs = %q; want %q", urls, want)
+	}
+	// Segment 0 is served from the store, segment 1 from the buffer.
+	if got := get("/logs/0"); got != "segment zero" {
+		t.Errorf("/logs/0 = %q", got)
+	}
+	if got := get("/logs/1"); got != "segment one" {
+		t.Errorf("/logs/1 = %q", got)
+	}
+
+	// Clients resume downloads with range requests.
+	req := httptest.NewRequest("GET", "/logs/0", nil)
+	req.Header.Set("Range", "bytes=8-")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "zero" {
+		t.Errorf("GET /logs/0 from byte 8 = %v %q; want %v %q", rec.Code, rec.Body.String(), http.StatusPartialContent, "zero")
+	}
+}
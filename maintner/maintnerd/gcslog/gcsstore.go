@@ -0,0 +1,230 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gcslog
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/build/maintner"
+	"google.golang.org/api/iterator"
+)
+
+var _ maintner.LogStore = &GCSStore{}
+
+// GCSStore is a maintner.LogStore that keeps segments as objects
+// named "NNNN.<sha224>.mutlog" in a GCS bucket.
+type GCSStore struct {
+	bucketName    string
+	bucket        *storage.BucketHandle
+	segmentPrefix string
+
+	mu  sync.Mutex
+	obj map[int]string // segment number => object name, as of last list or write
+}
+
+// NewGCSStore returns a GCSStore for the given bucket. If the bucket
+// name contains a "/", the part after the slash will be a prefix for
+// the segments.
+func NewGCSStore(ctx context.Context, bucketName string) (*GCSStore, error) {
+	sc, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("storage.NewClient: %v", err)
+	}
+	prefix := ""
+	if f := strings.SplitN(bucketName, "/", 2); len(f) > 1 {
+		bucketName, prefix = f[0], f[1]
+	}
+	return &GCSStore{
+		bucketName:    bucketName,
+		bucket:        sc.Bucket(bucketName),
+		segmentPrefix: prefix,
+		obj:           map[int]string{},
+	}, nil
+}
+
+// objNameRx is used to identify a mutation log file by suffix.
+var objnameRx = regexp.MustCompile(`(\d{4})\.([0-9a-f]{56})\.mutlog$`)
+
+type gcsObject struct {
+	name    string
+	seg     maintner.LogSegmentJSON
+	created time.Time
+}
+
+// Segments implements maintner.LogStore. If a segment number has
+// several objects, as happens if a process dies while replacing a
+// segment, the newest is used. DeleteStaleSegments deletes the others.
+func (s *GCSStore) Segments(ctx context.Context) ([]maintner.LogSegmentJSON, error) {
+	latest, _, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	segs := make([]maintner.LogSegmentJSON, 0, len(latest))
+	for _, o := range latest {
+		segs = append(segs, o.seg)
+	}
+	sort.Slice(segs, func(i, j int) bool { return segs[i].Number < segs[j].Number })
+	for i, seg := range segs {
+		if seg.Number != i {
+			return nil, fmt.Errorf("saw max segment number %d but missing segment %d", segs[len(segs)-1].Number, i)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.obj = map[int]string{}
+	for n, o := range latest {
+		s.obj[n] = o.name
+	}
+	return segs, nil
+}
+
+// DeleteStaleSegments deletes the objects of segments which have been
+// replaced by a newer object for the same segment number, but which
+// weren't deleted, as happens if a process dies while replacing a
+// segment. Only the process writing the log should call it.
+func (s *GCSStore) DeleteStaleSegments(ctx context.Context) error {
+	_, stale, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range stale {
+		s.deleteOldSegment(ctx, name)
+	}
+	return nil
+}
+
+// list lists the segment objects in the bucket, returning the newest
+// object of each segment number and the names of the older ones.
+func (s *GCSStore) list(ctx context.Context) (latest map[int]gcsObject, stale []string, err error) {
+	latest = map[int]gcsObject{}
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: s.segmentPrefix})
+	for {
+		objAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("iterating over %s bucket: %v", s.bucketName, err)
+		}
+		m := objnameRx.FindStringSubmatch(objAttrs.Name)
+		if m == nil {
+			log.Printf("Ignoring unrecognized GCS object %q", objAttrs.Name)
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		o := gcsObject{
+			name: objAttrs.Name,
+			seg: maintner.LogSegmentJSON{
+				Number: n,
+				Size:   objAttrs.Size,
+				SHA224: m[2],
+				URL:    s.objectURL(objAttrs.Name),
+			},
+			created: objAttrs.Created,
+		}
+		prev, ok := latest[n]
+		if ok && !prev.created.Before(o.created) {
+			prev, o = o, prev
+		}
+		latest[n] = o
+		if ok {
+			stale = append(stale, prev.name)
+		}
+	}
+	return latest, stale, nil
+}
+
+// ReadSegment implements maintner.LogStore.
+func (s *GCSStore) ReadSegment(ctx context.Context, n int) (io.ReadCloser, error) {
+	name, err := s.objectName(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("segment %d not found", n)
+	}
+	return s.bucket.Object(name).NewReader(ctx)
+}
+
+// WriteSegment implements maintner.LogStore. Once the new object is
+// written, the segment's previous object, if any, is deleted.
+func (s *GCSStore) WriteSegment(ctx context.Context, n int, data []byte) (maintner.LogSegmentJSON, error) {
+	old, err := s.objectName(ctx, n)
+	if err != nil {
+		return maintner.LogSegmentJSON{}, err
+	}
+	seg := maintner.LogSegmentJSON{
+		Number: n,
+		Size:   int64(len(data)),
+		SHA224: fmt.Sprintf("%x", sha256.Sum224(data)),
+	}
+	name := path.Join(s.segmentPrefix, fmt.Sprintf("%04d.%s.mutlog", n, seg.SHA224))
+	seg.URL = s.objectURL(name)
+	w := s.bucket.Object(name).NewWriter(ctx)
+	w.ContentType = "application/octet-stream"
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return maintner.LogSegmentJSON{}, err
+	}
+	if err := w.Close(); err != nil {
+		return maintner.LogSegmentJSON{}, err
+	}
+
+	s.mu.Lock()
+	s.obj[n] = name
+	s.mu.Unlock()
+
+	// Delete any old segment from the same position.
+	if old != "" && old != name {
+		s.deleteOldSegment(ctx, old)
+	}
+	return seg, nil
+}
+
+// objectName returns the name of the object holding segment n,
+// or the empty string if there is no such segment yet.
+func (s *GCSStore) objectName(ctx context.Context, n int) (string, error) {
+	s.mu.Lock()
+	name, ok := s.obj[n]
+	loaded := len(s.obj) > 0
+	s.mu.Unlock()
+	if ok || loaded {
+		return name, nil
+	}
+	if _, err := s.Segments(ctx); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.obj[n], nil
+}
+
+func (s *GCSStore) objectURL(name string) string {
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", s.bucketName, name)
+}
+
+func (s *GCSStore) deleteOldSegment(ctx context.Context, objName string) {
+	err := s.bucket.Object(objName).Delete(ctx)
+	if err != nil {
+		// Can ignore, though. Probably emphemeral, and not critical.
+		// It'll be deleted by new versions or next start-up anyway.
+		log.Printf("Warning: error deleting old segment version %v: %v", objName, err)
+	} else {
+		log.Printf("deleted old segment version %v", objName)
+	}
+}
@@ -66,6 +66,72 @@ func (s apiService) HasAncestor(ctx context.Context, req *apipb.HasAncestorReque
 	return res, nil
 }
 
+// defaultMaxCommitRange is the default limit on the number of commits
+// returned by ListCommitRange.
+const defaultMaxCommitRange = 1000
+
+func (s apiService) ListCommitRange(ctx context.Context, req *apipb.ListCommitRangeRequest) (*apipb.ListCommitRangeResponse, error) {
+	if len(req.Good) != 40 {
+		return nil, errors.New("invalid Good")
+	}
+	if len(req.Bad) != 40 {
+		return nil, errors.New("invalid Bad")
+	}
+	max := int(req.MaxCommits)
+	if max <= 0 {
+		max = defaultMaxCommitRange
+	}
+	s.c.RLock()
+	defer s.c.RUnlock()
+
+	res := new(apipb.ListCommitRangeResponse)
+	good, bad := s.c.GitCommit(req.Good), s.c.GitCommit(req.Bad)
+	if good == nil || bad == nil {
+		res.UnknownCommit = true
+		return res, nil
+	}
+	if !bad.HasAncestor(good) {
+		res.NotAncestor = true
+		return res, nil
+	}
+	commits, err := commitRange(good, bad, max)
+	if err != nil {
+		return nil, grpc.Errorf(codes.OutOfRange, "%v", err)
+	}
+	res.Commits = commits
+	return res, nil
+}
+
+// commitRange returns the hex hashes of the commits after good, up to
+// and including bad, from oldest to newest. bad must have good as an
+// ancestor. At merge commits, it follows the first parent whose
+// history contains good.
+func commitRange(good, bad *maintner.GitCommit, max int) ([]string, error) {
+	var commits []string
+	for c := bad; c.Hash != good.Hash; {
+		if len(commits) == max {
+			return nil, fmt.Errorf("more than %d commits between %v and %v", max, good.Hash, bad.Hash)
+		}
+		commits = append(commits, c.Hash.String())
+		var next *maintner.GitCommit
+		for _, p := range c.Parents {
+			// Without merges, the only parent must lead to good.
+			if len(c.Parents) == 1 || p.Hash == good.Hash || p.HasAncestor(good) {
+				next = p
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("no parent of %v leads to %v", c.Hash, good.Hash)
+		}
+		c = next
+	}
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
 func isStagingCommit(cl *maintner.GerritCL) bool {
 	return cl.Commit != nil &&
 		strings.Contains(cl.Commit.Msg, "DO NOT SUBMIT") &&
@@ -127,6 +193,7 @@ func tryWorkItem(
 		w.Commit = ci.CurrentRevision
 		w.Version = int32(ci.Revisions[ci.CurrentRevision].PatchSetNumber)
 	}
+	w.EquivalentCommit = equivalentCommits(ci, cl.CommitAtVersion)
 
 	// Look for "TRY=" comments. Only consider messages that are accompanied
 	// by a Run-TryBot+1 vote, as a way of confirming the comment author has
@@ -223,6 +290,62 @@ func tryWorkItem(
 	return w, nil
 }
 
+// equivalentCommits returns the commits of the patch sets before the
+// current one which have the same code, differing only in their commit
+// messages or in rebases that leave the tree unchanged, from newest to
+// oldest. It uses the patch set kinds reported by Gerrit, and compares
+// the trees of the commits in maintner for trivial rebases and patch
+// sets that Gerrit reported no kind for.
+func equivalentCommits(ci *gerrit.ChangeInfo, commitAt func(version int32) *maintner.GitCommit) []string {
+	cur, ok := ci.Revisions[ci.CurrentRevision]
+	if !ok {
+		return nil
+	}
+	commits := make(map[int]string) // patch set number -> commit
+	kinds := make(map[int]string)   // patch set number -> kind
+	for hash, ri := range ci.Revisions {
+		commits[ri.PatchSetNumber] = hash
+		kinds[ri.PatchSetNumber] = ri.Kind
+	}
+	var equiv []string
+	for n := cur.PatchSetNumber; n > 1; n-- {
+		if !sameCodeAsPrevious(kinds[n], commitAt(int32(n)), commitAt(int32(n-1))) {
+			break
+		}
+		prev := commits[n-1]
+		if prev == "" {
+			if c := commitAt(int32(n - 1)); c != nil {
+				prev = c.Hash.String()
+			}
+		}
+		if prev == "" {
+			break
+		}
+		equiv = append(equiv, prev)
+	}
+	return equiv
+}
+
+// sameCodeAsPrevious reports whether a patch set of the given Gerrit
+// kind with commit c has the same code as the previous patch set with
+// commit prev. Either commit may be nil if maintner doesn't have it.
+func sameCodeAsPrevious(kind string, c, prev *maintner.GitCommit) bool {
+	switch kind {
+	case "NO_CODE_CHANGE", "NO_CHANGE":
+		return true
+	case "TRIVIAL_REBASE", "":
+		// A trivial rebase applies the same diff to a different
+		// parent, and Gerrit may not say at all; compare the trees.
+		// Commits with the same diff stat may still change different
+		// code, so only an identical tree counts.
+		if c == nil || prev == nil {
+			return false
+		}
+		return c.Tree == prev.Tree
+	}
+	return false
+}
+
 func firstLine(s string) string {
 	if nl := strings.Index(s, "\n"); nl < 0 {
 		return s
@@ -306,7 +429,7 @@ func (s apiService) GoFindTryWork(ctx context.Context, req *apipb.GoFindTryWorkR
 func goFindTryWork(ctx context.Context, gerritc *gerrit.Client, maintc *maintner.Corpus) (*apipb.GoFindTryWorkResponse, error) {
 	const query = "label:Run-TryBot=1 label:TryBot-Result=0 status:open"
 	cis, err := gerritc.QueryChanges(ctx, query, gerrit.QueryChangesOpt{
-		Fields: []string{"CURRENT_REVISION", "CURRENT_COMMIT", "MESSAGES", "DETAILED_ACCOUNTS"},
+		Fields: []string{"ALL_REVISIONS", "CURRENT_COMMIT", "MESSAGES", "DETAILED_ACCOUNTS"},
 	})
 	if err != nil {
 		return nil, err
@@ -21,6 +21,7 @@ import (
 	"golang.org/x/build/maintner"
 	"golang.org/x/build/maintner/godata"
 	"golang.org/x/build/maintner/maintnerd/apipb"
+	"golang.org/x/build/maintner/maintpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -822,3 +823,123 @@ func gitHash(hexa string) maintner.GitHash {
 	}
 	return maintner.GitHash(binary)
 }
+
+func TestEquivalentCommits(t *testing.T) {
+	stat := func(added int64) []*maintpb.GitDiffTreeFile {
+		return []*maintpb.GitDiffTreeFile{{File: "a.go", Added: added}}
+	}
+	// Patch sets 1-5 of a CL in maintner:
+	// 2 reworks 1, 3 changes the code of 2 without changing its diff
+	// stat, 4 only edits the message of 3, and 5 is a trivial rebase
+	// reported by Gerrit that leaves the tree of 4 unchanged.
+	maintnerCommits := map[int32]*maintner.GitCommit{
+		1: {Hash: gitHash("1111111111111111111111111111111111111111"), Tree: gitHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), Files: stat(1)},
+		2: {Hash: gitHash("2222222222222222222222222222222222222222"), Tree: gitHash("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"), Files: stat(2)},
+		3: {Hash: gitHash("3333333333333333333333333333333333333333"), Tree: gitHash("cccccccccccccccccccccccccccccccccccccccc"), Files: stat(2)},
+		4: {Hash: gitHash("4444444444444444444444444444444444444444"), Tree: gitHash("cccccccccccccccccccccccccccccccccccccccc"), Files: stat(2)},
+		5: {Hash: gitHash("5555555555555555555555555555555555555555"), Tree: gitHash("cccccccccccccccccccccccccccccccccccccccc"), Files: stat(2)},
+	}
+	commitAt := func(v int32) *maintner.GitCommit { return maintnerCommits[v] }
+	ci := &gerrit.ChangeInfo{
+		CurrentRevision: "5555555555555555555555555555555555555555",
+		Revisions: map[string]gerrit.RevisionInfo{
+			"5555555555555555555555555555555555555555": {PatchSetNumber: 5, Kind: "TRIVIAL_REBASE"},
+		},
+	}
+	got := equivalentCommits(ci, commitAt)
+	// Patch set 2 has the diff stat of 3 but a different tree, so
+	// its results must not be reused for 3.
+	want := []string{
+		"4444444444444444444444444444444444444444",
+		"3333333333333333333333333333333333333333",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("equivalentCommits mismatch (-want +got):\n%s", diff)
+	}
+
+	// A rework reported by Gerrit stops the search.
+	ci.Revisions["4444444444444444444444444444444444444444"] = gerrit.RevisionInfo{PatchSetNumber: 4, Kind: "REWORK"}
+	got = equivalentCommits(ci, commitAt)
+	if diff := cmp.Diff(want[:1], got); diff != "" {
+		t.Errorf("equivalentCommits after rework mismatch (-want +got):\n%s", diff)
+	}
+
+	// So does a trivial rebase onto a parent that changes the tree:
+	// the code of 5 was never built as 4.
+	maintnerCommits[5].Tree = gitHash("dddddddddddddddddddddddddddddddddddddddd")
+	if got := equivalentCommits(ci, commitAt); len(got) != 0 {
+		t.Errorf("equivalentCommits after rebase to a new tree = %q; want none", got)
+	}
+}
+
+func TestSameCodeAsPrevious(t *testing.T) {
+	files := []*maintpb.GitDiffTreeFile{{File: "a.go", Added: 1, Deleted: 1}}
+	prev := &maintner.GitCommit{Tree: gitHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), Files: files}
+	sameTree := &maintner.GitCommit{Tree: prev.Tree}
+	sameStat := &maintner.GitCommit{Tree: gitHash("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"), Files: files}
+	for _, tt := range []struct {
+		name    string
+		kind    string
+		c, prev *maintner.GitCommit
+		want    bool
+	}{
+		{"trivial rebase", "TRIVIAL_REBASE", nil, nil, false},
+		{"trivial rebase, same tree", "TRIVIAL_REBASE", sameTree, prev, true},
+		{"trivial rebase, different tree", "TRIVIAL_REBASE", sameStat, prev, false},
+		{"no code change", "NO_CODE_CHANGE", sameStat, prev, true},
+		{"rework", "REWORK", sameTree, prev, false},
+		{"same tree", "", sameTree, prev, true},
+		{"same diff stat, different tree", "", sameStat, prev, false},
+		{"missing commit", "", nil, prev, false},
+	} {
+		if got := sameCodeAsPrevious(tt.kind, tt.c, tt.prev); got != tt.want {
+			t.Errorf("%s: sameCodeAsPrevious = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCommitRange(t *testing.T) {
+	// a - b - c - e - f
+	//      \     /
+	//        d -
+	// e merges d into c; d branches from b.
+	commit := func(h string, parents ...*maintner.GitCommit) *maintner.GitCommit {
+		return &maintner.GitCommit{Hash: gitHash(strings.Repeat(h, 40)), Parents: parents}
+	}
+	a := commit("a")
+	b := commit("b", a)
+	c := commit("c", b)
+	d := commit("d", b)
+	e := commit("e", c, d)
+	f := commit("f", e)
+	hashes := func(cs ...*maintner.GitCommit) (s []string) {
+		for _, c := range cs {
+			s = append(s, c.Hash.String())
+		}
+		return s
+	}
+
+	tests := []struct {
+		good, bad *maintner.GitCommit
+		max       int
+		want      []string
+		wantErr   bool
+	}{
+		{good: a, bad: f, max: 10, want: hashes(b, c, e, f)},
+		{good: c, bad: f, max: 10, want: hashes(e, f)},
+		// d is only reachable through the second parent of e.
+		{good: d, bad: f, max: 10, want: hashes(e, f)},
+		{good: f, bad: f, max: 10, want: nil},
+		{good: a, bad: f, max: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := commitRange(tt.good, tt.bad, tt.max)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("commitRange(%v, %v, %d) error = %v; want error: %v", tt.good.Hash, tt.bad.Hash, tt.max, err, tt.wantErr)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("commitRange(%v, %v, %d) mismatch (-want +got):\n%s", tt.good.Hash, tt.bad.Hash, tt.max, diff)
+		}
+	}
+}
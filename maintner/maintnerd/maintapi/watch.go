@@ -0,0 +1,110 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintapi
+
+import (
+	"errors"
+
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// changeKinds maps the kinds of maintner change events to their kinds
+// in the API.
+var changeKinds = map[maintner.ChangeKind]apipb.ChangeKind{
+	maintner.IssueCreated:   apipb.ChangeKind_ISSUE_CREATED,
+	maintner.IssueClosed:    apipb.ChangeKind_ISSUE_CLOSED,
+	maintner.IssueReopened:  apipb.ChangeKind_ISSUE_REOPENED,
+	maintner.IssueLabeled:   apipb.ChangeKind_ISSUE_LABELED,
+	maintner.IssueUnlabeled: apipb.ChangeKind_ISSUE_UNLABELED,
+	maintner.IssueCommented: apipb.ChangeKind_ISSUE_COMMENTED,
+	maintner.CLCreated:      apipb.ChangeKind_CL_CREATED,
+	maintner.CLPatchSet:     apipb.ChangeKind_CL_PATCH_SET,
+	maintner.CLVote:         apipb.ChangeKind_CL_VOTE,
+	maintner.CLStatus:       apipb.ChangeKind_CL_STATUS,
+}
+
+func (s apiService) WatchMutations(req *apipb.WatchMutationsRequest, stream apipb.MaintnerService_WatchMutationsServer) error {
+	if req.StartOffset < 0 {
+		return grpc.Errorf(codes.InvalidArgument, "negative start offset")
+	}
+	want := newChangeFilter(req)
+	err := s.c.WatchChanges(stream.Context(), req.StartOffset, func(e *maintner.ChangeEvent) error {
+		ce := changeEventProto(e)
+		if !want(ce) {
+			return nil
+		}
+		return stream.Send(ce)
+	})
+	switch {
+	case errors.Is(err, maintner.ErrChangesExpired):
+		return grpc.Errorf(codes.OutOfRange, "the events after offset %d are no longer kept", req.StartOffset)
+	case errors.Is(err, maintner.ErrChangesAhead):
+		return grpc.Errorf(codes.InvalidArgument, "start offset %d is past the last mutation", req.StartOffset)
+	}
+	return err
+}
+
+// newChangeFilter returns a func reporting whether the change event ce
+// matches the filters of req.
+func newChangeFilter(req *apipb.WatchMutationsRequest) func(ce *apipb.ChangeEvent) bool {
+	repos, projects := stringSet(req.GithubRepos), stringSet(req.GerritProjects)
+	var kinds map[apipb.ChangeKind]bool
+	if len(req.Kinds) > 0 {
+		kinds = make(map[apipb.ChangeKind]bool)
+		for _, k := range req.Kinds {
+			kinds[k] = true
+		}
+	}
+	return func(ce *apipb.ChangeEvent) bool {
+		if kinds != nil && !kinds[ce.Kind] {
+			return false
+		}
+		if ce.GithubRepo != "" && repos != nil && !repos[ce.GithubRepo] {
+			return false
+		}
+		if ce.GerritProject != "" && projects != nil && !projects[ce.GerritProject] {
+			return false
+		}
+		return true
+	}
+}
+
+// stringSet returns the set of the strings in list, or nil if it's
+// empty.
+func stringSet(list []string) map[string]bool {
+	if len(list) == 0 {
+		return nil
+	}
+	set := make(map[string]bool)
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
+
+// changeEventProto returns the API form of the change event e.
+func changeEventProto(e *maintner.ChangeEvent) *apipb.ChangeEvent {
+	ce := &apipb.ChangeEvent{
+		Offset:        e.Mutation,
+		Kind:          changeKinds[e.Kind],
+		GerritProject: e.GerritProject,
+		Number:        e.Number,
+		Actor:         e.Actor,
+		Label:         e.Label,
+		Value:         int32(e.Value),
+		Version:       e.Version,
+		Status:        e.Status,
+	}
+	if !e.Time.IsZero() {
+		ce.TimeSec = e.Time.Unix()
+	}
+	if e.Kind.IsGitHub() {
+		ce.GithubRepo = e.GitHubRepo.String()
+	}
+	return ce
+}
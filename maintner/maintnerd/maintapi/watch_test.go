@@ -0,0 +1,113 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package maintapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintnerd/apipb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestChangeFilter(t *testing.T) {
+	events := []*maintner.ChangeEvent{
+		{Mutation: 1, Kind: maintner.IssueLabeled, GitHubRepo: maintner.GitHubRepoID{Owner: "golang", Repo: "go"}, Number: 1, Label: "NeedsFix"},
+		{Mutation: 2, Kind: maintner.IssueCreated, GitHubRepo: maintner.GitHubRepoID{Owner: "golang", Repo: "vscode-go"}, Number: 2},
+		{Mutation: 3, Kind: maintner.CLVote, GerritProject: "go.googlesource.com/go", Number: 3, Label: "Code-Review", Value: 2},
+		{Mutation: 4, Kind: maintner.CLCreated, GerritProject: "go.googlesource.com/net", Number: 4},
+	}
+	tests := []struct {
+		name string
+		req  *apipb.WatchMutationsRequest
+		want []int64 // offsets
+	}{
+		{"all", &apipb.WatchMutationsRequest{}, []int64{1, 2, 3, 4}},
+		{"github repo", &apipb.WatchMutationsRequest{GithubRepos: []string{"golang/go"}}, []int64{1, 3, 4}},
+		{"gerrit project", &apipb.WatchMutationsRequest{GerritProjects: []string{"go.googlesource.com/go"}}, []int64{1, 2, 3}},
+		{"kinds", &apipb.WatchMutationsRequest{Kinds: []apipb.ChangeKind{apipb.ChangeKind_CL_VOTE, apipb.ChangeKind_ISSUE_CREATED}}, []int64{2, 3}},
+		{"all filters", &apipb.WatchMutationsRequest{
+			GithubRepos:    []string{"golang/go"},
+			GerritProjects: []string{"go.googlesource.com/go"},
+			Kinds:          []apipb.ChangeKind{apipb.ChangeKind_CL_CREATED, apipb.ChangeKind_CL_VOTE},
+		}, []int64{3}},
+	}
+	for _, tt := range tests {
+		want := newChangeFilter(tt.req)
+		var got []int64
+		for _, e := range events {
+			if ce := changeEventProto(e); want(ce) {
+				got = append(got, ce.Offset)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got events %v; want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got events %v; want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestChangeEventProto(t *testing.T) {
+	ce := changeEventProto(&maintner.ChangeEvent{
+		Mutation:   7,
+		Kind:       maintner.IssueCommented,
+		Time:       time.Unix(1672531200, 0),
+		GitHubRepo: maintner.GitHubRepoID{Owner: "golang", Repo: "go"},
+		Number:     1,
+		Actor:      "gopher",
+	})
+	if ce.Offset != 7 || ce.Kind != apipb.ChangeKind_ISSUE_COMMENTED || ce.TimeSec != 1672531200 ||
+		ce.GithubRepo != "golang/go" || ce.GerritProject != "" || ce.Number != 1 || ce.Actor != "gopher" {
+		t.Errorf("changeEventProto = %v", ce)
+	}
+	for k := maintner.IssueCreated; k <= maintner.CLStatus; k++ {
+		if _, ok := changeKinds[k]; !ok {
+			t.Errorf("change kind %v isn't in the API", k)
+		}
+	}
+}
+
+// fakeWatchStream is a WatchMutations stream collecting the events sent.
+type fakeWatchStream struct {
+	apipb.MaintnerService_WatchMutationsServer
+	ctx    context.Context
+	events []*apipb.ChangeEvent
+}
+
+func (s *fakeWatchStream) Context() context.Context { return s.ctx }
+
+func (s *fakeWatchStream) Send(ce *apipb.ChangeEvent) error {
+	s.events = append(s.events, ce)
+	return nil
+}
+
+func TestWatchMutationsOffsets(t *testing.T) {
+	c := new(maintner.Corpus)
+	c.TrackChanges(10)
+	s := apiService{c: c}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for _, offset := range []int64{
+		-1,
+		1, // past the last mutation, as after a restart
+	} {
+		err := s.WatchMutations(&apipb.WatchMutationsRequest{StartOffset: offset}, &fakeWatchStream{ctx: ctx})
+		if code := grpc.Code(err); code != codes.InvalidArgument {
+			t.Errorf("WatchMutations from offset %d = %v; want code InvalidArgument", offset, err)
+		}
+	}
+	if err := s.WatchMutations(&apipb.WatchMutationsRequest{}, &fakeWatchStream{ctx: ctx}); err != context.DeadlineExceeded {
+		t.Errorf("WatchMutations without new changes = %v; want it to wait", err)
+	}
+}
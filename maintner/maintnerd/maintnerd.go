@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"cloud.google.com/go/compute/metadata"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"golang.org/x/build/internal/gitauth"
 	"golang.org/x/build/internal/https"
 	"golang.org/x/build/internal/secret"
@@ -30,6 +32,7 @@ import (
 	"golang.org/x/build/maintner/maintnerd/apipb"
 	"golang.org/x/build/maintner/maintnerd/gcslog"
 	"golang.org/x/build/maintner/maintnerd/maintapi"
+	"golang.org/x/build/maintner/maintnerd/s3log"
 	"golang.org/x/build/repos"
 	"golang.org/x/crypto/acme/autocert"
 	"golang.org/x/time/rate"
@@ -48,9 +51,12 @@ var (
 	dataDir         = flag.String("data-dir", "", "Local directory to write protobuf files to (default $HOME/var/maintnerd)")
 	debug           = flag.Bool("debug", false, "Print debug logging information")
 	githubRateLimit = flag.Int("github-rate", 10, "Rate to limit GitHub requests (in queries per second, 0 is treated as unlimited)")
+	changeEvents    = flag.Int("change-events", 100000, "Number of recent change events, such as CLs created or issues labeled, to keep for the WatchMutations RPC (0 disables it)")
 
-	bucket         = flag.String("bucket", "", "if non-empty, Google Cloud Storage bucket to use for log storage. If the bucket name contains a \"/\", the part after the slash will be a prefix for the segments.")
-	migrateGCSFlag = flag.Bool("migrate-disk-to-gcs", false, "[dev] If true, migrate from disk-based logs to GCS logs on start-up, then quit.")
+	bucket      = flag.String("bucket", "", "if non-empty, Google Cloud Storage bucket to use for log storage. If the bucket name contains a \"/\", the part after the slash will be a prefix for the segments.")
+	s3Bucket    = flag.String("s3-bucket", "", "if non-empty, S3 bucket to use for log storage, like --bucket. Credentials and region come from the standard AWS environment variables and config files.")
+	s3Endpoint  = flag.String("s3-endpoint", "", "if non-empty, the URL of an S3-compatible service to use instead of AWS for --s3-bucket and s3:// stores")
+	migrateFrom = flag.String("migrate-from", "", "[dev] If non-empty, copy the mutation log from this store to the one given by --bucket, --s3-bucket or --data-dir, in segments of up to 16 MB, verifying each segment's SHA-224, then quit. The store is of the form gs://bucket[/prefix], s3://bucket[/prefix] or a local directory.")
 )
 
 func init() {
@@ -77,15 +83,30 @@ func main() {
 
 	if *dataDir == "" {
 		*dataDir = filepath.Join(os.Getenv("HOME"), "var", "maintnerd")
-		if *bucket == "" {
+		if *bucket == "" && *s3Bucket == "" {
 			if err := os.MkdirAll(*dataDir, 0755); err != nil {
 				log.Fatal(err)
 			}
 			log.Printf("Storing data in implicit directory %s", *dataDir)
 		}
 	}
-	if *migrateGCSFlag && *bucket == "" {
-		log.Fatalf("--bucket flag required with --migrate-disk-to-gcs")
+	if *bucket != "" && *s3Bucket != "" {
+		log.Fatalf("can't set both --bucket and --s3-bucket")
+	}
+	if *migrateFrom != "" {
+		src, err := openLogStore(ctx, *migrateFrom)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dst, err := openLogStore(ctx, logStoreName())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := maintner.CopyLog(ctx, dst, src, gcslog.TargetObjectSize); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		log.Printf("Success.")
+		return
 	}
 
 	type storage interface {
@@ -133,22 +154,27 @@ func main() {
 		log.Fatalf("unknown --config=%s", *config)
 	}
 	if *genMut {
-		if *bucket != "" {
+		if *bucket != "" || *s3Bucket != "" {
 			ctx := context.Background()
-			gl, err := gcslog.NewGCSLog(ctx, *bucket)
+			store, err := openLogStore(ctx, logStoreName())
 			if err != nil {
-				log.Fatalf("newGCSLog: %v", err)
+				log.Fatal(err)
 			}
-			gl.SetDebug(*debug)
-			gl.RegisterHandlers(http.DefaultServeMux)
-			if *migrateGCSFlag {
-				diskLog := maintner.NewDiskMutationLogger(*dataDir)
-				if err := gl.CopyFrom(diskLog); err != nil {
-					log.Fatalf("migrate: %v", err)
+			// Clean up after a leader which died while replacing a
+			// segment.
+			if s, ok := store.(interface {
+				DeleteStaleSegments(context.Context) error
+			}); ok {
+				if err := s.DeleteStaleSegments(ctx); err != nil {
+					log.Fatal(err)
 				}
-				log.Printf("Success.")
-				return
 			}
+			gl, err := gcslog.NewLog(ctx, store)
+			if err != nil {
+				log.Fatalf("gcslog.NewLog: %v", err)
+			}
+			gl.SetDebug(*debug)
+			gl.RegisterHandlers(http.DefaultServeMux)
 			logger = gl
 		} else {
 			logger = maintner.NewDiskMutationLogger(*dataDir)
@@ -221,6 +247,10 @@ func main() {
 		corpus.StartPubSubHelperSubscribe(*pubsub)
 	}
 
+	if *changeEvents > 0 {
+		corpus.TrackChanges(*changeEvents)
+	}
+
 	grpcServer := grpc.NewServer()
 	apipb.RegisterMaintnerServiceServer(grpcServer, maintapi.NewAPIService(corpus))
 	http.Handle("/apipb.MaintnerService/", grpcServer)
@@ -255,6 +285,42 @@ func main() {
 	log.Fatalln(https.ListenAndServe(ctx, http.DefaultServeMux))
 }
 
+// logStoreName returns the name of the log store given by the
+// --bucket, --s3-bucket and --data-dir flags, for openLogStore.
+func logStoreName() string {
+	switch {
+	case *bucket != "":
+		return "gs://" + *bucket
+	case *s3Bucket != "":
+		return "s3://" + *s3Bucket
+	}
+	return *dataDir
+}
+
+// openLogStore opens the mutation log store named by name, which is of
+// the form gs://bucket[/prefix], s3://bucket[/prefix] or a local
+// directory.
+func openLogStore(ctx context.Context, name string) (maintner.LogStore, error) {
+	switch {
+	case strings.HasPrefix(name, "gs://"):
+		return gcslog.NewGCSStore(ctx, strings.TrimPrefix(name, "gs://"))
+	case strings.HasPrefix(name, "s3://"):
+		cfg := aws.NewConfig()
+		if *s3Endpoint != "" {
+			cfg = cfg.WithEndpoint(*s3Endpoint).WithS3ForcePathStyle(true)
+		}
+		sess, err := session.NewSessionWithOptions(session.Options{
+			Config:            *cfg,
+			SharedConfigState: session.SharedConfigEnable,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating AWS session: %v", err)
+		}
+		return s3log.NewS3Store(sess, strings.TrimPrefix(name, "s3://")), nil
+	}
+	return maintner.NewDiskMutationLogger(name), nil
+}
+
 func setGoConfig() {
 	if *watchGithub != "" {
 		log.Fatalf("can't set both --config and --watch-github")
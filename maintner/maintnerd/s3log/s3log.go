@@ -0,0 +1,244 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package s3log is an implementation of maintner.LogStore for Amazon S3
+// and S3-compatible object stores.
+//
+// Use gcslog.NewLog to log mutations to an S3Store.
+package s3log
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"golang.org/x/build/maintner"
+)
+
+var _ maintner.LogStore = &S3Store{}
+
+// S3Store is a maintner.LogStore that keeps segments as objects named
+// "NNNN.<sha224>.mutlog" in an S3 bucket, the same layout that
+// gcslog.GCSStore uses in GCS.
+//
+// Segments have no public URL; a GCSLog serves them itself.
+type S3Store struct {
+	client        *s3.S3
+	bucketName    string
+	segmentPrefix string
+
+	mu  sync.Mutex
+	obj map[int]string // segment number => object key, as of last list or write
+}
+
+// NewS3Store returns an S3Store for the given bucket, using the
+// configuration of sess (typically a *session.Session), which may set
+// an endpoint for an S3-compatible service. If the bucket name contains
+// a "/", the part after the slash will be a prefix for the segments.
+func NewS3Store(sess client.ConfigProvider, bucketName string) *S3Store {
+	prefix := ""
+	if f := strings.SplitN(bucketName, "/", 2); len(f) > 1 {
+		bucketName, prefix = f[0], f[1]
+	}
+	return &S3Store{
+		client:        s3.New(sess),
+		bucketName:    bucketName,
+		segmentPrefix: prefix,
+		obj:           map[int]string{},
+	}
+}
+
+// objNameRx is used to identify a mutation log file by suffix.
+var objnameRx = regexp.MustCompile(`(\d{4})\.([0-9a-f]{56})\.mutlog$`)
+
+type s3Object struct {
+	key      string
+	seg      maintner.LogSegmentJSON
+	modified time.Time
+}
+
+// Segments implements maintner.LogStore. If a segment number has
+// several objects, as happens if a process dies while replacing a
+// segment, the newest is used. DeleteStaleSegments deletes the others.
+func (s *S3Store) Segments(ctx context.Context) ([]maintner.LogSegmentJSON, error) {
+	latest, _, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	segs := make([]maintner.LogSegmentJSON, 0, len(latest))
+	for _, o := range latest {
+		segs = append(segs, o.seg)
+	}
+	sort.Slice(segs, func(i, j int) bool { return segs[i].Number < segs[j].Number })
+	for i, seg := range segs {
+		if seg.Number != i {
+			return nil, fmt.Errorf("saw max segment number %d but missing segment %d", segs[len(segs)-1].Number, i)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.obj = map[int]string{}
+	for n, o := range latest {
+		s.obj[n] = o.key
+	}
+	return segs, nil
+}
+
+// DeleteStaleSegments deletes the objects of segments which have been
+// replaced by a newer object for the same segment number, but which
+// weren't deleted, as happens if a process dies while replacing a
+// segment. Only the process writing the log should call it.
+func (s *S3Store) DeleteStaleSegments(ctx context.Context) error {
+	_, stale, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	for _, key := range stale {
+		s.deleteOldSegment(ctx, key)
+	}
+	return nil
+}
+
+// list lists the segment objects in the bucket, returning the newest
+// object of each segment number and the keys of the older ones.
+func (s *S3Store) list(ctx context.Context) (latest map[int]s3Object, stale []string, err error) {
+	var objs []*s3.Object
+	err = s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucketName),
+		Prefix: aws.String(s.segmentPrefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		objs = append(objs, page.Contents...)
+		return true
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing %s bucket: %v", s.bucketName, err)
+	}
+
+	latest = map[int]s3Object{}
+	for _, obj := range objs {
+		key := aws.StringValue(obj.Key)
+		m := objnameRx.FindStringSubmatch(key)
+		if m == nil {
+			log.Printf("Ignoring unrecognized S3 object %q", key)
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		o := s3Object{
+			key: key,
+			seg: maintner.LogSegmentJSON{
+				Number: n,
+				Size:   aws.Int64Value(obj.Size),
+				SHA224: m[2],
+			},
+			modified: aws.TimeValue(obj.LastModified),
+		}
+		prev, ok := latest[n]
+		if ok && !prev.modified.Before(o.modified) {
+			prev, o = o, prev
+		}
+		latest[n] = o
+		if ok {
+			stale = append(stale, prev.key)
+		}
+	}
+	return latest, stale, nil
+}
+
+// ReadSegment implements maintner.LogStore.
+func (s *S3Store) ReadSegment(ctx context.Context, n int) (io.ReadCloser, error) {
+	key, err := s.objectKey(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, fmt.Errorf("segment %d not found", n)
+	}
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// WriteSegment implements maintner.LogStore. Once the new object is
+// written, the segment's previous object, if any, is deleted.
+func (s *S3Store) WriteSegment(ctx context.Context, n int, data []byte) (maintner.LogSegmentJSON, error) {
+	old, err := s.objectKey(ctx, n)
+	if err != nil {
+		return maintner.LogSegmentJSON{}, err
+	}
+	seg := maintner.LogSegmentJSON{
+		Number: n,
+		Size:   int64(len(data)),
+		SHA224: fmt.Sprintf("%x", sha256.Sum224(data)),
+	}
+	key := path.Join(s.segmentPrefix, fmt.Sprintf("%04d.%s.mutlog", n, seg.SHA224))
+	_, err = s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/octet-stream"),
+	})
+	if err != nil {
+		return maintner.LogSegmentJSON{}, err
+	}
+
+	s.mu.Lock()
+	s.obj[n] = key
+	s.mu.Unlock()
+
+	// Delete any old segment from the same position.
+	if old != "" && old != key {
+		s.deleteOldSegment(ctx, old)
+	}
+	return seg, nil
+}
+
+// objectKey returns the key of the object holding segment n,
+// or the empty string if there is no such segment yet.
+func (s *S3Store) objectKey(ctx context.Context, n int) (string, error) {
+	s.mu.Lock()
+	key, ok := s.obj[n]
+	loaded := len(s.obj) > 0
+	s.mu.Unlock()
+	if ok || loaded {
+		return key, nil
+	}
+	if _, err := s.Segments(ctx); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.obj[n], nil
+}
+
+func (s *S3Store) deleteOldSegment(ctx context.Context, key string) {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		// Not critical; it'll be deleted by new versions or next start-up.
+		log.Printf("Warning: error deleting old segment version %v: %v", key, err)
+	} else {
+		log.Printf("deleted old segment version %v", key)
+	}
+}
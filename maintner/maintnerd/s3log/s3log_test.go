@@ -0,0 +1,336 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3log
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"golang.org/x/build/maintner"
+	"golang.org/x/build/maintner/maintnerd/gcslog"
+	"golang.org/x/build/maintner/maintpb"
+)
+
+// fakeS3 is a minimal stand-in for an S3-compatible server, holding a
+// single bucket and serving path-style requests.
+type fakeS3 struct {
+	bucket string
+
+	mu   sync.Mutex
+	objs map[string]fakeObject // by key
+	now  time.Time             // modification time of the next object
+}
+
+type fakeObject struct {
+	data     []byte
+	modified time.Time
+}
+
+func newFakeS3(bucket string) *fakeS3 {
+	return &fakeS3{
+		bucket: bucket,
+		objs:   map[string]fakeObject{},
+		now:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *fakeS3) put(key string, data []byte) {
+	s.objs[key] = fakeObject{data: data, modified: s.now}
+	s.now = s.now.Add(time.Second)
+}
+
+func (s *fakeS3) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for k := range s.objs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		http.Error(w, "unsigned request", http.StatusForbidden)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if path == s.bucket && r.Method == "GET" && r.FormValue("list-type") == "2" {
+		type content struct {
+			Key          string
+			Size         int
+			LastModified string
+		}
+		res := struct {
+			XMLName     xml.Name `xml:"ListBucketResult"`
+			Name        string
+			IsTruncated bool
+			Contents    []content
+		}{Name: s.bucket}
+		for k, o := range s.objs {
+			if strings.HasPrefix(k, r.FormValue("prefix")) {
+				res.Contents = append(res.Contents, content{k, len(o.data), o.modified.Format(time.RFC3339)})
+			}
+		}
+		sort.Slice(res.Contents, func(i, j int) bool { return res.Contents[i].Key < res.Contents[j].Key })
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(res)
+		return
+	}
+	key := strings.TrimPrefix(path, s.bucket+"/")
+	if key == path {
+		http.Error(w, "no such bucket", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		o, ok := s.objs[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		w.Write(o.data)
+	case "PUT":
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.put(key, data)
+	case "DELETE":
+		delete(s.objs, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "bad method", http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestStore(t *testing.T, fake *fakeS3, bucket string) *S3Store {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("us-east-1"),
+		Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewS3Store(sess, bucket)
+}
+
+func segName(n int, data string) string {
+	return fmt.Sprintf("logs/%04d.%x.mutlog", n, sha256.Sum224([]byte(data)))
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3("maintner")
+	fake.put("logs/README", []byte("not a segment"))
+	fake.put(segName(0, "old"), []byte("old"))
+	fake.put(segName(0, "new"), []byte("new"))
+	s := newTestStore(t, fake, "maintner/logs")
+
+	segs, err := s.Segments(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []maintner.LogSegmentJSON{{Number: 0, Size: 3, SHA224: fmt.Sprintf("%x", sha256.Sum224([]byte("new")))}}
+	if !reflect.DeepEqual(segs, want) {
+		t.Errorf("Segments = %+v; want %+v", segs, want)
+	}
+	if got, want := fake.keys(), []string{segName(0, "new"), segName(0, "old"), "logs/README"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after Segments, objects = %q; want %q (nothing deleted)", got, want)
+	}
+	if err := s.DeleteStaleSegments(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fake.keys(), []string{segName(0, "new"), "logs/README"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after DeleteStaleSegments, objects = %q; want %q (old version deleted)", got, want)
+	}
+
+	seg, err := s.WriteSegment(ctx, 0, []byte("newer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seg.Size != 5 || seg.SHA224 != fmt.Sprintf("%x", sha256.Sum224([]byte("newer"))) {
+		t.Errorf("WriteSegment = %+v", seg)
+	}
+	if _, err := s.WriteSegment(ctx, 1, []byte("second")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fake.keys(), []string{segName(0, "newer"), segName(1, "second"), "logs/README"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after writes, objects = %q; want %q", got, want)
+	}
+	rc, err := s.ReadSegment(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "second" {
+		t.Errorf("ReadSegment(1) = %q, %v; want second", data, err)
+	}
+	if _, err := s.ReadSegment(ctx, 2); err == nil {
+		t.Errorf("ReadSegment(2) succeeded; want error")
+	}
+}
+
+func TestMigrateAndLog(t *testing.T) {
+	ctx := context.Background()
+	disk := maintner.NewDiskMutationLogger(t.TempDir())
+	for i := 1; i <= 3; i++ {
+		if err := disk.Log(&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: int32(i)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake := newFakeS3("maintner")
+	s := newTestStore(t, fake, "maintner")
+	if err := maintner.CopyLog(ctx, s, disk, gcslog.TargetObjectSize); err != nil {
+		t.Fatalf("CopyLog: %v", err)
+	}
+	// Copying again is a no-op.
+	if err := maintner.CopyLog(ctx, s, disk, gcslog.TargetObjectSize); err != nil {
+		t.Fatalf("second CopyLog: %v", err)
+	}
+	if n := len(fake.keys()); n != 1 {
+		t.Errorf("got %d objects; want 1", n)
+	}
+
+	gl, err := gcslog.NewLog(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gl.Log(&maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: 4}}); err != nil {
+		t.Fatal(err)
+	}
+	var nums []int32
+	for e := range gl.GetMutations(ctx) {
+		if e.Err != nil {
+			t.Fatal(e.Err)
+		}
+		if e.End {
+			break
+		}
+		nums = append(nums, e.Mutation.GithubIssue.Number)
+	}
+	// The fourth mutation is still buffered, not yet in the store.
+	if want := []int32{1, 2, 3}; !reflect.DeepEqual(nums, want) {
+		t.Errorf("mutations = %v; want %v", nums, want)
+	}
+}
+
+// TestNetworkSync checks that a client of maintnerd serving a log
+// stored in S3 keeps the completed segments it downloaded and only
+// fetches the new data of the growing one after a restart.
+func TestNetworkSync(t *testing.T) {
+	ctx := context.Background()
+	disk := maintner.NewDiskMutationLogger(t.TempDir())
+	mut := func(n int32) *maintpb.Mutation {
+		return &maintpb.Mutation{GithubIssue: &maintpb.GithubIssueMutation{Owner: "golang", Repo: "go", Number: n}}
+	}
+	for i := int32(1); i <= 2; i++ {
+		if err := disk.Log(mut(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := newTestStore(t, newFakeS3("maintner"), "maintner")
+	// Put each mutation in a segment of its own. The log appends to
+	// the last one, so segment 0 is complete and 1 is growing.
+	if err := maintner.CopyLog(ctx, s, disk, 1); err != nil {
+		t.Fatal(err)
+	}
+	gl, err := gcslog.NewLog(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gl.Log(mut(3)); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu       sync.Mutex
+		requests []string // segment requests, with their ranges
+	)
+	mux := http.NewServeMux()
+	gl.RegisterHandlers(mux)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs" {
+			mu.Lock()
+			requests = append(requests, r.URL.Path+" "+r.Header.Get("Range"))
+			mu.Unlock()
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	syncClient := func(want ...int32) {
+		t.Helper()
+		mu.Lock()
+		requests = nil
+		mu.Unlock()
+		var nums []int32
+		src := maintner.NewNetworkMutationSource(server.URL+"/logs", cacheDir)
+		for e := range src.GetMutations(ctx) {
+			if e.Err != nil {
+				t.Fatal(e.Err)
+			}
+			if e.End {
+				break
+			}
+			nums = append(nums, e.Mutation.GithubIssue.Number)
+		}
+		if !reflect.DeepEqual(nums, want) {
+			t.Errorf("mutations = %v; want %v", nums, want)
+		}
+	}
+
+	syncClient(1, 2, 3)
+	var growing []string
+	files, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range files {
+		if strings.HasSuffix(fi.Name(), ".growing.mutlog") {
+			growing = append(growing, fi.Name())
+		}
+	}
+	if want := []string{"0001.growing.mutlog"}; !reflect.DeepEqual(growing, want) {
+		t.Errorf("growing segments in the cache = %q; want %q", growing, want)
+	}
+
+	// A restarted client reads the rest from its cache and only
+	// downloads the new data.
+	if err := gl.Log(mut(4)); err != nil {
+		t.Fatal(err)
+	}
+	syncClient(1, 2, 3, 4)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 || !strings.HasPrefix(requests[0], "/logs/1 bytes=") || strings.HasPrefix(requests[0], "/logs/1 bytes=0-") {
+		t.Errorf("segment requests after restart = %q; want one for the new data of /logs/1", requests)
+	}
+}
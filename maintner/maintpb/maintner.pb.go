@@ -32,6 +32,9 @@ It has these top-level messages:
 	GitDiffTreeFile
 	GerritMutation
 	GitRef
+	GithubPullRequestMutation
+	GithubPullRequestCommit
+	GithubPullRequestFile
 */
 package maintpb
 
@@ -175,6 +178,9 @@ type GithubIssueMutation struct {
 	EventStatus    *GithubIssueSyncStatus        `protobuf:"bytes,27,opt,name=event_status,json=eventStatus" json:"event_status,omitempty"`
 	Review         []*GithubReview               `protobuf:"bytes,29,rep,name=review" json:"review,omitempty"`
 	ReviewStatus   *GithubIssueSyncStatus        `protobuf:"bytes,30,opt,name=review_status,json=reviewStatus" json:"review_status,omitempty"`
+	// pull_request_change updates the branches, mergeability, commits
+	// and files of a pull request.
+	PullRequestChange *GithubPullRequestMutation `protobuf:"bytes,32,opt,name=pull_request_change,json=pullRequestChange" json:"pull_request_change,omitempty"`
 }
 
 func (m *GithubIssueMutation) Reset()                    { *m = GithubIssueMutation{} }
@@ -392,6 +398,13 @@ func (m *GithubIssueMutation) GetReviewStatus() *GithubIssueSyncStatus {
 	return nil
 }
 
+func (m *GithubIssueMutation) GetPullRequestChange() *GithubPullRequestMutation {
+	if m != nil {
+		return m.PullRequestChange
+	}
+	return nil
+}
+
 // BoolChange represents a change to a boolean value.
 // (Notably, the wrapper type permits representing a change to false.)
 type BoolChange struct {
@@ -1124,6 +1137,263 @@ func (m *GitRef) GetSha1() string {
 	return ""
 }
 
+// GithubPullRequestMutation updates the pull request state of a GitHub
+// issue. Empty fields are unchanged.
+type GithubPullRequestMutation struct {
+	HeadRepo       string                     `protobuf:"bytes,1,opt,name=head_repo,json=headRepo" json:"head_repo,omitempty"`
+	HeadRef        string                     `protobuf:"bytes,2,opt,name=head_ref,json=headRef" json:"head_ref,omitempty"`
+	HeadSha        string                     `protobuf:"bytes,3,opt,name=head_sha,json=headSha" json:"head_sha,omitempty"`
+	BaseRef        string                     `protobuf:"bytes,4,opt,name=base_ref,json=baseRef" json:"base_ref,omitempty"`
+	BaseSha        string                     `protobuf:"bytes,5,opt,name=base_sha,json=baseSha" json:"base_sha,omitempty"`
+	Mergeable      *BoolChange                `protobuf:"bytes,6,opt,name=mergeable" json:"mergeable,omitempty"`
+	MergeableState string                     `protobuf:"bytes,7,opt,name=mergeable_state,json=mergeableState" json:"mergeable_state,omitempty"`
+	Merged         *BoolChange                `protobuf:"bytes,8,opt,name=merged" json:"merged,omitempty"`
+	MergedAt       *google_protobuf.Timestamp `protobuf:"bytes,9,opt,name=merged_at,json=mergedAt" json:"merged_at,omitempty"`
+	// If commit_status is set, commit replaces the commits of the pull
+	// request, oldest first, as of head_sha.
+	Commit       []*GithubPullRequestCommit `protobuf:"bytes,10,rep,name=commit" json:"commit,omitempty"`
+	CommitStatus *GithubIssueSyncStatus     `protobuf:"bytes,11,opt,name=commit_status,json=commitStatus" json:"commit_status,omitempty"`
+	// If file_status is set, file replaces the files changed by the
+	// pull request, as of head_sha.
+	File       []*GithubPullRequestFile `protobuf:"bytes,12,rep,name=file" json:"file,omitempty"`
+	FileStatus *GithubIssueSyncStatus   `protobuf:"bytes,13,opt,name=file_status,json=fileStatus" json:"file_status,omitempty"`
+	// sync_status notes when the pull request was last synced.
+	SyncStatus *GithubIssueSyncStatus `protobuf:"bytes,14,opt,name=sync_status,json=syncStatus" json:"sync_status,omitempty"`
+}
+
+func (m *GithubPullRequestMutation) Reset()                    { *m = GithubPullRequestMutation{} }
+func (m *GithubPullRequestMutation) String() string            { return proto.CompactTextString(m) }
+func (*GithubPullRequestMutation) ProtoMessage()               {}
+func (*GithubPullRequestMutation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *GithubPullRequestMutation) GetHeadRepo() string {
+	if m != nil {
+		return m.HeadRepo
+	}
+	return ""
+}
+
+func (m *GithubPullRequestMutation) GetHeadRef() string {
+	if m != nil {
+		return m.HeadRef
+	}
+	return ""
+}
+
+func (m *GithubPullRequestMutation) GetHeadSha() string {
+	if m != nil {
+		return m.HeadSha
+	}
+	return ""
+}
+
+func (m *GithubPullRequestMutation) GetBaseRef() string {
+	if m != nil {
+		return m.BaseRef
+	}
+	return ""
+}
+
+func (m *GithubPullRequestMutation) GetBaseSha() string {
+	if m != nil {
+		return m.BaseSha
+	}
+	return ""
+}
+
+func (m *GithubPullRequestMutation) GetMergeable() *BoolChange {
+	if m != nil {
+		return m.Mergeable
+	}
+	return nil
+}
+
+func (m *GithubPullRequestMutation) GetMergeableState() string {
+	if m != nil {
+		return m.MergeableState
+	}
+	return ""
+}
+
+func (m *GithubPullRequestMutation) GetMerged() *BoolChange {
+	if m != nil {
+		return m.Merged
+	}
+	return nil
+}
+
+func (m *GithubPullRequestMutation) GetMergedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.MergedAt
+	}
+	return nil
+}
+
+func (m *GithubPullRequestMutation) GetCommit() []*GithubPullRequestCommit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
+func (m *GithubPullRequestMutation) GetCommitStatus() *GithubIssueSyncStatus {
+	if m != nil {
+		return m.CommitStatus
+	}
+	return nil
+}
+
+func (m *GithubPullRequestMutation) GetFile() []*GithubPullRequestFile {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *GithubPullRequestMutation) GetFileStatus() *GithubIssueSyncStatus {
+	if m != nil {
+		return m.FileStatus
+	}
+	return nil
+}
+
+func (m *GithubPullRequestMutation) GetSyncStatus() *GithubIssueSyncStatus {
+	if m != nil {
+		return m.SyncStatus
+	}
+	return nil
+}
+
+// GithubPullRequestCommit is a commit of a pull request.
+type GithubPullRequestCommit struct {
+	Sha            string                     `protobuf:"bytes,1,opt,name=sha" json:"sha,omitempty"`
+	Author         *GithubUser                `protobuf:"bytes,2,opt,name=author" json:"author,omitempty"`
+	AuthorName     string                     `protobuf:"bytes,3,opt,name=author_name,json=authorName" json:"author_name,omitempty"`
+	AuthorEmail    string                     `protobuf:"bytes,4,opt,name=author_email,json=authorEmail" json:"author_email,omitempty"`
+	AuthorTime     *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=author_time,json=authorTime" json:"author_time,omitempty"`
+	CommitterName  string                     `protobuf:"bytes,6,opt,name=committer_name,json=committerName" json:"committer_name,omitempty"`
+	CommitterEmail string                     `protobuf:"bytes,7,opt,name=committer_email,json=committerEmail" json:"committer_email,omitempty"`
+	CommitTime     *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=commit_time,json=commitTime" json:"commit_time,omitempty"`
+	Message        string                     `protobuf:"bytes,9,opt,name=message" json:"message,omitempty"`
+	ParentSha      []string                   `protobuf:"bytes,10,rep,name=parent_sha,json=parentSha" json:"parent_sha,omitempty"`
+}
+
+func (m *GithubPullRequestCommit) Reset()                    { *m = GithubPullRequestCommit{} }
+func (m *GithubPullRequestCommit) String() string            { return proto.CompactTextString(m) }
+func (*GithubPullRequestCommit) ProtoMessage()               {}
+func (*GithubPullRequestCommit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *GithubPullRequestCommit) GetSha() string {
+	if m != nil {
+		return m.Sha
+	}
+	return ""
+}
+
+func (m *GithubPullRequestCommit) GetAuthor() *GithubUser {
+	if m != nil {
+		return m.Author
+	}
+	return nil
+}
+
+func (m *GithubPullRequestCommit) GetAuthorName() string {
+	if m != nil {
+		return m.AuthorName
+	}
+	return ""
+}
+
+func (m *GithubPullRequestCommit) GetAuthorEmail() string {
+	if m != nil {
+		return m.AuthorEmail
+	}
+	return ""
+}
+
+func (m *GithubPullRequestCommit) GetAuthorTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.AuthorTime
+	}
+	return nil
+}
+
+func (m *GithubPullRequestCommit) GetCommitterName() string {
+	if m != nil {
+		return m.CommitterName
+	}
+	return ""
+}
+
+func (m *GithubPullRequestCommit) GetCommitterEmail() string {
+	if m != nil {
+		return m.CommitterEmail
+	}
+	return ""
+}
+
+func (m *GithubPullRequestCommit) GetCommitTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CommitTime
+	}
+	return nil
+}
+
+func (m *GithubPullRequestCommit) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *GithubPullRequestCommit) GetParentSha() []string {
+	if m != nil {
+		return m.ParentSha
+	}
+	return nil
+}
+
+// GithubPullRequestFile is a file changed by a pull request.
+type GithubPullRequestFile struct {
+	Filename  string `protobuf:"bytes,1,opt,name=filename" json:"filename,omitempty"`
+	Status    string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	Additions int32  `protobuf:"varint,3,opt,name=additions" json:"additions,omitempty"`
+	Deletions int32  `protobuf:"varint,4,opt,name=deletions" json:"deletions,omitempty"`
+}
+
+func (m *GithubPullRequestFile) Reset()                    { *m = GithubPullRequestFile{} }
+func (m *GithubPullRequestFile) String() string            { return proto.CompactTextString(m) }
+func (*GithubPullRequestFile) ProtoMessage()               {}
+func (*GithubPullRequestFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *GithubPullRequestFile) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *GithubPullRequestFile) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *GithubPullRequestFile) GetAdditions() int32 {
+	if m != nil {
+		return m.Additions
+	}
+	return 0
+}
+
+func (m *GithubPullRequestFile) GetDeletions() int32 {
+	if m != nil {
+		return m.Deletions
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Mutation)(nil), "maintpb.Mutation")
 	proto.RegisterType((*GithubMutation)(nil), "maintpb.GithubMutation")
@@ -1147,107 +1417,134 @@ func init() {
 	proto.RegisterType((*GitDiffTreeFile)(nil), "maintpb.GitDiffTreeFile")
 	proto.RegisterType((*GerritMutation)(nil), "maintpb.GerritMutation")
 	proto.RegisterType((*GitRef)(nil), "maintpb.GitRef")
+	proto.RegisterType((*GithubPullRequestMutation)(nil), "maintpb.GithubPullRequestMutation")
+	proto.RegisterType((*GithubPullRequestCommit)(nil), "maintpb.GithubPullRequestCommit")
+	proto.RegisterType((*GithubPullRequestFile)(nil), "maintpb.GithubPullRequestFile")
 }
 
 func init() { proto.RegisterFile("maintner.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1540 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x6d, 0x6f, 0xdb, 0xb6,
-	0x13, 0x87, 0x9f, 0xa5, 0xb3, 0x93, 0xb8, 0x4c, 0xd3, 0xb2, 0xe9, 0x53, 0xfe, 0xea, 0x1f, 0x6b,
-	0xd0, 0x07, 0xa7, 0xed, 0x86, 0xae, 0x40, 0x31, 0x0c, 0x69, 0xda, 0x0e, 0x2e, 0xd6, 0xbe, 0x60,
-	0xd3, 0xd7, 0x82, 0x6c, 0xd1, 0x8e, 0x3a, 0x49, 0xf4, 0x24, 0x3a, 0x5d, 0x80, 0xbd, 0xea, 0xd7,
-	0xd8, 0x8b, 0x0d, 0xfb, 0x4e, 0xfb, 0x3e, 0x03, 0x8f, 0xa4, 0x24, 0x3b, 0x4e, 0x93, 0xee, 0x1d,
-	0xef, 0xee, 0x77, 0xe4, 0x1d, 0xef, 0x89, 0x84, 0xf5, 0x24, 0x88, 0x52, 0x99, 0xf2, 0x6c, 0x30,
-	0xcb, 0x84, 0x14, 0xa4, 0x83, 0xf4, 0x6c, 0xb4, 0xfd, 0x7c, 0x1a, 0xc9, 0xa3, 0xf9, 0x68, 0x30,
-	0x16, 0xc9, 0xde, 0x54, 0xc4, 0x41, 0x3a, 0xdd, 0x43, 0xc4, 0x68, 0x3e, 0xd9, 0x9b, 0xc9, 0x93,
-	0x19, 0xcf, 0xf7, 0x64, 0x94, 0xf0, 0x5c, 0x06, 0xc9, 0xac, 0x5c, 0xe9, 0x5d, 0xbc, 0x7f, 0x6a,
-	0xe0, 0xbc, 0x9d, 0xcb, 0x40, 0x46, 0x22, 0x25, 0x3f, 0x42, 0x4f, 0xef, 0xe5, 0x47, 0x79, 0x3e,
-	0xe7, 0xb4, 0xb6, 0x53, 0xdb, 0xed, 0x3e, 0xb9, 0x31, 0x30, 0x27, 0x0d, 0x7e, 0x42, 0xe1, 0x50,
-	0xc9, 0xac, 0x0e, 0xeb, 0x4e, 0x4b, 0x26, 0xd9, 0x83, 0xb6, 0x26, 0x69, 0x03, 0x55, 0xaf, 0x2e,
-	0xa9, 0x16, 0x5a, 0x06, 0x46, 0xbe, 0x81, 0xc6, 0x34, 0x92, 0xb4, 0x8e, 0xe8, 0xcb, 0x55, 0x74,
-	0x01, 0x55, 0x00, 0xdc, 0x98, 0x67, 0x59, 0x24, 0x69, 0x73, 0x79, 0x63, 0x64, 0x57, 0x36, 0x46,
-	0xda, 0xfb, 0xbb, 0x06, 0xeb, 0x8b, 0x67, 0x92, 0xcb, 0xd0, 0x12, 0x9f, 0x52, 0x9e, 0xa1, 0x5b,
-	0x2e, 0xd3, 0x04, 0x21, 0xd0, 0xcc, 0xf8, 0x4c, 0xa0, 0x09, 0x2e, 0xc3, 0x35, 0x79, 0x00, 0xed,
-	0x38, 0x18, 0xf1, 0x38, 0xa7, 0x8d, 0x9d, 0xc6, 0xb2, 0x61, 0x47, 0xf3, 0xd1, 0xcf, 0x4a, 0xc8,
-	0x0c, 0x86, 0x3c, 0x03, 0x48, 0xa2, 0x98, 0xe7, 0x52, 0xa4, 0x3c, 0xa7, 0x4d, 0xd4, 0xa0, 0xcb,
-	0x8e, 0x5b, 0x00, 0xab, 0x60, 0xbd, 0x3f, 0x00, 0x36, 0x57, 0xdc, 0xe9, 0x57, 0x58, 0x7a, 0x05,
-	0xda, 0xe9, 0x3c, 0x19, 0xf1, 0x0c, 0x2f, 0xbc, 0xc5, 0x0c, 0x45, 0xae, 0x83, 0x9b, 0x0a, 0xe9,
-	0xf3, 0xdf, 0xa2, 0x5c, 0xd2, 0xb5, 0x9d, 0xda, 0xae, 0xc3, 0x9c, 0x54, 0xc8, 0x57, 0x8a, 0x26,
-	0xeb, 0x50, 0x8f, 0x42, 0xda, 0xdb, 0xa9, 0xed, 0x36, 0x58, 0x3d, 0x0a, 0xc9, 0x5d, 0x68, 0xce,
-	0x73, 0x9e, 0x99, 0xab, 0xdd, 0x5c, 0x32, 0xfd, 0x43, 0xce, 0x33, 0x86, 0x00, 0xf2, 0x18, 0xdc,
-	0x20, 0xcf, 0xa3, 0x69, 0xca, 0x79, 0x4e, 0x01, 0x1d, 0x5d, 0x89, 0x2e, 0x51, 0xe4, 0x3e, 0x5c,
-	0x0a, 0x79, 0xcc, 0x25, 0x0f, 0xfd, 0x52, 0xb5, 0xbb, 0xd3, 0xd8, 0x6d, 0xb0, 0xbe, 0x11, 0xec,
-	0x17, 0xe0, 0xef, 0xa0, 0x33, 0xce, 0x78, 0x20, 0x79, 0x48, 0x5b, 0x68, 0xcb, 0xf6, 0x60, 0x2a,
-	0xc4, 0x34, 0xe6, 0x03, 0x9b, 0xd0, 0x83, 0x43, 0x9b, 0xbf, 0xcc, 0x42, 0x95, 0xd6, 0x7c, 0x16,
-	0xa2, 0x56, 0xfb, 0x7c, 0x2d, 0x03, 0x55, 0x77, 0x2c, 0x23, 0x19, 0x73, 0xea, 0xea, 0x3b, 0x46,
-	0x82, 0x3c, 0x85, 0xee, 0x48, 0x84, 0x27, 0xfe, 0xf8, 0x28, 0x48, 0xa7, 0x9c, 0xde, 0xc6, 0xfd,
-	0xb6, 0x0a, 0x1f, 0xdf, 0xcb, 0x2c, 0x4a, 0xa7, 0x07, 0x28, 0x64, 0xa0, 0x90, 0x7a, 0xad, 0x62,
-	0xa3, 0x28, 0xda, 0xd1, 0xb1, 0x51, 0x6b, 0xf2, 0x3f, 0xe8, 0xa5, 0xc2, 0x2f, 0xc2, 0x4d, 0x37,
-	0x30, 0x0c, 0xdd, 0x54, 0x14, 0xc9, 0xa0, 0x20, 0x85, 0xdc, 0x8f, 0x42, 0xda, 0xc7, 0x98, 0x74,
-	0x0b, 0xde, 0x30, 0x24, 0x77, 0x60, 0xad, 0x84, 0xa4, 0xf3, 0x84, 0x5e, 0x42, 0x4c, 0xa9, 0xf7,
-	0x6e, 0x9e, 0x90, 0xbb, 0xb0, 0x51, 0x82, 0xb4, 0x5b, 0x04, 0x2d, 0x59, 0x2f, 0xd8, 0x87, 0xe8,
-	0xdf, 0x7d, 0x68, 0x8f, 0x63, 0x91, 0xf3, 0x90, 0x6e, 0x2e, 0x05, 0xfb, 0x85, 0x10, 0xb1, 0x71,
-	0xcc, 0x40, 0x14, 0x38, 0x16, 0xe3, 0x5f, 0x78, 0x48, 0xaf, 0x7d, 0x01, 0xac, 0x21, 0xca, 0x95,
-	0xd9, 0x3c, 0x8e, 0xfd, 0x8c, 0xff, 0x3a, 0xe7, 0xb9, 0xa4, 0x37, 0xb4, 0xb7, 0x8a, 0xc7, 0x34,
-	0x8b, 0x7c, 0x0f, 0xae, 0xde, 0xd9, 0x0f, 0x24, 0xdd, 0x3a, 0x37, 0x54, 0x8e, 0x06, 0xef, 0x4b,
-	0xf2, 0xa8, 0x50, 0x1c, 0x9d, 0xd0, 0x2b, 0x67, 0x67, 0xa9, 0xd1, 0x78, 0x81, 0x77, 0x9f, 0xf1,
-	0x44, 0x1c, 0x73, 0x1f, 0x8b, 0x94, 0x5e, 0xc5, 0x8c, 0xeb, 0x6a, 0x1e, 0x96, 0x2f, 0x26, 0x73,
-	0x18, 0x1a, 0x39, 0xfd, 0x42, 0x9d, 0x3b, 0x41, 0x18, 0x6a, 0x95, 0x1f, 0xa0, 0x33, 0x16, 0x49,
-	0xc2, 0x53, 0x49, 0x1d, 0x54, 0xb8, 0xb3, 0xaa, 0x35, 0x1e, 0x68, 0x48, 0xd1, 0x92, 0xac, 0x0e,
-	0x79, 0x05, 0xeb, 0x66, 0xe9, 0xe7, 0x32, 0x90, 0xf3, 0x9c, 0xae, 0xa3, 0x2f, 0xb7, 0x56, 0xed,
-	0xf2, 0xfe, 0x24, 0x1d, 0xbf, 0x47, 0x14, 0x5b, 0x33, 0x5a, 0x9a, 0x24, 0x7b, 0xd0, 0xe2, 0xc7,
-	0xca, 0x86, 0x6d, 0xb4, 0xe1, 0xda, 0x2a, 0xed, 0x57, 0x0a, 0xc0, 0x34, 0x8e, 0xec, 0x43, 0x0f,
-	0x17, 0xf6, 0xd4, 0xeb, 0x17, 0x3a, 0xb5, 0x8b, 0x3a, 0xe6, 0xcc, 0x87, 0xd0, 0xce, 0xf8, 0x71,
-	0xc4, 0x3f, 0xd1, 0x9b, 0x78, 0xe8, 0xd6, 0x92, 0x32, 0x43, 0x21, 0x33, 0x20, 0x72, 0x00, 0x6b,
-	0x7a, 0x65, 0x8f, 0xbc, 0x75, 0xa1, 0x23, 0x7b, 0x5a, 0x49, 0x53, 0xde, 0x2d, 0x80, 0x32, 0xcf,
-	0x48, 0x1f, 0x1a, 0xc7, 0x41, 0x8c, 0x1d, 0xd1, 0x61, 0x6a, 0xe9, 0xed, 0x40, 0xaf, 0x5a, 0x8f,
-	0x55, 0x84, 0xab, 0x11, 0x8f, 0xa1, 0x5b, 0x09, 0xa4, 0xe9, 0x7b, 0xb5, 0xa2, 0xef, 0x11, 0x68,
-	0xa6, 0x41, 0xc2, 0x6d, 0x43, 0x55, 0x6b, 0xef, 0x77, 0xd8, 0x58, 0xea, 0xd8, 0xa7, 0xd4, 0x8a,
-	0xce, 0x51, 0xaf, 0x76, 0x8e, 0xb2, 0xb2, 0x1a, 0xe7, 0x57, 0x56, 0xd9, 0xb6, 0x9b, 0xb8, 0xad,
-	0xa1, 0xbc, 0x3f, 0x5b, 0xd0, 0x5f, 0x8e, 0xe2, 0xa9, 0xf3, 0x6f, 0x02, 0xe8, 0x70, 0xaa, 0xd9,
-	0x6e, 0x8c, 0x70, 0x91, 0x73, 0x78, 0x32, 0xe3, 0xe4, 0x1a, 0x38, 0xc1, 0x58, 0x8a, 0x4c, 0xf5,
-	0x93, 0x06, 0x2a, 0x75, 0x90, 0x1e, 0x86, 0xd5, 0xfe, 0xda, 0xbc, 0x78, 0x7f, 0xbd, 0x07, 0x2d,
-	0x5d, 0x24, 0xad, 0xd3, 0x53, 0xba, 0x28, 0x12, 0x0d, 0x21, 0x4f, 0xc1, 0x2d, 0x1b, 0x9e, 0xee,
-	0xc6, 0x67, 0x8f, 0xc2, 0x12, 0x4a, 0x6e, 0x43, 0xd7, 0x8e, 0x07, 0x65, 0x77, 0x07, 0xed, 0x06,
-	0xcb, 0x1a, 0x86, 0x15, 0x00, 0x3a, 0xe6, 0x2c, 0x00, 0x94, 0x6f, 0x0f, 0xa1, 0xad, 0xca, 0x24,
-	0x92, 0xd8, 0xd0, 0x4f, 0x67, 0xe8, 0x01, 0x0a, 0x99, 0x01, 0xa9, 0xfd, 0x32, 0xae, 0x22, 0xee,
-	0x4f, 0x32, 0x91, 0xd0, 0x2e, 0xde, 0x22, 0x68, 0xd6, 0xeb, 0x4c, 0x24, 0x6a, 0x82, 0x1a, 0x80,
-	0x14, 0x38, 0x2b, 0x5d, 0xe6, 0x68, 0xc6, 0xa1, 0xd0, 0xda, 0x2a, 0x55, 0xb5, 0x35, 0x6b, 0xda,
-	0x1a, 0xcb, 0x1a, 0x86, 0x64, 0x00, 0x9b, 0xa6, 0x00, 0x4c, 0x3f, 0xd4, 0xc0, 0x75, 0x04, 0x5e,
-	0xd2, 0x22, 0x66, 0x25, 0xc3, 0x90, 0x3c, 0x83, 0x35, 0xc9, 0x83, 0xc4, 0xb7, 0x5b, 0xe0, 0x24,
-	0x38, 0xdd, 0xe5, 0x0e, 0x79, 0x90, 0xb0, 0x9e, 0x42, 0x32, 0x03, 0x24, 0xef, 0xa0, 0x1f, 0x46,
-	0x79, 0x12, 0xe5, 0xaa, 0x3d, 0x9a, 0x1a, 0xdd, 0x40, 0xe5, 0xe5, 0xe6, 0xf4, 0xd2, 0xc2, 0xb4,
-	0xae, 0x6e, 0x11, 0x1b, 0xe1, 0x22, 0x57, 0x65, 0x97, 0x90, 0x47, 0x3c, 0xf3, 0x3f, 0xe6, 0x22,
-	0xa5, 0xb0, 0x53, 0xdb, 0xed, 0x31, 0x17, 0x39, 0x6f, 0x72, 0x91, 0x7a, 0x9f, 0x6b, 0xb0, 0x7d,
-	0xf6, 0x76, 0xfa, 0xd6, 0xd0, 0xef, 0x22, 0x65, 0x1d, 0xcd, 0x18, 0x86, 0xf8, 0x16, 0xd0, 0x4a,
-	0x41, 0xec, 0x27, 0x3c, 0xcf, 0x83, 0x29, 0xc7, 0x14, 0x75, 0x59, 0xbf, 0x10, 0xbc, 0xd5, 0x7c,
-	0x55, 0x65, 0xaa, 0x77, 0x70, 0xcc, 0x54, 0x97, 0x69, 0xe2, 0x4d, 0xd3, 0xa9, 0xf7, 0x1b, 0xde,
-	0x07, 0xe8, 0x55, 0x83, 0xfa, 0x15, 0xef, 0xa5, 0xeb, 0xe0, 0xea, 0x04, 0xb0, 0xd5, 0xe1, 0x32,
-	0x47, 0x33, 0x86, 0xa1, 0xf7, 0xb9, 0x6e, 0xf7, 0x35, 0x77, 0xb1, 0x5c, 0x79, 0xd5, 0xd2, 0xaa,
-	0x9f, 0x59, 0x5a, 0x8d, 0x8b, 0x97, 0x96, 0x7d, 0x36, 0x34, 0x2b, 0xcf, 0x86, 0xc2, 0xf1, 0x56,
-	0xc5, 0xf1, 0x45, 0xc3, 0xdb, 0x8b, 0x86, 0xab, 0x8b, 0xd5, 0x76, 0x05, 0x79, 0x2e, 0xc6, 0x11,
-	0x8e, 0x1d, 0xf3, 0x14, 0xe9, 0xa3, 0x60, 0xbf, 0xe4, 0x2f, 0x05, 0xd8, 0x59, 0x0e, 0xf0, 0x21,
-	0x6c, 0xad, 0x6c, 0xce, 0xe4, 0x39, 0x74, 0x73, 0x9e, 0x1d, 0xf3, 0xcc, 0x57, 0x0f, 0x28, 0xf3,
-	0x37, 0xf8, 0x92, 0x97, 0xa0, 0xe1, 0x2f, 0x03, 0xc9, 0xd5, 0x37, 0x63, 0xfb, 0xec, 0x11, 0x79,
-	0xea, 0xa2, 0xed, 0x8b, 0xb4, 0x7e, 0xde, 0x8b, 0xd4, 0x5e, 0x60, 0xa3, 0x72, 0x81, 0xff, 0xad,
-	0xcb, 0x55, 0x5e, 0x91, 0xad, 0x0b, 0xbf, 0x22, 0xbd, 0x27, 0x00, 0xa5, 0x4d, 0xab, 0x26, 0x45,
-	0x2c, 0xa6, 0x51, 0x6a, 0x27, 0x05, 0x12, 0xde, 0x23, 0xab, 0xa3, 0xaa, 0x79, 0xd5, 0x50, 0xca,
-	0xe3, 0xf9, 0xd4, 0x66, 0xad, 0x5a, 0x7b, 0x3e, 0xce, 0xb1, 0xe2, 0xb6, 0xfe, 0x6f, 0x12, 0x5b,
-	0x87, 0xa0, 0x5f, 0xbd, 0x1d, 0xc6, 0x67, 0xc2, 0xa4, 0xfa, 0xbd, 0xa2, 0x21, 0xea, 0x5b, 0x24,
-	0x55, 0xdc, 0x62, 0x37, 0xf4, 0x3c, 0xe8, 0x18, 0x65, 0x72, 0x15, 0x3a, 0x53, 0xe1, 0x17, 0xfb,
-	0xbb, 0xac, 0x3d, 0x15, 0x4a, 0xe0, 0x85, 0xe0, 0x16, 0x8a, 0x68, 0xe5, 0x51, 0xf0, 0xd8, 0x40,
-	0x70, 0xad, 0xe6, 0x6f, 0x16, 0x7c, 0xc2, 0xd3, 0x7a, 0x4c, 0x2d, 0xd5, 0x13, 0x2b, 0x8c, 0x26,
-	0x13, 0x5f, 0x66, 0x9c, 0x9b, 0xb2, 0x58, 0x98, 0x1e, 0x2f, 0xa3, 0xc9, 0xe4, 0x30, 0xe3, 0x9c,
-	0x39, 0xa1, 0x59, 0x79, 0xcf, 0xd1, 0x55, 0x2b, 0x20, 0x0f, 0xa0, 0x39, 0x89, 0x62, 0x95, 0x6d,
-	0xa7, 0x7e, 0x55, 0x16, 0xf3, 0x3a, 0x8a, 0x39, 0x43, 0x94, 0x97, 0xe0, 0xf0, 0xae, 0x0a, 0x94,
-	0xa1, 0x66, 0x03, 0x34, 0x54, 0xad, 0x55, 0x58, 0x82, 0x30, 0xe4, 0xb6, 0x86, 0x35, 0x41, 0x28,
-	0x74, 0xcc, 0x87, 0xc4, 0x8e, 0x4d, 0x43, 0xaa, 0x69, 0x3d, 0x8a, 0xd2, 0x20, 0xd3, 0x75, 0xea,
-	0x30, 0x43, 0x79, 0x7f, 0xa9, 0x3f, 0xe6, 0xc2, 0xf7, 0x53, 0x6d, 0x32, 0xcb, 0xc4, 0x47, 0x3e,
-	0x96, 0xe6, 0x44, 0x4b, 0x92, 0x07, 0xfa, 0xed, 0x18, 0xc9, 0x9c, 0xd6, 0xd1, 0x99, 0x55, 0xf1,
-	0xb0, 0x10, 0x72, 0x47, 0x85, 0x78, 0x62, 0xff, 0x9f, 0x1b, 0x8b, 0x21, 0x9e, 0x30, 0x14, 0xaa,
-	0x47, 0xae, 0xfd, 0x5b, 0x21, 0x58, 0x7d, 0x3d, 0x5d, 0xd6, 0x35, 0x3c, 0xc6, 0x27, 0xb9, 0x37,
-	0x80, 0xb6, 0x56, 0xc1, 0xe8, 0xf0, 0x89, 0x7d, 0x1d, 0x65, 0x7c, 0x52, 0xc4, 0xb0, 0x5e, 0xc6,
-	0x70, 0xd4, 0xc6, 0x64, 0xff, 0xf6, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xdd, 0x4d, 0xc5, 0xae,
-	0x6d, 0x10, 0x00, 0x00,
+	// 1929 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x5f, 0x6f, 0x1b, 0xc7,
+	0x11, 0x07, 0x79, 0xfc, 0x73, 0x37, 0xa4, 0x24, 0x7a, 0x1d, 0xc7, 0x67, 0xc5, 0x71, 0xd8, 0x73,
+	0x5b, 0x0b, 0x89, 0x23, 0xc5, 0x6e, 0x91, 0x1a, 0x30, 0x0a, 0x43, 0x91, 0x9d, 0x42, 0x41, 0x63,
+	0x14, 0x2b, 0xe5, 0xf9, 0xb0, 0xe4, 0x2d, 0xc9, 0x4b, 0xef, 0x0f, 0x7b, 0xb7, 0x94, 0x2b, 0xa0,
+	0x4f, 0x79, 0xe9, 0x87, 0xe8, 0x43, 0x8a, 0x7e, 0xa7, 0x7c, 0x8e, 0x7e, 0x85, 0x62, 0x67, 0x76,
+	0xef, 0x4e, 0x14, 0x69, 0xc9, 0x7d, 0xe2, 0xce, 0xcc, 0x6f, 0xf6, 0x66, 0x77, 0xfe, 0x2e, 0x61,
+	0x37, 0x15, 0x71, 0xa6, 0x32, 0x59, 0x1c, 0x2e, 0x8b, 0x5c, 0xe5, 0xac, 0x8f, 0xf4, 0x72, 0xb2,
+	0xff, 0x72, 0x1e, 0xab, 0xc5, 0x6a, 0x72, 0x38, 0xcd, 0xd3, 0xa3, 0x79, 0x9e, 0x88, 0x6c, 0x7e,
+	0x84, 0x88, 0xc9, 0x6a, 0x76, 0xb4, 0x54, 0x97, 0x4b, 0x59, 0x1e, 0xa9, 0x38, 0x95, 0xa5, 0x12,
+	0xe9, 0xb2, 0x5e, 0xd1, 0x2e, 0xc1, 0x2f, 0x2d, 0x70, 0xbf, 0x5f, 0x29, 0xa1, 0xe2, 0x3c, 0x63,
+	0xaf, 0x60, 0x48, 0x7b, 0x85, 0x71, 0x59, 0xae, 0xa4, 0xdf, 0x1a, 0xb7, 0x0e, 0x06, 0xcf, 0x1f,
+	0x1e, 0x9a, 0x2f, 0x1d, 0xfe, 0x09, 0x85, 0xa7, 0x5a, 0x66, 0x75, 0xf8, 0x60, 0x5e, 0x33, 0xd9,
+	0x11, 0xf4, 0x88, 0xf4, 0x1d, 0x54, 0xbd, 0xbf, 0xa6, 0x5a, 0x69, 0x19, 0x18, 0xfb, 0x2d, 0x38,
+	0xf3, 0x58, 0xf9, 0x6d, 0x44, 0x7f, 0xd4, 0x44, 0x57, 0x50, 0x0d, 0xc0, 0x8d, 0x65, 0x51, 0xc4,
+	0xca, 0xef, 0xac, 0x6f, 0x8c, 0xec, 0xc6, 0xc6, 0x48, 0x07, 0xff, 0x69, 0xc1, 0xee, 0xd5, 0x6f,
+	0xb2, 0x8f, 0xa0, 0x9b, 0xbf, 0xcb, 0x64, 0x81, 0xc7, 0xf2, 0x38, 0x11, 0x8c, 0x41, 0xa7, 0x90,
+	0xcb, 0x1c, 0x4d, 0xf0, 0x38, 0xae, 0xd9, 0x53, 0xe8, 0x25, 0x62, 0x22, 0x93, 0xd2, 0x77, 0xc6,
+	0xce, 0xba, 0x61, 0x8b, 0xd5, 0xe4, 0xcf, 0x5a, 0xc8, 0x0d, 0x86, 0xbd, 0x00, 0x48, 0xe3, 0x44,
+	0x96, 0x2a, 0xcf, 0x64, 0xe9, 0x77, 0x50, 0xc3, 0x5f, 0x3f, 0xb8, 0x05, 0xf0, 0x06, 0x36, 0xf8,
+	0x2f, 0xc0, 0xdd, 0x0d, 0x77, 0xfa, 0x01, 0x96, 0x7e, 0x0c, 0xbd, 0x6c, 0x95, 0x4e, 0x64, 0x81,
+	0x17, 0xde, 0xe5, 0x86, 0x62, 0x9f, 0x80, 0x97, 0xe5, 0x2a, 0x94, 0x7f, 0x8f, 0x4b, 0xe5, 0xef,
+	0x8c, 0x5b, 0x07, 0x2e, 0x77, 0xb3, 0x5c, 0xbd, 0xd1, 0x34, 0xdb, 0x85, 0x76, 0x1c, 0xf9, 0xc3,
+	0x71, 0xeb, 0xc0, 0xe1, 0xed, 0x38, 0x62, 0x4f, 0xa0, 0xb3, 0x2a, 0x65, 0x61, 0xae, 0xf6, 0xee,
+	0x9a, 0xe9, 0x3f, 0x94, 0xb2, 0xe0, 0x08, 0x60, 0xcf, 0xc0, 0x13, 0x65, 0x19, 0xcf, 0x33, 0x29,
+	0x4b, 0x1f, 0xc6, 0xce, 0x36, 0x74, 0x8d, 0x62, 0x5f, 0xc0, 0x9d, 0x48, 0x26, 0x52, 0xc9, 0x28,
+	0xac, 0x55, 0x07, 0x63, 0xe7, 0xc0, 0xe1, 0x23, 0x23, 0x38, 0xae, 0xc0, 0xbf, 0x87, 0xfe, 0xb4,
+	0x90, 0x42, 0xc9, 0xc8, 0xef, 0xa2, 0x2d, 0xfb, 0x87, 0xf3, 0x3c, 0x9f, 0x27, 0xf2, 0xd0, 0x06,
+	0xf4, 0xe1, 0xb9, 0x8d, 0x5f, 0x6e, 0xa1, 0x5a, 0x6b, 0xb5, 0x8c, 0x50, 0xab, 0x77, 0xb3, 0x96,
+	0x81, 0xea, 0x3b, 0x56, 0xb1, 0x4a, 0xa4, 0xef, 0xd1, 0x1d, 0x23, 0xc1, 0xbe, 0x86, 0xc1, 0x24,
+	0x8f, 0x2e, 0xc3, 0xe9, 0x42, 0x64, 0x73, 0xe9, 0x7f, 0x86, 0xfb, 0xdd, 0xab, 0xce, 0x78, 0xa6,
+	0x8a, 0x38, 0x9b, 0x9f, 0xa0, 0x90, 0x83, 0x46, 0xd2, 0x5a, 0xfb, 0x46, 0x53, 0x7e, 0x9f, 0x7c,
+	0xa3, 0xd7, 0xec, 0x57, 0x30, 0xcc, 0xf2, 0xb0, 0x72, 0xb7, 0xbf, 0x87, 0x6e, 0x18, 0x64, 0x79,
+	0x15, 0x0c, 0x1a, 0x52, 0xc9, 0xc3, 0x38, 0xf2, 0x47, 0xe8, 0x93, 0x41, 0xc5, 0x3b, 0x8d, 0xd8,
+	0x63, 0xd8, 0xa9, 0x21, 0xd9, 0x2a, 0xf5, 0xef, 0x20, 0xa6, 0xd6, 0x7b, 0xbb, 0x4a, 0xd9, 0x13,
+	0xd8, 0xab, 0x41, 0x74, 0x2c, 0x86, 0x96, 0xec, 0x56, 0xec, 0x73, 0x3c, 0xdf, 0x17, 0xd0, 0x9b,
+	0x26, 0x79, 0x29, 0x23, 0xff, 0xee, 0x9a, 0xb3, 0xbf, 0xc9, 0xf3, 0xc4, 0x1c, 0xcc, 0x40, 0x34,
+	0x38, 0xc9, 0xa7, 0x7f, 0x95, 0x91, 0xff, 0xe0, 0x3d, 0x60, 0x82, 0xe8, 0xa3, 0x2c, 0x57, 0x49,
+	0x12, 0x16, 0xf2, 0x6f, 0x2b, 0x59, 0x2a, 0xff, 0x21, 0x9d, 0x56, 0xf3, 0x38, 0xb1, 0xd8, 0x1f,
+	0xc0, 0xa3, 0x9d, 0x43, 0xa1, 0xfc, 0x7b, 0x37, 0xba, 0xca, 0x25, 0xf0, 0xb1, 0x62, 0x5f, 0x55,
+	0x8a, 0x93, 0x4b, 0xff, 0xe3, 0xed, 0x51, 0x6a, 0x34, 0xbe, 0xc1, 0xbb, 0x2f, 0x64, 0x9a, 0x5f,
+	0xc8, 0x10, 0x93, 0xd4, 0xbf, 0x8f, 0x11, 0x37, 0x20, 0x1e, 0xa6, 0x2f, 0x06, 0x73, 0x14, 0x19,
+	0xb9, 0xff, 0x9e, 0x3c, 0x77, 0x45, 0x14, 0x91, 0xca, 0x1f, 0xa1, 0x3f, 0xcd, 0xd3, 0x54, 0x66,
+	0xca, 0x77, 0x51, 0xe1, 0xf1, 0xa6, 0xd2, 0x78, 0x42, 0x90, 0xaa, 0x24, 0x59, 0x1d, 0xf6, 0x06,
+	0x76, 0xcd, 0x32, 0x2c, 0x95, 0x50, 0xab, 0xd2, 0xdf, 0xc5, 0xb3, 0x3c, 0xda, 0xb4, 0xcb, 0xd9,
+	0x65, 0x36, 0x3d, 0x43, 0x14, 0xdf, 0x31, 0x5a, 0x44, 0xb2, 0x23, 0xe8, 0xca, 0x0b, 0x6d, 0xc3,
+	0x3e, 0xda, 0xf0, 0x60, 0x93, 0xf6, 0x1b, 0x0d, 0xe0, 0x84, 0x63, 0xc7, 0x30, 0x94, 0x17, 0x8d,
+	0xaf, 0x7e, 0x72, 0xab, 0xaf, 0x0e, 0x50, 0xc7, 0x7c, 0xf3, 0x4b, 0xe8, 0x15, 0xf2, 0x22, 0x96,
+	0xef, 0xfc, 0x4f, 0xc7, 0xce, 0x95, 0x94, 0x20, 0x65, 0x8e, 0x42, 0x6e, 0x40, 0xec, 0x04, 0x76,
+	0x68, 0x65, 0x3f, 0xf9, 0xe8, 0x56, 0x9f, 0x1c, 0x92, 0x92, 0xf9, 0x26, 0x87, 0xbb, 0xcd, 0x88,
+	0xb2, 0x39, 0x39, 0xc6, 0xad, 0x82, 0xb5, 0xad, 0xfe, 0x52, 0xc7, 0x59, 0x75, 0xf1, 0x77, 0x1a,
+	0xc1, 0x47, 0xd1, 0x1a, 0x3c, 0x02, 0xa8, 0x63, 0x97, 0x8d, 0xc0, 0xb9, 0x10, 0x09, 0x56, 0x59,
+	0x97, 0xeb, 0x65, 0x30, 0x86, 0x61, 0x33, 0xc7, 0x9b, 0x08, 0x8f, 0x10, 0xcf, 0x60, 0xd0, 0x08,
+	0x0e, 0x53, 0x4b, 0x5b, 0x55, 0x2d, 0x65, 0xd0, 0xc9, 0x44, 0x2a, 0x6d, 0x91, 0xd6, 0xeb, 0xe0,
+	0x1f, 0xb0, 0xb7, 0xd6, 0x05, 0xae, 0xa9, 0x55, 0xd5, 0xa8, 0xdd, 0xac, 0x46, 0x75, 0xb6, 0x3a,
+	0x37, 0x67, 0x6b, 0xdd, 0x0a, 0x3a, 0xb8, 0xad, 0xa1, 0x82, 0x9f, 0xbb, 0x30, 0x5a, 0x8f, 0x8c,
+	0x6b, 0xdf, 0xff, 0x14, 0x80, 0x42, 0x44, 0xcf, 0x0b, 0xc6, 0x08, 0x0f, 0x39, 0xe7, 0x97, 0x4b,
+	0xc9, 0x1e, 0x80, 0x2b, 0xa6, 0x2a, 0x2f, 0xc2, 0x98, 0x4c, 0x71, 0x78, 0x1f, 0xe9, 0xd3, 0xa8,
+	0x59, 0xb3, 0x3b, 0xb7, 0xaf, 0xd9, 0x9f, 0x43, 0x97, 0x12, 0xaf, 0x7b, 0xbd, 0xf3, 0x57, 0x89,
+	0x47, 0x10, 0xf6, 0x35, 0x78, 0x75, 0x11, 0xa5, 0x0a, 0xbf, 0xbd, 0xbd, 0xd6, 0x50, 0xf6, 0x19,
+	0x0c, 0x6c, 0xcb, 0xd1, 0x76, 0xf7, 0xd1, 0x6e, 0xb0, 0xac, 0xd3, 0xa8, 0x01, 0xc0, 0x83, 0xb9,
+	0x57, 0x00, 0xfa, 0x6c, 0x5f, 0x42, 0x4f, 0xa7, 0x5e, 0xac, 0xb0, 0x49, 0x5c, 0x8f, 0xfa, 0x13,
+	0x14, 0x72, 0x03, 0xd2, 0xfb, 0x15, 0x52, 0x7b, 0x3c, 0x9c, 0x15, 0x79, 0xea, 0x0f, 0xf0, 0x16,
+	0x81, 0x58, 0xdf, 0x16, 0x79, 0xaa, 0xbb, 0xb2, 0x01, 0xa8, 0x1c, 0xfb, 0xaf, 0xc7, 0x5d, 0x62,
+	0x9c, 0xe7, 0xa4, 0xad, 0xc3, 0x9f, 0xac, 0xd9, 0x21, 0x6b, 0x2c, 0xeb, 0x34, 0x62, 0x87, 0x70,
+	0xd7, 0x24, 0x95, 0xc9, 0x08, 0x02, 0xee, 0x22, 0xf0, 0x0e, 0x89, 0xb8, 0x95, 0x9c, 0x46, 0xec,
+	0x05, 0xec, 0x28, 0x29, 0xd2, 0xd0, 0x6e, 0xe1, 0x8f, 0xd6, 0x82, 0x88, 0x0e, 0x71, 0x2e, 0x45,
+	0xca, 0x87, 0x1a, 0xc9, 0x0d, 0x90, 0xbd, 0x85, 0x51, 0x14, 0x97, 0x69, 0x5c, 0xea, 0x92, 0x6b,
+	0xf2, 0x7e, 0x6f, 0xdc, 0xda, 0x50, 0xf0, 0x5e, 0x5b, 0x18, 0xe9, 0x52, 0xd9, 0xd9, 0x8b, 0xae,
+	0x72, 0x75, 0x74, 0xe5, 0x6a, 0x21, 0x8b, 0xf0, 0xc7, 0x32, 0xcf, 0x7c, 0x18, 0xb7, 0x0e, 0x86,
+	0xdc, 0x43, 0xce, 0x77, 0x65, 0x9e, 0x05, 0x3f, 0xb5, 0x60, 0x7f, 0xfb, 0x76, 0x74, 0x6b, 0x78,
+	0xee, 0x2a, 0x64, 0x5d, 0x62, 0x9c, 0x46, 0x38, 0x5f, 0x90, 0x92, 0x48, 0xc2, 0x54, 0x96, 0xa5,
+	0x98, 0x4b, 0x0c, 0x51, 0x8f, 0x8f, 0x2a, 0xc1, 0xf7, 0xc4, 0xd7, 0x59, 0xa6, 0xeb, 0x91, 0xc4,
+	0x48, 0xf5, 0x38, 0x11, 0xdf, 0x75, 0xdc, 0xf6, 0xc8, 0x09, 0x7e, 0x80, 0x61, 0xd3, 0xa9, 0x1f,
+	0x30, 0x83, 0x7d, 0x02, 0x1e, 0x05, 0x80, 0xcd, 0x0e, 0x8f, 0xbb, 0xc4, 0x38, 0x8d, 0x82, 0x9f,
+	0xda, 0x76, 0x5f, 0x73, 0x17, 0xeb, 0x99, 0xd7, 0x4c, 0xad, 0xf6, 0xd6, 0xd4, 0x72, 0x6e, 0x9f,
+	0x5a, 0x76, 0x14, 0xe9, 0x34, 0x46, 0x91, 0xea, 0xe0, 0xdd, 0xc6, 0xc1, 0xaf, 0x1a, 0xde, 0xbb,
+	0x6a, 0xb8, 0xbe, 0x58, 0xb2, 0x4b, 0x94, 0x65, 0x3e, 0x8d, 0xb1, 0xa2, 0x9a, 0xf1, 0x66, 0x84,
+	0x82, 0xe3, 0x9a, 0xbf, 0xe6, 0x60, 0x77, 0xdd, 0xc1, 0xe7, 0x70, 0x6f, 0x63, 0xc1, 0x67, 0x2f,
+	0x61, 0x50, 0xca, 0xe2, 0x42, 0x16, 0xa1, 0x1e, 0xca, 0xfc, 0xd6, 0x8d, 0xa7, 0x04, 0x82, 0xbf,
+	0x16, 0x4a, 0x06, 0xbf, 0x54, 0x61, 0xb3, 0xa9, 0xed, 0x5e, 0xbb, 0x68, 0x3b, 0xe5, 0xb6, 0x6f,
+	0x9a, 0x72, 0xed, 0x05, 0x3a, 0x8d, 0x0b, 0xfc, 0xff, 0xaa, 0x5c, 0x63, 0x32, 0xed, 0xde, 0x7a,
+	0x32, 0x0d, 0x9e, 0x03, 0xd4, 0x36, 0x6d, 0xea, 0x14, 0x49, 0x3e, 0x8f, 0x33, 0xdb, 0x29, 0x90,
+	0x08, 0xbe, 0x02, 0xa8, 0xb3, 0x79, 0x53, 0x53, 0x2a, 0x93, 0xd5, 0xdc, 0x46, 0xad, 0x5e, 0x07,
+	0x21, 0xf6, 0xb1, 0xea, 0xb6, 0x7e, 0x6d, 0x02, 0x9b, 0x5c, 0x30, 0x6a, 0xde, 0x0e, 0x97, 0xcb,
+	0xdc, 0x84, 0xfa, 0xe7, 0x55, 0x41, 0xa4, 0x5b, 0x64, 0x4d, 0xdc, 0xd5, 0x6a, 0x18, 0x04, 0xd0,
+	0x37, 0xca, 0xec, 0x3e, 0xf4, 0xe7, 0x79, 0x58, 0xed, 0xef, 0xf1, 0xde, 0x3c, 0xd7, 0x82, 0x20,
+	0x02, 0xaf, 0x52, 0x44, 0x2b, 0x17, 0xe2, 0x99, 0x81, 0xe0, 0x5a, 0xf7, 0xdf, 0x42, 0xbc, 0xc3,
+	0xaf, 0x0d, 0xb9, 0x5e, 0xea, 0xb1, 0x2d, 0x8a, 0x67, 0xb3, 0x50, 0x15, 0x52, 0xfa, 0xce, 0xf5,
+	0xee, 0xf1, 0x3a, 0x9e, 0xcd, 0xce, 0x0b, 0x29, 0xb9, 0x1b, 0x99, 0x55, 0xf0, 0x12, 0x06, 0x0d,
+	0x01, 0x7b, 0x0a, 0x9d, 0x59, 0x9c, 0xe8, 0x68, 0xbb, 0xf6, 0x52, 0xb3, 0x98, 0x6f, 0xe3, 0x44,
+	0x72, 0x44, 0x05, 0x29, 0xec, 0xad, 0x09, 0xb4, 0xa1, 0x66, 0x03, 0x34, 0x54, 0xaf, 0xb5, 0x5b,
+	0x44, 0x14, 0x49, 0x9b, 0xc3, 0x44, 0x30, 0x1f, 0xfa, 0xe6, 0x91, 0x63, 0xdb, 0xa6, 0x21, 0x75,
+	0xb7, 0x9e, 0xc4, 0x99, 0x28, 0x28, 0x4f, 0x5d, 0x6e, 0xa8, 0xe0, 0xdf, 0xfa, 0xdd, 0x7a, 0xe5,
+	0x49, 0xab, 0x37, 0x59, 0x16, 0xf9, 0x8f, 0x72, 0xaa, 0xcc, 0x17, 0x2d, 0xc9, 0x9e, 0xd2, 0x3c,
+	0x1a, 0xab, 0xd2, 0x6f, 0x8f, 0x9d, 0x2d, 0xfe, 0xb0, 0x10, 0xf6, 0x58, 0xbb, 0x78, 0x66, 0xdf,
+	0xb4, 0x7b, 0x57, 0x5d, 0x3c, 0xe3, 0x28, 0xd4, 0x83, 0xb3, 0x7d, 0xaf, 0x21, 0x58, 0x3f, 0x67,
+	0x3d, 0x3e, 0x30, 0x3c, 0x2e, 0x67, 0x65, 0x70, 0x08, 0x3d, 0x52, 0x41, 0xef, 0xc8, 0x99, 0x9d,
+	0x8e, 0x0a, 0x39, 0xab, 0x7c, 0xd8, 0xae, 0x7d, 0x18, 0xfc, 0xab, 0x0b, 0x0f, 0xb6, 0x0e, 0x69,
+	0xba, 0x08, 0x2d, 0xa4, 0x88, 0x9a, 0xd1, 0xe1, 0x6a, 0x06, 0x06, 0xce, 0x03, 0x70, 0x8d, 0x70,
+	0x66, 0xb6, 0xec, 0x93, 0x6c, 0x56, 0x89, 0xca, 0x85, 0xf0, 0x9d, 0x5a, 0x74, 0xb6, 0x10, 0x5a,
+	0x34, 0x11, 0xa5, 0x44, 0x2d, 0xaa, 0x82, 0x7d, 0x4d, 0x1b, 0x2d, 0x14, 0x69, 0xad, 0x6e, 0x2d,
+	0xd2, 0x5a, 0xcf, 0xc0, 0x4b, 0x65, 0x31, 0x97, 0x62, 0x92, 0xd8, 0x31, 0x63, 0xe3, 0xbc, 0x55,
+	0xa3, 0xf0, 0xd9, 0x65, 0x89, 0x90, 0x0a, 0x6c, 0xdf, 0x3c, 0xbb, 0x2c, 0x5b, 0x17, 0x3a, 0x1c,
+	0xe4, 0x90, 0x43, 0x43, 0xc6, 0xb6, 0x41, 0x8e, 0x20, 0xfa, 0x99, 0x44, 0xab, 0x50, 0xd8, 0xc1,
+	0xe3, 0xbd, 0xcf, 0x24, 0x02, 0x1f, 0x2b, 0xf6, 0xa2, 0xca, 0x4e, 0x7a, 0x9b, 0x8f, 0xb7, 0xcf,
+	0xc8, 0x6b, 0x93, 0xcb, 0x09, 0xec, 0xd0, 0xca, 0xce, 0xeb, 0x83, 0xdb, 0xcd, 0xeb, 0xa4, 0x44,
+	0x14, 0x7b, 0x6e, 0xd2, 0x62, 0x38, 0x76, 0x36, 0xe8, 0x36, 0x3e, 0x5e, 0x67, 0x17, 0x7b, 0x05,
+	0x03, 0xfd, 0x6b, 0x3f, 0xbb, 0x73, 0xab, 0xcf, 0x82, 0x56, 0x31, 0x1f, 0x7d, 0x05, 0x83, 0xf2,
+	0x32, 0x9b, 0x7e, 0xd8, 0x83, 0x0a, 0xca, 0x6a, 0x1d, 0xfc, 0xec, 0xc0, 0xfd, 0x2d, 0xd7, 0xa3,
+	0xe3, 0x5b, 0x07, 0x8a, 0x89, 0xef, 0x72, 0x21, 0xb4, 0x23, 0xc5, 0x4a, 0x2d, 0xf2, 0xf7, 0xb6,
+	0x11, 0x03, 0xc1, 0xf9, 0x12, 0x57, 0x21, 0x3e, 0x09, 0x28, 0x4a, 0x81, 0x58, 0x6f, 0x45, 0x8a,
+	0xcf, 0x7f, 0x03, 0x90, 0xa9, 0x88, 0x13, 0x13, 0xac, 0x46, 0xe9, 0x8d, 0x66, 0xe9, 0x0e, 0x69,
+	0x20, 0x2a, 0x4e, 0xe5, 0x2d, 0xda, 0x88, 0xd9, 0x5f, 0x33, 0xd8, 0x6f, 0xe8, 0xc1, 0x19, 0x2b,
+	0x25, 0x8d, 0x0d, 0xd4, 0xe5, 0x77, 0x2a, 0x2e, 0x9a, 0xf1, 0x04, 0xf6, 0x6a, 0x18, 0x59, 0x62,
+	0xc2, 0xb8, 0x62, 0x57, 0xc6, 0x98, 0x30, 0x41, 0x63, 0xdc, 0x9b, 0x8d, 0x21, 0x38, 0x1a, 0xe3,
+	0x43, 0xdf, 0xce, 0x67, 0xf4, 0x97, 0x8b, 0x25, 0xf5, 0xf4, 0xb0, 0x14, 0x05, 0x3e, 0x50, 0x17,
+	0x02, 0x63, 0xd7, 0xe3, 0x1e, 0x71, 0xce, 0x16, 0x22, 0xf8, 0x67, 0x0b, 0xee, 0x5d, 0xf3, 0x10,
+	0x16, 0xe2, 0x7d, 0x70, 0x75, 0x28, 0xe0, 0xc9, 0x4c, 0xe9, 0xb0, 0xb4, 0x2e, 0xb0, 0x26, 0x26,
+	0xa8, 0x70, 0x18, 0x8a, 0x3d, 0xc4, 0x67, 0x7f, 0xac, 0x6b, 0x4f, 0x69, 0xfe, 0x34, 0xab, 0x19,
+	0x5a, 0x8a, 0xa5, 0x0e, 0xa5, 0x1d, 0x92, 0x56, 0x8c, 0x49, 0x0f, 0x8f, 0xf8, 0xbb, 0xff, 0x0d,
+	0x00, 0x4b, 0x61, 0x97, 0xa5, 0x8b, 0x15, 0x00, 0x00,
 }
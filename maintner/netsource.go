@@ -92,7 +92,7 @@ func TailNetworkMutationSource(ctx context.Context, server string, fn func(Mutat
 	}
 	segSize := sumJSONSegSize(segs)
 	lastSeg := segs[len(segs)-1]
-	if _, _, err := ns.syncSeg(ctx, lastSeg); err != nil {
+	if _, _, err := ns.syncSeg(ctx, lastSeg, true); err != nil {
 		return err
 	}
 
@@ -113,7 +113,7 @@ func TailNetworkMutationSource(ctx context.Context, server string, fn func(Mutat
 			if seg.Number == lastSeg.Number {
 				off = lastSeg.Size
 			}
-			_, newData, err := ns.syncSeg(ctx, seg)
+			_, newData, err := ns.syncSeg(ctx, seg, seg.Number == segs[len(segs)-1].Number)
 			if err != nil {
 				return err
 			}
@@ -139,7 +139,8 @@ type netMutSource struct {
 	cacheDir string
 
 	last  []fileSeg
-	quiet bool // disable verbose logging
+	quiet bool                  // disable verbose logging
+	want  func(rec []byte) bool // if non-nil, reports whether to decode a record
 
 	// Hooks for testing. If nil, unused:
 	testHookGetServerSegments func(context.Context, int64) ([]LogSegmentJSON, error)
@@ -164,6 +165,8 @@ func (ns *netMutSource) GetMutations(ctx context.Context) <-chan MutationStreamE
 	return ch
 }
 
+func (ns *netMutSource) setRecordFilter(want func(rec []byte) bool) { ns.want = want }
+
 // isNoInternetError reports whether the provided error is because there's no
 // network connectivity.
 func isNoInternetError(err error) bool {
@@ -358,9 +361,9 @@ func (ns *netMutSource) getNewSegments(ctx context.Context) ([]fileSeg, error) {
 	// Second, fetch the new segments or their fragments
 	// that we don't yet have locally.
 	var fileSegs []fileSeg
-	for _, seg := range serverSegs {
+	for i, seg := range serverSegs {
 		for try := 1; ; {
-			fileSeg, _, err := ns.syncSeg(ctx, seg)
+			fileSeg, _, err := ns.syncSeg(ctx, seg, i == len(serverSegs)-1)
 			if isNoInternetError(err) {
 				log.Printf("No internet; blocking.")
 				select {
@@ -518,6 +521,9 @@ func (ns *netMutSource) fetchAndSendMutations(ctx context.Context, ch chan<- Mut
 			}
 		}
 		return reclog.ForeachRecord(io.LimitReader(f, seg.size-seg.skip), seg.skip, func(off int64, hdr, rec []byte) error {
+			if ns.want != nil && !ns.want(rec) {
+				return nil
+			}
 			m := new(maintpb.Mutation)
 			if err := proto.Unmarshal(rec, m); err != nil {
 				return err
@@ -553,16 +559,21 @@ type fileSeg struct {
 
 // syncSeg syncs the provided log segment, returning its on-disk metadata.
 // The newData result is the new data that was added to the segment in this sync.
+// The last argument reports whether seg is the last segment listed by the
+// server, the only one that may still grow.
 //
 // syncSeg returns an error that matches fetchError with PossiblyRetryable set
 // to true when it has signal that repeating the same call after some time may
 // succeed.
-func (ns *netMutSource) syncSeg(ctx context.Context, seg LogSegmentJSON) (_ fileSeg, newData []byte, _ error) {
+func (ns *netMutSource) syncSeg(ctx context.Context, seg LogSegmentJSON, last bool) (_ fileSeg, newData []byte, _ error) {
 	if fn := ns.testHookSyncSeg; fn != nil {
 		return fn(ctx, seg)
 	}
 
-	isFinalSeg := !strings.HasPrefix(seg.URL, "https://storage.googleapis.com/")
+	// Only the last segment may still be growing. Segments in GCS are
+	// complete even when they're last, but those in stores without
+	// public URLs are served by maintnerd like the growing one.
+	isFinalSeg := last && !strings.HasPrefix(seg.URL, "https://storage.googleapis.com/")
 	relURL, err := url.Parse(seg.URL)
 	if err != nil {
 		return fileSeg{}, nil, err
@@ -642,7 +653,7 @@ func (ns *netMutSource) syncSeg(ctx context.Context, seg LogSegmentJSON) (_ file
 		}
 		// Try again.
 		os.Remove(partial)
-		return ns.syncSeg(ctx, seg)
+		return ns.syncSeg(ctx, seg, last)
 	}
 	// TODO: this is a quadratic amount of write I/O as the 16 MB
 	// segment grows. Switch to appending to the existing file,
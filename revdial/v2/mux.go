@@ -0,0 +1,416 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package revdial
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Multiplexed mode
+//
+// In the original protocol, every Dial sends a "conn-ready" control
+// message and waits for the Listener to dial back to the ConnHandler
+// over a new connection. In multiplexed mode, connections are instead
+// carried as streams over the registered connection itself.
+//
+// A Listener which supports multiplexing sends a "mux-hello" control
+// message when it starts. Older Dialers ignore it, and the pick-up
+// protocol is used. A Dialer which supports multiplexing answers with
+// "mux-start" as the last JSON message it writes; the Listener answers
+// with "mux-started" as the last JSON message it writes. After those,
+// each side only writes frames.
+//
+// A frame is a 9 byte header of a one byte frame type, a 4 byte stream
+// ID and a 4 byte length or value, all big-endian, followed by the
+// payload for data and control frames. Only the Dialer opens streams.
+// Each stream has a receive window of muxWindow bytes; the receiver
+// grants more with window frames as the data is read.
+
+const (
+	frameOpen    = iota // open a stream
+	frameData           // stream data
+	frameWindow         // increase the peer's send window by value bytes
+	frameClose          // close a stream
+	framePing           // keepalive; answered with framePong
+	framePong           // keepalive response
+	frameControl        // JSON-encoded controlMsg
+)
+
+const (
+	frameHeaderLen = 9
+	muxMaxData     = 16 << 10  // largest data payload written
+	muxMaxPayload  = 64 << 10  // largest payload accepted
+	muxWindow      = 256 << 10 // per-stream receive window
+)
+
+var errMuxClosed = errors.New("revdial: multiplexed connection closed")
+
+// muxSession multiplexes streams over a single connection.
+type muxSession struct {
+	write     func([]byte) error // writes a whole frame; must be safe for concurrent use
+	onOpen    func(*muxStream)   // called for streams opened by the peer; nil on the dialing side
+	onControl func(controlMsg)
+
+	mu       sync.Mutex
+	streams  map[uint32]*muxStream
+	nextID   uint32
+	lastRecv time.Time
+	err      error // non-nil once closed
+}
+
+func newMuxSession(write func([]byte) error, onOpen func(*muxStream), onControl func(controlMsg)) *muxSession {
+	return &muxSession{
+		write:     write,
+		onOpen:    onOpen,
+		onControl: onControl,
+		streams:   map[uint32]*muxStream{},
+		lastRecv:  time.Now(),
+	}
+}
+
+func (s *muxSession) writeFrame(typ byte, id, val uint32, payload []byte) error {
+	b := make([]byte, frameHeaderLen+len(payload))
+	b[0] = typ
+	binary.BigEndian.PutUint32(b[1:5], id)
+	if payload != nil {
+		val = uint32(len(payload))
+	}
+	binary.BigEndian.PutUint32(b[5:9], val)
+	copy(b[frameHeaderLen:], payload)
+	return s.write(b)
+}
+
+func (s *muxSession) writeControl(m controlMsg) error {
+	j, _ := json.Marshal(m)
+	return s.writeFrame(frameControl, 0, 0, j)
+}
+
+func (s *muxSession) ping() error {
+	return s.writeFrame(framePing, 0, 0, nil)
+}
+
+// idle reports how long it has been since a frame was received.
+func (s *muxSession) idle() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Since(s.lastRecv)
+}
+
+// open opens a new stream to the peer.
+func (s *muxSession) open() (*muxStream, error) {
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return nil, s.err
+	}
+	s.nextID++
+	st := newMuxStream(s, s.nextID)
+	s.streams[st.id] = st
+	s.mu.Unlock()
+	if err := s.writeFrame(frameOpen, st.id, 0, nil); err != nil {
+		s.removeStream(st.id)
+		return nil, err
+	}
+	return st, nil
+}
+
+func (s *muxSession) removeStream(id uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.streams, id)
+}
+
+// close closes the session and all of its streams.
+func (s *muxSession) close(err error) {
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.err = err
+	streams := s.streams
+	s.streams = nil
+	s.mu.Unlock()
+	for _, st := range streams {
+		st.mu.Lock()
+		st.err = err
+		st.cond.Broadcast()
+		st.mu.Unlock()
+	}
+}
+
+// readLoop reads frames from r until an error occurs, at which point
+// the session is closed.
+func (s *muxSession) readLoop(r io.Reader) error {
+	err := s.readFrames(r)
+	s.close(errMuxClosed)
+	return err
+}
+
+func (s *muxSession) readFrames(r io.Reader) error {
+	var hdr [frameHeaderLen]byte
+	for {
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return err
+		}
+		typ, id, val := hdr[0], binary.BigEndian.Uint32(hdr[1:5]), binary.BigEndian.Uint32(hdr[5:9])
+		var payload []byte
+		if typ == frameData || typ == frameControl {
+			if val > muxMaxPayload {
+				return fmt.Errorf("revdial: frame payload of %d bytes is too large", val)
+			}
+			payload = make([]byte, val)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return err
+			}
+		}
+		s.mu.Lock()
+		s.lastRecv = time.Now()
+		st := s.streams[id]
+		s.mu.Unlock()
+
+		switch typ {
+		case frameOpen:
+			if s.onOpen == nil || st != nil {
+				return fmt.Errorf("revdial: unexpected open of stream %d", id)
+			}
+			st = newMuxStream(s, id)
+			s.mu.Lock()
+			if s.err != nil {
+				s.mu.Unlock()
+				return s.err
+			}
+			s.streams[id] = st
+			s.mu.Unlock()
+			s.onOpen(st)
+		case frameData:
+			// Data may arrive for a stream which was closed locally; drop it.
+			if st != nil {
+				if err := st.deliver(payload); err != nil {
+					return err
+				}
+			}
+		case frameWindow:
+			if st != nil {
+				st.grant(val)
+			}
+		case frameClose:
+			if st != nil {
+				st.remoteClose()
+			}
+		case framePing:
+			if err := s.writeFrame(framePong, 0, val, nil); err != nil {
+				return err
+			}
+		case framePong:
+		case frameControl:
+			var msg controlMsg
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				return fmt.Errorf("revdial: invalid control message %q: %v", payload, err)
+			}
+			s.onControl(msg)
+		default:
+			// Ignore unknown frames.
+		}
+	}
+}
+
+// muxStream is a net.Conn carried over a muxSession.
+type muxStream struct {
+	s  *muxSession
+	id uint32
+
+	mu           sync.Mutex
+	cond         *sync.Cond
+	buf          []byte // received but unread data
+	consumed     uint32 // bytes read since the last window frame
+	sendWindow   uint32
+	closed       bool  // closed locally
+	remoteClosed bool  // closed by the peer
+	err          error // set when the session is closed
+	rdeadline    time.Time
+	wdeadline    time.Time
+	rtimer       *time.Timer
+	wtimer       *time.Timer
+}
+
+var _ net.Conn = (*muxStream)(nil)
+
+func newMuxStream(s *muxSession, id uint32) *muxStream {
+	st := &muxStream{s: s, id: id, sendWindow: muxWindow}
+	st.cond = sync.NewCond(&st.mu)
+	return st
+}
+
+func (st *muxStream) deliver(p []byte) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if len(st.buf)+len(p) > muxWindow {
+		return fmt.Errorf("revdial: peer exceeded the receive window of stream %d", st.id)
+	}
+	st.buf = append(st.buf, p...)
+	st.cond.Broadcast()
+	return nil
+}
+
+func (st *muxStream) grant(n uint32) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.sendWindow += n
+	st.cond.Broadcast()
+}
+
+func (st *muxStream) remoteClose() {
+	st.mu.Lock()
+	st.remoteClosed = true
+	st.cond.Broadcast()
+	st.mu.Unlock()
+	st.s.removeStream(st.id)
+}
+
+// checkLocked returns the error which an operation on st should fail
+// with, if any. The stream lock must be held.
+func (st *muxStream) checkLocked(deadline time.Time) error {
+	switch {
+	case st.closed:
+		return net.ErrClosed
+	case st.err != nil:
+		return st.err
+	case !deadline.IsZero() && !time.Now().Before(deadline):
+		return os.ErrDeadlineExceeded
+	}
+	return nil
+}
+
+func (st *muxStream) Read(p []byte) (int, error) {
+	st.mu.Lock()
+	for len(st.buf) == 0 {
+		if st.remoteClosed && !st.closed {
+			st.mu.Unlock()
+			return 0, io.EOF
+		}
+		if err := st.checkLocked(st.rdeadline); err != nil {
+			st.mu.Unlock()
+			return 0, err
+		}
+		st.cond.Wait()
+	}
+	n := copy(p, st.buf)
+	st.buf = st.buf[n:]
+	st.consumed += uint32(n)
+	var grant uint32
+	if st.consumed >= muxWindow/2 && !st.remoteClosed {
+		grant, st.consumed = st.consumed, 0
+	}
+	st.mu.Unlock()
+	if grant > 0 {
+		if err := st.s.writeFrame(frameWindow, st.id, grant, nil); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (st *muxStream) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		st.mu.Lock()
+		for {
+			if err := st.checkLocked(st.wdeadline); err != nil {
+				st.mu.Unlock()
+				return n, err
+			}
+			if st.remoteClosed {
+				st.mu.Unlock()
+				return n, errors.New("revdial: stream closed by peer")
+			}
+			if st.sendWindow > 0 {
+				break
+			}
+			st.cond.Wait()
+		}
+		chunk := len(p)
+		if chunk > muxMaxData {
+			chunk = muxMaxData
+		}
+		if uint32(chunk) > st.sendWindow {
+			chunk = int(st.sendWindow)
+		}
+		st.sendWindow -= uint32(chunk)
+		st.mu.Unlock()
+		if err := st.s.writeFrame(frameData, st.id, 0, p[:chunk]); err != nil {
+			return n, err
+		}
+		n += chunk
+		p = p[chunk:]
+	}
+	return n, nil
+}
+
+func (st *muxStream) Close() error {
+	st.mu.Lock()
+	if st.closed {
+		st.mu.Unlock()
+		return nil
+	}
+	st.closed = true
+	notify := !st.remoteClosed && st.err == nil
+	st.cond.Broadcast()
+	st.mu.Unlock()
+	st.s.removeStream(st.id)
+	if notify {
+		return st.s.writeFrame(frameClose, st.id, 0, nil)
+	}
+	return nil
+}
+
+func (st *muxStream) LocalAddr() net.Addr  { return fakeAddr{} }
+func (st *muxStream) RemoteAddr() net.Addr { return fakeAddr{} }
+
+func (st *muxStream) SetDeadline(t time.Time) error {
+	st.SetReadDeadline(t)
+	st.SetWriteDeadline(t)
+	return nil
+}
+
+func (st *muxStream) SetReadDeadline(t time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.rdeadline = t
+	st.rtimer = st.resetTimer(st.rtimer, t)
+	return nil
+}
+
+func (st *muxStream) SetWriteDeadline(t time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.wdeadline = t
+	st.wtimer = st.resetTimer(st.wtimer, t)
+	return nil
+}
+
+// resetTimer stops timer and returns a new one which wakes any blocked
+// Read or Write at the deadline t. The stream lock must be held.
+func (st *muxStream) resetTimer(timer *time.Timer, t time.Time) *time.Timer {
+	if timer != nil {
+		timer.Stop()
+	}
+	st.cond.Broadcast()
+	if t.IsZero() {
+		return nil
+	}
+	return time.AfterFunc(time.Until(t), func() {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		st.cond.Broadcast()
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package revdial
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMultiplexedDial(t *testing.T) {
+	dc, lc := net.Pipe()
+	d := NewDialer(dc, "/revdial")
+	defer d.Close()
+	ln := NewListener(lc, func(context.Context) (net.Conn, error) {
+		return nil, errors.New("unexpected pick-up dial")
+	})
+	defer ln.Close()
+
+	// Echo everything back on accepted connections.
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				io.Copy(c, c)
+			}()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for !d.Multiplexed() {
+		select {
+		case <-ctx.Done():
+			t.Fatal("Dialer did not switch to multiplexed mode")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	// Several concurrent streams, each sending more than the receive
+	// window so that flow control is exercised.
+	want := bytes.Repeat([]byte("revdial"), 3*muxWindow/7)
+	errc := make(chan error, 3)
+	for i := 0; i < cap(errc); i++ {
+		go func() {
+			c, err := d.Dial(ctx)
+			if err != nil {
+				errc <- err
+				return
+			}
+			defer c.Close()
+			go c.Write(want)
+			got := make([]byte, len(want))
+			if _, err := io.ReadFull(c, got); err != nil {
+				errc <- err
+				return
+			}
+			if !bytes.Equal(got, want) {
+				errc <- errors.New("echoed data mismatch")
+				return
+			}
+			errc <- nil
+		}()
+	}
+	for i := 0; i < cap(errc); i++ {
+		if err := <-errc; err != nil {
+			t.Errorf("stream %d: %v", i, err)
+		}
+	}
+
+	c, err := d.Dial(ctx)
+	if err != nil {
+		t.Fatalf("Dial() = _, %v; want no error", err)
+	}
+	c.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	if _, err := c.Read(make([]byte, 1)); !errors.Is(err, context.DeadlineExceeded) && !isTimeout(err) {
+		t.Errorf("Read past deadline = %v; want timeout", err)
+	}
+	c.Close()
+	if _, err := c.Write([]byte("x")); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Write after Close = %v; want net.ErrClosed", err)
+	}
+}
+
+func isTimeout(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// TestPickupFallback verifies that the Dialer keeps using the pick-up
+// protocol with a Listener which does not offer to multiplex.
+func TestPickupFallback(t *testing.T) {
+	dc, lc := net.Pipe()
+	d := NewDialer(dc, "/revdial")
+	defer d.Close()
+	defer lc.Close()
+
+	// An old Listener, which fails every pick-up.
+	go func() {
+		br := bufio.NewReader(lc)
+		for {
+			line, err := br.ReadSlice('\n')
+			if err != nil {
+				return
+			}
+			var msg controlMsg
+			if err := json.Unmarshal(line, &msg); err != nil {
+				return
+			}
+			if msg.Command == "conn-ready" {
+				j, _ := json.Marshal(controlMsg{Command: "pickup-failed", ConnPath: msg.ConnPath, Err: "old listener"})
+				lc.Write(append(j, '\n'))
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := d.Dial(ctx)
+	if err == nil || !strings.Contains(err.Error(), "old listener") {
+		t.Errorf("Dial() = _, %v; want pick-up failure", err)
+	}
+	if d.Multiplexed() {
+		t.Errorf("Multiplexed() = true; want false")
+	}
+}
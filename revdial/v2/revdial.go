@@ -15,6 +15,10 @@
 // sequestered machine connect out to a public machine. Both sides
 // then use revdial and the public machine can become a client for the
 // NATed machine.
+//
+// When both sides support it, connections are multiplexed over the
+// original connection. Otherwise, the Listener dials back to the
+// ConnHandler for every connection.
 package revdial
 
 import (
@@ -38,6 +42,10 @@ import (
 // containing the Dialer's random unique ID.
 const dialerUniqParam = "revdial.dialer"
 
+// keepAliveInterval is how often the Dialer sends keepalives to the
+// Listener to keep the connection alive through NAT timeouts.
+const keepAliveInterval = 30 * time.Second
+
 // The Dialer can create new connections.
 type Dialer struct {
 	conn       net.Conn // hijacked client conn
@@ -48,8 +56,13 @@ type Dialer struct {
 	incomingConn chan net.Conn
 	pickupFailed chan error
 	connReady    chan bool
+	muxHello     chan bool
 	donec        chan struct{}
 	closeOnce    sync.Once
+
+	wmu   sync.Mutex // guards writes to conn
+	muxMu sync.Mutex // guards mux and switching to it
+	mux   *muxSession
 }
 
 var (
@@ -70,6 +83,7 @@ func NewDialer(c net.Conn, connPath string) *Dialer {
 		conn:         c,
 		donec:        make(chan struct{}),
 		connReady:    make(chan bool),
+		muxHello:     make(chan bool),
 		incomingConn: make(chan net.Conn),
 		pickupFailed: make(chan error),
 	}
@@ -121,6 +135,10 @@ func (d *Dialer) close() {
 
 // Dial creates a new connection back to the Listener.
 func (d *Dialer) Dial(ctx context.Context) (net.Conn, error) {
+	if s := d.muxSession(); s != nil {
+		return s.open()
+	}
+
 	// First, tell serve that we want a connection:
 	select {
 	case d.connReady <- true:
@@ -143,6 +161,19 @@ func (d *Dialer) Dial(ctx context.Context) (net.Conn, error) {
 	}
 }
 
+// Multiplexed reports whether connections are multiplexed over the
+// connection the Dialer was created with. Otherwise, the Listener picks
+// up each connection over a new connection to the ConnHandler.
+func (d *Dialer) Multiplexed() bool {
+	return d.muxSession() != nil
+}
+
+func (d *Dialer) muxSession() *muxSession {
+	d.muxMu.Lock()
+	defer d.muxMu.Unlock()
+	return d.mux
+}
+
 func (d *Dialer) matchConn(c net.Conn) {
 	select {
 	case d.incomingConn <- c:
@@ -156,6 +187,11 @@ func (d *Dialer) serve() error {
 	defer d.Close()
 	go func() {
 		defer d.Close()
+		defer func() {
+			if s := d.muxSession(); s != nil {
+				s.close(errors.New("revdial.Dialer closed"))
+			}
+		}()
 		br := bufio.NewReader(d.conn)
 		for {
 			line, err := br.ReadSlice('\n')
@@ -168,25 +204,49 @@ func (d *Dialer) serve() error {
 				return
 			}
 			switch msg.Command {
-			case "pickup-failed":
-				err := fmt.Errorf("revdial listener failed to pick up connection: %v", msg.Err)
+			case "mux-hello":
 				select {
-				case d.pickupFailed <- err:
+				case d.muxHello <- true:
 				case <-d.donec:
 					return
 				}
+			case "mux-started":
+				// The Listener only writes frames from now on.
+				s := d.muxSession()
+				if s == nil {
+					log.Printf("revdial.Dialer: unexpected mux-started message")
+					return
+				}
+				if err := s.readLoop(br); err != nil && !errors.Is(err, net.ErrClosed) {
+					log.Printf("revdial.Dialer: multiplexed connection: %v", err)
+				}
+				return
+			default:
+				d.handleMessage(msg)
 			}
 		}
 	}()
 	for {
-		if err := d.sendMessage(controlMsg{Command: "keep-alive"}); err != nil {
+		if s := d.muxSession(); s != nil {
+			if s.idle() > 3*keepAliveInterval {
+				return errors.New("revdial.Dialer: peer stopped responding to keepalives")
+			}
+			if err := s.ping(); err != nil {
+				return err
+			}
+		} else if err := d.sendMessage(controlMsg{Command: "keep-alive"}); err != nil {
 			return err
 		}
 
-		t := time.NewTimer(30 * time.Second)
+		t := time.NewTimer(keepAliveInterval)
 		select {
 		case <-t.C:
 			continue
+		case <-d.muxHello:
+			t.Stop()
+			if err := d.startMux(); err != nil {
+				return err
+			}
 		case <-d.connReady:
 			t.Stop()
 			if err := d.sendMessage(controlMsg{
@@ -202,11 +262,51 @@ func (d *Dialer) serve() error {
 	}
 }
 
+// handleMessage handles a control message from the Listener.
+func (d *Dialer) handleMessage(msg controlMsg) {
+	switch msg.Command {
+	case "pickup-failed":
+		err := fmt.Errorf("revdial listener failed to pick up connection: %v", msg.Err)
+		select {
+		case d.pickupFailed <- err:
+		case <-d.donec:
+		}
+	}
+}
+
+// startMux switches to multiplexing connections after the Listener
+// offered to do so.
+func (d *Dialer) startMux() error {
+	d.muxMu.Lock()
+	defer d.muxMu.Unlock()
+	if d.mux != nil {
+		return nil
+	}
+	if err := d.sendMessage(controlMsg{Command: "mux-start"}); err != nil {
+		return err
+	}
+	d.mux = newMuxSession(d.write, nil, func(msg controlMsg) {
+		go d.handleMessage(msg)
+	})
+	return nil
+}
+
+// sendMessage sends a control message to the Listener. It is only
+// called by serve.
 func (d *Dialer) sendMessage(m controlMsg) error {
+	if d.mux != nil {
+		return d.mux.writeControl(m)
+	}
 	j, _ := json.Marshal(m)
-	d.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
 	j = append(j, '\n')
-	_, err := d.conn.Write(j)
+	return d.write(j)
+}
+
+func (d *Dialer) write(b []byte) error {
+	d.wmu.Lock()
+	defer d.wmu.Unlock()
+	d.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := d.conn.Write(b)
 	d.conn.SetWriteDeadline(time.Time{})
 	return err
 }
@@ -239,13 +339,16 @@ type Listener struct {
 	dial   func(context.Context) (net.Conn, error)
 	writec chan<- []byte
 
-	mu      sync.Mutex // guards below, closing connc, and writing to rw
+	mu      sync.Mutex // guards below and writing to rw
 	readErr error
 	closed  bool
+
+	muxMu sync.Mutex // guards mux and switching to it
+	mux   *muxSession
 }
 
 type controlMsg struct {
-	Command  string `json:"command,omitempty"`  // "keep-alive", "conn-ready", "pickup-failed"
+	Command  string `json:"command,omitempty"`  // "keep-alive", "conn-ready", "pickup-failed", "mux-hello", "mux-start", "mux-started"
 	ConnPath string `json:"connPath,omitempty"` // conn pick-up URL path for "conn-url", "pickup-failed"
 	Err      string `json:"err,omitempty"`
 }
@@ -273,6 +376,10 @@ func (ln *Listener) run() {
 		}
 	}()
 
+	// Offer to multiplex connections. Dialers which don't support it
+	// ignore the message and keep using the pick-up protocol.
+	ln.sendMessage(controlMsg{Command: "mux-hello"})
+
 	// Read loop
 	br := bufio.NewReader(ln.sc)
 	for {
@@ -285,22 +392,68 @@ func (ln *Listener) run() {
 			log.Printf("revdial.Listener read invalid JSON: %q: %v", line, err)
 			return
 		}
-		switch msg.Command {
-		case "keep-alive":
-			// Occasional no-op message from server to keep
-			// us alive through NAT timeouts.
-		case "conn-ready":
-			go ln.grabConn(msg.ConnPath)
-		default:
-			// Ignore unknown messages
+		if msg.Command == "mux-start" {
+			// The Dialer only writes frames from now on.
+			s := ln.startMux()
+			if err := s.readLoop(br); err != nil && !ln.Closed() {
+				log.Printf("revdial.Listener: multiplexed connection: %v", err)
+			}
+			return
 		}
+		ln.handleMessage(msg)
 	}
 }
 
+// handleMessage handles a control message from the Dialer.
+func (ln *Listener) handleMessage(msg controlMsg) {
+	switch msg.Command {
+	case "keep-alive":
+		// Occasional no-op message from server to keep
+		// us alive through NAT timeouts.
+	case "conn-ready":
+		go ln.grabConn(msg.ConnPath)
+	default:
+		// Ignore unknown messages
+	}
+}
+
+// startMux switches to multiplexing connections after the Dialer
+// accepted the offer to do so.
+func (ln *Listener) startMux() *muxSession {
+	ln.muxMu.Lock()
+	defer ln.muxMu.Unlock()
+	ln.sendMessage(controlMsg{Command: "mux-started"})
+	ln.mux = newMuxSession(ln.write, func(st *muxStream) {
+		go func() {
+			select {
+			case ln.connc <- st:
+			case <-ln.donec:
+				st.Close()
+			}
+		}()
+	}, ln.handleMessage)
+	return ln.mux
+}
+
+// sendMessage sends a control message to the Dialer. Once connections
+// are multiplexed, it must be called with muxMu held.
 func (ln *Listener) sendMessage(m controlMsg) {
+	if ln.mux != nil {
+		ln.mux.writeControl(m)
+		return
+	}
 	j, _ := json.Marshal(m)
 	j = append(j, '\n')
-	ln.writec <- j
+	ln.write(j)
+}
+
+func (ln *Listener) write(b []byte) error {
+	select {
+	case ln.writec <- b:
+		return nil
+	case <-ln.donec:
+		return ErrListenerClosed
+	}
 }
 
 func (ln *Listener) grabConn(path string) {
@@ -308,13 +461,13 @@ func (ln *Listener) grabConn(path string) {
 	defer cancel()
 	c, err := ln.dial(ctx)
 	if err != nil {
-		ln.sendMessage(controlMsg{Command: "pickup-failed", ConnPath: path, Err: err.Error()})
+		ln.sendPickupFailed(path, err)
 		return
 	}
 	failPickup := func(err error) {
 		c.Close()
 		log.Printf("revdial.Listener: failed to pick up connection to %s: %v", path, err)
-		ln.sendMessage(controlMsg{Command: "pickup-failed", ConnPath: path, Err: err.Error()})
+		ln.sendPickupFailed(path, err)
 	}
 	bufr := bufio.NewReader(c)
 
@@ -347,6 +500,12 @@ func (ln *Listener) grabConn(path string) {
 	}
 }
 
+func (ln *Listener) sendPickupFailed(path string, err error) {
+	ln.muxMu.Lock()
+	defer ln.muxMu.Unlock()
+	ln.sendMessage(controlMsg{Command: "pickup-failed", ConnPath: path, Err: err.Error()})
+}
+
 // Closed reports whether the listener has been closed.
 func (ln *Listener) Closed() bool {
 	ln.mu.Lock()
@@ -356,8 +515,16 @@ func (ln *Listener) Closed() bool {
 
 // Accept blocks and returns a new connection, or an error.
 func (ln *Listener) Accept() (net.Conn, error) {
-	c, ok := <-ln.connc
-	if !ok {
+	// Prefer connections which arrived before the Listener was closed.
+	select {
+	case c := <-ln.connc:
+		return c, nil
+	default:
+	}
+	select {
+	case c := <-ln.connc:
+		return c, nil
+	case <-ln.donec:
 		ln.mu.Lock()
 		err, closed := ln.readErr, ln.closed
 		ln.mu.Unlock()
@@ -366,7 +533,6 @@ func (ln *Listener) Accept() (net.Conn, error) {
 		}
 		return nil, ErrListenerClosed
 	}
-	return c, nil
 }
 
 // ErrListenerClosed is returned by Accept after Close has been called.
@@ -382,7 +548,6 @@ func (ln *Listener) Close() error {
 	}
 	go ln.sc.Close()
 	ln.closed = true
-	close(ln.connc)
 	close(ln.donec)
 	return nil
 }